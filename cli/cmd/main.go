@@ -49,6 +49,71 @@ func main() {
 			return nil
 		},
 		Commands: []*cli.Command{
+			{
+				Name:  "navigation",
+				Usage: "work with navigation services",
+				Subcommands: []*cli.Command{
+					{
+						Name:  "waypoints",
+						Usage: "work with routes of waypoints in GeoJSON, GPX and KML files",
+						Subcommands: []*cli.Command{
+							{
+								Name:      "import",
+								Usage:     "add the waypoints in a file to a navigation service's mongodb store",
+								ArgsUsage: "<file>",
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:  "mongodb-uri",
+										Usage: "`URI` of the mongodb store; defaults to a local one",
+									},
+								},
+								Action: func(c *cli.Context) error {
+									path := c.Args().First()
+									if path == "" {
+										fmt.Fprintln(c.App.ErrWriter, "file required")
+										cli.ShowSubcommandHelpAndExit(c, 1)
+										return nil
+									}
+									return rdkcli.ImportWaypoints(c.Context, c.App.Writer, c.String("mongodb-uri"), path)
+								},
+							},
+							{
+								Name:      "export",
+								Usage:     "write the waypoints in a navigation service's mongodb store to a file",
+								ArgsUsage: "<file>",
+								Flags: []cli.Flag{
+									&cli.StringFlag{
+										Name:  "mongodb-uri",
+										Usage: "`URI` of the mongodb store; defaults to a local one",
+									},
+								},
+								Action: func(c *cli.Context) error {
+									path := c.Args().First()
+									if path == "" {
+										fmt.Fprintln(c.App.ErrWriter, "file required")
+										cli.ShowSubcommandHelpAndExit(c, 1)
+										return nil
+									}
+									return rdkcli.ExportWaypoints(c.Context, c.App.Writer, c.String("mongodb-uri"), path)
+								},
+							},
+							{
+								Name:      "convert",
+								Usage:     "convert waypoints between file formats",
+								ArgsUsage: "<input file> <output file>",
+								Action: func(c *cli.Context) error {
+									if c.Args().Len() != 2 {
+										fmt.Fprintln(c.App.ErrWriter, "input and output files required")
+										cli.ShowSubcommandHelpAndExit(c, 1)
+										return nil
+									}
+									return rdkcli.ConvertWaypoints(c.Context, c.App.Writer, c.Args().Get(0), c.Args().Get(1))
+								},
+							},
+						},
+					},
+				},
+			},
 			{
 				Name:  "auth",
 				Usage: "authenticate to app.viam.com",
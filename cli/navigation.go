@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.uber.org/multierr"
+
+	"go.viam.com/rdk/services/navigation"
+)
+
+// ImportWaypoints adds the route of waypoints in the given GeoJSON, GPX or KML file to the navigation service's MongoDB
+// store at the given URI, after any waypoints already in it.
+func ImportWaypoints(ctx context.Context, w io.Writer, mongoURI, path string) (err error) {
+	format, err := navigation.MissionFormatFromPath(path)
+	if err != nil {
+		return err
+	}
+	//nolint:gosec
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, file.Close())
+	}()
+
+	store, err := openMongoDBNavStore(ctx, mongoURI)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, store.Close(ctx))
+	}()
+	wps, err := navigation.ImportWaypoints(ctx, store, file, format)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "imported %d waypoints from %s\n", len(wps), path)
+	return nil
+}
+
+// ExportWaypoints writes every waypoint, visited or not, in the navigation service's MongoDB store at the given URI to
+// the given GeoJSON, GPX or KML file.
+func ExportWaypoints(ctx context.Context, w io.Writer, mongoURI, path string) (err error) {
+	format, err := navigation.MissionFormatFromPath(path)
+	if err != nil {
+		return err
+	}
+	store, err := openMongoDBNavStore(ctx, mongoURI)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, store.Close(ctx))
+	}()
+	wps, err := store.AllWaypoints(ctx)
+	if err != nil {
+		return err
+	}
+	if err := writeWaypoints(path, format, wps); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "exported %d waypoints to %s\n", len(wps), path)
+	return nil
+}
+
+// ConvertWaypoints converts a route of waypoints between GeoJSON, GPX and KML files, going by their extensions.
+func ConvertWaypoints(ctx context.Context, w io.Writer, inPath, outPath string) (err error) {
+	inFormat, err := navigation.MissionFormatFromPath(inPath)
+	if err != nil {
+		return err
+	}
+	outFormat, err := navigation.MissionFormatFromPath(outPath)
+	if err != nil {
+		return err
+	}
+	//nolint:gosec
+	file, err := os.Open(inPath)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, file.Close())
+	}()
+
+	store := navigation.NewMemoryNavigationStore()
+	if _, err := navigation.ImportWaypoints(ctx, store, file, inFormat); err != nil {
+		return err
+	}
+	wps, err := store.AllWaypoints(ctx)
+	if err != nil {
+		return err
+	}
+	if err := writeWaypoints(outPath, outFormat, wps); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "converted %d waypoints from %s to %s\n", len(wps), inPath, outPath)
+	return nil
+}
+
+func openMongoDBNavStore(ctx context.Context, mongoURI string) (*navigation.MongoDBNavigationStore, error) {
+	config := map[string]interface{}{}
+	if mongoURI != "" {
+		config["uri"] = mongoURI
+	}
+	return navigation.NewMongoDBNavigationStore(ctx, config)
+}
+
+func writeWaypoints(path string, format navigation.MissionFormat, wps []navigation.Waypoint) (err error) {
+	//nolint:gosec
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, file.Close())
+	}()
+	return navigation.EncodeWaypoints(file, format, wps)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+)
+
+// ValidateConfig checks the robot config in the given file without building any of it, writing every problem found
+// along with the order its resources would be built in. If a config to compare against is given, it also writes what
+// reconfiguring a robot from that config to the checked one would do to its resources.
+func ValidateConfig(w io.Writer, configPath, againstPath string) error {
+	result, err := checkConfig(configPath)
+	if err != nil {
+		return err
+	}
+	for _, problem := range result.Problems {
+		fmt.Fprintln(w, problem.Error())
+	}
+	if len(result.Problems) != 0 {
+		return errors.Errorf("found %d problems in %s", len(result.Problems), configPath)
+	}
+
+	fmt.Fprintln(w, "build order:")
+	for _, name := range result.Graph.ReverseTopologicalSort() {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+
+	if againstPath == "" {
+		return nil
+	}
+	against, err := checkConfig(againstPath)
+	if err != nil {
+		return err
+	}
+	if len(against.Problems) != 0 {
+		return errors.Errorf("found %d problems in %s to compare against", len(against.Problems), againstPath)
+	}
+	diff, err := config.DiffConfigs(*against.Config, *result.Config, false)
+	if err != nil {
+		return err
+	}
+	plan := diff.Plan()
+	if plan.Empty() {
+		fmt.Fprintf(w, "no resources would change from %s\n", againstPath)
+		return nil
+	}
+	fmt.Fprintf(w, "changes from %s:\n", againstPath)
+	fmt.Fprint(w, plan.String())
+	return nil
+}
+
+// checkConfig checks the config in the given file, including that the models of its components and services are registered.
+func checkConfig(path string) (*config.CheckResult, error) {
+	cfg, err := config.ReadUnprocessed(path)
+	if err != nil {
+		return nil, err
+	}
+	result := config.Check(cfg, false)
+	for idx, c := range result.Config.Components {
+		rName := c.ResourceName()
+		if _, ok := result.Graph.Node(rName); !ok {
+			continue
+		}
+		if registry.ComponentLookup(rName.Subtype, c.Model) == nil {
+			result.Problems = append(result.Problems, config.Problem{
+				Path: fmt.Sprintf("%s.%d.model", "components", idx),
+				Err:  errors.Errorf("unknown component subtype: %s and/or model: %s", rName.Subtype, c.Model),
+			})
+		}
+	}
+	for idx, s := range result.Config.Services {
+		rName := s.ResourceName()
+		if _, ok := result.Graph.Node(rName); !ok {
+			continue
+		}
+		if registry.ServiceLookup(rName.Subtype, s.Model) == nil {
+			result.Problems = append(result.Problems, config.Problem{
+				Path: fmt.Sprintf("%s.%d.model", "services", idx),
+				Err:  errors.Errorf("unknown service subtype: %s and/or model: %s", rName.Subtype, s.Model),
+			})
+		}
+	}
+	return result, nil
+}
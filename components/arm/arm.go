@@ -259,6 +259,12 @@ func (r *reconfigurableArm) GoToInputs(ctx context.Context, goal []referencefram
 	return r.actual.GoToInputs(ctx, goal)
 }
 
+func (r *reconfigurableArm) FollowTrajectory(ctx context.Context, traj *motionplan.Trajectory) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return motionplan.FollowTrajectory(ctx, r.actual, traj)
+}
+
 func (r *reconfigurableArm) Close(ctx context.Context) error {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -386,6 +392,14 @@ func Move(ctx context.Context, r robot.Robot, a Arm, dst *commonpb.Pose, worldSt
 	if err != nil {
 		return err
 	}
+	// Arms whose models declare dynamic limits are moved along a timed trajectory rather than waypoint by waypoint
+	if limiter, ok := a.ModelFrame().(referenceframe.DynamicLimiter); ok && len(limiter.DynamicLimits()) > 0 {
+		traj, err := motionplan.TimeParameterize(solution, limiter.DynamicLimits())
+		if err != nil {
+			return err
+		}
+		return motionplan.FollowTrajectory(ctx, a, traj)
+	}
 	return GoToWaypoints(ctx, a, solution)
 }
 
@@ -5,6 +5,7 @@ import (
 	"context"
 	// used to import model referenceframe.
 	_ "embed"
+	"sync"
 
 	"github.com/edaniels/golog"
 	"github.com/pkg/errors"
@@ -50,6 +51,7 @@ func NewArm(cfg config.Component) (arm.LocalArm, error) {
 // Arm is a fake arm that can simply read and set properties.
 type Arm struct {
 	generic.Echo
+	mu         sync.Mutex
 	Name       string
 	position   *commonpb.Pose
 	joints     *pb.JointPositions
@@ -64,23 +66,31 @@ func (a *Arm) ModelFrame() referenceframe.Model {
 
 // EndPosition returns the set position.
 func (a *Arm) EndPosition(ctx context.Context, extra map[string]interface{}) (*commonpb.Pose, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	return a.position, nil
 }
 
 // MoveToPosition sets the position.
 func (a *Arm) MoveToPosition(ctx context.Context, c *commonpb.Pose, worldState *commonpb.WorldState, extra map[string]interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.position = c
 	return nil
 }
 
 // MoveToJointPositions sets the joints.
 func (a *Arm) MoveToJointPositions(ctx context.Context, joints *pb.JointPositions, extra map[string]interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	a.joints = joints
 	return nil
 }
 
 // JointPositions returns the set joints.
 func (a *Arm) JointPositions(ctx context.Context, extra map[string]interface{}) (*pb.JointPositions, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	return a.joints, nil
 }
 
@@ -18,10 +18,12 @@ import (
 	"go.viam.com/rdk/robot"
 )
 
-// AttrConfig is used for converting config attributes.
+// AttrConfig is used for converting config attributes. The model may be a JSON or URDF file; for URDF files describing a
+// tree of links, the end effector names the link at which the arm ends.
 type AttrConfig struct {
-	ModelPath string `json:"model-path"`
-	ArmName   string `json:"arm-name"`
+	ModelPath   string `json:"model-path"`
+	ArmName     string `json:"arm-name"`
+	EndEffector string `json:"end-effector"`
 }
 
 func init() {
@@ -53,11 +55,12 @@ type Arm struct {
 
 // NewWrapperArm returns a wrapper component for another arm.
 func NewWrapperArm(cfg config.Component, r robot.Robot, logger golog.Logger) (arm.LocalArm, error) {
-	model, err := referenceframe.ParseModelJSONFile(cfg.ConvertedAttributes.(*AttrConfig).ModelPath, cfg.Name)
+	attrs := cfg.ConvertedAttributes.(*AttrConfig)
+	model, err := referenceframe.ParseModelFile(attrs.ModelPath, cfg.Name, attrs.EndEffector)
 	if err != nil {
 		return nil, err
 	}
-	wrappedArm, err := arm.FromRobot(r, cfg.ConvertedAttributes.(*AttrConfig).ArmName)
+	wrappedArm, err := arm.FromRobot(r, attrs.ArmName)
 	if err != nil {
 		return nil, err
 	}
@@ -359,32 +359,57 @@ func (x *xArm) MoveToJointPositions(ctx context.Context, newPositions *pb.JointP
 	nSteps := int((diff / float64(x.speed)) * x.moveHZ)
 	for i := 1; i <= nSteps; i++ {
 		step := referenceframe.InputsToFloats(referenceframe.InterpolateInputs(from, to, float64(i)/float64(nSteps)))
-
-		c := x.newCmd(regMap["MoveJoints"])
-		jFloatBytes := make([]byte, 4)
-		for _, jRad := range step {
-			binary.LittleEndian.PutUint32(jFloatBytes, math.Float32bits(float32(jRad)))
-			c.params = append(c.params, jFloatBytes...)
+		if err := x.servoJoints(ctx, step); err != nil {
+			return err
 		}
-		// xarm 6 has 6 joints, but protocol needs 7- add 4 bytes for a blank 7th joint
-		for dof := x.dof; dof < 7; dof++ {
-			c.params = append(c.params, 0, 0, 0, 0)
+		if !utils.SelectContextOrWait(ctx, time.Duration(1000000./x.moveHZ)*time.Microsecond) {
+			return ctx.Err()
 		}
-		// When in servoj mode, motion time, speed, and acceleration are not handled by the control box
-		c.params = append(c.params, 0, 0, 0, 0)
-		c.params = append(c.params, 0, 0, 0, 0)
-		c.params = append(c.params, 0, 0, 0, 0)
-		_, err := x.send(ctx, c, true)
-		if err != nil {
+	}
+	return nil
+}
+
+// FollowTrajectory streams the given timed trajectory to the arm at its configured move rate.
+func (x *xArm) FollowTrajectory(ctx context.Context, traj *motionplan.Trajectory) error {
+	ctx, done := x.opMgr.New(ctx)
+	defer done()
+	if !x.started {
+		if err := x.start(ctx); err != nil {
 			return err
 		}
-		if !utils.SelectContextOrWait(ctx, time.Duration(1000000./x.moveHZ)*time.Microsecond) {
+	}
+	period := time.Duration(1000000./x.moveHZ) * time.Microsecond
+	for _, point := range traj.Discretize(period)[1:] {
+		if err := x.servoJoints(ctx, referenceframe.InputsToFloats(point.Positions)); err != nil {
+			return err
+		}
+		if !utils.SelectContextOrWait(ctx, period) {
 			return ctx.Err()
 		}
 	}
 	return nil
 }
 
+// servoJoints sends a single servoj command moving the joints to the given radian positions.
+func (x *xArm) servoJoints(ctx context.Context, radians []float64) error {
+	c := x.newCmd(regMap["MoveJoints"])
+	jFloatBytes := make([]byte, 4)
+	for _, jRad := range radians {
+		binary.LittleEndian.PutUint32(jFloatBytes, math.Float32bits(float32(jRad)))
+		c.params = append(c.params, jFloatBytes...)
+	}
+	// xarm 6 has 6 joints, but protocol needs 7- add 4 bytes for a blank 7th joint
+	for dof := x.dof; dof < 7; dof++ {
+		c.params = append(c.params, 0, 0, 0, 0)
+	}
+	// When in servoj mode, motion time, speed, and acceleration are not handled by the control box
+	c.params = append(c.params, 0, 0, 0, 0)
+	c.params = append(c.params, 0, 0, 0, 0)
+	c.params = append(c.params, 0, 0, 0, 0)
+	_, err := x.send(ctx, c, true)
+	return err
+}
+
 // EndPosition computes and returns the current cartesian position.
 func (x *xArm) EndPosition(ctx context.Context, extra map[string]interface{}) (*commonpb.Pose, error) {
 	joints, err := x.JointPositions(ctx, extra)
@@ -0,0 +1,52 @@
+package base
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/anypb"
+
+	"go.viam.com/rdk/control"
+	"go.viam.com/rdk/data"
+)
+
+type method int64
+
+const (
+	controlSignals method = iota
+)
+
+func (m method) String() string {
+	if m == controlSignals {
+		return "ControlSignals"
+	}
+	return "Unknown"
+}
+
+// ControlSignals wraps the internal signals of the controllers of a base.
+type ControlSignals struct {
+	Signals map[string]interface{}
+}
+
+func newControlSignalsCollector(resource interface{}, params data.CollectorParams) (data.Collector, error) {
+	base, err := assertBase(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	cFunc := data.CaptureFunc(func(ctx context.Context, _ map[string]*anypb.Any) (interface{}, error) {
+		v, err := base.DoCommand(ctx, map[string]interface{}{control.SignalsCommand: true})
+		if err != nil {
+			return nil, data.FailedToReadErr(params.ComponentName, controlSignals.String(), err)
+		}
+		return ControlSignals{Signals: v}, nil
+	})
+	return data.NewCollector(cFunc, params)
+}
+
+func assertBase(resource interface{}) (Base, error) {
+	base, ok := resource.(Base)
+	if !ok {
+		return nil, data.InvalidInterfaceErr(SubtypeName)
+	}
+	return base, nil
+}
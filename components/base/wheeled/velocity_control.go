@@ -0,0 +1,263 @@
+package wheeled
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/control"
+)
+
+const (
+	defaultControlFrequencyHz = 20.
+	// The fastest a control loop can run.
+	maxControlFrequencyHz = 200.
+
+	// A spin is given this long beyond the time it would take open-loop before it fails.
+	spinTimeoutSlack = 2 * time.Second
+	// A spin fails when the base turns slower than this fraction of the commanded rate for spinStallTimeout.
+	spinStallFraction = 0.1
+	spinStallTimeout  = time.Second
+)
+
+// velocityControl holds a base at a commanded velocity. Each velocity which the movement sensor measures, and which the base
+// has PID gains for, is corrected by a control loop whose output is added to the velocity the motors are run at.
+type velocityControl struct {
+	base            *wheeledBase
+	linear, angular float64
+	linearLoop      *control.Loop
+	angularLoop     *control.Loop
+
+	mu                                  sync.Mutex
+	linearCorrection, angularCorrection float64
+	err                                 error
+}
+
+// velocityAxis is one of the velocities of a base as the control.Controllable of its loop. Its position is the velocity
+// measured by the movement sensor, and its power is the correction to the commanded velocity.
+type velocityAxis struct {
+	vc      *velocityControl
+	angular bool
+}
+
+// Position returns the velocity of the axis measured by the movement sensor, in mm/s or deg/s.
+func (a *velocityAxis) Position(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	if a.angular {
+		w, err := a.vc.base.sensor.AngularVelocity(ctx)
+		if err != nil {
+			return 0, a.vc.fail(err)
+		}
+		return w.Z, nil
+	}
+	v, err := a.vc.base.sensor.LinearVelocity(ctx)
+	if err != nil {
+		return 0, a.vc.fail(err)
+	}
+	return v.Y, nil
+}
+
+// SetPower runs the motors at the commanded velocities, with the correction to the velocity of the axis replaced by power.
+func (a *velocityAxis) SetPower(ctx context.Context, power float64, extra map[string]interface{}) error {
+	a.vc.mu.Lock()
+	if a.vc.err != nil {
+		a.vc.mu.Unlock()
+		return a.vc.err
+	}
+	if a.angular {
+		a.vc.angularCorrection = power
+	} else {
+		a.vc.linearCorrection = power
+	}
+	linear, angular := a.vc.linear+a.vc.linearCorrection, a.vc.angular+a.vc.angularCorrection
+	a.vc.mu.Unlock()
+
+	l, r := a.vc.base.velocityMath(linear, angular)
+	if err := a.vc.base.runAll(ctx, l, 0, r, 0); err != nil {
+		return a.vc.fail(err)
+	}
+	return nil
+}
+
+// fail records the first error from controlling the base and stops it, so that the loops no longer drive it.
+func (vc *velocityControl) fail(err error) error {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	if vc.err == nil {
+		vc.err = err
+		vc.base.logger.Errorw("failed to control base velocity, stopping", "error", err)
+		if err := vc.base.stopMotors(context.Background(), nil); err != nil {
+			vc.base.logger.Errorw("failed to stop base", "error", err)
+		}
+	}
+	return err
+}
+
+// failure returns the error which stopped the base, if any.
+func (vc *velocityControl) failure() error {
+	vc.mu.Lock()
+	defer vc.mu.Unlock()
+	return vc.err
+}
+
+// stop stops the loops of the controller, leaving the motors running.
+func (vc *velocityControl) stop() {
+	for _, loop := range []*control.Loop{vc.linearLoop, vc.angularLoop} {
+		if loop != nil {
+			loop.Stop()
+		}
+	}
+}
+
+// signals returns the internal signals of the loops, prefixed by the velocity each controls.
+func (vc *velocityControl) signals(ctx context.Context) map[string]interface{} {
+	signals := map[string]interface{}{}
+	for prefix, loop := range map[string]*control.Loop{"linear_": vc.linearLoop, "angular_": vc.angularLoop} {
+		if loop == nil {
+			continue
+		}
+		for name, value := range loop.Signals(ctx) {
+			signals[prefix+name] = value
+		}
+	}
+	return signals
+}
+
+// DoCommand returns the internal signals of the velocity control loops when given a control.SignalsCommand, if the base has a
+// movement sensor to control its velocity from.
+func (base *wheeledBase) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if !control.IsSignalsCommand(cmd) || base.sensor == nil {
+		return nil, generic.ErrUnimplemented
+	}
+	base.controlMu.Lock()
+	defer base.controlMu.Unlock()
+	if base.velocityControl == nil {
+		return map[string]interface{}{}, nil
+	}
+	return base.velocityControl.signals(ctx), nil
+}
+
+// startVelocityControl starts the loops which hold the base at the given velocities until it is stopped or given another
+// command.
+func (base *wheeledBase) startVelocityControl(linear, angular float64) (*velocityControl, error) {
+	base.stopVelocityControl()
+
+	vc := &velocityControl{base: base, linear: linear, angular: angular}
+	// the motors are started before the loops, so that a base which cannot move is reported to the caller
+	l, r := base.velocityMath(linear, angular)
+	if err := base.runAll(context.Background(), l, 0, r, 0); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if base.linearFeedback && base.linearPID != nil {
+		vc.linearLoop, err = base.startAxisLoop(&velocityAxis{vc: vc}, *base.linearPID, linear)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if base.angularFeedback && base.angularPID != nil {
+		vc.angularLoop, err = base.startAxisLoop(&velocityAxis{vc: vc, angular: true}, *base.angularPID, angular)
+		if err != nil {
+			vc.stop()
+			return nil, err
+		}
+	}
+
+	base.controlMu.Lock()
+	defer base.controlMu.Unlock()
+	base.velocityControl = vc
+	return vc, nil
+}
+
+// startAxisLoop starts a loop which holds the velocity of the axis at setPoint.
+func (base *wheeledBase) startAxisLoop(axis *velocityAxis, gains control.PIDConfig, setPoint float64) (*control.Loop, error) {
+	loop, err := control.NewLoop(base.logger, control.PIDLoopConfig(gains, setPoint, base.controlFrequencyHz), axis)
+	if err != nil {
+		return nil, err
+	}
+	if err := loop.Start(); err != nil {
+		return nil, err
+	}
+	return loop, nil
+}
+
+// stopVelocityControl stops any loops holding the base at a velocity, leaving its motors running.
+func (base *wheeledBase) stopVelocityControl() {
+	base.controlMu.Lock()
+	vc := base.velocityControl
+	base.velocityControl = nil
+	base.controlMu.Unlock()
+	if vc != nil {
+		vc.stop()
+	}
+}
+
+// spinWithFeedback spins the base at the given rate until the angle it has turned through, measured by integrating the angular
+// velocity from its movement sensor, reaches the given angle. The spin fails if the base stalls, or if it takes much longer
+// than it would open-loop.
+func (base *wheeledBase) spinWithFeedback(ctx context.Context, angleDeg, degsPerSec float64) (err error) {
+	defer func() {
+		if err != nil {
+			err = errors.Wrap(err, "failed to control spin")
+		}
+		// the spin ends with the base stopped, however it ended
+		if stopErr := base.stopMotors(context.Background(), nil); err == nil {
+			err = stopErr
+		}
+	}()
+
+	// a negative rate spins the base the other way, as it does open-loop
+	direction := math.Copysign(1, angleDeg) * math.Copysign(1, degsPerSec)
+	speed := math.Abs(degsPerSec)
+	remaining := math.Abs(angleDeg)
+	openLoop := time.Duration(remaining / speed * float64(time.Second))
+	timeout := openLoop + openLoop/2 + spinTimeoutSlack
+
+	vc, err := base.startVelocityControl(0, direction*speed)
+	if err != nil {
+		return err
+	}
+	defer base.stopVelocityControl()
+
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / base.controlFrequencyHz))
+	defer ticker.Stop()
+	start := time.Now()
+	last, lastTurning := start, start
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		if err := vc.failure(); err != nil {
+			return err
+		}
+		w, err := base.sensor.AngularVelocity(ctx)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		rate := direction * w.Z
+		step := rate * now.Sub(last).Seconds()
+		last = now
+
+		remaining -= step
+		// stop at whichever tick is closest to the target angle
+		if remaining <= math.Max(step, 0)/2 {
+			return nil
+		}
+		if rate >= spinStallFraction*speed {
+			lastTurning = now
+		}
+		if now.Sub(lastTurning) > spinStallTimeout {
+			return errors.Errorf("base stalled with %.1f degrees left to spin", remaining)
+		}
+		if now.Sub(start) > timeout {
+			return errors.Errorf("spin timed out after %v with %.1f degrees left to spin", timeout, remaining)
+		}
+	}
+}
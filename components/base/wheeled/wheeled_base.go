@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/edaniels/golog"
@@ -16,7 +17,9 @@ import (
 	"go.viam.com/rdk/components/base"
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/movementsensor"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/control"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/registry"
 	rdkutils "go.viam.com/rdk/utils"
@@ -53,11 +56,22 @@ type wheeledBase struct {
 	allMotors []motor.Motor
 
 	opMgr operation.SingleOperationManager
+
+	// when a movement sensor is given, velocities and spins are corrected by what it measures
+	sensor                movementsensor.MovementSensor
+	linearFeedback        bool
+	angularFeedback       bool
+	linearPID, angularPID *control.PIDConfig
+	controlFrequencyHz    float64
+	controlMu             sync.Mutex
+	velocityControl       *velocityControl
+	logger                golog.Logger
 }
 
 func (base *wheeledBase) Spin(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
 	ctx, done := base.opMgr.New(ctx)
 	defer done()
+	base.stopVelocityControl()
 
 	// Stop the motors if the speed is 0
 	if math.Abs(degsPerSec) < 0.0001 {
@@ -68,6 +82,11 @@ func (base *wheeledBase) Spin(ctx context.Context, angleDeg, degsPerSec float64,
 		return err
 	}
 
+	// the angle spun through can only be measured by a sensor of angular velocity
+	if base.angularFeedback {
+		return base.spinWithFeedback(ctx, angleDeg, degsPerSec)
+	}
+
 	// Spin math
 	rpm, revolutions := base.spinMath(angleDeg, degsPerSec)
 
@@ -77,6 +96,7 @@ func (base *wheeledBase) Spin(ctx context.Context, angleDeg, degsPerSec float64,
 func (base *wheeledBase) MoveStraight(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
 	ctx, done := base.opMgr.New(ctx)
 	defer done()
+	base.stopVelocityControl()
 
 	// Stop the motors if the speed or distance are 0
 	if math.Abs(mmPerSec) < 0.0001 || distanceMm == 0 {
@@ -105,7 +125,7 @@ func (base *wheeledBase) runAll(ctx context.Context, leftRPM, leftRotations, rig
 	}
 
 	if _, err := rdkutils.RunInParallel(ctx, fs); err != nil {
-		return multierr.Combine(err, base.Stop(ctx, nil))
+		return multierr.Combine(err, base.stopMotors(ctx, nil))
 	}
 	return nil
 }
@@ -151,12 +171,17 @@ func (base *wheeledBase) differentialDrive(forward, left float64) (float64, floa
 
 func (base *wheeledBase) SetVelocity(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
 	base.opMgr.CancelRunning(ctx)
+	if base.sensor != nil {
+		_, err := base.startVelocityControl(linear.Y, angular.Z)
+		return err
+	}
 	l, r := base.velocityMath(linear.Y, angular.Z)
 	return base.runAll(ctx, l, 0, r, 0)
 }
 
 func (base *wheeledBase) SetPower(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
 	base.opMgr.CancelRunning(ctx)
+	base.stopVelocityControl()
 
 	lPower, rPower := base.differentialDrive(linear.Y, angular.Z)
 
@@ -249,6 +274,12 @@ func (base *wheeledBase) WaitForMotorsToStop(ctx context.Context) error {
 }
 
 func (base *wheeledBase) Stop(ctx context.Context, extra map[string]interface{}) error {
+	base.opMgr.CancelRunning(ctx)
+	base.stopVelocityControl()
+	return base.stopMotors(ctx, extra)
+}
+
+func (base *wheeledBase) stopMotors(ctx context.Context, extra map[string]interface{}) error {
 	var err error
 	for _, m := range base.allMotors {
 		err = multierr.Combine(err, m.Stop(ctx, extra))
@@ -284,6 +315,14 @@ type Config struct {
 	SpinSlipFactor       float64  `json:"spin_slip_factor,omitempty"`
 	Left                 []string `json:"left"`
 	Right                []string `json:"right"`
+
+	// MovementSensor, when given, measures the velocity of the base so that SetVelocity and Spin can correct the motors
+	// until the commanded velocity or angle is achieved. The PID gains correct the forwards velocity in mm/s and the angular
+	// velocity in deg/s; each is only corrected if the sensor supports measuring it.
+	MovementSensor     string             `json:"movement_sensor,omitempty"`
+	LinearPID          *control.PIDConfig `json:"linear_pid,omitempty"`
+	AngularPID         *control.PIDConfig `json:"angular_pid,omitempty"`
+	ControlFrequencyHz float64            `json:"control_frequency_hz,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -306,8 +345,25 @@ func (config *Config) Validate(path string) ([]string, error) {
 		return nil, fmt.Errorf("left and right need to have the same number of motors, not %d vs %d", len(config.Left), len(config.Right))
 	}
 
+	if config.ControlFrequencyHz < 0 || config.ControlFrequencyHz > maxControlFrequencyHz {
+		return nil, errors.Errorf("control_frequency_hz must be between 0 and %v", maxControlFrequencyHz)
+	}
+
+	for name, gains := range map[string]*control.PIDConfig{"linear_pid": config.LinearPID, "angular_pid": config.AngularPID} {
+		if gains != nil && gains.KP == 0 && gains.KI == 0 && gains.KD == 0 {
+			return nil, errors.Errorf("%s needs at least one of kP, kI or kD", name)
+		}
+	}
+
+	if config.MovementSensor == "" && (config.LinearPID != nil || config.AngularPID != nil) {
+		return nil, errors.New("need a movement_sensor to use linear_pid or angular_pid")
+	}
+
 	deps = append(deps, config.Left...)
 	deps = append(deps, config.Right...)
+	if config.MovementSensor != "" {
+		deps = append(deps, config.MovementSensor)
+	}
 
 	return deps, nil
 }
@@ -323,6 +379,7 @@ func CreateWheeledBase(
 		widthMm:              config.WidthMM,
 		wheelCircumferenceMm: config.WheelCircumferenceMM,
 		spinSlipFactor:       config.SpinSlipFactor,
+		logger:               logger,
 	}
 
 	if base.spinSlipFactor == 0 {
@@ -348,5 +405,27 @@ func CreateWheeledBase(
 	base.allMotors = append(base.allMotors, base.left...)
 	base.allMotors = append(base.allMotors, base.right...)
 
+	if config.MovementSensor != "" {
+		sensor, err := movementsensor.FromDependencies(deps, config.MovementSensor)
+		if err != nil {
+			return nil, errors.Wrapf(err, "no movement sensor named (%s)", config.MovementSensor)
+		}
+		props, err := sensor.Properties(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !props.LinearVelocitySupported && !props.AngularVelocitySupported {
+			return nil, errors.Errorf("movement sensor (%s) measures neither linear nor angular velocity", config.MovementSensor)
+		}
+		base.sensor = sensor
+		base.linearFeedback = props.LinearVelocitySupported
+		base.angularFeedback = props.AngularVelocitySupported
+		base.linearPID, base.angularPID = config.LinearPID, config.AngularPID
+		base.controlFrequencyHz = config.ControlFrequencyHz
+		if base.controlFrequencyHz == 0 {
+			base.controlFrequencyHz = defaultControlFrequencyHz
+		}
+	}
+
 	return base, nil
 }
@@ -3,15 +3,22 @@ package wheeled
 import (
 	"context"
 	"math"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
 	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
 
 	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/components/motor/fake"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/control"
 	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
 )
 
 func fakeMotorDependencies(t *testing.T, deps []string) registry.Dependencies {
@@ -343,3 +350,249 @@ func TestValidate(t *testing.T) {
 	test.That(t, deps, test.ShouldResemble, []string{"fl-m", "bl-m", "fr-m", "br-m"})
 	test.That(t, err, test.ShouldBeNil)
 }
+
+// slippingBase simulates a base whose wheels slip, so that it only moves at a fraction of the velocity its motors are driven at.
+type slippingBase struct {
+	mu              sync.Mutex
+	slip            float64
+	leftRPM         float64
+	rightRPM        float64
+	stopped         bool
+	headingDeg      float64
+	lastHeadingAt   time.Time
+	widthMm         float64
+	circumferenceMm float64
+}
+
+func (b *slippingBase) velocities() (float64, float64) {
+	left, right := b.leftRPM/60*b.circumferenceMm, b.rightRPM/60*b.circumferenceMm
+	return b.slip * (left + right) / 2, b.slip * (right - left) / b.widthMm * 180 / math.Pi
+}
+
+// advance turns the base at its angular velocity since it was last advanced.
+func (b *slippingBase) advance() {
+	now := time.Now()
+	if !b.lastHeadingAt.IsZero() {
+		_, angular := b.velocities()
+		b.headingDeg += angular * now.Sub(b.lastHeadingAt).Seconds()
+	}
+	b.lastHeadingAt = now
+}
+
+func (b *slippingBase) motor(left bool) motor.Motor {
+	m := &inject.Motor{}
+	m.GoForFunc = func(ctx context.Context, rpm, rotations float64, extra map[string]interface{}) error {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.advance()
+		if left {
+			b.leftRPM = rpm
+		} else {
+			b.rightRPM = rpm
+		}
+		b.stopped = false
+		return nil
+	}
+	m.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.advance()
+		b.leftRPM, b.rightRPM, b.stopped = 0, 0, true
+		return nil
+	}
+	return m
+}
+
+func (b *slippingBase) sensor(linear, angular bool) movementsensor.MovementSensor {
+	s := &inject.MovementSensor{}
+	s.PropertiesFunc = func(ctx context.Context) (*movementsensor.Properties, error) {
+		return &movementsensor.Properties{LinearVelocitySupported: linear, AngularVelocitySupported: angular}, nil
+	}
+	s.LinearVelocityFunc = func(ctx context.Context) (r3.Vector, error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		v, _ := b.velocities()
+		return r3.Vector{Y: v}, nil
+	}
+	s.AngularVelocityFunc = func(ctx context.Context) (spatialmath.AngularVelocity, error) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.advance()
+		_, w := b.velocities()
+		return spatialmath.AngularVelocity{Z: w}, nil
+	}
+	return s
+}
+
+func TestWheeledBaseVelocityFeedback(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	sim := &slippingBase{slip: 0.5, widthMm: 100, circumferenceMm: 1000}
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		Left:                 []string{"left"},
+		Right:                []string{"right"},
+		MovementSensor:       "sensor",
+		LinearPID:            &control.PIDConfig{KP: 0.2, KI: 10},
+		AngularPID:           &control.PIDConfig{KP: 0.2, KI: 10},
+		ControlFrequencyHz:   100,
+	}
+	deps, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"left", "right", "sensor"})
+	resources := registry.Dependencies{
+		motor.Named("left"):            sim.motor(true),
+		motor.Named("right"):           sim.motor(false),
+		movementsensor.Named("sensor"): sim.sensor(true, true),
+	}
+
+	baseBase, err := CreateWheeledBase(ctx, resources, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	base := baseBase.(*wheeledBase)
+	defer base.Close(ctx)
+
+	t.Run("velocity", func(t *testing.T) {
+		err := base.SetVelocity(ctx, r3.Vector{Y: 100}, r3.Vector{Z: 10}, nil)
+		test.That(t, err, test.ShouldBeNil)
+		// open-loop the base would only reach half the velocity
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			sim.mu.Lock()
+			defer sim.mu.Unlock()
+			linear, angular := sim.velocities()
+			test.That(tb, linear, test.ShouldAlmostEqual, 100, 2)
+			test.That(tb, angular, test.ShouldAlmostEqual, 10, 0.5)
+		})
+
+		// the controllers report how much they are correcting the base
+		signals, err := base.DoCommand(ctx, map[string]interface{}{control.SignalsCommand: true})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, signals["linear_"+control.PIDLoopPID], test.ShouldAlmostEqual, 100, 10)
+		test.That(t, signals, test.ShouldContainKey, "angular_"+control.PIDLoopError)
+
+		test.That(t, base.Stop(ctx, nil), test.ShouldBeNil)
+		time.Sleep(50 * time.Millisecond)
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		test.That(t, sim.stopped, test.ShouldBeTrue)
+	})
+
+	t.Run("spin", func(t *testing.T) {
+		sim.mu.Lock()
+		sim.headingDeg = 0
+		sim.mu.Unlock()
+		test.That(t, base.Spin(ctx, 90, 180, nil), test.ShouldBeNil)
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		test.That(t, sim.stopped, test.ShouldBeTrue)
+		test.That(t, sim.headingDeg, test.ShouldAlmostEqual, 90, 5)
+	})
+
+	t.Run("spin backwards", func(t *testing.T) {
+		sim.mu.Lock()
+		sim.headingDeg = 0
+		sim.mu.Unlock()
+		test.That(t, base.Spin(ctx, 90, -180, nil), test.ShouldBeNil)
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		test.That(t, sim.headingDeg, test.ShouldAlmostEqual, -90, 5)
+	})
+
+	t.Run("spin stalled", func(t *testing.T) {
+		sim.mu.Lock()
+		sim.slip = 0
+		sim.mu.Unlock()
+		defer func() {
+			sim.mu.Lock()
+			sim.slip = 0.5
+			sim.mu.Unlock()
+		}()
+		err := base.Spin(ctx, 90, 180, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "stalled")
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		test.That(t, sim.stopped, test.ShouldBeTrue)
+	})
+
+	t.Run("spin cancelled", func(t *testing.T) {
+		cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		err := base.Spin(cancelCtx, 3600, 180, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		test.That(t, sim.stopped, test.ShouldBeTrue)
+	})
+}
+
+func TestValidateVelocityFeedback(t *testing.T) {
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		Left:                 []string{"left"},
+		Right:                []string{"right"},
+		LinearPID:            &control.PIDConfig{KP: 1},
+	}
+	_, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "need a movement_sensor")
+
+	cfg.MovementSensor = "sensor"
+	cfg.ControlFrequencyHz = -1
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// a sensor which measures no velocities cannot correct them
+	cfg.ControlFrequencyHz = 0
+	sim := &slippingBase{slip: 1, widthMm: 100, circumferenceMm: 1000}
+	resources := registry.Dependencies{
+		motor.Named("left"):            sim.motor(true),
+		motor.Named("right"):           sim.motor(false),
+		movementsensor.Named("sensor"): sim.sensor(false, false),
+	}
+	_, err = CreateWheeledBase(context.Background(), resources, cfg, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	cfg.ControlFrequencyHz = 500
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// gains which are all zero would tune the controller rather than correct the base
+	cfg.ControlFrequencyHz = 0
+	cfg.LinearPID = &control.PIDConfig{}
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "linear_pid")
+}
+
+func TestWheeledBaseSpinTimeout(t *testing.T) {
+	ctx := context.Background()
+	// without gains to correct it, the base turns so much slower than commanded that the spin takes too long
+	sim := &slippingBase{slip: 0.12, widthMm: 100, circumferenceMm: 1000}
+	cfg := &Config{
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		Left:                 []string{"left"},
+		Right:                []string{"right"},
+		MovementSensor:       "sensor",
+		ControlFrequencyHz:   100,
+	}
+	resources := registry.Dependencies{
+		motor.Named("left"):            sim.motor(true),
+		motor.Named("right"):           sim.motor(false),
+		movementsensor.Named("sensor"): sim.sensor(false, true),
+	}
+	baseBase, err := CreateWheeledBase(ctx, resources, cfg, golog.NewTestLogger(t))
+	test.That(t, err, test.ShouldBeNil)
+	base := baseBase.(*wheeledBase)
+	defer base.Close(ctx)
+
+	err = base.Spin(ctx, 90, 180, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "timed out")
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	test.That(t, sim.stopped, test.ShouldBeTrue)
+}
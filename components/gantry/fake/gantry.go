@@ -4,6 +4,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
@@ -26,11 +27,18 @@ func init() {
 
 // NewGantry returns a new fake gantry.
 func NewGantry(name string) gantry.LocalGantry {
-	return &Gantry{name, []float64{1.2}, []float64{5}, r3.Vector{1, 0, 0}, 2, generic.Echo{}}
+	return &Gantry{
+		name:         name,
+		positionsMm:  []float64{1.2},
+		lengths:      []float64{5},
+		axis:         r3.Vector{1, 0, 0},
+		lengthMeters: 2,
+	}
 }
 
 // Gantry is a fake gantry that can simply read and set properties.
 type Gantry struct {
+	mu           sync.Mutex
 	name         string
 	positionsMm  []float64
 	lengths      []float64
@@ -41,6 +49,8 @@ type Gantry struct {
 
 // Position returns the position in meters.
 func (g *Gantry) Position(ctx context.Context, extra map[string]interface{}) ([]float64, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	return g.positionsMm, nil
 }
 
@@ -56,6 +66,8 @@ func (g *Gantry) MoveToPosition(
 	worldState *commonpb.WorldState,
 	extra map[string]interface{},
 ) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
 	g.positionsMm = positionsMm
 	return nil
 }
@@ -4,6 +4,7 @@ package oneaxis
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/edaniels/golog"
@@ -18,6 +19,7 @@ import (
 	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/control"
 	"go.viam.com/rdk/operation"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/registry"
@@ -25,7 +27,15 @@ import (
 	rdkutils "go.viam.com/rdk/utils"
 )
 
-const modelname = "oneaxis"
+const (
+	modelname = "oneaxis"
+
+	defaultControlFrequencyHz = 100.
+	// The fastest a control loop can run.
+	maxControlFrequencyHz = 200.
+	// A move by the control loop ends when the gantry is this close to its goal.
+	controlToleranceMm = 0.5
+)
 
 // AttrConfig is used for converting oneAxis config attributes.
 type AttrConfig struct {
@@ -37,6 +47,11 @@ type AttrConfig struct {
 	MmPerRevolution float64                   `json:"mm_per_rev,omitempty"`
 	GantryRPM       float64                   `json:"gantry_rpm,omitempty"`
 	Axis            spatial.TranslationConfig `json:"axis"`
+
+	// PositionPID, when given, moves the gantry with a control loop which sets the power of its motor to the output of a PID
+	// block with these gains, acting on the error in the position of the motor in revolutions, rather than with GoTo.
+	PositionPID        *control.PIDConfig `json:"position_pid,omitempty"`
+	ControlFrequencyHz float64            `json:"control_frequency_hz,omitempty"`
 }
 
 // Validate ensures all parts of the config are valid.
@@ -86,6 +101,15 @@ func (config *AttrConfig) validate() ([]string, error) {
 		return nil, errors.New("only one translational axis of movement allowed for single axis gantry")
 	}
 
+	if config.ControlFrequencyHz < 0 || config.ControlFrequencyHz > maxControlFrequencyHz {
+		return nil, errors.Errorf("control_frequency_hz must be between 0 and %v", maxControlFrequencyHz)
+	}
+
+	// gains which are all zero would tune the PID block rather than move the gantry
+	if pid := config.PositionPID; pid != nil && pid.KP == 0 && pid.KI == 0 && pid.KD == 0 {
+		return nil, errors.New("position_pid needs at least one of kP, kI or kD")
+	}
+
 	return deps, nil
 }
 
@@ -128,6 +152,9 @@ type oneAxis struct {
 	model referenceframe.Model
 	axis  r3.Vector
 
+	positionPID        *control.PIDConfig
+	controlFrequencyHz float64
+
 	logger golog.Logger
 	opMgr  operation.SingleOperationManager
 }
@@ -169,6 +196,11 @@ func newOneAxis(ctx context.Context, deps registry.Dependencies, config config.C
 		mmPerRevolution: conf.MmPerRevolution,
 		rpm:             conf.GantryRPM,
 		axis:            r3.Vector(conf.Axis),
+		positionPID:     conf.PositionPID,
+	}
+	oAx.controlFrequencyHz = conf.ControlFrequencyHz
+	if oAx.controlFrequencyHz == 0 {
+		oAx.controlFrequencyHz = defaultControlFrequencyHz
 	}
 
 	switch len(oAx.limitSwitchPins) {
@@ -261,7 +293,7 @@ func (g *oneAxis) homeTwoLimSwitch(ctx context.Context) error {
 
 	// Go backwards so limit stops are not hit.
 	x := g.rotationalToLinear(0.8 * g.lengthMm)
-	err = g.motor.GoTo(ctx, g.rpm, x, nil)
+	err = g.goTo(ctx, x, nil)
 	if err != nil {
 		return err
 	}
@@ -428,13 +460,61 @@ func (g *oneAxis) MoveToPosition(
 		return g.motor.Stop(ctx, extra)
 	}
 
-	err = g.motor.GoTo(ctx, g.rpm, x, extra)
+	err = g.goTo(ctx, x, extra)
 	if err != nil {
 		return err
 	}
 	return nil
 }
 
+// goTo moves the motor to the position x, in revolutions, with a control loop when the gantry has PID gains, or otherwise with
+// GoTo.
+func (g *oneAxis) goTo(ctx context.Context, x float64, extra map[string]interface{}) error {
+	if g.positionPID == nil {
+		return g.motor.GoTo(ctx, g.rpm, x, extra)
+	}
+	return g.controlTo(ctx, x)
+}
+
+// controlTo runs a control loop which drives the motor to the position x, in revolutions, and stops the motor once the gantry
+// is within controlToleranceMm of it.
+func (g *oneAxis) controlTo(ctx context.Context, x float64) (err error) {
+	gains := *g.positionPID
+	// the output of the loop is the power of the motor
+	if gains.OutputLimit == 0 || gains.OutputLimit > 1 {
+		gains.OutputLimit = 1
+	}
+	loop, err := control.NewLoop(g.logger, control.PIDLoopConfig(gains, x, g.controlFrequencyHz), g.motor)
+	if err != nil {
+		return err
+	}
+	if err := loop.Start(); err != nil {
+		return err
+	}
+	defer func() {
+		loop.Stop()
+		err = multierr.Combine(err, g.motor.Stop(context.Background(), nil))
+	}()
+
+	tolerance := controlToleranceMm / g.lengthMm * math.Abs(g.positionLimits[1]-g.positionLimits[0])
+	ticker := time.NewTicker(time.Duration(float64(time.Second) / g.controlFrequencyHz))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		pos, err := g.motor.Position(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if math.Abs(pos-x) <= tolerance {
+			return nil
+		}
+	}
+}
+
 // Stop stops the motor of the gantry.
 func (g *oneAxis) Stop(ctx context.Context, extra map[string]interface{}) error {
 	ctx, done := g.opMgr.New(ctx)
@@ -2,7 +2,9 @@ package oneaxis
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
@@ -15,6 +17,7 @@ import (
 	"go.viam.com/rdk/components/motor"
 	"go.viam.com/rdk/components/motor/fake"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/control"
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/registry"
 	spatial "go.viam.com/rdk/spatialmath"
@@ -165,6 +168,17 @@ func TestValidate(t *testing.T) {
 	deps, err = fakecfg.Validate("path")
 	test.That(t, deps, test.ShouldResemble, []string{fakecfg.Motor, fakecfg.Board})
 	test.That(t, err, test.ShouldBeNil)
+
+	fakecfg.PositionPID = &control.PIDConfig{}
+	deps, err = fakecfg.Validate("path")
+	test.That(t, deps, test.ShouldBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "position_pid")
+
+	fakecfg.PositionPID = &control.PIDConfig{KP: 1}
+	fakecfg.ControlFrequencyHz = 1000
+	deps, err = fakecfg.Validate("path")
+	test.That(t, deps, test.ShouldBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "control_frequency_hz")
 }
 
 func TestNewOneAxis(t *testing.T) {
@@ -682,6 +696,71 @@ func TestMoveToPosition(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestMoveToPositionControlLoop(t *testing.T) {
+	ctx := context.Background()
+	// the motor turns at up to 10 revolutions per second
+	var mu sync.Mutex
+	var position, power float64
+	var last time.Time
+	advance := func() {
+		now := time.Now()
+		if !last.IsZero() {
+			position += 10 * power * now.Sub(last).Seconds()
+		}
+		last = now
+	}
+	fakeMotor := &inject.Motor{
+		SetPowerFunc: func(ctx context.Context, powerPct float64, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			advance()
+			power = powerPct
+			return nil
+		},
+		PositionFunc: func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			advance()
+			return position, nil
+		},
+		StopFunc: func(ctx context.Context, extra map[string]interface{}) error {
+			mu.Lock()
+			defer mu.Unlock()
+			advance()
+			power = 0
+			return nil
+		},
+		GoToFunc: func(ctx context.Context, rpm, rotations float64, extra map[string]interface{}) error {
+			return errors.New("the control loop should move the gantry")
+		},
+	}
+	// neither limit switch is hit
+	pin := &inject.GPIOPin{GetFunc: func(ctx context.Context, extra map[string]interface{}) (bool, error) { return false, nil }}
+	fakegantry := &oneAxis{
+		logger:             golog.NewTestLogger(t),
+		board:              &inject.Board{GPIOPinByNameFunc: func(name string) (board.GPIOPin, error) { return pin, nil }},
+		motor:              fakeMotor,
+		limitHigh:          true,
+		limitSwitchPins:    []string{"1", "2"},
+		lengthMm:           100,
+		positionLimits:     []float64{0, 10},
+		positionPID:        &control.PIDConfig{KP: 5},
+		controlFrequencyHz: 100,
+	}
+	test.That(t, fakegantry.MoveToPosition(ctx, []float64{50}, &commonpb.WorldState{}, nil), test.ShouldBeNil)
+	pos, err := fakegantry.Position(ctx, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pos[0], test.ShouldAlmostEqual, 50, controlToleranceMm*2)
+	mu.Lock()
+	test.That(t, power, test.ShouldEqual, 0)
+	mu.Unlock()
+
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	err = fakegantry.MoveToPosition(cancelCtx, []float64{10}, &commonpb.WorldState{}, nil)
+	test.That(t, err, test.ShouldBeError, context.Canceled)
+}
+
 func TestModelFrame(t *testing.T) {
 	fakegantry := &oneAxis{
 		name:     "test",
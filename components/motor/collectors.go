@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/protobuf/types/known/anypb"
 
+	"go.viam.com/rdk/control"
 	"go.viam.com/rdk/data"
 )
 
@@ -13,6 +14,7 @@ type method int64
 const (
 	position method = iota
 	isPowered
+	controlSignals
 )
 
 func (m method) String() string {
@@ -21,6 +23,8 @@ func (m method) String() string {
 		return "GetPosition"
 	case isPowered:
 		return "IsPowered"
+	case controlSignals:
+		return "ControlSignals"
 	}
 	return "Unknown"
 }
@@ -67,6 +71,27 @@ func newIsPoweredCollector(resource interface{}, params data.CollectorParams) (d
 	return data.NewCollector(cFunc, params)
 }
 
+// ControlSignals wraps the internal signals of the control loop of a motor.
+type ControlSignals struct {
+	Signals map[string]interface{}
+}
+
+func newControlSignalsCollector(resource interface{}, params data.CollectorParams) (data.Collector, error) {
+	motor, err := assertMotor(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	cFunc := data.CaptureFunc(func(ctx context.Context, _ map[string]*anypb.Any) (interface{}, error) {
+		v, err := motor.DoCommand(ctx, map[string]interface{}{control.SignalsCommand: true})
+		if err != nil {
+			return nil, data.FailedToReadErr(params.ComponentName, controlSignals.String(), err)
+		}
+		return ControlSignals{Signals: v}, nil
+	})
+	return data.NewCollector(cFunc, params)
+}
+
 func assertMotor(resource interface{}) (Motor, error) {
 	motor, ok := resource.(Motor)
 	if !ok {
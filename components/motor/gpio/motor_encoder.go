@@ -534,6 +534,14 @@ func (m *EncodedMotor) IsPowered(ctx context.Context, extra map[string]interface
 	return m.real.IsPowered(ctx, extra)
 }
 
+// DoCommand returns the internal signals of the control loop when given a control.SignalsCommand.
+func (m *EncodedMotor) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if !control.IsSignalsCommand(cmd) || m.loop == nil {
+		return nil, generic.ErrUnimplemented
+	}
+	return m.loop.Signals(ctx), nil
+}
+
 // Close cleanly shuts down the motor.
 func (m *EncodedMotor) Close() {
 	if m.loop != nil {
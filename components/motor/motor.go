@@ -45,6 +45,10 @@ func init() {
 		Subtype:    SubtypeName,
 		MethodName: isPowered.String(),
 	}, newIsPoweredCollector)
+	data.RegisterCollector(data.MethodMetadata{
+		Subtype:    SubtypeName,
+		MethodName: controlSignals.String(),
+	}, newControlSignalsCollector)
 }
 
 // SubtypeName is a constant that identifies the component resource subtype string "motor".
@@ -8,4 +8,5 @@ import (
 	_ "go.viam.com/rdk/components/movementsensor/gpsnmea"
 	_ "go.viam.com/rdk/components/movementsensor/gpsrtk"
 	_ "go.viam.com/rdk/components/movementsensor/imuwit"
+	_ "go.viam.com/rdk/components/movementsensor/wheeledodometry"
 )
@@ -0,0 +1,65 @@
+package wheeledodometry
+
+import (
+	"math"
+)
+
+// Odometry is the pose of a base in the plane, found by dead reckoning from where it started, along with the covariance of the
+// pose. The base starts at the origin facing along the y axis, and its heading is the counterclockwise angle it has turned
+// through since.
+type Odometry struct {
+	X, Y       float64       // mm
+	Theta      float64       // radians
+	Covariance [3][3]float64 // of X, Y and Theta
+}
+
+// WheelMotion returns the distance driven forwards by a differential drive base, and the change in its heading, when its left
+// and right wheels travel the given distances. Each wheel slips by a variance proportional to its distance travelled, which
+// gives the covariance of the motion.
+func WheelMotion(leftMm, rightMm, widthMm, slipVariancePerMm float64) (float64, float64, [2][2]float64) {
+	leftVariance, rightVariance := slipVariancePerMm*math.Abs(leftMm), slipVariancePerMm*math.Abs(rightMm)
+	return (leftMm + rightMm) / 2, (rightMm - leftMm) / widthMm, [2][2]float64{
+		{(leftVariance + rightVariance) / 4, (rightVariance - leftVariance) / (2 * widthMm)},
+		{(rightVariance - leftVariance) / (2 * widthMm), (leftVariance + rightVariance) / (widthMm * widthMm)},
+	}
+}
+
+// Move updates the pose of a base which drives the given distance forwards while its heading changes by the given angle, where
+// the distance and angle have the given covariance. The base is taken to drive in the direction halfway between its headings
+// before and after the motion.
+func (o *Odometry) Move(distanceMm, angleRad float64, covariance [2][2]float64) {
+	direction := o.Theta + angleRad/2
+	sin, cos := math.Sin(direction), math.Cos(direction)
+
+	// the covariance is propagated through the jacobians of the new pose with respect to the old pose and to the motion
+	poseJacobian := [3][3]float64{
+		{1, 0, -distanceMm * cos},
+		{0, 1, -distanceMm * sin},
+		{0, 0, 1},
+	}
+	motionJacobian := [3][2]float64{
+		{-sin, -distanceMm * cos / 2},
+		{cos, -distanceMm * sin / 2},
+		{0, 1},
+	}
+	var updated [3][3]float64
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				for l := 0; l < 3; l++ {
+					updated[i][j] += poseJacobian[i][k] * o.Covariance[k][l] * poseJacobian[j][l]
+				}
+			}
+			for k := 0; k < 2; k++ {
+				for l := 0; l < 2; l++ {
+					updated[i][j] += motionJacobian[i][k] * covariance[k][l] * motionJacobian[j][l]
+				}
+			}
+		}
+	}
+
+	o.X -= distanceMm * sin
+	o.Y += distanceMm * cos
+	o.Theta += angleRad
+	o.Covariance = updated
+}
@@ -0,0 +1,374 @@
+// Package wheeledodometry implements a movement sensor which tracks the pose of a differential drive base by dead reckoning
+// from the positions of the motors which drive its wheels.
+package wheeledodometry
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/spatialmath"
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+const (
+	modelname = "wheeled_odometry"
+
+	defaultPollingFrequencyHz = 20.
+)
+
+// AttrConfig is used for converting config attributes of a wheeled odometry movement sensor. The wheels and width are those of
+// the wheeled base whose pose is tracked. When an angular velocity sensor is given, the heading of the base is tracked from its
+// readings instead of from the difference between its wheels. The base starts at the origin facing the start compass heading.
+type AttrConfig struct {
+	Left                  []string `json:"left"`
+	Right                 []string `json:"right"`
+	WidthMM               int      `json:"width_mm"`
+	WheelCircumferenceMM  int      `json:"wheel_circumference_mm"`
+	AngularVelocitySensor string   `json:"angular_velocity_sensor,omitempty"`
+	PollingFrequencyHz    float64  `json:"polling_frequency_hz,omitempty"`
+	SlipVariancePerMM     float64  `json:"slip_variance_per_mm,omitempty"`
+	GyroVariancePerSec    float64  `json:"gyro_variance_per_sec,omitempty"`
+	OriginLatitude        float64  `json:"origin_latitude,omitempty"`
+	OriginLongitude       float64  `json:"origin_longitude,omitempty"`
+	StartCompassHeading   float64  `json:"start_compass_heading,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (cfg *AttrConfig) Validate(path string) ([]string, error) {
+	if len(cfg.Left) == 0 || len(cfg.Right) == 0 {
+		return nil, utils.NewConfigValidationError(path, errors.New("need left and right motors"))
+	}
+	if cfg.WidthMM <= 0 {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "width_mm")
+	}
+	if cfg.WheelCircumferenceMM <= 0 {
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "wheel_circumference_mm")
+	}
+	if cfg.PollingFrequencyHz < 0 || cfg.SlipVariancePerMM < 0 || cfg.GyroVariancePerSec < 0 {
+		return nil, utils.NewConfigValidationError(path,
+			errors.New("polling_frequency_hz, slip_variance_per_mm and gyro_variance_per_sec cannot be negative"))
+	}
+
+	var deps []string
+	deps = append(deps, cfg.Left...)
+	deps = append(deps, cfg.Right...)
+	if cfg.AngularVelocitySensor != "" {
+		deps = append(deps, cfg.AngularVelocitySensor)
+	}
+	return deps, nil
+}
+
+func init() {
+	registry.RegisterComponent(
+		movementsensor.Subtype,
+		modelname,
+		registry.Component{
+			Constructor: func(
+				ctx context.Context,
+				deps registry.Dependencies,
+				config config.Component,
+				logger golog.Logger,
+			) (interface{}, error) {
+				conf, ok := config.ConvertedAttributes.(*AttrConfig)
+				if !ok {
+					return nil, rdkutils.NewUnexpectedTypeError(conf, config.ConvertedAttributes)
+				}
+				return newWheeledOdometry(ctx, deps, conf, logger)
+			},
+		})
+	config.RegisterComponentAttributeMapConverter(
+		movementsensor.SubtypeName,
+		modelname,
+		func(attributes config.AttributeMap) (interface{}, error) {
+			var conf AttrConfig
+			return config.TransformAttributeMapToStruct(&conf, attributes)
+		},
+		&AttrConfig{})
+}
+
+type wheeledOdometry struct {
+	generic.Unimplemented
+	left, right          []motor.Motor
+	gyro                 movementsensor.MovementSensor
+	widthMm              float64
+	wheelCircumferenceMm float64
+	slipVariancePerMm    float64
+	gyroVariancePerSec   float64
+	origin               *geo.Point
+	startCompassHeading  float64
+
+	mu                  sync.Mutex
+	odometry            Odometry
+	linearVelocity      r3.Vector
+	angularVelocity     spatialmath.AngularVelocity
+	lastLeft, lastRight float64
+	lastTime            time.Time
+	lastErr             error
+
+	cancelFunc              func()
+	activeBackgroundWorkers sync.WaitGroup
+	logger                  golog.Logger
+}
+
+func newWheeledOdometry(
+	ctx context.Context,
+	deps registry.Dependencies,
+	conf *AttrConfig,
+	logger golog.Logger,
+) (movementsensor.MovementSensor, error) {
+	o := &wheeledOdometry{
+		widthMm:              float64(conf.WidthMM),
+		wheelCircumferenceMm: float64(conf.WheelCircumferenceMM),
+		slipVariancePerMm:    conf.SlipVariancePerMM,
+		gyroVariancePerSec:   conf.GyroVariancePerSec,
+		origin:               geo.NewPoint(conf.OriginLatitude, conf.OriginLongitude),
+		startCompassHeading:  conf.StartCompassHeading,
+		logger:               logger,
+	}
+	for _, name := range conf.Left {
+		m, err := motor.FromDependencies(deps, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "no left motor named (%s)", name)
+		}
+		o.left = append(o.left, m)
+	}
+	for _, name := range conf.Right {
+		m, err := motor.FromDependencies(deps, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "no right motor named (%s)", name)
+		}
+		o.right = append(o.right, m)
+	}
+	if conf.AngularVelocitySensor != "" {
+		gyro, err := movementsensor.FromDependencies(deps, conf.AngularVelocitySensor)
+		if err != nil {
+			return nil, err
+		}
+		o.gyro = gyro
+	}
+
+	// the pose is tracked from where the wheels are now
+	if err := o.reset(ctx); err != nil {
+		return nil, err
+	}
+
+	frequency := conf.PollingFrequencyHz
+	if frequency == 0 {
+		frequency = defaultPollingFrequencyHz
+	}
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+	o.cancelFunc = cancelFunc
+	o.activeBackgroundWorkers.Add(1)
+	utils.ManagedGo(func() {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / frequency))
+		defer ticker.Stop()
+		for {
+			select {
+			case <-cancelCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			if err := o.update(cancelCtx); err != nil && !errors.Is(err, context.Canceled) {
+				o.logger.Debugw("failed to update odometry", "error", err)
+			}
+		}
+	}, o.activeBackgroundWorkers.Done)
+	return o, nil
+}
+
+// wheelPositions returns the average distance travelled by the wheels on each side of the base.
+func (o *wheeledOdometry) wheelPositions(ctx context.Context) (float64, float64, error) {
+	average := func(motors []motor.Motor) (float64, error) {
+		total := 0.
+		for _, m := range motors {
+			revolutions, err := m.Position(ctx, nil)
+			if err != nil {
+				return 0, err
+			}
+			total += revolutions
+		}
+		return total / float64(len(motors)) * o.wheelCircumferenceMm, nil
+	}
+	left, err := average(o.left)
+	if err != nil {
+		return 0, 0, err
+	}
+	right, err := average(o.right)
+	if err != nil {
+		return 0, 0, err
+	}
+	return left, right, nil
+}
+
+// reset moves the base back to the origin, with no uncertainty in its pose.
+func (o *wheeledOdometry) reset(ctx context.Context) error {
+	left, right, err := o.wheelPositions(ctx)
+	if err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.odometry = Odometry{}
+	o.linearVelocity = r3.Vector{}
+	o.angularVelocity = spatialmath.AngularVelocity{}
+	o.lastLeft, o.lastRight, o.lastTime = left, right, time.Now()
+	o.lastErr = nil
+	return nil
+}
+
+// update moves the base by the distance its wheels have travelled since the last update.
+func (o *wheeledOdometry) update(ctx context.Context) error {
+	left, right, err := o.wheelPositions(ctx)
+	var rate spatialmath.AngularVelocity
+	if err == nil && o.gyro != nil {
+		rate, err = o.gyro.AngularVelocity(ctx)
+	}
+	now := time.Now()
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.lastErr = err
+	if err != nil {
+		return err
+	}
+	seconds := now.Sub(o.lastTime).Seconds()
+	distance, angle, covariance := WheelMotion(left-o.lastLeft, right-o.lastRight, o.widthMm, o.slipVariancePerMm)
+	if o.gyro != nil {
+		angle = rdkutils.DegToRad(rate.Z) * seconds
+		covariance = [2][2]float64{{covariance[0][0], 0}, {0, o.gyroVariancePerSec * seconds}}
+	}
+	o.odometry.Move(distance, angle, covariance)
+	if seconds > 0 {
+		o.linearVelocity = r3.Vector{Y: distance / seconds}
+		o.angularVelocity = spatialmath.AngularVelocity{Z: rdkutils.RadToDeg(angle) / seconds}
+	}
+	o.lastLeft, o.lastRight, o.lastTime = left, right, now
+	return nil
+}
+
+// Odometry returns the current pose of the base, and its covariance.
+func (o *wheeledOdometry) Odometry() (Odometry, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.odometry, o.lastErr
+}
+
+// Position returns the geographic point reached by moving from the origin as far as the base has since it started.
+func (o *wheeledOdometry) Position(ctx context.Context) (*geo.Point, float64, error) {
+	odometry, err := o.Odometry()
+	if err != nil {
+		return nil, 0, err
+	}
+	// the base started facing along y, with x to its right
+	bearing := o.startCompassHeading + rdkutils.RadToDeg(math.Atan2(odometry.X, odometry.Y))
+	return o.origin.PointAtDistanceAndBearing(math.Hypot(odometry.X, odometry.Y)/1e6, bearing), 0, nil
+}
+
+// CompassHeading returns the compass heading of the base, which turns clockwise from the start compass heading as the base
+// turns counterclockwise.
+func (o *wheeledOdometry) CompassHeading(ctx context.Context) (float64, error) {
+	odometry, err := o.Odometry()
+	if err != nil {
+		return 0, err
+	}
+	heading := math.Mod(o.startCompassHeading-rdkutils.RadToDeg(odometry.Theta), 360)
+	if heading < 0 {
+		heading += 360
+	}
+	return heading, nil
+}
+
+// Orientation returns the rotation of the base about the z axis since it started.
+func (o *wheeledOdometry) Orientation(ctx context.Context) (spatialmath.Orientation, error) {
+	odometry, err := o.Odometry()
+	if err != nil {
+		return nil, err
+	}
+	return &spatialmath.R4AA{Theta: odometry.Theta, RZ: 1}, nil
+}
+
+// LinearVelocity returns the forwards velocity of the base over the last update.
+func (o *wheeledOdometry) LinearVelocity(ctx context.Context) (r3.Vector, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.linearVelocity, o.lastErr
+}
+
+// AngularVelocity returns the rate at which the base turned over the last update.
+func (o *wheeledOdometry) AngularVelocity(ctx context.Context) (spatialmath.AngularVelocity, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.angularVelocity, o.lastErr
+}
+
+// Accuracy returns the standard deviations of the position of the base, in mm, along its starting x and y axes.
+func (o *wheeledOdometry) Accuracy(ctx context.Context) (map[string]float32, error) {
+	odometry, err := o.Odometry()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]float32{
+		"x": float32(math.Sqrt(odometry.Covariance[0][0])),
+		"y": float32(math.Sqrt(odometry.Covariance[1][1])),
+	}, nil
+}
+
+func (o *wheeledOdometry) Properties(ctx context.Context) (*movementsensor.Properties, error) {
+	return &movementsensor.Properties{
+		LinearVelocitySupported:  true,
+		AngularVelocitySupported: true,
+		OrientationSupported:     true,
+		PositionSupported:        true,
+		CompassHeadingSupported:  true,
+	}, nil
+}
+
+// Readings returns the readings of a movement sensor, along with the pose of the base in its starting frame and its covariance.
+func (o *wheeledOdometry) Readings(ctx context.Context) (map[string]interface{}, error) {
+	readings, err := movementsensor.Readings(ctx, o)
+	if err != nil {
+		return nil, err
+	}
+	odometry, err := o.Odometry()
+	if err != nil {
+		return nil, err
+	}
+	readings["x_mm"] = odometry.X
+	readings["y_mm"] = odometry.Y
+	readings["theta_degs"] = rdkutils.RadToDeg(odometry.Theta)
+	covariance := make([]interface{}, 0, 9)
+	for _, row := range odometry.Covariance {
+		for _, value := range row {
+			covariance = append(covariance, value)
+		}
+	}
+	readings["covariance"] = covariance
+	return readings, nil
+}
+
+// DoCommand resets the pose of the base to the origin when given a "reset" command.
+func (o *wheeledOdometry) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if _, ok := cmd["reset"]; ok {
+		return map[string]interface{}{}, o.reset(ctx)
+	}
+	return nil, generic.ErrUnimplemented
+}
+
+// Close stops tracking the pose of the base.
+func (o *wheeledOdometry) Close() {
+	o.cancelFunc()
+	o.activeBackgroundWorkers.Wait()
+}
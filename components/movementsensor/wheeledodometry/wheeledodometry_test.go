@@ -0,0 +1,204 @@
+package wheeledodometry
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/motor"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/registry"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+)
+
+func TestOdometryMove(t *testing.T) {
+	var odometry Odometry
+
+	// driving straight ahead leaves the base uncertain of how far it went, and so of how far along y it is
+	odometry.Move(WheelMotion(1000, 1000, 100, 0.01))
+	test.That(t, odometry.X, test.ShouldAlmostEqual, 0)
+	test.That(t, odometry.Y, test.ShouldAlmostEqual, 1000)
+	test.That(t, odometry.Theta, test.ShouldAlmostEqual, 0)
+	test.That(t, odometry.Covariance[1][1], test.ShouldAlmostEqual, 5)
+	test.That(t, odometry.Covariance[2][2], test.ShouldAlmostEqual, 20./(100*100))
+	// both wheels slipping also turns the base, which it does halfway along on average
+	test.That(t, odometry.Covariance[0][0], test.ShouldAlmostEqual, 500*500*odometry.Covariance[2][2])
+
+	// turning in place a quarter turn counterclockwise does not move the base
+	quarter := math.Pi / 4 * 100
+	odometry.Move(WheelMotion(-quarter, quarter, 100, 0.01))
+	test.That(t, odometry.X, test.ShouldAlmostEqual, 0)
+	test.That(t, odometry.Y, test.ShouldAlmostEqual, 1000)
+	test.That(t, odometry.Theta, test.ShouldAlmostEqual, math.Pi/2)
+
+	// the uncertainty in the heading, which grew while turning, becomes uncertainty across the direction of travel
+	before := odometry.Covariance
+	odometry.Move(WheelMotion(500, 500, 100, 0))
+	test.That(t, odometry.X, test.ShouldAlmostEqual, -500)
+	test.That(t, odometry.Y, test.ShouldAlmostEqual, 1000)
+	test.That(t, odometry.Covariance[0][0], test.ShouldAlmostEqual, before[0][0])
+	test.That(t, odometry.Covariance[1][1], test.ShouldAlmostEqual, before[1][1]+500*500*before[2][2])
+
+	// a quarter circle of radius 500 driven in small steps ends where the arc does
+	odometry = Odometry{}
+	for i := 0; i < 100; i++ {
+		inner, outer := 450*math.Pi/2/100, 550*math.Pi/2/100
+		odometry.Move(WheelMotion(outer, inner, 100, 0))
+	}
+	test.That(t, odometry.X, test.ShouldAlmostEqual, 500, 0.01)
+	test.That(t, odometry.Y, test.ShouldAlmostEqual, 500, 0.01)
+	test.That(t, odometry.Theta, test.ShouldAlmostEqual, -math.Pi/2)
+}
+
+// fakeWheel is a motor whose position is set by the test.
+type fakeWheel struct {
+	mu          sync.Mutex
+	revolutions float64
+}
+
+func (w *fakeWheel) motor() motor.Motor {
+	m := &inject.Motor{}
+	m.PositionFunc = func(ctx context.Context, extra map[string]interface{}) (float64, error) {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return w.revolutions, nil
+	}
+	return m
+}
+
+func (w *fakeWheel) turn(revolutions float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.revolutions += revolutions
+}
+
+func TestWheeledOdometry(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	left, right := &fakeWheel{revolutions: 3}, &fakeWheel{revolutions: -2}
+	deps := registry.Dependencies{motor.Named("left"): left.motor(), motor.Named("right"): right.motor()}
+	conf := &AttrConfig{
+		Left:                 []string{"left"},
+		Right:                []string{"right"},
+		WidthMM:              100,
+		WheelCircumferenceMM: 1000,
+		SlipVariancePerMM:    0.01,
+		OriginLatitude:       40.7,
+		OriginLongitude:      -73.98,
+		StartCompassHeading:  90,
+		// updates are made by the test
+		PollingFrequencyHz: 0.001,
+	}
+	names, err := conf.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldResemble, []string{"left", "right"})
+
+	sensor, err := newWheeledOdometry(ctx, deps, conf, logger)
+	test.That(t, err, test.ShouldBeNil)
+	o := sensor.(*wheeledOdometry)
+	defer o.Close()
+
+	// the base starts at the origin, wherever its wheels are
+	position, _, err := o.Position(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, position.Lat(), test.ShouldAlmostEqual, 40.7)
+	test.That(t, position.Lng(), test.ShouldAlmostEqual, -73.98)
+
+	// driving forwards goes along the start compass heading
+	left.turn(2)
+	right.turn(2)
+	test.That(t, o.update(ctx), test.ShouldBeNil)
+	position, _, err = o.Position(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, o.origin.GreatCircleDistance(position), test.ShouldAlmostEqual, 0.002, 1e-6)
+	test.That(t, o.origin.BearingTo(position), test.ShouldAlmostEqual, 90, 1e-3)
+	accuracy, err := o.Accuracy(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, accuracy["y"], test.ShouldAlmostEqual, math.Sqrt(10), 1e-3)
+
+	// turning counterclockwise turns the compass heading back
+	left.turn(-math.Pi / 4 / 10)
+	right.turn(math.Pi / 4 / 10)
+	test.That(t, o.update(ctx), test.ShouldBeNil)
+	heading, err := o.CompassHeading(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, math.Remainder(heading, 360), test.ShouldAlmostEqual, 0)
+	orientation, err := o.Orientation(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, orientation.AxisAngles().Theta, test.ShouldAlmostEqual, math.Pi/2)
+
+	readings, err := o.Readings(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, readings["y_mm"], test.ShouldAlmostEqual, 2000)
+	test.That(t, readings["theta_degs"], test.ShouldAlmostEqual, 90)
+	test.That(t, readings["covariance"], test.ShouldHaveLength, 9)
+
+	_, err = o.DoCommand(ctx, map[string]interface{}{"reset": true})
+	test.That(t, err, test.ShouldBeNil)
+	odometry, err := o.Odometry()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, odometry, test.ShouldResemble, Odometry{})
+}
+
+func TestWheeledOdometryWithGyro(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	left, right := &fakeWheel{}, &fakeWheel{}
+	gyro := &inject.MovementSensor{}
+	gyro.AngularVelocityFunc = func(ctx context.Context) (spatialmath.AngularVelocity, error) {
+		return spatialmath.AngularVelocity{Z: 10}, nil
+	}
+	deps := registry.Dependencies{
+		motor.Named("left"):          left.motor(),
+		motor.Named("right"):         right.motor(),
+		movementsensor.Named("gyro"): gyro,
+	}
+	conf := &AttrConfig{
+		Left:                  []string{"left"},
+		Right:                 []string{"right"},
+		WidthMM:               100,
+		WheelCircumferenceMM:  1000,
+		AngularVelocitySensor: "gyro",
+		PollingFrequencyHz:    0.001,
+	}
+	names, err := conf.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, names, test.ShouldContain, "gyro")
+
+	sensor, err := newWheeledOdometry(ctx, deps, conf, logger)
+	test.That(t, err, test.ShouldBeNil)
+	o := sensor.(*wheeledOdometry)
+	defer o.Close()
+
+	// the heading follows the gyro, even though the wheels would turn the base the other way
+	left.turn(0.01)
+	test.That(t, o.update(ctx), test.ShouldBeNil)
+	odometry, err := o.Odometry()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, odometry.Theta, test.ShouldBeGreaterThan, 0)
+	velocity, err := o.AngularVelocity(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, velocity.Z, test.ShouldAlmostEqual, 10)
+}
+
+func TestValidate(t *testing.T) {
+	conf := &AttrConfig{Left: []string{"left"}, WidthMM: 100, WheelCircumferenceMM: 1000}
+	_, err := conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	conf.Right = []string{"right"}
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	conf.WidthMM = 0
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "width_mm")
+	conf.WidthMM = 100
+	conf.SlipVariancePerMM = -1
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+}
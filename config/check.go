@@ -0,0 +1,199 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/resource"
+)
+
+// A Problem is something wrong with the part of a config at a JSON path.
+type Problem struct {
+	Path string
+	Err  error
+}
+
+// Error returns the path of the problem along with what is wrong there.
+func (p Problem) Error() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Err)
+}
+
+// A CheckResult is what was found by checking a config.
+type CheckResult struct {
+	// Config is the config with as many attributes converted and dependencies resolved as could be.
+	Config *Config
+	// Graph is the graph of the dependencies between the components and services of the config, none of which are built.
+	Graph    *resource.Graph
+	Problems []Problem
+}
+
+// ReadUnprocessed reads a config from the given file, without validating or processing it in any way.
+func ReadUnprocessed(filePath string) (*Config, error) {
+	buf, _, err := readConfigFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	cfg := Config{ConfigFilePath: filePath}
+	if err := json.NewDecoder(bytes.NewReader(buf)).Decode(&cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode Config from json")
+	}
+	return &cfg, nil
+}
+
+// Check validates every part of the given config as processing it would, converting the attributes of its components and
+// services with their registered converters and resolving the dependencies between them. Unlike processing, it reports every
+// problem found instead of stopping at the first.
+func Check(unprocessedConfig *Config, fromCloud bool) *CheckResult {
+	result := &CheckResult{Graph: resource.NewGraph()}
+	addProblem := func(path string, err error) {
+		result.Problems = append(result.Problems, Problem{Path: path, Err: err})
+	}
+
+	cfg, err := unprocessedConfig.CopyOnlyPublicFields()
+	if err != nil {
+		addProblem("", errors.Wrap(err, "error copying config"))
+		result.Config = unprocessedConfig
+		return result
+	}
+	cfg.ConfigFilePath = unprocessedConfig.ConfigFilePath
+	result.Config = cfg
+
+	if cfg.Cloud != nil {
+		if err := cfg.Cloud.Validate("cloud", fromCloud); err != nil {
+			addProblem("cloud", err)
+		}
+	}
+
+	remotes := make(map[string]bool, len(cfg.Remotes))
+	for idx := range cfg.Remotes {
+		path := fmt.Sprintf("%s.%d", "remotes", idx)
+		if err := cfg.Remotes[idx].Validate(path); err != nil {
+			addProblem(path, err)
+			continue
+		}
+		remotes[cfg.Remotes[idx].Name] = true
+	}
+
+	components := make(map[string]resource.Name, len(cfg.Components))
+	for idx := range cfg.Components {
+		c := &cfg.Components[idx]
+		path := fmt.Sprintf("%s.%d", "components", idx)
+		if err := convertComponentAttributes(c); err != nil {
+			addProblem(path+".attributes", err)
+			continue
+		}
+		dependsOn, err := c.Validate(path)
+		if err != nil {
+			addProblem(path, err)
+			continue
+		}
+		c.ImplicitDependsOn = dependsOn
+		if _, ok := components[c.Name]; ok {
+			addProblem(path+".name", errors.Errorf("component name %q is not unique", c.Name))
+			continue
+		}
+		components[c.Name] = c.ResourceName()
+		result.Graph.AddNode(c.ResourceName(), c)
+	}
+
+	// dependencies are resolved once every component is known, since they may be in any order
+	for idx := range cfg.Components {
+		c := &cfg.Components[idx]
+		name, ok := components[c.Name]
+		if !ok || name != c.ResourceName() {
+			continue
+		}
+		path := fmt.Sprintf("%s.%d", "components", idx)
+		depPaths := make([]string, 0, len(c.DependsOn)+len(c.ImplicitDependsOn))
+		for depIdx := range c.DependsOn {
+			depPaths = append(depPaths, fmt.Sprintf("%s.depends_on.%d", path, depIdx))
+		}
+		for range c.ImplicitDependsOn {
+			// implicit dependencies come from the attributes
+			depPaths = append(depPaths, path+".attributes")
+		}
+		for depIdx, dep := range append(append([]string{}, c.DependsOn...), c.ImplicitDependsOn...) {
+			depPath := depPaths[depIdx]
+			parent, ok := components[dep]
+			if !ok {
+				if remote := strings.Split(dep, ":")[0]; strings.Contains(dep, ":") && remotes[remote] {
+					// resources of remotes are only known once connected to
+					continue
+				}
+				addProblem(depPath, errors.Errorf("unknown dependency %q", dep))
+				continue
+			}
+			if err := result.Graph.AddChildren(name, parent); err != nil {
+				addProblem(depPath, err)
+			}
+		}
+	}
+
+	for idx := range cfg.Processes {
+		path := fmt.Sprintf("%s.%d", "processes", idx)
+		if err := cfg.Processes[idx].Validate(path); err != nil {
+			addProblem(path, err)
+		}
+	}
+
+	services := make(map[string][]resource.Name, len(cfg.Services))
+	for idx := range cfg.Services {
+		s := &cfg.Services[idx]
+		path := fmt.Sprintf("%s.%d", "services", idx)
+		if err := convertServiceAttributes(s); err != nil {
+			addProblem(path+".attributes", err)
+			continue
+		}
+		if err := s.Validate(path); err != nil {
+			addProblem(path, err)
+			continue
+		}
+		if _, ok := result.Graph.Node(s.ResourceName()); ok {
+			addProblem(path+".name", errors.Errorf("service name %q is not unique", s.Name))
+			continue
+		}
+		result.Graph.AddNode(s.ResourceName(), s)
+		services[s.Name] = append(services[s.Name], s.ResourceName())
+	}
+
+	// services are built after components, and look up what their attributes name from every resource of the robot
+	for idx := range cfg.Services {
+		s := &cfg.Services[idx]
+		if node, ok := result.Graph.Node(s.ResourceName()); !ok || node != s {
+			continue
+		}
+		path := fmt.Sprintf("%s.%d", "services", idx)
+		for _, dep := range s.ImplicitDependsOn {
+			parents := services[dep]
+			if parent, ok := components[dep]; ok {
+				parents = []resource.Name{parent}
+			}
+			if len(parents) == 0 {
+				if remote := strings.Split(dep, ":")[0]; strings.Contains(dep, ":") && remotes[remote] {
+					continue
+				}
+				addProblem(path+".attributes", errors.Errorf("unknown dependency %q", dep))
+				continue
+			}
+			for _, parent := range parents {
+				if err := result.Graph.AddChildren(s.ResourceName(), parent); err != nil {
+					addProblem(path+".attributes", err)
+				}
+			}
+		}
+	}
+
+	if err := cfg.Network.Validate("network"); err != nil {
+		addProblem("network", err)
+	}
+
+	if err := cfg.Auth.Validate("auth"); err != nil {
+		addProblem("auth", err)
+	}
+
+	return result
+}
@@ -0,0 +1,165 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cfg := &config.Config{
+			Components: []config.Component{
+				{Name: "arm1", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"base1"}},
+				{Name: "base1", Type: base.SubtypeName, Model: "fake"},
+				{Name: "base2", Type: base.SubtypeName, Model: "fake", DependsOn: []string{"remote1:base3"}},
+			},
+			Remotes: []config.Remote{{Name: "remote1", Address: "addr1"}},
+		}
+		result := config.Check(cfg, false)
+		test.That(t, result.Problems, test.ShouldBeEmpty)
+		test.That(t, result.Graph.Names(), test.ShouldHaveLength, 3)
+		test.That(t, result.Graph.IsNodeDependingOn(base.Named("base1"), arm.Named("arm1")), test.ShouldBeTrue)
+		test.That(t, result.Graph.IsNodeDependingOn(base.Named("base2"), arm.Named("arm1")), test.ShouldBeFalse)
+	})
+
+	t.Run("problems", func(t *testing.T) {
+		cfg := &config.Config{
+			Components: []config.Component{
+				{Name: "arm1", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"base1", "nope"}},
+				{Name: "base1", Type: base.SubtypeName, Model: "fake"},
+				{Name: "base1", Type: base.SubtypeName, Model: "fake"},
+				{Type: base.SubtypeName, Model: "fake"},
+				{Name: "base2", Type: base.SubtypeName, Model: "fake", DependsOn: []string{"remote2:base3"}},
+			},
+			Remotes: []config.Remote{{Name: "remote1"}},
+		}
+		result := config.Check(cfg, false)
+		paths := []string{}
+		for _, problem := range result.Problems {
+			paths = append(paths, problem.Path)
+		}
+		test.That(t, paths, test.ShouldResemble, []string{
+			"remotes.0",
+			"components.2.name",
+			"components.3",
+			"components.0.depends_on.1",
+			"components.4.depends_on.0",
+		})
+		test.That(t, result.Problems[1].Error(), test.ShouldContainSubstring, `components.2.name: component name "base1" is not unique`)
+		test.That(t, result.Problems[3].Error(), test.ShouldContainSubstring, `unknown dependency "nope"`)
+		test.That(t, result.Graph.Names(), test.ShouldHaveLength, 3)
+	})
+
+	t.Run("service dependencies", func(t *testing.T) {
+		svcType := config.ServiceType("checkdeps")
+		config.RegisterServiceAttributeMapConverter(svcType, func(attributes config.AttributeMap) (interface{}, error) {
+			return &checkDepsConfig{Deps: attributes.StringSlice("deps")}, nil
+		}, &checkDepsConfig{})
+		cfg := &config.Config{
+			Components: []config.Component{{Name: "base1", Type: base.SubtypeName, Model: "fake"}},
+			Services: []config.Service{
+				{Name: "svc1", Type: svcType, Attributes: config.AttributeMap{"deps": []string{"base1", "svc2"}}},
+				{Name: "svc2", Type: svcType, Attributes: config.AttributeMap{"deps": []string{"remote1:base2"}}},
+				{Name: "svc3", Type: svcType, Attributes: config.AttributeMap{"deps": []string{"base1", "nope"}}},
+			},
+			Remotes: []config.Remote{{Name: "remote1", Address: "addr1"}},
+		}
+		result := config.Check(cfg, false)
+		test.That(t, result.Problems, test.ShouldHaveLength, 1)
+		test.That(t, result.Problems[0].Error(), test.ShouldEqual, `services.2.attributes: unknown dependency "nope"`)
+		svc1 := resource.NameFromSubtype(resource.NewSubtype(resource.ResourceNamespaceRDK, resource.ResourceTypeService,
+			resource.SubtypeName(svcType)), "svc1")
+		svc2 := resource.NameFromSubtype(svc1.Subtype, "svc2")
+		test.That(t, result.Graph.IsNodeDependingOn(base.Named("base1"), svc1), test.ShouldBeTrue)
+		test.That(t, result.Graph.IsNodeDependingOn(svc2, svc1), test.ShouldBeTrue)
+	})
+}
+
+// checkDepsConfig is the config of a service which depends on the resources it names.
+type checkDepsConfig struct {
+	Deps []string
+}
+
+func (config *checkDepsConfig) Validate(path string) ([]string, error) {
+	return config.Deps, nil
+}
+
+func TestReadUnprocessed(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "robot.json")
+	t.Setenv("ARM_MODEL", "fake")
+	test.That(t, os.WriteFile(path, []byte(`{"components": [{"name": "arm1", "type": "arm", "model": "${ARM_MODEL}"}]}`), 0o600),
+		test.ShouldBeNil)
+
+	cfg, err := config.ReadUnprocessed(path)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cfg.ConfigFilePath, test.ShouldEqual, path)
+	test.That(t, cfg.Components, test.ShouldHaveLength, 1)
+	test.That(t, cfg.Components[0].Model, test.ShouldEqual, "fake")
+
+	_, err = config.ReadUnprocessed(filepath.Join(dir, "missing.json"))
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestDiffPlan(t *testing.T) {
+	left := config.Config{
+		Components: []config.Component{
+			{Name: "arm1", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"base1"}},
+			{Name: "arm2", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"arm1"}},
+			{Name: "base1", Type: base.SubtypeName, Model: "fake"},
+			{Name: "base2", Type: base.SubtypeName, Model: "fake"},
+			{Name: "arm3", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"base2"}},
+		},
+	}
+	right := config.Config{
+		Components: []config.Component{
+			{Name: "arm1", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"base1"}},
+			{Name: "arm2", Type: arm.SubtypeName, Model: "fake", DependsOn: []string{"arm1"}},
+			{
+				Name: "base1", Type: base.SubtypeName, Model: "fake",
+				Attributes: config.AttributeMap{"one": 1},
+			},
+			{Name: "base3", Type: base.SubtypeName, Model: "fake"},
+		},
+	}
+	for _, cfg := range []config.Config{left, right} {
+		for idx := range cfg.Components {
+			cfg.Components[idx].Namespace = resource.ResourceNamespaceRDK
+		}
+	}
+	diff, err := config.DiffConfigs(left, right, false)
+	test.That(t, err, test.ShouldBeNil)
+
+	plan := diff.Plan()
+	test.That(t, plan.Empty(), test.ShouldBeFalse)
+	test.That(t, plan.Added, test.ShouldResemble, []resource.Name{base.Named("base3")})
+	test.That(t, plan.Modified, test.ShouldResemble, []resource.Name{base.Named("base1")})
+	test.That(t, plan.Rebuilt, test.ShouldResemble, []resource.Name{arm.Named("arm1"), arm.Named("arm2")})
+	test.That(t, plan.Removed, test.ShouldResemble, []resource.Name{arm.Named("arm3"), base.Named("base2")})
+	test.That(t, plan.String(), test.ShouldContainSubstring, "rebuilt rdk:component:arm/arm2\n")
+
+	diff, err = config.DiffConfigs(left, left, false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.Plan().Empty(), test.ShouldBeTrue)
+}
+
+func TestNewReconfigurePlan(t *testing.T) {
+	plan := config.NewReconfigurePlan(
+		[]resource.Name{arm.Named("arm1")},
+		[]resource.Name{base.Named("base1"), arm.Named("arm2")},
+		[]resource.Name{arm.Named("arm2"), arm.Named("arm3")},
+		[]resource.Name{arm.Named("arm3")},
+	)
+	test.That(t, plan.Added, test.ShouldResemble, []resource.Name{arm.Named("arm1")})
+	test.That(t, plan.Modified, test.ShouldResemble, []resource.Name{base.Named("base1")})
+	test.That(t, plan.Rebuilt, test.ShouldResemble, []resource.Name{arm.Named("arm2")})
+	test.That(t, plan.Removed, test.ShouldResemble, []resource.Name{arm.Named("arm3")})
+}
@@ -102,6 +102,8 @@ type Config struct {
 	Services   []Service             `json:"services,omitempty"`
 	Network    NetworkConfig         `json:"network"`
 	Auth       AuthConfig            `json:"auth"`
+	// FrameSystem configures the frame system service of the robot.
+	FrameSystem FrameSystemConfig `json:"frame_system"`
 
 	Debug bool `json:"debug,omitempty"`
 
@@ -168,6 +170,10 @@ func (c *Config) Ensure(fromCloud bool) error {
 		return err
 	}
 
+	if err := c.FrameSystem.Validate("frame_system"); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -369,6 +375,37 @@ func (nc *NetworkConfig) Validate(path string) error {
 	return nil
 }
 
+// FrameSystemConfig describes how the frame system service of the robot behaves.
+type FrameSystemConfig struct {
+	// History, if set, has the frame system service record the inputs of the robot so that poses can be transformed as
+	// they were in the recent past. It is off by default, since recording polls every input enabled component of the
+	// robot and its remotes.
+	History *FrameSystemHistoryConfig `json:"history,omitempty"`
+}
+
+// FrameSystemHistoryConfig describes how the frame system service records the inputs of the robot.
+type FrameSystemHistoryConfig struct {
+	// Interval is how often the inputs are recorded. If unset, framesystem.DefaultHistoryInterval is used.
+	Interval time.Duration `json:"interval,omitempty"`
+	// Duration is how far back the inputs are remembered. If unset, framesystem.DefaultHistoryDuration is used.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (config *FrameSystemConfig) Validate(path string) error {
+	if config.History == nil {
+		return nil
+	}
+	historyPath := fmt.Sprintf("%s.%s", path, "history")
+	if config.History.Interval < 0 {
+		return utils.NewConfigValidationError(historyPath, errors.New("interval cannot be negative"))
+	}
+	if config.History.Duration < 0 {
+		return utils.NewConfigValidationError(historyPath, errors.New("duration cannot be negative"))
+	}
+	return nil
+}
+
 // AuthConfig describes authentication and authorization settings for the web server.
 type AuthConfig struct {
 	Handlers        []AuthHandlerConfig `json:"handlers"`
@@ -316,6 +316,16 @@ func TestConfigEnsure(t *testing.T) {
 	}
 
 	test.That(t, invalidAuthConfig.Ensure(false), test.ShouldBeNil)
+
+	invalidFrameSystem := config.Config{
+		FrameSystem: config.FrameSystemConfig{History: &config.FrameSystemHistoryConfig{Interval: -time.Second}},
+	}
+	err = invalidFrameSystem.Ensure(false)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `frame_system.history`)
+	test.That(t, err.Error(), test.ShouldContainSubstring, `interval`)
+	invalidFrameSystem.FrameSystem.History = &config.FrameSystemHistoryConfig{}
+	test.That(t, invalidFrameSystem.Ensure(false), test.ShouldBeNil)
 }
 
 func TestCopyOnlyPublicFields(t *testing.T) {
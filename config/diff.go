@@ -67,6 +67,8 @@ func DiffConfigs(left, right Config, revealSensitiveConfigDiffs bool) (_ *Diff,
 
 	different = servicesDifferent || different
 	different = diffProcesses(left.Processes, right.Processes, &diff) || different
+	// the frame system service is configured by its own section, so it must be updated when that changes too
+	different = !reflect.DeepEqual(left.FrameSystem, right.FrameSystem) || different
 	diff.ResourcesEqual = !different
 
 	networkDifferent := diffNetworkingCfg(&left, &right)
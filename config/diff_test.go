@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/tls"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"go.viam.com/test"
@@ -366,6 +367,20 @@ func TestDiffConfigs(t *testing.T) {
 	}
 }
 
+func TestDiffFrameSystemConfig(t *testing.T) {
+	left := config.Config{}
+	right := config.Config{
+		FrameSystem: config.FrameSystemConfig{History: &config.FrameSystemHistoryConfig{Interval: time.Second}},
+	}
+	diff, err := config.DiffConfigs(left, right, false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.ResourcesEqual, test.ShouldBeFalse)
+
+	diff, err = config.DiffConfigs(right, right, false)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, diff.ResourcesEqual, test.ShouldBeTrue)
+}
+
 func TestDiffConfigHeterogenousTypes(t *testing.T) {
 	for _, tc := range []struct {
 		Name      string
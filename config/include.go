@@ -0,0 +1,140 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/a8m/envsubst/parse"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+)
+
+// An Include is a fragment of a config in another file, which is merged into the config including it. Its variables are
+// substituted for ${NAME} in the fragment before it is read, in addition to the environment.
+//
+// Lists in the fragment, like its components, are put before those of the config including it. Anything else the including
+// config sets itself wins over the fragment.
+type Include struct {
+	Path      string            `json:"path"`
+	Variables map[string]string `json:"variables,omitempty"`
+}
+
+// readConfigFile reads the config in the given file, substituting variables from the environment and merging in every
+// fragment it includes. It returns the merged config as JSON, along with the paths of every file read to make it.
+func readConfigFile(filePath string) ([]byte, []string, error) {
+	return readConfigFragment(filePath, os.Environ(), nil)
+}
+
+func readConfigFragment(filePath string, env, including []string) ([]byte, []string, error) {
+	absPath, err := filepath.Abs(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, path := range including {
+		if path == absPath {
+			return nil, nil, errors.Errorf("config file %q includes itself", filePath)
+		}
+	}
+
+	//nolint:gosec
+	rd, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	substituted, err := parse.New(filePath, env, &parse.Restrictions{}).Parse(string(rd))
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to substitute variables in %q", filePath)
+	}
+	buf := []byte(substituted)
+	files := []string{filePath}
+
+	var withIncludes struct {
+		Include []Include `json:"include"`
+	}
+	if err := json.Unmarshal(buf, &withIncludes); err != nil || len(withIncludes.Include) == 0 {
+		// anything wrong with the JSON is reported once it is decoded into a config
+		return buf, files, nil
+	}
+
+	including = append(including[:len(including):len(including)], absPath)
+	merged := map[string]interface{}{}
+	for idx, include := range withIncludes.Include {
+		if include.Path == "" {
+			return nil, nil, errors.Wrapf(
+				utils.NewConfigValidationFieldRequiredError(fmt.Sprintf("%s.%d", "include", idx), "path"), "failed to read %q", filePath)
+		}
+		path := include.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(filepath.Dir(filePath), path)
+		}
+		// the variables of an include take precedence over those of what included it
+		fragmentEnv := make([]string, 0, len(include.Variables)+len(env))
+		for name, value := range include.Variables {
+			fragmentEnv = append(fragmentEnv, fmt.Sprintf("%s=%s", name, value))
+		}
+		fragmentEnv = append(fragmentEnv, env...)
+
+		fragmentBuf, fragmentFiles, err := readConfigFragment(path, fragmentEnv, including)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to include %q in %q", include.Path, filePath)
+		}
+		files = append(files, fragmentFiles...)
+		fragment, err := decodeJSONObject(fragmentBuf)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "failed to decode %q", path)
+		}
+		merged = mergeJSONObjects(merged, fragment)
+	}
+
+	config, err := decodeJSONObject(buf)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to decode %q", filePath)
+	}
+	delete(config, "include")
+	merged = mergeJSONObjects(merged, config)
+	buf, err = json.Marshal(merged)
+	if err != nil {
+		return nil, nil, err
+	}
+	return buf, files, nil
+}
+
+func decodeJSONObject(buf []byte) (map[string]interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(buf))
+	// numbers are kept as they are written, rather than as floats
+	decoder.UseNumber()
+	var obj map[string]interface{}
+	if err := decoder.Decode(&obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// mergeJSONObjects merges the top object into the base one. Lists in both are joined, with those of the base first, objects
+// in both are merged, and anything else in the top object replaces what is in the base.
+func mergeJSONObjects(base, top map[string]interface{}) map[string]interface{} {
+	for key, topValue := range top {
+		baseValue, ok := base[key]
+		if !ok {
+			base[key] = topValue
+			continue
+		}
+		switch topV := topValue.(type) {
+		case []interface{}:
+			if baseV, ok := baseValue.([]interface{}); ok {
+				base[key] = append(baseV, topV...)
+				continue
+			}
+		case map[string]interface{}:
+			if baseV, ok := baseValue.(map[string]interface{}); ok {
+				base[key] = mergeJSONObjects(baseV, topV)
+				continue
+			}
+		}
+		base[key] = topValue
+	}
+	return base
+}
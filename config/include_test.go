@@ -0,0 +1,83 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/config"
+)
+
+func TestReadIncludes(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	dir := t.TempDir()
+	writeFile := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		test.That(t, os.MkdirAll(filepath.Dir(path), 0o700), test.ShouldBeNil)
+		test.That(t, os.WriteFile(path, []byte(contents), 0o600), test.ShouldBeNil)
+		return path
+	}
+	t.Setenv("BASE_MODEL", "fake")
+
+	writeFile("fragments/arm.json", `{
+		"components": [{"name": "${ARM_NAME}", "type": "arm", "model": "fake", "depends_on": ["${BASE_NAME:-base1}"]}],
+		"network": {"bind_address": "localhost:1234"}
+	}`)
+	writeFile("fragments/base.json", `{
+		"include": [{"path": "arm.json", "variables": {"ARM_NAME": "arm2", "BASE_NAME": "base2"}}],
+		"components": [{"name": "base2", "type": "base", "model": "${BASE_MODEL}"}]
+	}`)
+
+	t.Run("merged", func(t *testing.T) {
+		path := writeFile("robot.json", `{
+			"include": [
+				{"path": "fragments/arm.json", "variables": {"ARM_NAME": "arm1"}},
+				{"path": "fragments/base.json"}
+			],
+			"components": [{"name": "base1", "type": "base", "model": "${BASE_MODEL}"}],
+			"network": {"bind_address": "localhost:5678"}
+		}`)
+		cfg, err := config.Read(context.Background(), path, logger)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, cfg.ConfigFilePath, test.ShouldEqual, path)
+		test.That(t, cfg.Network.BindAddress, test.ShouldEqual, "localhost:5678")
+
+		byName := map[string]config.Component{}
+		for _, c := range cfg.Components {
+			byName[c.Name] = c
+		}
+		test.That(t, byName, test.ShouldHaveLength, 4)
+		test.That(t, byName["arm1"].Type, test.ShouldEqual, arm.SubtypeName)
+		test.That(t, byName["arm1"].DependsOn, test.ShouldResemble, []string{"base1"})
+		test.That(t, byName["arm2"].DependsOn, test.ShouldResemble, []string{"base2"})
+		test.That(t, byName["base1"].Model, test.ShouldEqual, "fake")
+		test.That(t, byName["base2"].Type, test.ShouldEqual, base.SubtypeName)
+		test.That(t, byName["base2"].Model, test.ShouldEqual, "fake")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		path := writeFile("missing.json", `{"include": [{"path": "nope.json"}]}`)
+		_, err := config.Read(context.Background(), path, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `failed to include "nope.json"`)
+
+		path = writeFile("nopath.json", `{"include": [{"variables": {"A": "B"}}]}`)
+		_, err = config.Read(context.Background(), path, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, `"path" is required`)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		writeFile("cycle_a.json", `{"include": [{"path": "cycle_b.json"}]}`)
+		path := writeFile("cycle_b.json", `{"include": [{"path": "cycle_a.json"}]}`)
+		_, err := config.Read(context.Background(), path, logger)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "includes itself")
+	})
+}
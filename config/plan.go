@@ -0,0 +1,128 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.viam.com/rdk/resource"
+)
+
+// A ReconfigurePlan is what reconfiguring a robot with a new config would do to its resources, without doing it.
+type ReconfigurePlan struct {
+	// Added are the resources which would be built for the first time.
+	Added []resource.Name
+	// Modified are the resources which would be reconfigured in place.
+	Modified []resource.Name
+	// Rebuilt are the resources which would be closed and built again, either because their config changed in a way they
+	// cannot be reconfigured for, or because something they depend on changed.
+	Rebuilt []resource.Name
+	// Removed are the resources which would be closed, along with everything depending on them.
+	Removed []resource.Name
+}
+
+// NewReconfigurePlan returns a plan of the given changes, with each resource listed once under the most drastic thing
+// happening to it, and sorted by name.
+func NewReconfigurePlan(added, modified, rebuilt, removed []resource.Name) *ReconfigurePlan {
+	seen := make(map[resource.Name]bool)
+	uniq := func(names []resource.Name) []resource.Name {
+		result := []resource.Name{}
+		for _, name := range names {
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			result = append(result, name)
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].String() < result[j].String()
+		})
+		return result
+	}
+	// removing a resource trumps anything else, and rebuilding trumps reconfiguring
+	plan := &ReconfigurePlan{}
+	plan.Removed = uniq(removed)
+	plan.Added = uniq(added)
+	plan.Rebuilt = uniq(rebuilt)
+	plan.Modified = uniq(modified)
+	return plan
+}
+
+// Empty returns whether the plan would not change any resources.
+func (plan *ReconfigurePlan) Empty() bool {
+	return len(plan.Added) == 0 && len(plan.Modified) == 0 && len(plan.Rebuilt) == 0 && len(plan.Removed) == 0
+}
+
+// String returns a line for each resource in the plan saying what would happen to it.
+func (plan *ReconfigurePlan) String() string {
+	var b strings.Builder
+	write := func(action string, names []resource.Name) {
+		for _, name := range names {
+			fmt.Fprintf(&b, "%s %s\n", action, name)
+		}
+	}
+	write("added", plan.Added)
+	write("modified", plan.Modified)
+	write("rebuilt", plan.Rebuilt)
+	write("removed", plan.Removed)
+	return b.String()
+}
+
+// Plan returns what reconfiguring a robot from the left config of the diff to the right would do to its resources, judging
+// only by the configs. Every modified component is assumed to be reconfigurable in place, and every component depending on
+// one that is modified or removed is assumed to be rebuilt or removed along with it. Remotes and processes are not included.
+func (diff *Diff) Plan() *ReconfigurePlan {
+	added := []resource.Name{}
+	for _, c := range diff.Added.Components {
+		added = append(added, c.ResourceName())
+	}
+	for _, s := range diff.Added.Services {
+		added = append(added, s.ResourceName())
+	}
+
+	modified := []resource.Name{}
+	rebuilt := []resource.Name{}
+	for _, c := range diff.Modified.Components {
+		modified = append(modified, c.ResourceName())
+		rebuilt = append(rebuilt, componentDependents(diff.Left, c.Name)...)
+	}
+	for _, s := range diff.Modified.Services {
+		modified = append(modified, s.ResourceName())
+	}
+
+	removed := []resource.Name{}
+	for _, c := range diff.Removed.Components {
+		removed = append(removed, c.ResourceName())
+		removed = append(removed, componentDependents(diff.Left, c.Name)...)
+	}
+	for _, s := range diff.Removed.Services {
+		removed = append(removed, s.ResourceName())
+	}
+
+	return NewReconfigurePlan(added, modified, rebuilt, removed)
+}
+
+// componentDependents returns the names of the components of the config which depend on the named one, directly or not.
+func componentDependents(cfg *Config, name string) []resource.Name {
+	dependents := []resource.Name{}
+	seen := map[string]bool{name: true}
+	toVisit := []string{name}
+	for len(toVisit) > 0 {
+		current := toVisit[0]
+		toVisit = toVisit[1:]
+		for _, c := range cfg.Components {
+			if seen[c.Name] {
+				continue
+			}
+			for _, dep := range c.Dependencies() {
+				if dep == current {
+					seen[c.Name] = true
+					dependents = append(dependents, c.ResourceName())
+					toVisit = append(toVisit, c.Name)
+					break
+				}
+			}
+		}
+	}
+	return dependents
+}
@@ -13,7 +13,6 @@ import (
 	"reflect"
 	"runtime"
 
-	"github.com/a8m/envsubst"
 	"github.com/edaniels/golog"
 	"github.com/mitchellh/copystructure"
 	"github.com/mitchellh/mapstructure"
@@ -524,13 +523,13 @@ func readFromCloud(
 	return cfg, nil
 }
 
-// Read reads a config from the given file.
+// Read reads a config from the given file, along with the fragments it includes.
 func Read(
 	ctx context.Context,
 	filePath string,
 	logger golog.Logger,
 ) (*Config, error) {
-	buf, err := envsubst.ReadFile(filePath)
+	buf, _, err := readConfigFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -544,7 +543,7 @@ func ReadLocalConfig(
 	filePath string,
 	logger golog.Logger,
 ) (*Config, error) {
-	buf, err := envsubst.ReadFile(filePath)
+	buf, _, err := readConfigFile(filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -620,52 +619,68 @@ func processConfig(unprocessedConfig *Config, fromCloud bool) (*Config, error) {
 	// Copy does not presve ConfigFilePath and we need to pass it along manually
 	cfg.ConfigFilePath = unprocessedConfig.ConfigFilePath
 
-	for idx, c := range cfg.Components {
-		conv := findMapConverter(c.Type, c.Model)
-		// inner attributes may have their own converters
-		for k, v := range c.Attributes {
-			attrConv := findConverter(c.Type, c.Model, k)
-			if attrConv == nil {
-				continue
-			}
-
-			n, err := attrConv(v)
-			if err != nil {
-				return nil, errors.Wrapf(err, "error converting attribute for (%s, %s, %s)", c.Type, c.Model, k)
-			}
-			cfg.Components[idx].Attributes[k] = n
-		}
-		if conv == nil {
-			continue
+	for idx := range cfg.Components {
+		if err := convertComponentAttributes(&cfg.Components[idx]); err != nil {
+			return nil, err
 		}
+	}
 
-		converted, err := conv(c.Attributes)
-		if err != nil {
-			return nil, errors.Wrapf(err, "error converting attributes for (%s, %s)", c.Type, c.Model)
+	for idx := range cfg.Services {
+		if err := convertServiceAttributes(&cfg.Services[idx]); err != nil {
+			return nil, err
 		}
-		cfg.Components[idx].Attributes = nil
-		cfg.Components[idx].ConvertedAttributes = converted
 	}
 
-	for idx, c := range cfg.Services {
-		conv := findServiceMapConverter(c.Type)
-		if conv == nil {
+	if err := cfg.Ensure(fromCloud); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+// convertComponentAttributes converts the attributes of the component with the converters registered for its model.
+func convertComponentAttributes(c *Component) error {
+	conv := findMapConverter(c.Type, c.Model)
+	// inner attributes may have their own converters
+	for k, v := range c.Attributes {
+		attrConv := findConverter(c.Type, c.Model, k)
+		if attrConv == nil {
 			continue
 		}
 
-		converted, err := conv(c.Attributes)
+		n, err := attrConv(v)
 		if err != nil {
-			return nil, errors.Wrapf(err, "error converting attributes for %s", c.Type)
+			return errors.Wrapf(err, "error converting attribute for (%s, %s, %s)", c.Type, c.Model, k)
 		}
-		cfg.Services[idx].Attributes = nil
-		cfg.Services[idx].ConvertedAttributes = converted
+		c.Attributes[k] = n
+	}
+	if conv == nil {
+		return nil
 	}
 
-	if err := cfg.Ensure(fromCloud); err != nil {
-		return nil, err
+	converted, err := conv(c.Attributes)
+	if err != nil {
+		return errors.Wrapf(err, "error converting attributes for (%s, %s)", c.Type, c.Model)
+	}
+	c.Attributes = nil
+	c.ConvertedAttributes = converted
+	return nil
+}
+
+// convertServiceAttributes converts the attributes of the service with the converter registered for its type.
+func convertServiceAttributes(c *Service) error {
+	conv := findServiceMapConverter(c.Type)
+	if conv == nil {
+		return nil
 	}
 
-	return cfg, nil
+	converted, err := conv(c.Attributes)
+	if err != nil {
+		return errors.Wrapf(err, "error converting attributes for %s", c.Type)
+	}
+	c.Attributes = nil
+	c.ConvertedAttributes = converted
+	return nil
 }
 
 // getFromCloudOrCache returns the config from either the legacy HTTP endpoint or gRPC endpoint depending if the original config
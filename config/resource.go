@@ -4,6 +4,7 @@ import (
 	"flag"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/pkg/errors"
@@ -66,12 +67,66 @@ type Component struct {
 	Frame         *Frame                       `json:"frame,omitempty"`
 	DependsOn     []string                     `json:"depends_on"`
 	ServiceConfig []ResourceLevelServiceConfig `json:"service_config"`
+	RestartPolicy *RestartPolicy               `json:"restart_policy,omitempty"`
 
 	Attributes          AttributeMap `json:"attributes"`
 	ConvertedAttributes interface{}  `json:"-"`
 	ImplicitDependsOn   []string     `json:"-"`
 }
 
+// A RestartPolicy describes how a component which fails to be built is retried, without waiting for the robot to be
+// reconfigured. Each retry waits twice as long as the last, starting from the initial backoff, up to the max backoff.
+type RestartPolicy struct {
+	// MaxRestarts is how many times the component is retried after it first fails to be built; zero retries it forever.
+	MaxRestarts    int           `json:"max_restarts,omitempty"`
+	InitialBackoff time.Duration `json:"initial_backoff,omitempty"`
+	MaxBackoff     time.Duration `json:"max_backoff,omitempty"`
+}
+
+const (
+	defaultRestartInitialBackoff = time.Second
+	defaultRestartMaxBackoff     = time.Minute
+)
+
+// Validate ensures all parts of the policy are valid, and fills in the default backoffs.
+func (policy *RestartPolicy) Validate(path string) error {
+	if policy.MaxRestarts < 0 {
+		return utils.NewConfigValidationError(path, errors.New("max_restarts cannot be negative"))
+	}
+	if policy.InitialBackoff < 0 || policy.MaxBackoff < 0 {
+		return utils.NewConfigValidationError(path, errors.New("backoffs cannot be negative"))
+	}
+	if policy.InitialBackoff == 0 {
+		policy.InitialBackoff = defaultRestartInitialBackoff
+	}
+	if policy.MaxBackoff == 0 {
+		policy.MaxBackoff = defaultRestartMaxBackoff
+	}
+	if policy.MaxBackoff < policy.InitialBackoff {
+		return utils.NewConfigValidationError(path, errors.New("max_backoff cannot be less than initial_backoff"))
+	}
+	return nil
+}
+
+// Backoff returns how long to wait before retrying a component which has failed to be built the given number of times in a
+// row.
+func (policy *RestartPolicy) Backoff(failures int) time.Duration {
+	backoff := policy.InitialBackoff
+	for i := 1; i < failures && backoff < policy.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > policy.MaxBackoff {
+		return policy.MaxBackoff
+	}
+	return backoff
+}
+
+// Exhausted returns whether a component which has failed to be built the given number of times in a row should no longer be
+// retried.
+func (policy *RestartPolicy) Exhausted(failures int) bool {
+	return policy.MaxRestarts > 0 && failures > policy.MaxRestarts
+}
+
 // Dependencies returns the deduplicated union of user-defined and implicit dependencies.
 func (config *Component) Dependencies() []string {
 	result := make([]string, 0, len(config.DependsOn)+len(config.ImplicitDependsOn))
@@ -128,6 +183,11 @@ func (config *Component) Validate(path string) ([]string, error) {
 	if err := resource.ContainsReservedCharacter(config.Name); err != nil {
 		return nil, err
 	}
+	if config.RestartPolicy != nil {
+		if err := config.RestartPolicy.Validate(fmt.Sprintf("%s.%s", path, "restart_policy")); err != nil {
+			return nil, err
+		}
+	}
 	for key, value := range config.Attributes {
 		fieldPath := fmt.Sprintf("%s.%s", path, key)
 		switch v := value.(type) {
@@ -229,6 +289,8 @@ type Service struct {
 	Type                ServiceType        `json:"type"`
 	Attributes          AttributeMap       `json:"attributes"`
 	ConvertedAttributes interface{}        `json:"-"`
+	// ImplicitDependsOn is the names of the resources the attributes of the service depend on, found by validating them.
+	ImplicitDependsOn []string `json:"-"`
 }
 
 // Ensure Service conforms to flag.Value.
@@ -310,7 +372,7 @@ func ParseServiceFlag(flag string) (Service, error) {
 	return svc, nil
 }
 
-// Validate ensures all parts of the config are valid.
+// Validate ensures all parts of the config are valid, and records the dependencies its attributes name.
 func (config *Service) Validate(path string) error {
 	if config.Type == "" {
 		return utils.NewConfigValidationFieldRequiredError(path, "type")
@@ -344,10 +406,17 @@ func (config *Service) Validate(path string) error {
 			return err
 		}
 	}
-	if v, ok := config.ConvertedAttributes.(validator); ok {
+	switch v := config.ConvertedAttributes.(type) {
+	case validator:
 		if err := v.Validate(path); err != nil {
 			return err
 		}
+	case dependencyValidator:
+		deps, err := v.Validate(path)
+		if err != nil {
+			return err
+		}
+		config.ImplicitDependsOn = deps
 	}
 	return nil
 }
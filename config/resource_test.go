@@ -2,6 +2,7 @@ package config_test
 
 import (
 	"testing"
+	"time"
 
 	"go.viam.com/test"
 	"go.viam.com/utils"
@@ -108,6 +109,34 @@ func TestComponentValidate(t *testing.T) {
 	})
 }
 
+func TestRestartPolicy(t *testing.T) {
+	conf := config.Component{Name: "foo", Type: "arm", RestartPolicy: &config.RestartPolicy{MaxRestarts: 3}}
+	_, err := conf.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	policy := conf.RestartPolicy
+	test.That(t, policy.InitialBackoff, test.ShouldEqual, time.Second)
+	test.That(t, policy.MaxBackoff, test.ShouldEqual, time.Minute)
+
+	// the backoff doubles after every failure, up to the max
+	test.That(t, policy.Backoff(1), test.ShouldEqual, time.Second)
+	test.That(t, policy.Backoff(2), test.ShouldEqual, 2*time.Second)
+	test.That(t, policy.Backoff(4), test.ShouldEqual, 8*time.Second)
+	test.That(t, policy.Backoff(100), test.ShouldEqual, time.Minute)
+
+	test.That(t, policy.Exhausted(3), test.ShouldBeFalse)
+	test.That(t, policy.Exhausted(4), test.ShouldBeTrue)
+	test.That(t, (&config.RestartPolicy{}).Exhausted(100), test.ShouldBeFalse)
+
+	conf.RestartPolicy = &config.RestartPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Second}
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "path.restart_policy")
+
+	conf.RestartPolicy = &config.RestartPolicy{MaxRestarts: -1}
+	_, err = conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
 func TestComponentResourceName(t *testing.T) {
 	for _, tc := range []struct {
 		Name            string
@@ -3,7 +3,6 @@ package config
 import (
 	"bytes"
 	"context"
-	"os"
 	"time"
 
 	"github.com/edaniels/golog"
@@ -109,13 +108,42 @@ type fsConfigWatcher struct {
 }
 
 // newFSWatcher returns a new v that will fetch new configs
-// as soon as the underlying file is written to.
+// as soon as the underlying file, or any file it includes, is written to.
 func newFSWatcher(ctx context.Context, configPath string, logger golog.Logger) (*fsConfigWatcher, error) {
 	fsWatcher, err := fsnotify.NewWatcher()
 	if err != nil {
 		return nil, err
 	}
-	if err := fsWatcher.Add(configPath); err != nil {
+	watched := map[string]bool{}
+	// watch adds the given files to those watched and stops watching any others, as includes may come and go
+	watch := func(files []string) error {
+		toWatch := make(map[string]bool, len(files))
+		for _, file := range files {
+			toWatch[file] = true
+			if watched[file] {
+				continue
+			}
+			if err := fsWatcher.Add(file); err != nil {
+				return err
+			}
+			watched[file] = true
+		}
+		for file := range watched {
+			if toWatch[file] {
+				continue
+			}
+			if err := fsWatcher.Remove(file); err != nil {
+				logger.Debugw("error no longer watching config file", "file", file, "error", err)
+			}
+			delete(watched, file)
+		}
+		return nil
+	}
+	files := []string{configPath}
+	if _, included, err := readConfigFile(configPath); err == nil {
+		files = included
+	}
+	if err := watch(files); err != nil {
 		return nil, err
 	}
 	configCh := make(chan *Config)
@@ -132,12 +160,14 @@ func newFSWatcher(ctx context.Context, configPath string, logger golog.Logger) (
 				return
 			case event := <-fsWatcher.Events:
 				if event.Op&fsnotify.Write == fsnotify.Write {
-					//nolint:gosec
-					rd, err := os.ReadFile(configPath)
+					rd, included, err := readConfigFile(configPath)
 					if err != nil {
 						logger.Errorw("error reading config file after write", "error", err)
 						continue
 					}
+					if err := watch(included); err != nil {
+						logger.Errorw("error watching included config files", "error", err)
+					}
 					if bytes.Equal(rd, lastRd) {
 						continue
 					}
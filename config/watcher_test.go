@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
@@ -155,6 +156,45 @@ func TestNewWatcherFile(t *testing.T) {
 	test.That(t, utils.TryClose(context.Background(), watcher), test.ShouldBeNil)
 }
 
+func TestNewWatcherFileIncludes(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "robot.json")
+	fragmentPath := filepath.Join(dir, "arm.json")
+	writeFile := func(path, contents string) {
+		test.That(t, os.WriteFile(path, []byte(contents), 0o600), test.ShouldBeNil)
+	}
+	writeFile(fragmentPath, `{"components": [{"name": "${NAME}", "type": "arm", "model": "fake"}]}`)
+	writeFile(configPath, `{"include": [{"path": "arm.json", "variables": {"NAME": "arm1"}}]}`)
+
+	watcher, err := NewWatcher(context.Background(), &Config{ConfigFilePath: configPath}, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, utils.TryClose(context.Background(), watcher), test.ShouldBeNil)
+	}()
+
+	// writing only the included file is enough to reconfigure
+	writeFile(fragmentPath, `{"components": [{"name": "${NAME}", "type": "arm", "model": "fake", "attributes": {"one": 1}}]}`)
+	newConf := <-watcher.Config()
+	test.That(t, newConf.Components, test.ShouldHaveLength, 1)
+	test.That(t, newConf.Components[0].Name, test.ShouldEqual, "arm1")
+	test.That(t, newConf.Components[0].Attributes.Int("one", 0), test.ShouldEqual, 1)
+
+	// files newly included are watched too
+	otherPath := filepath.Join(dir, "base.json")
+	writeFile(otherPath, `{"components": [{"name": "base1", "type": "base", "model": "fake"}]}`)
+	writeFile(configPath, `{"include": [{"path": "arm.json", "variables": {"NAME": "arm2"}}, {"path": "base.json"}]}`)
+	newConf = <-watcher.Config()
+	test.That(t, newConf.Components, test.ShouldHaveLength, 2)
+	test.That(t, newConf.Components[0].Name, test.ShouldEqual, "arm2")
+
+	writeFile(otherPath, `{"components": [{"name": "base2", "type": "base", "model": "fake"}]}`)
+	newConf = <-watcher.Config()
+	test.That(t, newConf.Components, test.ShouldHaveLength, 2)
+	test.That(t, newConf.Components[1].Name, test.ShouldEqual, "base2")
+}
+
 func TestNewWatcherCloud(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 
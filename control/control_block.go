@@ -22,6 +22,8 @@ const (
 	blockSum                        controlBlockType = "sum"
 	blockConstant                   controlBlockType = "constant"
 	blockEncoderToRPM               controlBlockType = "encoderToRpm"
+	blockFeedForward                controlBlockType = "feedForward"
+	blockRateLimiter                controlBlockType = "rateLimiter"
 )
 
 // BlockConfig configuration of a given block.
@@ -107,6 +109,18 @@ func createBlock(cfg BlockConfig, logger golog.Logger) (Block, error) {
 			return nil, err
 		}
 		return b, nil
+	case blockFeedForward:
+		b, err := newFeedForward(cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
+	case blockRateLimiter:
+		b, err := newRateLimiter(cfg, logger)
+		if err != nil {
+			return nil, err
+		}
+		return b, nil
 	}
 	return nil, errors.Errorf("unsupported block type %s", t)
 }
@@ -3,6 +3,7 @@ package control
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/edaniels/golog"
@@ -37,6 +38,7 @@ type Loop struct {
 	cancelCtx               context.Context
 	cancel                  context.CancelFunc
 	running                 bool
+	overruns                uint64
 }
 
 // NewLoop construct a new control loop for a specific endpoint.
@@ -219,6 +221,11 @@ func (l *Loop) Start() error {
 				for _, c := range ts {
 					c <- t
 				}
+				// a block still busy with the last tick holds up this one, so the loop is not keeping to its frequency
+				if late := time.Since(t); late > l.dt {
+					atomic.AddUint64(&l.overruns, 1)
+					l.logger.Debugf("control loop tick overran by %v", late-l.dt)
+				}
 			case <-ct.stop:
 				for _, c := range ts {
 					close(c)
@@ -266,6 +273,11 @@ func (l *Loop) startBenchmark(loops int) error {
 	return nil
 }
 
+// Overruns returns the number of ticks which were delivered to the blocks later than the loop period.
+func (l *Loop) Overruns() uint64 {
+	return atomic.LoadUint64(&l.overruns)
+}
+
 // Stop stops then loop.
 func (l *Loop) Stop() {
 	if l.running {
@@ -322,5 +322,9 @@ func TestControlLoop(t *testing.T) {
 		test.That(t, b[0].GetSignalValueAt(0), test.ShouldEqual, -3.0)
 		test.That(t, err, test.ShouldBeNil)
 	}
+	signals := cLoop.Signals(ctx)
+	test.That(t, signals["E"], test.ShouldEqual, 8.0)
+	test.That(t, signals["B"], test.ShouldEqual, -3.0)
+	test.That(t, signals["overruns"], test.ShouldEqual, cLoop.Overruns())
 	cLoop.Stop()
 }
@@ -0,0 +1,79 @@
+package control
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+)
+
+// feedForward outputs the value expected to achieve its setpoint, kF * setpoint + bias, to which the output of a feedback
+// block can be added so that feedback only has to correct what the model gets wrong. The setpoint is the first input and the
+// feedback, when there is one, the second.
+type feedForward struct {
+	mu     sync.Mutex
+	cfg    BlockConfig
+	y      []Signal
+	kF     float64
+	bias   float64
+	logger golog.Logger
+}
+
+func newFeedForward(config BlockConfig, logger golog.Logger) (Block, error) {
+	f := &feedForward{cfg: config, logger: logger}
+	if err := f.reset(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (b *feedForward) Next(ctx context.Context, x []Signal, dt time.Duration) ([]Signal, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(x) != len(b.cfg.DependsOn) {
+		return b.y, false
+	}
+	y := b.kF*x[0].GetSignalValueAt(0) + b.bias
+	if len(x) == 2 {
+		y += x[1].GetSignalValueAt(0)
+	}
+	b.y[0].SetSignalValueAt(0, y)
+	return b.y, true
+}
+
+func (b *feedForward) reset() error {
+	if !b.cfg.Attribute.Has("kF") {
+		return errors.Errorf("feedforward block %s doesn't have a kF field", b.cfg.Name)
+	}
+	if len(b.cfg.DependsOn) != 1 && len(b.cfg.DependsOn) != 2 {
+		return errors.Errorf("invalid number of inputs for feedforward block %s expected 1 or 2 got %d", b.cfg.Name, len(b.cfg.DependsOn))
+	}
+	b.kF = b.cfg.Attribute.Float64("kF", 1.0)
+	b.bias = b.cfg.Attribute.Float64("bias", 0.0)
+	b.y = make([]Signal, 1)
+	b.y[0] = makeSignal(b.cfg.Name)
+	return nil
+}
+
+func (b *feedForward) Reset(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reset()
+}
+
+func (b *feedForward) UpdateConfig(ctx context.Context, config BlockConfig) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = config
+	return b.reset()
+}
+
+func (b *feedForward) Output(ctx context.Context) []Signal {
+	return b.y
+}
+
+func (b *feedForward) Config(ctx context.Context) BlockConfig {
+	return b.cfg
+}
@@ -0,0 +1,85 @@
+package control
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+)
+
+func TestFeedForwardConfig(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	for _, c := range []struct {
+		conf BlockConfig
+		err  string
+	}{
+		{
+			BlockConfig{
+				Name:      "FF1",
+				Type:      "feedForward",
+				Attribute: config.AttributeMap{"kF": 0.5},
+				DependsOn: []string{"setpoint", "pid"},
+			},
+			"",
+		},
+		{
+			BlockConfig{
+				Name:      "FF1",
+				Type:      "feedForward",
+				Attribute: config.AttributeMap{"bias": 0.5},
+				DependsOn: []string{"setpoint"},
+			},
+			"feedforward block FF1 doesn't have a kF field",
+		},
+		{
+			BlockConfig{
+				Name:      "FF1",
+				Type:      "feedForward",
+				Attribute: config.AttributeMap{"kF": 0.5},
+				DependsOn: []string{},
+			},
+			"invalid number of inputs for feedforward block FF1 expected 1 or 2 got 0",
+		},
+	} {
+		_, err := createBlock(c.conf, logger)
+		if c.err == "" {
+			test.That(t, err, test.ShouldBeNil)
+		} else {
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldEqual, c.err)
+		}
+	}
+}
+
+func TestFeedForwardNext(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	c := BlockConfig{
+		Name:      "FF1",
+		Type:      "feedForward",
+		Attribute: config.AttributeMap{"kF": 0.5, "bias": 2.0},
+		DependsOn: []string{"setpoint"},
+	}
+	b, err := newFeedForward(c, logger)
+	test.That(t, err, test.ShouldBeNil)
+	setpoint := Signal{name: "setpoint", signal: []float64{10}, time: []int{1}, dimension: 1, mu: &sync.Mutex{}}
+	out, ok := b.Next(ctx, []Signal{setpoint}, time.Millisecond)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, out[0].GetSignalValueAt(0), test.ShouldEqual, 7.0)
+
+	// the output of a feedback block is added to what is fed forward
+	c.DependsOn = []string{"setpoint", "pid"}
+	test.That(t, b.UpdateConfig(ctx, c), test.ShouldBeNil)
+	feedback := Signal{name: "pid", signal: []float64{-1}, time: []int{1}, dimension: 1, mu: &sync.Mutex{}}
+	out, ok = b.Next(ctx, []Signal{setpoint, feedback}, time.Millisecond)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, out[0].GetSignalValueAt(0), test.ShouldEqual, 6.0)
+
+	_, ok = b.Next(ctx, []Signal{setpoint}, time.Millisecond)
+	test.That(t, ok, test.ShouldBeFalse)
+}
@@ -0,0 +1,76 @@
+package control
+
+import (
+	"math"
+
+	"go.viam.com/rdk/config"
+)
+
+// PIDConfig holds the gains of a PID block, for drivers which build their control loop with PIDLoopConfig.
+type PIDConfig struct {
+	KP float64 `json:"kP"`
+	KI float64 `json:"kI"`
+	KD float64 `json:"kD"`
+	// IntegralLimit bounds the magnitude of the integral term, to stop it winding up while the output has no effect.
+	IntegralLimit float64 `json:"int_lim,omitempty"`
+	// OutputLimit bounds the magnitude of the output.
+	OutputLimit float64 `json:"limit,omitempty"`
+}
+
+// Names of the blocks of a loop made by PIDLoopConfig.
+const (
+	PIDLoopSetPoint = "set_point"
+	PIDLoopError    = "error"
+	PIDLoopPID      = "pid"
+	PIDLoopEndpoint = "endpoint"
+)
+
+// PIDLoopConfig returns the config of a loop, run at the given frequency, which drives the position of its Controllable to
+// setPoint by setting its power to the output of a PID block with the given gains. Zero limits leave the integral and output
+// unbounded, and gains which are all zero make the PID block tune itself.
+func PIDLoopConfig(gains PIDConfig, setPoint, frequency float64) Config {
+	intLim, limit := math.MaxFloat64, math.MaxFloat64
+	if gains.IntegralLimit > 0 {
+		intLim = gains.IntegralLimit
+	}
+	if gains.OutputLimit > 0 {
+		limit = gains.OutputLimit
+	}
+	return Config{
+		Frequency: frequency,
+		Blocks: []BlockConfig{
+			{
+				Name:      PIDLoopSetPoint,
+				Type:      blockConstant,
+				Attribute: config.AttributeMap{"constant_val": setPoint},
+				DependsOn: []string{},
+			},
+			{
+				Name:      PIDLoopError,
+				Type:      blockSum,
+				Attribute: config.AttributeMap{"sum_string": "+-"},
+				DependsOn: []string{PIDLoopSetPoint, PIDLoopEndpoint},
+			},
+			{
+				Name: PIDLoopPID,
+				Type: blockPID,
+				Attribute: config.AttributeMap{
+					"kP":             gains.KP,
+					"kI":             gains.KI,
+					"kD":             gains.KD,
+					"int_sat_lim_up": intLim,
+					"int_sat_lim_lo": -intLim,
+					"limit_up":       limit,
+					"limit_lo":       -limit,
+				},
+				DependsOn: []string{PIDLoopError},
+			},
+			{
+				Name:      PIDLoopEndpoint,
+				Type:      blockEndpoint,
+				Attribute: config.AttributeMap{"motor_name": PIDLoopEndpoint},
+				DependsOn: []string{PIDLoopPID},
+			},
+		},
+	}
+}
@@ -0,0 +1,53 @@
+package control
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+)
+
+// integrator is a Controllable whose position moves at the rate of its power.
+type integrator struct {
+	mu       sync.Mutex
+	position float64
+	last     time.Time
+}
+
+func (i *integrator) SetPower(ctx context.Context, power float64, extra map[string]interface{}) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	now := time.Now()
+	if !i.last.IsZero() {
+		i.position += power * now.Sub(i.last).Seconds()
+	}
+	i.last = now
+	return nil
+}
+
+func (i *integrator) Position(ctx context.Context, extra map[string]interface{}) (float64, error) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.position, nil
+}
+
+func TestPIDLoopConfig(t *testing.T) {
+	plant := &integrator{}
+	loop, err := NewLoop(golog.NewTestLogger(t), PIDLoopConfig(PIDConfig{KP: 5, OutputLimit: 20}, 10, 100), plant)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, loop.Start(), test.ShouldBeNil)
+	defer loop.Stop()
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		position, err := plant.Position(context.Background(), nil)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, position, test.ShouldAlmostEqual, 10, 0.5)
+	})
+	signals := loop.Signals(context.Background())
+	test.That(t, signals[PIDLoopSetPoint], test.ShouldEqual, 10.0)
+	test.That(t, signals, test.ShouldContainKey, PIDLoopPID)
+}
@@ -0,0 +1,90 @@
+package control
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+)
+
+// rateLimiter follows its input, but changes by no more than rising_rate per second as it increases and falling_rate per
+// second as it decreases. The falling rate is the rising rate unless it is given.
+type rateLimiter struct {
+	mu          sync.Mutex
+	cfg         BlockConfig
+	y           []Signal
+	risingRate  float64
+	fallingRate float64
+	started     bool
+	logger      golog.Logger
+}
+
+func newRateLimiter(config BlockConfig, logger golog.Logger) (Block, error) {
+	r := &rateLimiter{cfg: config, logger: logger}
+	if err := r.reset(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (b *rateLimiter) Next(ctx context.Context, x []Signal, dt time.Duration) ([]Signal, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(x) != 1 {
+		return b.y, false
+	}
+	target := x[0].GetSignalValueAt(0)
+	if !b.started {
+		// the first value is taken as is, there being nothing to limit its change from
+		b.started = true
+		b.y[0].SetSignalValueAt(0, target)
+		return b.y, true
+	}
+	last := b.y[0].GetSignalValueAt(0)
+	y := math.Min(target, last+b.risingRate*dt.Seconds())
+	y = math.Max(y, last-b.fallingRate*dt.Seconds())
+	b.y[0].SetSignalValueAt(0, y)
+	return b.y, true
+}
+
+func (b *rateLimiter) reset() error {
+	if !b.cfg.Attribute.Has("rising_rate") {
+		return errors.Errorf("rate limiter block %s doesn't have a rising_rate field", b.cfg.Name)
+	}
+	if len(b.cfg.DependsOn) != 1 {
+		return errors.Errorf("invalid number of inputs for rate limiter block %s expected 1 got %d", b.cfg.Name, len(b.cfg.DependsOn))
+	}
+	b.risingRate = b.cfg.Attribute.Float64("rising_rate", 0.0)
+	b.fallingRate = b.cfg.Attribute.Float64("falling_rate", b.risingRate)
+	if b.risingRate <= 0 || b.fallingRate <= 0 {
+		return errors.Errorf("rate limiter block %s should have positive rates", b.cfg.Name)
+	}
+	b.started = false
+	b.y = make([]Signal, 1)
+	b.y[0] = makeSignal(b.cfg.Name)
+	return nil
+}
+
+func (b *rateLimiter) Reset(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.reset()
+}
+
+func (b *rateLimiter) UpdateConfig(ctx context.Context, config BlockConfig) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = config
+	return b.reset()
+}
+
+func (b *rateLimiter) Output(ctx context.Context) []Signal {
+	return b.y
+}
+
+func (b *rateLimiter) Config(ctx context.Context) BlockConfig {
+	return b.cfg
+}
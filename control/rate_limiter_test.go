@@ -0,0 +1,94 @@
+package control
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/config"
+)
+
+func TestRateLimiterConfig(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	for _, c := range []struct {
+		conf BlockConfig
+		err  string
+	}{
+		{
+			BlockConfig{
+				Name:      "RL1",
+				Type:      "rateLimiter",
+				Attribute: config.AttributeMap{"rising_rate": 10.0},
+				DependsOn: []string{"A"},
+			},
+			"",
+		},
+		{
+			BlockConfig{
+				Name:      "RL1",
+				Type:      "rateLimiter",
+				Attribute: config.AttributeMap{"falling_rate": 10.0},
+				DependsOn: []string{"A"},
+			},
+			"rate limiter block RL1 doesn't have a rising_rate field",
+		},
+		{
+			BlockConfig{
+				Name:      "RL1",
+				Type:      "rateLimiter",
+				Attribute: config.AttributeMap{"rising_rate": 10.0, "falling_rate": -1.0},
+				DependsOn: []string{"A"},
+			},
+			"rate limiter block RL1 should have positive rates",
+		},
+		{
+			BlockConfig{
+				Name:      "RL1",
+				Type:      "rateLimiter",
+				Attribute: config.AttributeMap{"rising_rate": 10.0},
+				DependsOn: []string{"A", "B"},
+			},
+			"invalid number of inputs for rate limiter block RL1 expected 1 got 2",
+		},
+	} {
+		_, err := createBlock(c.conf, logger)
+		if c.err == "" {
+			test.That(t, err, test.ShouldBeNil)
+		} else {
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldEqual, c.err)
+		}
+	}
+}
+
+func TestRateLimiterNext(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	c := BlockConfig{
+		Name:      "RL1",
+		Type:      "rateLimiter",
+		Attribute: config.AttributeMap{"rising_rate": 10.0, "falling_rate": 20.0},
+		DependsOn: []string{"A"},
+	}
+	b, err := newRateLimiter(c, logger)
+	test.That(t, err, test.ShouldBeNil)
+	next := func(x float64) float64 {
+		out, ok := b.Next(ctx, []Signal{{name: "A", signal: []float64{x}, time: []int{1}, dimension: 1, mu: &sync.Mutex{}}},
+			100*time.Millisecond)
+		test.That(t, ok, test.ShouldBeTrue)
+		return out[0].GetSignalValueAt(0)
+	}
+
+	test.That(t, next(5), test.ShouldEqual, 5.0)
+	test.That(t, next(10), test.ShouldEqual, 6.0)
+	test.That(t, next(10), test.ShouldEqual, 7.0)
+	test.That(t, next(0), test.ShouldEqual, 5.0)
+	test.That(t, next(4.5), test.ShouldEqual, 4.5)
+
+	test.That(t, b.Reset(ctx), test.ShouldBeNil)
+	test.That(t, next(-100), test.ShouldEqual, -100.0)
+}
@@ -0,0 +1,29 @@
+package control
+
+import (
+	"context"
+)
+
+// SignalsCommand is the DoCommand key which asks a resource running a control loop for its internal signals, so that they
+// can be captured by the data manager.
+const SignalsCommand = "control_signals"
+
+// IsSignalsCommand returns whether a DoCommand asks for control signals.
+func IsSignalsCommand(cmd map[string]interface{}) bool {
+	_, ok := cmd[SignalsCommand]
+	return ok
+}
+
+// Signals returns the latest output of every block in the loop, along with the number of ticks the loop has overrun.
+func (l *Loop) Signals(ctx context.Context) map[string]interface{} {
+	signals := make(map[string]interface{}, len(l.blocks)+1)
+	for name, b := range l.blocks {
+		out := b.blk.Output(ctx)
+		if len(out) == 0 {
+			continue
+		}
+		signals[name] = out[0].GetSignalValueAt(0)
+	}
+	signals["overruns"] = l.Overruns()
+	return signals
+}
@@ -0,0 +1,474 @@
+package motionplan
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+const (
+	// Tolerance used when checking whether a model has the structure an analytic solver requires.
+	dhStructureEpsilon = 1e-6
+
+	// Analytic solutions must place the end effector within this many mm of the goal.
+	analyticSolutionEpsilon = 1e-3
+
+	// Metric scores of analytic solutions which differ by less than this are treated as equal, so that solutions whose
+	// scores differ only by numerical error are ordered by their distance from the seed.
+	analyticScoreResolution = 1e-6
+
+	// Below this, the sine of the middle wrist joint is treated as zero and the wrist as singular.
+	wristSingularityEpsilon = 1e-9
+)
+
+// Note that the RotationMatrix of an orientation is laid out with the axes of the rotated frame as its rows, so below the
+// element in row i and column j of the usual rotation matrix is At(j, i), and Mul applies the inverse rotation.
+
+// AnalyticInverseKinematics is an InverseKinematics which computes solutions in closed form. Since every solution is found at
+// once, its Solve sends those accepted by the metric in order of their score, and then of their distance from the seed, so
+// the best and closest is always considered first.
+type AnalyticInverseKinematics interface {
+	InverseKinematics
+	// AllSolutions returns one configuration for every discrete solution branch which places the end effector at the goal
+	// and is within the joint limits of the model.
+	AllSolutions(goal spatial.Pose) [][]frame.Input
+}
+
+// dhParam holds the Denavit-Hartenberg parameters of a revolute joint and the link following it.
+type dhParam struct {
+	a, d, alpha float64
+	link        spatial.Pose
+}
+
+// dhTransform returns the pose of the link following the joint relative to the previous link, with the joint at angle q.
+func (p dhParam) dhTransform(q float64) spatial.Pose {
+	return spatial.Compose(spatial.NewPoseFromOrientation(r3.Vector{}, &spatial.R4AA{Theta: q, RZ: 1}), p.link)
+}
+
+// dhParamsFromModel recovers the DH parameters of a model consisting of revolute joints about their z axis, each followed by a
+// static link, as are models with kinematic_param_type DH.
+func dhParamsFromModel(model frame.Model) ([]dhParam, error) {
+	simple, ok := model.(*frame.SimpleModel)
+	if !ok || len(simple.OrdTransforms)%2 != 0 {
+		return nil, errors.New("analytic IK requires a model defined with DH parameters")
+	}
+	params := make([]dhParam, 0, len(simple.OrdTransforms)/2)
+	for i := 0; i < len(simple.OrdTransforms); i += 2 {
+		joint, link := simple.OrdTransforms[i], simple.OrdTransforms[i+1]
+		if len(joint.DoF()) != 1 || len(link.DoF()) != 0 {
+			return nil, errors.New("analytic IK requires a model defined with DH parameters")
+		}
+		jointPose, err := joint.Transform([]frame.Input{{math.Pi / 2}})
+		if err != nil {
+			return nil, err
+		}
+		if orientDist(jointPose.Orientation(), &spatial.R4AA{Theta: math.Pi / 2, RZ: 1}) > dhStructureEpsilon ||
+			jointPose.Point().Norm() > dhStructureEpsilon {
+			return nil, errors.Errorf("joint %q of model is not a revolute joint about its z axis", joint.Name())
+		}
+		linkPose, err := link.Transform([]frame.Input{})
+		if err != nil {
+			return nil, err
+		}
+		rm := linkPose.Orientation().RotationMatrix()
+		alpha := math.Atan2(rm.At(1, 2), rm.At(1, 1))
+		if math.Abs(linkPose.Point().Y) > dhStructureEpsilon ||
+			orientDist(linkPose.Orientation(), &spatial.R4AA{Theta: alpha, RX: 1}) > dhStructureEpsilon {
+			return nil, errors.Errorf("link %q of model cannot be described with DH parameters", link.Name())
+		}
+		params = append(params, dhParam{a: linkPose.Point().X, d: linkPose.Point().Z, alpha: alpha, link: linkPose})
+	}
+	return params, nil
+}
+
+// analyticIK holds what is common to all analytic solvers: the model, and a function returning candidate solutions.
+type analyticIK struct {
+	model      frame.Model
+	candidates func(goal spatial.Pose) [][]float64
+}
+
+// AllSolutions returns one configuration for every solution branch within the limits of the model.
+func (ik *analyticIK) AllSolutions(goal spatial.Pose) [][]frame.Input {
+	return ik.solutions(goal, make([]frame.Input, len(ik.model.DoF())), nil)
+}
+
+// Solve sends every solution which the metric accepts to the channel, best scoring and then closest to the seed first.
+// A solution is accepted if the metric scores it below analyticSolutionEpsilon.
+func (ik *analyticIK) Solve(ctx context.Context, c chan<- []frame.Input, goal spatial.Pose, seed []frame.Input, m Metric) error {
+	solutions := ik.solutions(goal, seed, m)
+	if len(solutions) == 0 {
+		return errIKSolve
+	}
+	for _, solution := range solutions {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c <- solution:
+		}
+	}
+	return nil
+}
+
+// solutions returns the valid candidate solutions, with each joint wrapped by a multiple of 2pi to be as close as possible
+// to the seed. If a metric is given, only solutions it accepts are returned, sorted by their score rounded to
+// analyticScoreResolution, and then by their distance from the seed. Otherwise they are sorted by their distance alone.
+func (ik *analyticIK) solutions(goal spatial.Pose, seed []frame.Input, m Metric) [][]frame.Input {
+	type scoredSolution struct {
+		inputs []frame.Input
+		score  float64
+		dist   float64
+	}
+	limits := ik.model.DoF()
+	scored := []scoredSolution{}
+	for _, candidate := range ik.candidates(goal) {
+		solution := make([]frame.Input, 0, len(candidate))
+		for i, q := range candidate {
+			wrapped, ok := wrapToLimit(q, seed[i].Value, limits[i])
+			if !ok {
+				break
+			}
+			solution = append(solution, frame.Input{wrapped})
+		}
+		if len(solution) != len(candidate) {
+			continue
+		}
+		pose, err := ik.model.Transform(solution)
+		if err != nil ||
+			!spatial.PoseAlmostCoincidentEps(pose, goal, analyticSolutionEpsilon) ||
+			orientDist(pose.Orientation(), goal.Orientation()) > analyticSolutionEpsilon {
+			continue
+		}
+		score := 0.
+		if m != nil {
+			if score = m(pose, goal); score >= analyticSolutionEpsilon {
+				continue
+			}
+		}
+		duplicate := false
+		for _, other := range scored {
+			if inputDist(other.inputs, solution) < analyticSolutionEpsilon {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			scored = append(scored, scoredSolution{
+				inputs: solution,
+				score:  math.Round(score / analyticScoreResolution),
+				dist:   inputDist(solution, seed),
+			})
+		}
+	}
+	sort.SliceStable(scored, func(i, j int) bool {
+		if scored[i].score != scored[j].score {
+			return scored[i].score < scored[j].score
+		}
+		return scored[i].dist < scored[j].dist
+	})
+	solutions := make([][]frame.Input, 0, len(scored))
+	for _, solution := range scored {
+		solutions = append(solutions, solution.inputs)
+	}
+	return solutions
+}
+
+// wrapToLimit returns the angle equivalent to q, within the limit, which is closest to the target.
+func wrapToLimit(q, target float64, limit frame.Limit) (float64, bool) {
+	q += 2 * math.Pi * math.Round((target-q)/(2*math.Pi))
+	for q > limit.Max {
+		q -= 2 * math.Pi
+	}
+	for q < limit.Min {
+		q += 2 * math.Pi
+	}
+	return q, q <= limit.Max
+}
+
+// NewURInverseKinematics creates an analytic IK solver for arms with the kinematic structure of Universal Robots arms, such as
+// ur5e_DH.json: three parallel shoulder and elbow joints after a vertical waist, followed by a wrist whose axes do not intersect.
+// There are up to eight solutions for every goal.
+func NewURInverseKinematics(model frame.Model) (AnalyticInverseKinematics, error) {
+	dh, err := dhParamsFromModel(model)
+	if err != nil {
+		return nil, err
+	}
+	if !matchesDH(dh,
+		[]float64{math.Pi / 2, 0, 0, math.Pi / 2, -math.Pi / 2, 0},
+		map[int]bool{3: true, 4: true, 5: true},
+		map[int]bool{1: true, 2: true},
+	) {
+		return nil, errors.New("model does not have the kinematic structure of a UR arm")
+	}
+	return &analyticIK{model: model, candidates: func(goal spatial.Pose) [][]float64 {
+		return urCandidates(dh, goal)
+	}}, nil
+}
+
+// urCandidates returns the solutions for a UR arm, following Hawkins, "Analytic Inverse Kinematics for the Universal Robots
+// UR-5/UR-10 Arms", 2013.
+func urCandidates(dh []dhParam, goal spatial.Pose) [][]float64 {
+	d4, d6 := dh[3].d, dh[5].d
+	rot := goal.Orientation().RotationMatrix()
+	p := goal.Point()
+	p5 := p.Sub(rot.Row(2).Mul(d6))
+
+	candidates := [][]float64{}
+	for _, q1 := range offsetAngles(p5.X, p5.Y, d4) {
+		// the wrist axes are offset from the plane of the shoulder and elbow along the shoulder axis
+		z1 := r3.Vector{X: math.Sin(q1), Y: -math.Cos(q1)}
+		c5 := (p.Dot(z1) - d4) / d6
+		if math.Abs(c5) > 1+dhStructureEpsilon {
+			continue
+		}
+		for _, q5 := range []float64{math.Acos(clamp(c5)), -math.Acos(clamp(c5))} {
+			s5 := math.Sin(q5)
+			q6 := 0.
+			if math.Abs(s5) > wristSingularityEpsilon {
+				// the shoulder axis in the frame of the end effector
+				v := rot.Mul(z1)
+				q6 = math.Atan2(-v.Y/s5, v.X/s5)
+			}
+
+			// the remaining joints are planar
+			t14 := spatial.Compose(
+				spatial.Compose(spatial.PoseInverse(dh[0].dhTransform(q1)), goal),
+				spatial.PoseInverse(spatial.Compose(dh[4].dhTransform(q5), dh[5].dhTransform(q6))),
+			)
+			for _, q23 := range planarAngles(t14.Point().X, t14.Point().Y, dh[1].a, dh[2].a, 0) {
+				rot14 := t14.Orientation().RotationMatrix()
+				q4 := math.Atan2(rot14.At(0, 1), rot14.At(0, 0)) - q23[0] - q23[1]
+				candidates = append(candidates, []float64{q1, q23[0], q23[1], q4, q5, q6})
+			}
+		}
+	}
+	return candidates
+}
+
+// NewSphericalWristInverseKinematics creates an analytic IK solver for arms whose last three joint axes intersect at a point,
+// such as most six axis industrial arms. The first joint must be vertical, and the second and third parallel, with the wrist
+// center offset from the third along its link. There are up to eight solutions for every goal.
+func NewSphericalWristInverseKinematics(model frame.Model) (AnalyticInverseKinematics, error) {
+	dh, err := dhParamsFromModel(model)
+	if err != nil {
+		return nil, err
+	}
+	if len(dh) != 6 ||
+		math.Abs(math.Cos(dh[0].alpha)) > dhStructureEpsilon ||
+		math.Abs(dh[1].alpha) > dhStructureEpsilon ||
+		math.Abs(math.Cos(dh[2].alpha)) > dhStructureEpsilon ||
+		math.Abs(math.Cos(dh[3].alpha)) > dhStructureEpsilon ||
+		math.Abs(math.Cos(dh[4].alpha)) > dhStructureEpsilon ||
+		math.Abs(dh[5].alpha) > dhStructureEpsilon ||
+		math.Abs(dh[3].a) > dhStructureEpsilon ||
+		math.Abs(dh[4].a) > dhStructureEpsilon ||
+		math.Abs(dh[4].d) > dhStructureEpsilon ||
+		math.Abs(dh[5].a) > dhStructureEpsilon {
+		return nil, errors.New("model does not have a spherical wrist")
+	}
+	return &analyticIK{model: model, candidates: func(goal spatial.Pose) [][]float64 {
+		return sphericalWristCandidates(dh, goal)
+	}}, nil
+}
+
+// sphericalWristCandidates returns the solutions for an arm with a spherical wrist, by first positioning the wrist center with
+// the first three joints and then orienting the end effector with the wrist, after Pieper, 1968.
+func sphericalWristCandidates(dh []dhParam, goal spatial.Pose) [][]float64 {
+	rot := goal.Orientation().RotationMatrix()
+	wrist := goal.Point().Sub(rot.Row(2).Mul(dh[5].d))
+	s4, s5 := math.Sin(dh[3].alpha), math.Sin(dh[4].alpha)
+
+	candidates := [][]float64{}
+	for _, q1 := range offsetAngles(wrist.X, wrist.Y, (dh[1].d+dh[2].d)/math.Sin(dh[0].alpha)) {
+		w1 := spatial.Compose(spatial.PoseInverse(dh[0].dhTransform(q1)), spatial.NewPoseFromPoint(wrist)).Point()
+		for _, q23 := range planarAngles(w1.X, w1.Y, dh[1].a, dh[2].a, -math.Sin(dh[2].alpha)*dh[3].d) {
+			t03 := spatial.Compose(spatial.Compose(dh[0].dhTransform(q1), dh[1].dhTransform(q23[0])), dh[2].dhTransform(q23[1]))
+			rot36 := spatial.Compose(spatial.PoseInverse(t03), goal).Orientation().RotationMatrix()
+
+			c5 := -rot36.At(2, 2) / (s4 * s5)
+			for _, q5 := range []float64{math.Acos(clamp(c5)), -math.Acos(clamp(c5))} {
+				q4 := 0.
+				if sinQ5 := math.Sin(q5); math.Abs(sinQ5) > wristSingularityEpsilon {
+					q4 = math.Atan2(rot36.At(2, 1)/(s5*sinQ5), rot36.At(2, 0)/(s5*sinQ5))
+				}
+				// the last joint accounts for the rotation remaining after the first two wrist joints
+				t35 := spatial.Compose(
+					spatial.Compose(spatial.NewPoseFromOrientation(r3.Vector{}, &spatial.R4AA{Theta: q4, RZ: 1}), dh[3].link),
+					spatial.Compose(spatial.NewPoseFromOrientation(r3.Vector{}, &spatial.R4AA{Theta: q5, RZ: 1}), dh[4].link),
+				)
+				rot6 := spatial.Compose(spatial.PoseInverse(t35), spatial.NewPoseFromOrientation(r3.Vector{}, rot36)).
+					Orientation().RotationMatrix()
+				q6 := math.Atan2(rot6.At(0, 1), rot6.At(0, 0))
+				candidates = append(candidates, []float64{q1, q23[0], q23[1], q4, q5, q6})
+			}
+		}
+	}
+	return candidates
+}
+
+// matchesDH returns whether six DH parameters have the given link twists, and zero link lengths and offsets at the given indices.
+func matchesDH(dh []dhParam, alphas []float64, zeroA, zeroD map[int]bool) bool {
+	if len(dh) != len(alphas) {
+		return false
+	}
+	for i, p := range dh {
+		if math.Abs(p.alpha-alphas[i]) > dhStructureEpsilon ||
+			(zeroA[i] && math.Abs(p.a) > dhStructureEpsilon) ||
+			(zeroD[i] && math.Abs(p.d) > dhStructureEpsilon) {
+			return false
+		}
+	}
+	return true
+}
+
+// offsetAngles returns the angles q for which the point (x, y), rotated by -q, lies at the given offset along -y. That is,
+// the solutions to x*sin(q) - y*cos(q) = offset.
+func offsetAngles(x, y, offset float64) []float64 {
+	r := math.Hypot(x, y)
+	if r < math.Abs(offset) || r < dhStructureEpsilon {
+		return nil
+	}
+	psi := math.Atan2(y, x)
+	theta := math.Asin(offset / r)
+	return []float64{psi + theta, psi + math.Pi - theta}
+}
+
+// planarAngles returns the pairs of joint angles for which a planar two link chain, with the first link of length a and the
+// second ending at (l, offset) in the frame of the second joint, reaches the point (x, y).
+func planarAngles(x, y, a, l, offset float64) [][2]float64 {
+	length := math.Hypot(l, offset)
+	phi := math.Atan2(offset, l)
+	c := (x*x + y*y - a*a - length*length) / (2 * a * length)
+	if math.Abs(c) > 1+dhStructureEpsilon {
+		return nil
+	}
+	angles := [][2]float64{}
+	for _, gamma := range []float64{math.Acos(clamp(c)), -math.Acos(clamp(c))} {
+		q1 := math.Atan2(y, x) - math.Atan2(length*math.Sin(gamma), a+length*math.Cos(gamma))
+		angles = append(angles, [2]float64{q1, gamma - phi})
+	}
+	return angles
+}
+
+func clamp(c float64) float64 {
+	return math.Max(-1, math.Min(1, c))
+}
+
+// fallbackIK solves with an analytic solver, and falls back to numeric IK when the analytic solver finds no solution, such
+// as when the metric accepts poses near the goal but none of the exact solutions for the goal itself.
+type fallbackIK struct {
+	analytic AnalyticInverseKinematics
+	numeric  InverseKinematics
+}
+
+func (ik *fallbackIK) Solve(ctx context.Context, c chan<- []frame.Input, goal spatial.Pose, seed []frame.Input, m Metric) error {
+	if err := ik.analytic.Solve(ctx, c, goal, seed, m); !errors.Is(err, errIKSolve) {
+		return err
+	}
+	return ik.numeric.Solve(ctx, c, goal, seed, m)
+}
+
+// solverFrameIK solves for a solver frame whose only moving frame is a model with an analytic solver, by expressing goals
+// relative to the base of the model and its end effector. Since the model is the only moving frame, the inputs of the
+// solver frame are those of the model.
+type solverFrameIK struct {
+	model AnalyticInverseKinematics
+	// base is the pose of the base of the model in the frame goals are given in, and tool the pose of the solve frame
+	// relative to the end effector of the model.
+	base, tool spatial.Pose
+}
+
+// newSolverFrameIK returns an analytic IK solver for a solver frame in which a single model with an analytic solver moves.
+func newSolverFrameIK(sf *solverFrame) (AnalyticInverseKinematics, bool) {
+	var model frame.Model
+	for _, f := range sf.frames {
+		if len(f.DoF()) == 0 {
+			continue
+		}
+		m, ok := f.(frame.Model)
+		if !ok || model != nil {
+			return nil, false
+		}
+		model = m
+	}
+	if model == nil {
+		return nil, false
+	}
+	analytic, ok := newAnalyticIKSolver(model)
+	if !ok {
+		return nil, false
+	}
+
+	// the base and tool are fixed, so they can be found from any configuration within the limits of the model
+	inputs := make([]frame.Input, 0, len(sf.DoF()))
+	for _, limit := range sf.DoF() {
+		inputs = append(inputs, frame.Input{(limit.Min + limit.Max) / 2})
+	}
+	solvePose, err := sf.Transform(inputs)
+	if err != nil {
+		return nil, false
+	}
+	goalFrame := sf.goalFrame.Name()
+	if sf.worldRooted {
+		goalFrame = frame.World
+	}
+	tf, err := sf.movingFs.Transform(sf.sliceToMap(inputs), frame.NewPoseInFrame(model.Name(), spatial.NewZeroPose()), goalFrame)
+	if err != nil {
+		return nil, false
+	}
+	modelPose, err := model.Transform(inputs)
+	if err != nil {
+		return nil, false
+	}
+	endPose := tf.(*frame.PoseInFrame).Pose()
+	return &solverFrameIK{
+		model: analytic,
+		base:  spatial.Compose(endPose, spatial.PoseInverse(modelPose)),
+		tool:  spatial.Compose(spatial.PoseInverse(endPose), solvePose),
+	}, true
+}
+
+// modelGoal returns the pose of the end effector of the model, relative to its base, which places the solve frame at the goal.
+func (ik *solverFrameIK) modelGoal(goal spatial.Pose) spatial.Pose {
+	return spatial.Compose(spatial.Compose(spatial.PoseInverse(ik.base), goal), spatial.PoseInverse(ik.tool))
+}
+
+// AllSolutions returns one configuration for every solution branch of the model which places the solve frame at the goal.
+func (ik *solverFrameIK) AllSolutions(goal spatial.Pose) [][]frame.Input {
+	return ik.model.AllSolutions(ik.modelGoal(goal))
+}
+
+// Solve sends the solutions of the model for the goal, scoring them by the metric with the pose of the solve frame.
+func (ik *solverFrameIK) Solve(ctx context.Context, c chan<- []frame.Input, goal spatial.Pose, seed []frame.Input, m Metric) error {
+	var modelMetric Metric
+	if m != nil {
+		modelMetric = func(pose, _ spatial.Pose) float64 {
+			return m(spatial.Compose(spatial.Compose(ik.base, pose), ik.tool), goal)
+		}
+	}
+	return ik.model.Solve(ctx, c, ik.modelGoal(goal), seed, modelMetric)
+}
+
+// newAnalyticIKSolver returns an analytic IK solver for the frame if its kinematic structure has one. This is the case for
+// models with an analytic solver, and for solver frames in which such a model is the only moving frame.
+func newAnalyticIKSolver(f frame.Frame) (AnalyticInverseKinematics, bool) {
+	if sf, ok := f.(*solverFrame); ok {
+		return newSolverFrameIK(sf)
+	}
+	model, ok := f.(frame.Model)
+	if !ok {
+		return nil, false
+	}
+	if ik, err := NewURInverseKinematics(model); err == nil {
+		return ik, true
+	}
+	if ik, err := NewSphericalWristInverseKinematics(model); err == nil {
+		return ik, true
+	}
+	return nil, false
+}
@@ -0,0 +1,221 @@
+package motionplan
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/test"
+
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+// sphericalWristArmJSON is a six axis arm with a spherical wrist, with the proportions of a typical industrial arm.
+var sphericalWristArmJSON = []byte(`{
+	"name": "spherical",
+	"kinematic_param_type": "DH",
+	"dhParams": [
+		{"id": "waist", "parent": "world", "a": 50, "d": 400, "alpha": 1.5707963267948966, "max": 180, "min": -180},
+		{"id": "shoulder", "parent": "waist", "a": 450, "d": 30, "alpha": 0, "max": 180, "min": -180},
+		{"id": "elbow", "parent": "shoulder", "a": 35, "d": 0, "alpha": 1.5707963267948966, "max": 180, "min": -180},
+		{"id": "forearm", "parent": "elbow", "a": 0, "d": 420, "alpha": -1.5707963267948966, "max": 180, "min": -180},
+		{"id": "wrist", "parent": "forearm", "a": 0, "d": 0, "alpha": 1.5707963267948966, "max": 180, "min": -180},
+		{"id": "flange", "parent": "wrist", "a": 0, "d": 80, "alpha": 0, "max": 180, "min": -180}
+	]
+}`)
+
+// testAnalyticIK checks that every solution for poses of random configurations reaches the pose, and that the configuration
+// itself is among the solutions.
+func testAnalyticIK(t *testing.T, model frame.Model, ik AnalyticInverseKinematics) {
+	t.Helper()
+	//nolint:gosec
+	randseed := rand.New(rand.NewSource(1))
+	for i := 0; i < 50; i++ {
+		inputs := frame.RandomFrameInputs(model, randseed)
+		goal, err := model.Transform(inputs)
+		test.That(t, err, test.ShouldBeNil)
+
+		solutions := ik.AllSolutions(goal)
+		test.That(t, len(solutions), test.ShouldBeBetweenOrEqual, 1, 8)
+		found := false
+		for _, solution := range solutions {
+			pose, err := model.Transform(solution)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, spatial.PoseAlmostCoincidentEps(pose, goal, analyticSolutionEpsilon), test.ShouldBeTrue)
+
+			same := true
+			for j, q := range solution {
+				diff := math.Remainder(q.Value-inputs[j].Value, 2*math.Pi)
+				same = same && math.Abs(diff) < 1e-4
+			}
+			found = found || same
+		}
+		test.That(t, found, test.ShouldBeTrue)
+	}
+}
+
+func TestURInverseKinematics(t *testing.T) {
+	model, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e_DH.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	ik, err := NewURInverseKinematics(model)
+	test.That(t, err, test.ShouldBeNil)
+	testAnalyticIK(t, model, ik)
+
+	// the UR5e reaches most poses with all eight of its solutions
+	goal, err := model.Transform(frame.FloatsToInputs([]float64{0.3, -1, 1.2, -0.5, 1, 0.2}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(ik.AllSolutions(goal)), test.ShouldEqual, 8)
+
+	// poses out of reach have no solutions
+	test.That(t, ik.AllSolutions(spatial.NewPoseFromPoint(goal.Point().Mul(10))), test.ShouldBeEmpty)
+
+	// the SVA version of the arm has no DH parameters
+	sva, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	_, err = NewURInverseKinematics(sva)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestSphericalWristInverseKinematics(t *testing.T) {
+	model, err := frame.UnmarshalModelJSON(sphericalWristArmJSON, "")
+	test.That(t, err, test.ShouldBeNil)
+	ik, err := NewSphericalWristInverseKinematics(model)
+	test.That(t, err, test.ShouldBeNil)
+	testAnalyticIK(t, model, ik)
+
+	// the UR5e's wrist axes do not intersect
+	ur5e, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e_DH.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	_, err = NewSphericalWristInverseKinematics(ur5e)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = NewURInverseKinematics(model)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestAnalyticIKSolve(t *testing.T) {
+	model, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e_DH.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	ik, ok := newAnalyticIKSolver(model)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	inputs := frame.FloatsToInputs([]float64{0.3, -1, 1.2, -0.5, 1, 0.2})
+	goal, err := model.Transform(inputs)
+	test.That(t, err, test.ShouldBeNil)
+
+	// solutions are sent closest to the seed first, so a seed which reaches the goal is the first solution
+	c := make(chan []frame.Input, 8)
+	test.That(t, ik.Solve(context.Background(), c, goal, inputs, NewSquaredNormMetric()), test.ShouldBeNil)
+	close(c)
+	prev := -1.
+	for solution := range c {
+		dist := inputDist(solution, inputs)
+		if prev < 0 {
+			test.That(t, dist, test.ShouldBeLessThan, 1e-4)
+		}
+		test.That(t, dist, test.ShouldBeGreaterThanOrEqualTo, prev)
+		prev = dist
+	}
+
+	err = ik.Solve(context.Background(), c, spatial.NewPoseFromPoint(goal.Point().Mul(10)), inputs, NewSquaredNormMetric())
+	test.That(t, err, test.ShouldBeError, errIKSolve)
+
+	// solutions which the metric does not accept are not sent
+	rejectAll := func(from, to spatial.Pose) float64 { return 1 }
+	test.That(t, ik.Solve(context.Background(), make(chan []frame.Input, 8), goal, inputs, rejectAll), test.ShouldBeError, errIKSolve)
+
+	// when there are no analytic solutions, the goal is solved for numerically
+	fallback := &fallbackIK{analytic: ik, numeric: &echoIK{f: model}}
+	c = make(chan []frame.Input, 8)
+	test.That(t, fallback.Solve(context.Background(), c, goal, inputs, rejectAll), test.ShouldBeNil)
+	test.That(t, c, test.ShouldHaveLength, 1)
+	test.That(t, <-c, test.ShouldResemble, inputs)
+	test.That(t, fallback.Solve(context.Background(), c, goal, inputs, NewSquaredNormMetric()), test.ShouldBeNil)
+	test.That(t, len(c), test.ShouldBeGreaterThan, 1)
+	//nolint:gosec
+	mp, err := newPlanner(model, 1, rand.New(rand.NewSource(1)), logger.Sugar())
+	test.That(t, err, test.ShouldBeNil)
+	_, ok = mp.solver.(*fallbackIK)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	// models without a closed form solution are solved numerically
+	xarm, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	_, ok = newAnalyticIKSolver(xarm)
+	test.That(t, ok, test.ShouldBeFalse)
+}
+
+func TestAnalyticIKPlanMotion(t *testing.T) {
+	model, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e_DH.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+	basePose := spatial.NewPoseFromOrientation(r3.Vector{X: 100, Y: -50, Z: 200}, &spatial.OrientationVectorDegrees{OZ: 1, Theta: 30})
+	offset, err := frame.NewStaticFrame("arm_offset", basePose)
+	test.That(t, err, test.ShouldBeNil)
+	toolPose := spatial.NewPoseFromPoint(r3.Vector{X: 10, Z: 100})
+	gripper, err := frame.NewStaticFrame("gripper", toolPose)
+	test.That(t, err, test.ShouldBeNil)
+	fs := frame.NewEmptySimpleFrameSystem("test")
+	test.That(t, fs.AddFrame(offset, fs.World()), test.ShouldBeNil)
+	test.That(t, fs.AddFrame(model, offset), test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gripper, model), test.ShouldBeNil)
+
+	seedMap := frame.StartPositions(fs)
+	inputs := frame.FloatsToInputs([]float64{0.3, -1, 1.2, -0.5, 1, 0.2})
+	tf, err := fs.Transform(map[string][]frame.Input{"arm": inputs}, frame.NewPoseInFrame("gripper", spatial.NewZeroPose()), frame.World)
+	test.That(t, err, test.ShouldBeNil)
+	goal := tf.(*frame.PoseInFrame).Pose()
+
+	// the solver frame from the gripper to the world is solved analytically for the arm
+	fss := NewSolvableFrameSystem(fs, logger.Sugar())
+	solveFrames, err := fss.TracebackFrame(gripper)
+	test.That(t, err, test.ShouldBeNil)
+	sf, err := newSolverFrame(fss, solveFrames, frame.World, seedMap)
+	test.That(t, err, test.ShouldBeNil)
+	mp, _, err := fss.newPlanner(sf, nil)
+	test.That(t, err, test.ShouldBeNil)
+	solver, ok := mp.(*cBiRRTMotionPlanner).solver.(*fallbackIK)
+	test.That(t, ok, test.ShouldBeTrue)
+	_, ok = solver.analytic.(*solverFrameIK)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	// the plan ends at one of the closed form solutions for the arm, found with the goal relative to its base and end effector
+	plan, err := PlanMotion(
+		context.Background(),
+		logger.Sugar(),
+		frame.NewPoseInFrame(frame.World, goal),
+		gripper,
+		seedMap,
+		fs,
+		&commonpb.WorldState{},
+		nil,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(plan), test.ShouldBeGreaterThanOrEqualTo, 2)
+	end := plan[len(plan)-1]["arm"]
+	ik, err := NewURInverseKinematics(model)
+	test.That(t, err, test.ShouldBeNil)
+	modelGoal := spatial.Compose(spatial.Compose(spatial.PoseInverse(basePose), goal), spatial.PoseInverse(toolPose))
+	found := false
+	for _, solution := range ik.AllSolutions(modelGoal) {
+		found = found || inputDist(solution, end) < 1e-9
+	}
+	test.That(t, found, test.ShouldBeTrue)
+
+	// solver frames in which frames other than the model move are solved numerically
+	gantry, err := frame.NewTranslationalFrame("gantry", r3.Vector{X: 1}, frame.Limit{Min: -100, Max: 100})
+	test.That(t, err, test.ShouldBeNil)
+	fs = frame.NewEmptySimpleFrameSystem("test")
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+	test.That(t, fs.AddFrame(model, gantry), test.ShouldBeNil)
+	fss = NewSolvableFrameSystem(fs, logger.Sugar())
+	solveFrames, err = fss.TracebackFrame(model)
+	test.That(t, err, test.ShouldBeNil)
+	sf, err = newSolverFrame(fss, solveFrames, frame.World, frame.StartPositions(fs))
+	test.That(t, err, test.ShouldBeNil)
+	_, ok = newAnalyticIKSolver(sf)
+	test.That(t, ok, test.ShouldBeFalse)
+}
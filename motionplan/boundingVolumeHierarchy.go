@@ -0,0 +1,120 @@
+package motionplan
+
+import (
+	"math"
+	"sort"
+
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+// maxEntitiesPerLeaf is the number of entities below which a node of a boundingVolumeHierarchy will not be split further.
+const maxEntitiesPerLeaf = 4
+
+// boundingVolumeHierarchy is a binary tree of axis-aligned bounding boxes used as a broad phase for collision checking.
+// Each node bounds all of the entities beneath it, so whole subtrees can be skipped when their bounds do not overlap
+// the queried region, which allows finding the candidates for a collision in logarithmic rather than linear time.
+type boundingVolumeHierarchy struct {
+	root   *bvhNode
+	bounds []spatial.AABB
+}
+
+type bvhNode struct {
+	bounds      spatial.AABB
+	left, right *bvhNode
+
+	// indices of the entities bounded by this node, only populated for leaves
+	indices []int
+}
+
+// newBoundingVolumeHierarchy builds a boundingVolumeHierarchy over the given bounding boxes, which are referred to by their index.
+func newBoundingVolumeHierarchy(bounds []spatial.AABB) *boundingVolumeHierarchy {
+	if len(bounds) == 0 {
+		return &boundingVolumeHierarchy{}
+	}
+	indices := make([]int, len(bounds))
+	for i := range indices {
+		indices[i] = i
+	}
+	return &boundingVolumeHierarchy{buildBVHNode(bounds, indices), bounds}
+}
+
+// buildBVHNode recursively builds a node bounding the entities at the given indices. The entities are ordered by their
+// centers along the axis on which those centers are most spread out, and split where the surface area heuristic finds
+// the smallest expected cost of querying both children: the surface area of each child, which is proportional to the
+// chance a query overlaps it, multiplied by the number of entities in it.
+func buildBVHNode(bounds []spatial.AABB, indices []int) *bvhNode {
+	node := &bvhNode{bounds: bounds[indices[0]]}
+	centers := spatial.AABB{Min: bounds[indices[0]].Center(), Max: bounds[indices[0]].Center()}
+	for _, i := range indices[1:] {
+		node.bounds = node.bounds.Union(bounds[i])
+		centers = centers.Union(spatial.AABB{Min: bounds[i].Center(), Max: bounds[i].Center()})
+	}
+	if len(indices) <= maxEntitiesPerLeaf {
+		node.indices = indices
+		return node
+	}
+
+	spread := centers.Max.Sub(centers.Min)
+	axis := func(b spatial.AABB) float64 { return b.Center().X }
+	if spread.Y > spread.X && spread.Y >= spread.Z {
+		axis = func(b spatial.AABB) float64 { return b.Center().Y }
+	} else if spread.Z > spread.X && spread.Z > spread.Y {
+		axis = func(b spatial.AABB) float64 { return b.Center().Z }
+	}
+	sort.Slice(indices, func(i, j int) bool { return axis(bounds[indices[i]]) < axis(bounds[indices[j]]) })
+
+	split := surfaceAreaSplit(bounds, indices)
+	node.left = buildBVHNode(bounds, indices[:split])
+	node.right = buildBVHNode(bounds, indices[split:])
+	return node
+}
+
+// surfaceAreaSplit returns the position at which to split the ordered entities into two children of a bvhNode which
+// minimizes the surface area heuristic. Splits of equal cost are broken towards the median to keep the tree balanced.
+func surfaceAreaSplit(bounds []spatial.AABB, indices []int) int {
+	n := len(indices)
+	// suffix[k] bounds the entities from k onwards
+	suffix := make([]spatial.AABB, n)
+	suffix[n-1] = bounds[indices[n-1]]
+	for k := n - 2; k > 0; k-- {
+		suffix[k] = suffix[k+1].Union(bounds[indices[k]])
+	}
+
+	best, bestCost := n/2, math.Inf(1)
+	prefix := bounds[indices[0]]
+	for k := 1; k < n; k++ {
+		cost := prefix.SurfaceArea()*float64(k) + suffix[k].SurfaceArea()*float64(n-k)
+		if cost < bestCost || (cost == bestCost && math.Abs(float64(k-n/2)) < math.Abs(float64(best-n/2))) {
+			best, bestCost = k, cost
+		}
+		prefix = prefix.Union(bounds[indices[k]])
+	}
+	return best
+}
+
+// overlapping returns the indices of all entities whose bounding boxes overlap the given bounding box, in ascending order.
+func (bvh *boundingVolumeHierarchy) overlapping(bounds spatial.AABB) []int {
+	var indices []int
+	if bvh.root == nil {
+		return indices
+	}
+	stack := []*bvhNode{bvh.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !node.bounds.Overlaps(bounds) {
+			continue
+		}
+		if node.left == nil {
+			for _, i := range node.indices {
+				if bvh.bounds[i].Overlaps(bounds) {
+					indices = append(indices, i)
+				}
+			}
+			continue
+		}
+		stack = append(stack, node.left, node.right)
+	}
+	sort.Ints(indices)
+	return indices
+}
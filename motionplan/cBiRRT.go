@@ -363,10 +363,8 @@ func (mp *cBiRRTMotionPlanner) SmoothPath(
 		default:
 		}
 		// Pick two random non-adjacent indices, excepting the ends
-		//nolint:gosec
-		j := 2 + rand.Intn(len(inputSteps)-3)
-		//nolint:gosec
-		i := rand.Intn(j) + 1
+		j := 2 + mp.randseed.Intn(len(inputSteps)-3)
+		i := mp.randseed.Intn(j) + 1
 
 		ok, hitCorners := smoothable(inputSteps, i, j, corners)
 		if !ok {
@@ -3,6 +3,7 @@ package motionplan
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	spatial "go.viam.com/rdk/spatialmath"
 	"go.viam.com/rdk/utils"
@@ -47,10 +48,12 @@ func collisionListsAlmostEqual(cs1, cs2 []Collision) bool {
 	return true
 }
 
-// collisionEntity is an object that is used in collision checking and contains a named geometry.
+// collisionEntity is an object that is used in collision checking and contains a named geometry, along with the
+// axis-aligned box bounding that geometry which is used as a broad phase for collision checks.
 type collisionEntity struct {
 	name     string
 	geometry spatial.Geometry
+	bounds   spatial.AABB
 }
 
 // CollisionEntities defines an interface for a set of collisionEntities that can be treated as a single batch.
@@ -58,31 +61,40 @@ type CollisionEntities interface {
 	count() int
 	entityFromIndex(int) *collisionEntity
 	indexFromName(string) (int, error)
+	overlapping(spatial.AABB) []int
 	checkCollision(*collisionEntity, *collisionEntity) (float64, error)
-	reportCollisions([]float64) []int
+	culledCollision(*collisionEntity, *collisionEntity) float64
+	reportCollisions(map[int]float64) []int
 }
 
 // ObjectCollisionEntities is an implementation of CollisionEntities for entities that occupy physical space and should not be intersected
 // it is exported because the key CollisionEntities in a CollisionSystem must be of this type.
 type ObjectCollisionEntities struct {
-	entities []*collisionEntity
-	indices  map[string]int
+	entities  []*collisionEntity
+	indices   map[string]int
+	hierarchy *boundingVolumeHierarchy
 }
 
 // NewObjectCollisionEntities is a constructor for ObjectCollisionEntities, an exported implementation of CollisionEntities.
 func NewObjectCollisionEntities(geometries map[string]spatial.Geometry) (*ObjectCollisionEntities, error) {
 	entities := make([]*collisionEntity, len(geometries))
 	indices := make(map[string]int, len(geometries))
+	bounds := make([]spatial.AABB, len(geometries))
 	size := 0
 	for name, geometry := range geometries {
 		if _, ok := indices[name]; ok {
 			return nil, fmt.Errorf("error creating CollisionEntities, found geometry with duplicate name: %s", name)
 		}
-		entities[size] = &collisionEntity{name, geometry}
+		aabb, err := spatial.NewAABB(geometry)
+		if err != nil {
+			return nil, err
+		}
+		entities[size] = &collisionEntity{name, geometry, aabb}
 		indices[name] = size
+		bounds[size] = aabb
 		size++
 	}
-	return &ObjectCollisionEntities{entities, indices}, nil
+	return &ObjectCollisionEntities{entities, indices, newBoundingVolumeHierarchy(bounds)}, nil
 }
 
 // count returns the number of collisionEntities in a CollisionEntities class.
@@ -103,18 +115,30 @@ func (oce *ObjectCollisionEntities) indexFromName(name string) (int, error) {
 	return -1, fmt.Errorf("collision entity %q not found", name)
 }
 
+// overlapping returns the indices of the entities whose bounding boxes overlap the given bounding box.
+func (oce *ObjectCollisionEntities) overlapping(bounds spatial.AABB) []int {
+	return oce.hierarchy.overlapping(bounds)
+}
+
 func (oce *ObjectCollisionEntities) checkCollision(key, test *collisionEntity) (float64, error) {
 	distance, err := key.geometry.DistanceFrom(test.geometry)
 	return -distance, err // multiply distance by -1 so that weights of edges are positive
 }
 
-func (oce *ObjectCollisionEntities) reportCollisions(distances []float64) []int {
+// culledCollision returns the edge weight for entities that were never checked because their bounding boxes do not overlap.
+// The distance between the bounding boxes is a lower bound on the distance between the entities, so is used in its place.
+func (oce *ObjectCollisionEntities) culledCollision(key, test *collisionEntity) float64 {
+	return -key.bounds.Distance(test.bounds)
+}
+
+func (oce *ObjectCollisionEntities) reportCollisions(distances map[int]float64) []int {
 	var collisionIndices []int
-	for i := range distances {
-		if distances[i] >= 0 {
+	for i, distance := range distances {
+		if distance >= 0 {
 			collisionIndices = append(collisionIndices, i)
 		}
 	}
+	sort.Ints(collisionIndices)
 	return collisionIndices
 }
 
@@ -140,10 +164,17 @@ func (sce spaceCollisionEntities) checkCollision(key, test *collisionEntity) (fl
 	return -1, nil
 }
 
-func (sce spaceCollisionEntities) reportCollisions(distances []float64) []int {
+// culledCollision returns the edge weight for entities that were never checked because their bounding boxes do not overlap,
+// in which case the key entity cannot be encompassed by the test entity.
+func (sce spaceCollisionEntities) culledCollision(_, _ *collisionEntity) float64 {
+	return 1
+}
+
+func (sce spaceCollisionEntities) reportCollisions(distances map[int]float64) []int {
 	collisionIndices := make([]int, 0)
-	for i := range distances {
-		if distances[i] >= 0 {
+	for i := 0; i < sce.count(); i++ {
+		distance, checked := distances[i]
+		if !checked || distance >= 0 {
 			collisionIndices = append(collisionIndices, i)
 		} else {
 			return []int{}
@@ -162,9 +193,10 @@ type collisionGraph struct {
 	// test CollisionEntities and key Collision Entities
 	test CollisionEntities
 
-	// adjacencies is 2D array encoding edges between collisionEntiies in the collisionGraph.
+	// adjacencies is a sparse 2D array encoding edges between collisionEntiies in the collisionGraph.
 	// if adjacencies[i][j] >= 0 this corresponds to an edge between the entities at key[i] and test[j]
-	adjacencies [][]float64
+	// pairs of entities whose bounding boxes do not overlap are never checked, and have no entry
+	adjacencies []map[int]float64
 
 	// triangular is a bool that describes if the adjacencies matrix is triangular, which will be the case when key == test
 	triangular bool
@@ -172,20 +204,17 @@ type collisionGraph struct {
 
 // newCollisionGraph instantiates a collisionGraph object and checks for collisions between the key and test sets of CollisionEntities
 // collisions that are reported in the reference CollisionSystem argument will be ignore and not stored as edges in the graph.
+// Only pairs of entities with overlapping bounding boxes, as found by the test CollisionEntities' boundingVolumeHierarchy, are checked.
 func newCollisionGraph(key *ObjectCollisionEntities, test CollisionEntities, reference *CollisionSystem) (*collisionGraph, error) {
 	var err error
-	cg := &collisionGraph{key: key, test: test, adjacencies: make([][]float64, key.count()), triangular: key == test}
+	cg := &collisionGraph{key: key, test: test, adjacencies: make([]map[int]float64, key.count()), triangular: key == test}
 	for i := range cg.adjacencies {
-		cg.adjacencies[i] = make([]float64, test.count())
+		cg.adjacencies[i] = make(map[int]float64)
 		keyi := key.entityFromIndex(i)
-		startIndex := 0
-		if cg.triangular {
-			startIndex = i + 1
-			for j := 0; j < startIndex; j++ {
-				cg.adjacencies[i][j] = math.NaN()
+		for _, j := range test.overlapping(keyi.bounds) {
+			if cg.triangular && j <= i {
+				continue
 			}
-		}
-		for j := startIndex; j < len(cg.adjacencies[i]); j++ {
 			testj := test.entityFromIndex(j)
 			if reference.CollisionBetween(keyi.name, testj.name) {
 				cg.adjacencies[i][j] = math.NaN() // represent previously seen collisions as NaNs
@@ -207,18 +236,29 @@ func (cg *collisionGraph) collisionBetween(keyName, testName string) bool {
 	if cg.triangular && i > j {
 		i, j = j, i
 	}
-	if iOk == nil && jOk == nil && cg.adjacencies[i][j] >= 0 {
+	if iOk == nil && jOk == nil && cg.distance(i, j) >= 0 {
 		return true
 	}
 	return false
 }
 
+// distance returns the weight of the edge between the entities at key[i] and test[j], which is nonnegative if they collide.
+func (cg *collisionGraph) distance(i, j int) float64 {
+	if distance, checked := cg.adjacencies[i][j]; checked {
+		return distance
+	}
+	if cg.triangular && j <= i {
+		return math.NaN()
+	}
+	return cg.test.culledCollision(cg.key.entityFromIndex(i), cg.test.entityFromIndex(j))
+}
+
 // collisions returns a list of all the Collisions as reported by test CollisionEntities' collisionReportFn.
 func (cg *collisionGraph) collisions() []Collision {
 	var collisions []Collision
 	for i := range cg.adjacencies {
 		for _, j := range cg.test.reportCollisions(cg.adjacencies[i]) {
-			collisions = append(collisions, Collision{cg.key.entityFromIndex(i).name, cg.test.entityFromIndex(j).name, cg.adjacencies[i][j]})
+			collisions = append(collisions, Collision{cg.key.entityFromIndex(i).name, cg.test.entityFromIndex(j).name, cg.distance(i, j)})
 		}
 	}
 	return collisions
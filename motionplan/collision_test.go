@@ -1,6 +1,9 @@
 package motionplan
 
 import (
+	"context"
+	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/golang/geo/r3"
@@ -123,3 +126,146 @@ func TestUniqueCollisions(t *testing.T) {
 	expectedCollisions := []Collision{{"xArm6:base_top", "xArm6:wrist_link", 0}, {"xArm6:wrist_link", "xArm6:upper_arm", 0}}
 	test.That(t, collisionListsAlmostEqual(cs.Collisions(), expectedCollisions), test.ShouldBeTrue)
 }
+
+// makeTestObstacles returns a set of randomly placed boxes and spheres around the workspace of an arm.
+func makeTestObstacles(n int, seed int64) map[string]spatial.Geometry {
+	//nolint:gosec
+	randSeed := rand.New(rand.NewSource(seed))
+	obstacles := make(map[string]spatial.Geometry, n)
+	for i := 0; i < n; i++ {
+		pt := r3.Vector{X: randSeed.Float64()*2000 - 1000, Y: randSeed.Float64()*2000 - 1000, Z: randSeed.Float64() * 1000}
+		size := 10 + randSeed.Float64()*90
+		var geometry spatial.Geometry
+		if i%2 == 0 {
+			geometry, _ = spatial.NewBox(spatial.NewPoseFromPoint(pt), r3.Vector{X: size, Y: size, Z: size})
+		} else {
+			geometry, _ = spatial.NewSphere(pt, size/2)
+		}
+		obstacles[fmt.Sprintf("obstacle%d", i)] = geometry
+	}
+	return obstacles
+}
+
+// pairwiseCollisions checks every pair of geometries without any broad phase, for comparison with a CollisionSystem.
+func pairwiseCollisions(key, test map[string]spatial.Geometry) ([]Collision, error) {
+	var collisions []Collision
+	for keyName, keyGeometry := range key {
+		for testName, testGeometry := range test {
+			distance, err := keyGeometry.DistanceFrom(testGeometry)
+			if err != nil {
+				return nil, err
+			}
+			if distance <= 0 {
+				collisions = append(collisions, Collision{keyName, testName, -distance})
+			}
+		}
+	}
+	return collisions, nil
+}
+
+func TestBoundingVolumeHierarchy(t *testing.T) {
+	bounds := []spatial.AABB{}
+	for i := 0; i < 20; i++ {
+		pt := r3.Vector{X: float64(i), Y: 0, Z: 0}
+		bounds = append(bounds, spatial.AABB{Min: pt, Max: pt.Add(r3.Vector{X: 0.5, Y: 0.5, Z: 0.5})})
+	}
+	bvh := newBoundingVolumeHierarchy(bounds)
+	test.That(t, bvh.overlapping(spatial.AABB{Min: r3.Vector{X: 4.2}, Max: r3.Vector{X: 6.2}}), test.ShouldResemble, []int{4, 5, 6})
+	test.That(t, bvh.overlapping(spatial.AABB{Min: r3.Vector{X: 0.6}, Max: r3.Vector{X: 0.9}}), test.ShouldBeEmpty)
+	test.That(t, len(bvh.overlapping(bvh.root.bounds)), test.ShouldEqual, 20)
+	test.That(t, newBoundingVolumeHierarchy(nil).overlapping(bvh.root.bounds), test.ShouldBeEmpty)
+
+	// a cluster and a couple of outliers are split apart rather than at the median
+	bounds = bounds[:0]
+	for i := 0; i < 8; i++ {
+		pt := r3.Vector{X: float64(i) / 10}
+		bounds = append(bounds, spatial.AABB{Min: pt, Max: pt.Add(r3.Vector{X: 0.1, Y: 0.1, Z: 0.1})})
+	}
+	for i := 0; i < 2; i++ {
+		pt := r3.Vector{X: 100 + float64(i)}
+		bounds = append(bounds, spatial.AABB{Min: pt, Max: pt.Add(r3.Vector{X: 0.1, Y: 0.1, Z: 0.1})})
+	}
+	test.That(t, surfaceAreaSplit(bounds, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), test.ShouldEqual, 8)
+	bvh = newBoundingVolumeHierarchy(bounds)
+	test.That(t, bvh.root.right.bounds.Min.X, test.ShouldEqual, 100)
+
+	// points have no surface area, so are split at the median
+	points := make([]spatial.AABB, 10)
+	test.That(t, surfaceAreaSplit(points, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}), test.ShouldEqual, 5)
+}
+
+func TestCollisionSystemMatchesPairwise(t *testing.T) {
+	m, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	gf, _ := m.Geometries(make([]frame.Input, len(m.DoF())))
+	test.That(t, gf, test.ShouldNotBeNil)
+	robotEntities, err := NewObjectCollisionEntities(gf.Geometries())
+	test.That(t, err, test.ShouldBeNil)
+
+	obstacles := makeTestObstacles(2000, 1)
+	obstacleEntities, err := NewObjectCollisionEntities(obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	cs, err := NewCollisionSystem(robotEntities, []CollisionEntities{obstacleEntities})
+	test.That(t, err, test.ShouldBeNil)
+
+	expected, err := pairwiseCollisions(gf.Geometries(), obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(expected), test.ShouldBeGreaterThan, 0)
+	selfCollisions, err := NewCollisionSystem(robotEntities, []CollisionEntities{})
+	test.That(t, err, test.ShouldBeNil)
+	expected = append(expected, selfCollisions.Collisions()...)
+	test.That(t, collisionListsAlmostEqual(cs.Collisions(), expected), test.ShouldBeTrue)
+}
+
+// BenchmarkPlanningScenes plans in each of the scenes used to test the planners, and separately checks their constraints,
+// which are dominated by collision checks, at the same random configurations a planner would sample. Compare with an
+// earlier commit using benchstat.
+func BenchmarkPlanningScenes(b *testing.B) {
+	scenes := []struct {
+		name   string
+		config planConfigConstructor
+	}{
+		{"2D map", simple2DMap},
+		{"UR5e", simpleUR5eMotion},
+		{"xArm7", simpleXArmMotion},
+		{"xArm7 constrained", constrainedXArmMotion},
+	}
+	checkConstraints := func(b *testing.B, cfg *planConfig) {
+		b.Helper()
+		//nolint:gosec
+		randSeed := rand.New(rand.NewSource(1))
+		for i := 0; i < b.N; i++ {
+			cfg.Options.constraintHandler.CheckConstraints(&ConstraintInput{
+				StartInput: frame.RandomFrameInputs(cfg.RobotFrame, randSeed),
+				EndInput:   frame.RandomFrameInputs(cfg.RobotFrame, randSeed),
+				Frame:      cfg.RobotFrame,
+			})
+		}
+	}
+	for _, scene := range scenes {
+		cfg, err := scene.config()
+		test.That(b, err, test.ShouldBeNil)
+		b.Run(scene.name+" constraints", func(b *testing.B) {
+			checkConstraints(b, cfg)
+		})
+		b.Run(scene.name+" constraints with 50 obstacles", func(b *testing.B) {
+			cfg, err := scene.config()
+			test.That(b, err, test.ShouldBeNil)
+			cfg.Options.AddConstraint("obstacles", NewCollisionConstraint(cfg.RobotFrame, cfg.Start, makeTestObstacles(50, 1), nil))
+			checkConstraints(b, cfg)
+		})
+		b.Run(scene.name+" plan", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				cfg, err := scene.config()
+				test.That(b, err, test.ShouldBeNil)
+				//nolint:gosec
+				mp, err := NewCBiRRTMotionPlannerWithSeed(cfg.RobotFrame, 1, rand.New(rand.NewSource(1)), logger.Sugar())
+				test.That(b, err, test.ShouldBeNil)
+				b.StartTimer()
+				_, err = mp.Plan(context.Background(), cfg.Goal, cfg.Start, cfg.Options)
+				test.That(b, err, test.ShouldBeNil)
+			}
+		})
+	}
+}
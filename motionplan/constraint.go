@@ -178,6 +178,36 @@ func NewCollisionConstraint(
 	return constraint
 }
 
+// geometriesInWorldFrame transforms the geometries of a world state into the world frame, naming each by its index in the
+// world state and its label.
+func geometriesInWorldFrame(
+	fs referenceframe.FrameSystem,
+	gfs []*commonpb.GeometriesInFrame,
+	observationInput map[string][]referenceframe.Input,
+) (*referenceframe.GeometriesInFrame, error) {
+	allGeometries := make(map[string]spatial.Geometry)
+	for name1, gf := range gfs {
+		obstacles, err := referenceframe.ProtobufToGeometriesInFrame(gf)
+		if err != nil {
+			return nil, err
+		}
+		// TODO(rb) it is bad practice to assume that the current inputs of the robot correspond to the passed in world state
+		// the state that observed the worldState should ultimately be included as part of the worldState message
+		tf, err := fs.Transform(observationInput, obstacles, referenceframe.World)
+		if err != nil {
+			return nil, err
+		}
+		for name2, g := range tf.(*referenceframe.GeometriesInFrame).Geometries() {
+			geomName := strconv.Itoa(name1) + "_" + name2
+			if _, present := allGeometries[geomName]; present {
+				return nil, errors.New("multiple geometries with the same name")
+			}
+			allGeometries[geomName] = g
+		}
+	}
+	return referenceframe.NewGeometriesInFrame(referenceframe.World, allGeometries), nil
+}
+
 // NewCollisionConstraintFromWorldState creates a collision constraint from a world state, framesystem, a model and a set of initial states.
 func NewCollisionConstraintFromWorldState(
 	frame referenceframe.Frame,
@@ -185,34 +215,11 @@ func NewCollisionConstraintFromWorldState(
 	worldState *commonpb.WorldState,
 	observationInput map[string][]referenceframe.Input,
 ) (Constraint, error) {
-	transformGeometriesToWorldFrame := func(gfs []*commonpb.GeometriesInFrame) (*referenceframe.GeometriesInFrame, error) {
-		allGeometries := make(map[string]spatial.Geometry)
-		for name1, gf := range gfs {
-			obstacles, err := referenceframe.ProtobufToGeometriesInFrame(gf)
-			if err != nil {
-				return nil, err
-			}
-			// TODO(rb) it is bad practice to assume that the current inputs of the robot correspond to the passed in world state
-			// the state that observed the worldState should ultimately be included as part of the worldState message
-			tf, err := fs.Transform(observationInput, obstacles, referenceframe.World)
-			if err != nil {
-				return nil, err
-			}
-			for name2, g := range tf.(*referenceframe.GeometriesInFrame).Geometries() {
-				geomName := strconv.Itoa(name1) + "_" + name2
-				if _, present := allGeometries[geomName]; present {
-					return nil, errors.New("multiple geometries with the same name")
-				}
-				allGeometries[geomName] = g
-			}
-		}
-		return referenceframe.NewGeometriesInFrame(referenceframe.World, allGeometries), nil
-	}
-	obstacles, err := transformGeometriesToWorldFrame(worldState.GetObstacles())
+	obstacles, err := geometriesInWorldFrame(fs, worldState.GetObstacles(), observationInput)
 	if err != nil {
 		return nil, err
 	}
-	interactionSpaces, err := transformGeometriesToWorldFrame(worldState.GetInteractionSpaces())
+	interactionSpaces, err := geometriesInWorldFrame(fs, worldState.GetInteractionSpaces(), observationInput)
 	if err != nil {
 		return nil, err
 	}
@@ -247,6 +254,29 @@ func NewAbsoluteLinearInterpolatingConstraint(from, to spatial.Pose, linTol, ori
 	return f, interpMetric
 }
 
+// NewPlanarInterpolatingConstraint provides a Constraint whose valid manifold allows a specified amount of deviation from the plane
+// with normal pNorm which passes through the start point. planeTol is the allowed distance from the plane in mm, orientTol is the allowed
+// orientation deviation measured by norm of the R3AA orientation difference to the slerp path between start/goal orientations.
+func NewPlanarInterpolatingConstraint(from, to spatial.Pose, pNorm r3.Vector, planeTol, orientTol float64) (Constraint, Metric) {
+	orientConstraint, orientMetric := NewSlerpOrientationConstraint(from, to, orientTol)
+	pNorm = pNorm.Normalize()
+	pt := from.Point()
+	planeMetric := func(pose, _ spatial.Pose) float64 {
+		return math.Max(math.Abs(pose.Point().Sub(pt).Dot(pNorm))-planeTol, 0)
+	}
+	interpMetric := CombineMetrics(orientMetric, planeMetric)
+
+	f := func(cInput *ConstraintInput) (bool, float64) {
+		oValid, oDist := orientConstraint(cInput)
+		if err := resolveInputsToPositions(cInput); err != nil {
+			return false, 0
+		}
+		pDist := planeMetric(cInput.StartPos, cInput.EndPos)
+		return oValid && pDist == 0, oDist + pDist
+	}
+	return f, interpMetric
+}
+
 // NewProportionalLinearInterpolatingConstraint will provide the same metric and constraint as NewAbsoluteLinearInterpolatingConstraint,
 // except that allowable linear and orientation deviation is scaled based on the distance from start to goal.
 func NewProportionalLinearInterpolatingConstraint(from, to spatial.Pose, epsilon float64) (Constraint, Metric) {
@@ -210,3 +210,21 @@ func TestCollisionConstraint(t *testing.T) {
 		})
 	}
 }
+
+func TestPlanarInterpolatingConstraint(t *testing.T) {
+	from := spatial.NewPoseFromPoint(r3.Vector{X: 100, Y: 0, Z: 200})
+	to := spatial.NewPoseFromPoint(r3.Vector{X: 0, Y: 100, Z: 200})
+	constraint, metric := NewPlanarInterpolatingConstraint(from, to, r3.Vector{Z: 2}, 1, 0.01)
+
+	check := func(pose spatial.Pose) bool {
+		ok, _ := constraint(&ConstraintInput{StartPos: pose, EndPos: pose})
+		return ok
+	}
+	test.That(t, check(spatial.NewPoseFromPoint(r3.Vector{X: -500, Y: 300, Z: 200.5})), test.ShouldBeTrue)
+	test.That(t, check(spatial.NewPoseFromPoint(r3.Vector{X: 50, Y: 50, Z: 202})), test.ShouldBeFalse)
+	test.That(t, metric(spatial.NewPoseFromPoint(r3.Vector{X: 50, Y: 50, Z: 203}), nil), test.ShouldAlmostEqual, 2, 0.01)
+
+	// orientations must stay between the start and goal orientations
+	tilted := spatial.NewPoseFromOrientation(r3.Vector{X: 50, Y: 50, Z: 200}, &spatial.OrientationVectorDegrees{OX: 1, OZ: 1})
+	test.That(t, check(tilted), test.ShouldBeFalse)
+}
@@ -210,125 +210,62 @@ func (d *Dubins) AllPaths(start, end []float64, sorts bool) []DubinPathAttr {
 	return paths
 }
 
-func (d *Dubins) generatePointsStraight(start, end, path []float64) [][]float64 {
-	total := d.Radius*(math.Abs(path[1])+math.Abs(path[0])) + path[2]
-
-	center0 := d.findCenter(start, false) // "R"
-	center2 := d.findCenter(end, false)   // "R"
-
-	if path[0] > 0 {
-		center0 = d.findCenter(start, true) // "L"
-		center2 = d.findCenter(end, true)   // "L"
-	}
-
-	// start of straight
-	ini := start[:2]
-	if math.Abs(path[0]) > 0 {
-		angle := start[2]
-		if path[0] > 0 {
-			angle += (math.Abs(path[0]) - math.Pi/2)
-		} else if path[0] < 0 {
-			angle -= (math.Abs(path[0]) - math.Pi/2)
-		}
-		sides := []float64{math.Cos(angle), math.Sin(angle)}
-		ini = add(center0, mul(sides, d.Radius))
-	}
+// DubinsSegment is one of the parts of a Dubins path, which is a turn about a circle of the turning radius, or a straight line
+// when it does not turn.
+type DubinsSegment struct {
+	Length float64 // Distance along the segment
+	Turn   float64 // Change in heading along the segment, counterclockwise
+}
 
-	// end of straight
-	fin := end[:2]
-	if math.Abs(path[1]) > 0 {
-		angle := end[2] + (-math.Abs(path[1]) - math.Pi/2)
-		if path[1] > 0 {
-			angle += (-math.Abs(path[1]) - math.Pi/2)
-		} else if path[1] < 0 {
-			angle -= (-math.Abs(path[1]) - math.Pi/2)
+// Segments returns the three segments which make up the Dubins path, in the order they are driven, for a car of the given turning
+// radius. Paths which are not straight have a middle turn, in the opposite direction to the other two.
+func (attr DubinPathAttr) Segments(radius float64) []DubinsSegment {
+	first, last := attr.DubinsPath[0], attr.DubinsPath[1]
+	middle := DubinsSegment{Length: attr.DubinsPath[2]}
+	if !attr.Straight {
+		middle.Turn = attr.DubinsPath[2]
+		if first+last > 0 {
+			middle.Turn = -middle.Turn
 		}
-		sides := []float64{math.Cos(angle), math.Sin(angle)}
-		fin = add(center2, sides)
+		middle.Length = attr.DubinsPath[2] * radius
 	}
-
-	distStraight := dist(ini, fin)
-
-	// generate all points
-	points := make([][]float64, 0)
-	x := 0.0
-	for x < total {
-		switch {
-		case x < math.Abs(path[0])*d.Radius:
-			points = append(points, d.circleArc(start, path[0], center0, x))
-		case x > total-math.Abs(path[1])*d.Radius:
-			points = append(points, d.circleArc(end, path[1], center2, x-total))
-		default:
-			coeff := (x - math.Abs(path[0])*d.Radius) / distStraight
-			points = append(points, add(mul(fin, coeff), mul(ini, (1-coeff))))
-		}
-		x += d.PointSeparation
+	return []DubinsSegment{
+		{Length: math.Abs(first) * radius, Turn: first},
+		middle,
+		{Length: math.Abs(last) * radius, Turn: last},
 	}
-	points = append(points, end[:2])
-	return points
 }
 
-func (d *Dubins) generatePointsCurve(start, end, path []float64) [][]float64 {
-	total := d.Radius*(math.Abs(path[1])+math.Abs(path[0])) + path[2]
-
-	center0 := d.findCenter(start, false) // "R"
-	center2 := d.findCenter(end, false)   // "R"
-	if path[0] > 0 {
-		center0 = d.findCenter(start, true) // "L"
-		center2 = d.findCenter(end, true)   // "L"
-	}
-
-	intercenter := dist(center0, center2)
-	center1 := mul(add(center0, center2), 0.5)
-	if path[0] > 0 {
-		add(center1, mul(ortho(mul(sub(center2, center0), 1/intercenter)), math.Sqrt((4*math.Pow(d.Radius, 2)-math.Pow((intercenter/2), 2)))))
-	} else if path[0] < 0 {
-		sub(center1, mul(ortho(mul(sub(center2, center0), 1/intercenter)), math.Sqrt((4*math.Pow(d.Radius, 2)-math.Pow((intercenter/2), 2)))))
-	}
-	psi0 := math.Atan2(sub(center1, center0)[1], sub(center1, center0)[0]) - math.Pi
-
-	// generate all points
+// generatePoints returns points separated by PointSeparation along the Dubins path from start, followed by the end point.
+func (d *Dubins) generatePoints(start, end, dubinsPath []float64, straight bool) [][]float64 {
 	points := make([][]float64, 0)
-	x := 0.0
-	for x < total {
-		switch {
-		case x < math.Abs(path[0])*d.Radius:
-			points = append(points, d.circleArc(start, path[0], center0, x))
-		case x > total-math.Abs(path[1])*d.Radius:
-			points = append(points, d.circleArc(end, path[1], center2, x-total))
-		default:
-			angle := psi0
-			if path[0] > 0 {
-				angle += (x/d.Radius - math.Abs(path[0]))
-			} else if path[0] < 0 {
-				angle -= (x/d.Radius - math.Abs(path[0]))
-			}
-			sides := []float64{math.Cos(angle), math.Sin(angle)}
-			points = append(points, add(center1, mul(sides, d.Radius)))
+	current := []float64{start[0], start[1], start[2]}
+	traveled, next := 0., 0.
+	for _, segment := range (DubinPathAttr{DubinsPath: dubinsPath, Straight: straight}).Segments(d.Radius) {
+		for ; next < traveled+segment.Length; next += d.PointSeparation {
+			along := next - traveled
+			points = append(points, travel(current, along, segment.Turn*along/segment.Length)[:2])
 		}
-		x += d.PointSeparation
+		current = travel(current, segment.Length, segment.Turn)
+		traveled += segment.Length
 	}
 	points = append(points, end[:2])
 	return points
 }
 
-func (d *Dubins) circleArc(reference []float64, beta float64, center []float64, x float64) []float64 {
-	angle := reference[2]
-	if beta > 0 {
-		angle += ((x / d.Radius) - math.Pi/2)
-	} else if beta < 0 {
-		angle -= ((x / d.Radius) - math.Pi/2)
+// travel returns the point and heading reached by moving from the given point and heading along an arc of the given length
+// which turns through the given angle.
+func travel(from []float64, length, turn float64) []float64 {
+	x, y, heading := from[0], from[1], from[2]
+	if math.Abs(turn) < 1e-9 {
+		return []float64{x + length*math.Cos(heading), y + length*math.Sin(heading), heading}
 	}
-	sides := []float64{math.Cos(angle), math.Sin(angle)}
-	point := add(center, mul(sides, d.Radius))
-	return point
-}
-
-func (d *Dubins) generatePoints(start, end, dubinsPath []float64, straight bool) [][]float64 {
-	if straight {
-		return d.generatePointsStraight(start, end, dubinsPath)
+	radius := length / turn
+	return []float64{
+		x + radius*(math.Sin(heading+turn)-math.Sin(heading)),
+		y + radius*(math.Cos(heading)-math.Cos(heading+turn)),
+		heading + turn,
 	}
-	return d.generatePointsCurve(start, end, dubinsPath)
 }
 
 // DubinsPath returns a list of points along the shortest Dubins path from start to end.
@@ -356,11 +293,6 @@ func norm(p1 []float64) float64 {
 	return math.Sqrt(math.Pow(p1[0], 2) + math.Pow(p1[1], 2))
 }
 
-func ortho(vect []float64) []float64 {
-	orth := []float64{-vect[1], vect[0]}
-	return orth
-}
-
 // element wise subtraction.
 func sub(vect1, vect2 []float64) []float64 {
 	subv := make([]float64, len(vect1))
@@ -370,24 +302,6 @@ func sub(vect1, vect2 []float64) []float64 {
 	return subv
 }
 
-// element wise addition.
-func add(vect1, vect2 []float64) []float64 {
-	addv := make([]float64, len(vect1))
-	for i := range vect1 {
-		addv[i] = vect1[i] + vect2[i]
-	}
-	return addv
-}
-
-// element wise multiplication by a scalar.
-func mul(vect1 []float64, scalar float64) []float64 {
-	mulv := make([]float64, len(vect1))
-	for i, x := range vect1 {
-		mulv[i] = x * scalar
-	}
-	return mulv
-}
-
 // GetDubinTrajectoryFromPath takes a path of waypoints that can be followed using Dubins paths and returns
 // a list of DubinPathAttrs describing the Dubins paths to get between waypoints.
 func GetDubinTrajectoryFromPath(waypoints [][]referenceframe.Input, d Dubins) []DubinPathAttr {
@@ -148,41 +148,7 @@ func (mp *DubinsRRTMotionPlanner) planRunner(ctx context.Context,
 		}
 
 		if targetConnected && target != goalConfig {
-			// reroute near neighbors through new node if it shortens the path
-			neighbors := findNearNeighbors(target, seedMap, 10)
-			for _, n := range neighbors {
-				start := nodeToSlice(target)
-				end := nodeToSlice(n)
-
-				bestOption := dm.d.AllPaths(start, end, true)[0]
-				if bestOption.TotalLen < 0 {
-					continue
-				}
-
-				if pathLenMap[target]+bestOption.TotalLen < pathLenMap[n] {
-					// Remove n from it's parent's children
-					parentChildList := childMap[seedMap[n]]
-					for i, child := range parentChildList {
-						if child == n {
-							parentChildList[i] = parentChildList[len(parentChildList)-1]
-							parentChildList[len(parentChildList)-1] = nil
-							break
-						}
-					}
-					childMap[seedMap[n]] = parentChildList[:len(parentChildList)-1]
-
-					// Add n to target's children
-					childMap[target] = append(childMap[target], n)
-
-					// Set target as n's parent
-					seedMap[n] = target
-
-					// Update path lengths of n and its children
-					diff := pathLenMap[n] - (pathLenMap[target] + bestOption.TotalLen)
-
-					updateChildren(n, pathLenMap, childMap, diff)
-				}
-			}
+			mp.rewire(target, seedMap, childMap, pathLenMap, planOpts, dm)
 		}
 
 		if targetConnected && target == goalConfig {
@@ -211,6 +177,53 @@ func (mp *DubinsRRTMotionPlanner) planRunner(ctx context.Context,
 	solutionChan <- &planReturn{err: errors.New("could not solve path")}
 }
 
+// rewire reroutes the near neighbors of target through it wherever that shortens their path from the seed, so long as
+// the path from target to each of them is clear.
+func (mp *DubinsRRTMotionPlanner) rewire(
+	target node,
+	seedMap map[node]node,
+	childMap map[node][]node,
+	pathLenMap map[node]float64,
+	planOpts *PlannerOptions,
+	dm *dubinPathAttrManager,
+) {
+	neighbors := findNearNeighbors(target, seedMap, 10)
+	for _, n := range neighbors {
+		start := nodeToSlice(target)
+		end := nodeToSlice(n)
+
+		bestOption := dm.d.AllPaths(start, end, true)[0]
+		if bestOption.TotalLen < 0 {
+			continue
+		}
+
+		// rerouting must not take n through any obstacles on its way from target
+		if pathLenMap[target]+bestOption.TotalLen < pathLenMap[n] && mp.checkPath(target, n, planOpts, dm, bestOption) {
+			// Remove n from it's parent's children
+			parentChildList := childMap[seedMap[n]]
+			for i, child := range parentChildList {
+				if child == n {
+					parentChildList[i] = parentChildList[len(parentChildList)-1]
+					parentChildList[len(parentChildList)-1] = nil
+					break
+				}
+			}
+			childMap[seedMap[n]] = parentChildList[:len(parentChildList)-1]
+
+			// Add n to target's children
+			childMap[target] = append(childMap[target], n)
+
+			// Set target as n's parent
+			seedMap[n] = target
+
+			// Update path lengths of n and its children
+			diff := pathLenMap[n] - (pathLenMap[target] + bestOption.TotalLen)
+
+			updateChildren(n, pathLenMap, childMap, diff)
+		}
+	}
+}
+
 func updateChildren(
 	relinkedNode node,
 	pathLenMap map[node]float64,
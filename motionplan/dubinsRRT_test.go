@@ -61,3 +61,47 @@ func TestDubinsRRT(t *testing.T) {
 	obstacleGeometries["1"] = box
 	test.That(t, testDubin(obstacleGeometries), test.ShouldBeFalse)
 }
+
+func TestDubinsRRTRewire(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	robotGeometry, err := spatial.NewBoxCreator(r3.Vector{X: 1, Y: 1, Z: 1}, spatial.NewZeroPose())
+	test.That(t, err, test.ShouldBeNil)
+	limits := []frame.Limit{{Min: -10, Max: 10}, {Min: -10, Max: 10}}
+	model, err := frame.NewMobile2DFrame("name", limits, robotGeometry)
+	test.That(t, err, test.ShouldBeNil)
+	d := Dubins{Radius: 0.6, PointSeparation: 0.1}
+	mp, err := NewDubinsRRTMotionPlanner(model, 1, logger, d)
+	test.That(t, err, test.ShouldBeNil)
+	dubins, ok := mp.(*DubinsRRTMotionPlanner)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	// n hangs off the seed by a long path, and rerouting it through target, just ahead of the seed, is far shorter
+	rewire := func(obstacleGeometries map[string]spatial.Geometry) (node, node) {
+		seed := &basicNode{q: frame.FloatsToInputs([]float64{0, 0, 0})}
+		target := &basicNode{q: frame.FloatsToInputs([]float64{2, 0, 0})}
+		n := &basicNode{q: frame.FloatsToInputs([]float64{8, 0, 0})}
+		seedMap := map[node]node{seed: nil, target: seed, n: seed}
+		childMap := map[node][]node{seed: {target, n}, target: {}, n: {}}
+		pathLenMap := map[node]float64{seed: 0, target: 2, n: 100}
+
+		opt := NewBasicPlannerOptions()
+		opt.AddConstraint("collision", NewCollisionConstraint(
+			dubins.Frame(),
+			frame.FloatsToInputs([]float64{0, 0}),
+			obstacleGeometries,
+			map[string]spatial.Geometry{},
+		))
+		dubins.rewire(target, seedMap, childMap, pathLenMap, opt, &dubinPathAttrManager{nCPU: 1, d: d})
+		return seedMap[n], target
+	}
+
+	// with nothing in the way n is rerouted through target
+	parent, target := rewire(map[string]spatial.Geometry{})
+	test.That(t, parent, test.ShouldEqual, target)
+
+	// a wall between target and n keeps n where it was
+	box, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{X: 5, Y: 0, Z: 0}), r3.Vector{X: 1, Y: 20, Z: 1})
+	test.That(t, err, test.ShouldBeNil)
+	parent, target = rewire(map[string]spatial.Geometry{"1": box})
+	test.That(t, parent, test.ShouldNotEqual, target)
+}
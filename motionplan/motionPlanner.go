@@ -28,7 +28,43 @@ type MotionPlanner interface {
 	Frame() frame.Frame // Frame will return the frame used for planning
 }
 
-type plannerConstructor func(frame.Frame, int, golog.Logger) (MotionPlanner, error)
+type (
+	plannerConstructor       func(frame.Frame, int, golog.Logger) (MotionPlanner, error)
+	seededPlannerConstructor func(frame frame.Frame, nCPU int, seed *rand.Rand, logger golog.Logger) (MotionPlanner, error)
+)
+
+// the set of planners which can be chosen with the "planner" field of a motion config.
+const (
+	CBiRRTPlanner         = "cbirrt"
+	RRTConnectPlanner     = "rrtconnect"
+	RRTStarConnectPlanner = "rrtstarconnect"
+	PRMPlanner            = "prm"
+)
+
+// seededPlannerConstructors are the constructors of each of the planners which can be chosen by name.
+var seededPlannerConstructors = map[string]seededPlannerConstructor{
+	CBiRRTPlanner:         NewCBiRRTMotionPlannerWithSeed,
+	RRTConnectPlanner:     NewRRTConnectMotionPlannerWithSeed,
+	RRTStarConnectPlanner: NewRRTStarConnectMotionPlannerWithSeed,
+	PRMPlanner:            NewPRMMotionPlannerWithSeed,
+}
+
+// plannerNameFromMotionConfig returns the name of the planner chosen by the "planner" field of the motion config,
+// defaulting to cBiRRT.
+func plannerNameFromMotionConfig(motionConfig map[string]interface{}) (string, error) {
+	name, ok := motionConfig["planner"]
+	if !ok {
+		return CBiRRTPlanner, nil
+	}
+	plannerName, ok := name.(string)
+	if !ok {
+		return "", errors.New("could not interpret planner field as string")
+	}
+	if _, ok := seededPlannerConstructors[plannerName]; !ok {
+		return "", errors.Errorf("unknown planner %q", plannerName)
+	}
+	return plannerName, nil
+}
 
 type planner struct {
 	solver InverseKinematics
@@ -40,10 +76,14 @@ type planner struct {
 }
 
 func newPlanner(frame frame.Frame, nCPU int, seed *rand.Rand, logger golog.Logger) (*planner, error) {
-	ik, err := CreateCombinedIKSolver(frame, logger, nCPU)
+	combined, err := CreateCombinedIKSolver(frame, logger, nCPU)
 	if err != nil {
 		return nil, err
 	}
+	var ik InverseKinematics = combined
+	if analytic, ok := newAnalyticIKSolver(frame); ok {
+		ik = &fallbackIK{analytic: analytic, numeric: combined}
+	}
 	mp := &planner{
 		solver:   ik,
 		frame:    frame,
@@ -6,7 +6,6 @@ import (
 	"strconv"
 	"testing"
 
-	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
 	"go.uber.org/zap"
 	commonpb "go.viam.com/api/common/v1"
@@ -35,10 +34,7 @@ type planConfig struct {
 	Options    *PlannerOptions
 }
 
-type (
-	seededPlannerConstructor func(frame frame.Frame, nCPU int, seed *rand.Rand, logger golog.Logger) (MotionPlanner, error)
-	planConfigConstructor    func() (*planConfig, error)
-)
+type planConfigConstructor func() (*planConfig, error)
 
 func BenchmarkUnconstrainedMotion(b *testing.B) {
 	config, err := simpleUR5eMotion()
@@ -0,0 +1,377 @@
+package motionplan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/edaniels/golog"
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	frame "go.viam.com/rdk/referenceframe"
+)
+
+// PlanRecordVersion is the version of the serialization format written for PlanRecords. Records written with a newer
+// version than this cannot be read.
+const PlanRecordVersion = 2
+
+// customPlanner is the name recorded for planners set with SetPlannerGen, which cannot be replayed.
+const customPlanner = "custom"
+
+// PlanRequest contains everything needed to reproduce a call to the motion planner.
+type PlanRequest struct {
+	FrameSystem   frame.FrameSystem
+	SolveFrame    string
+	Seed          map[string][]frame.Input
+	Goals         []*frame.PoseInFrame
+	WorldState    *commonpb.WorldState
+	MotionConfigs []map[string]interface{}
+	RandomSeed    int64
+
+	// NCPU is the number of threads planned with; zero means one
+	NCPU int
+
+	// PlannerSettings are the planner and settings used for each goal, if known. They are applied over the motion
+	// configs, so that the request is planned for in the same way even if the defaults have since changed.
+	PlannerSettings []PlannerSettings
+}
+
+// PlannerSettings are the planner, and the settings of its PlannerOptions, with which one goal of a PlanRequest was planned
+// for. Constraints are recorded by name for inspection, as they are created from the motion config.
+type PlannerSettings struct {
+	Planner      string   `json:"planner"`
+	MaxSolutions int      `json:"max_ik_solutions"`
+	MinScore     float64  `json:"min_ik_score"`
+	Resolution   float64  `json:"resolution"`
+	Constraints  []string `json:"constraints"`
+}
+
+func newPlannerSettings(planner string, opt *PlannerOptions) *PlannerSettings {
+	constraints := opt.Constraints()
+	sort.Strings(constraints)
+	return &PlannerSettings{
+		Planner:      planner,
+		MaxSolutions: opt.MaxSolutions,
+		MinScore:     opt.MinScore,
+		Resolution:   opt.Resolution,
+		Constraints:  constraints,
+	}
+}
+
+// Plan solves the request with the planner and settings it records, seeded with the request's RandomSeed. When planning
+// with a single thread, the same request will always produce the same plan.
+func (req *PlanRequest) Plan(ctx context.Context, logger golog.Logger) ([]map[string][]frame.Input, error) {
+	steps, _, err := req.plan(ctx, logger)
+	return steps, err
+}
+
+func (req *PlanRequest) plan(ctx context.Context, logger golog.Logger) ([]map[string][]frame.Input, []PlannerSettings, error) {
+	fss := NewSolvableFrameSystem(req.FrameSystem, logger)
+	fss.nCPU = req.NCPU
+	if fss.nCPU < 1 {
+		fss.nCPU = 1
+	}
+	fss.randomSeed = req.RandomSeed
+	seed := make(map[string][]frame.Input, len(req.Seed))
+	for name, inputs := range req.Seed {
+		seed[name] = inputs
+	}
+
+	motionConfigs := req.MotionConfigs
+	if len(req.PlannerSettings) > 0 {
+		if len(req.PlannerSettings) > len(req.Goals) {
+			return nil, nil, errors.New("plan request has more planner settings than goals")
+		}
+		motionConfigs = make([]map[string]interface{}, 0, len(req.Goals))
+		for i := range req.Goals {
+			motionConfig := map[string]interface{}{}
+			switch len(req.MotionConfigs) {
+			case 0:
+			case 1:
+				motionConfig = req.MotionConfigs[0]
+			default:
+				if i < len(req.MotionConfigs) {
+					motionConfig = req.MotionConfigs[i]
+				}
+			}
+			if i < len(req.PlannerSettings) {
+				if motionConfig, _ = withPlannerSettings(motionConfig, req.PlannerSettings[i]); motionConfig == nil {
+					return nil, nil, errors.New("cannot replay a plan made with a custom planner")
+				}
+			}
+			motionConfigs = append(motionConfigs, motionConfig)
+		}
+	}
+	return fss.solveWaypoints(ctx, seed, req.Goals, req.SolveFrame, req.WorldState, motionConfigs)
+}
+
+// withPlannerSettings returns a copy of the motion config which chooses the planner and settings given, or nil if the
+// planner was a custom one which cannot be chosen by a motion config.
+func withPlannerSettings(motionConfig map[string]interface{}, settings PlannerSettings) (map[string]interface{}, error) {
+	if settings.Planner == customPlanner {
+		return nil, nil
+	}
+	withSettings := make(map[string]interface{}, len(motionConfig)+4)
+	for k, v := range motionConfig {
+		withSettings[k] = v
+	}
+	withSettings["planner"] = settings.Planner
+	withSettings["max_ik_solutions"] = settings.MaxSolutions
+	withSettings["min_ik_score"] = settings.MinScore
+	withSettings["resolution"] = settings.Resolution
+	return withSettings, nil
+}
+
+// PlanRecord is a PlanRequest along with the plan that was produced for it, or the error that occurred while planning.
+// Records can be written to disk and replayed later, such as to reproduce a planning failure or as a regression test.
+type PlanRecord struct {
+	Request *PlanRequest
+	Plan    []map[string][]frame.Input
+	Error   string
+}
+
+// RecordPlan solves the given request and returns a record of it, including the planner settings used for each goal.
+// An error from the planner is stored in the record rather than returned so that failed plans can be saved as well.
+func RecordPlan(ctx context.Context, logger golog.Logger, req *PlanRequest) *PlanRecord {
+	plan, settings, err := req.plan(ctx, logger)
+	recorded := *req
+	recorded.PlannerSettings = settings
+	record := &PlanRecord{Request: &recorded, Plan: plan}
+	if err != nil {
+		record.Error = err.Error()
+	}
+	return record
+}
+
+// Replay solves the recorded request again, and returns a new record of the result.
+func (r *PlanRecord) Replay(ctx context.Context, logger golog.Logger) *PlanRecord {
+	return RecordPlan(ctx, logger, r.Request)
+}
+
+// planRecordJSON is the on-disk format of a PlanRecord. Protobuf messages are stored in their canonical JSON encoding,
+// and inputs as lists of numbers.
+type planRecordJSON struct {
+	Version         int                      `json:"version"`
+	FrameSystem     json.RawMessage          `json:"frame_system"`
+	SolveFrame      string                   `json:"solve_frame"`
+	Seed            map[string][]float64     `json:"seed"`
+	Goals           []json.RawMessage        `json:"goals"`
+	WorldState      json.RawMessage          `json:"world_state,omitempty"`
+	MotionConfigs   []map[string]interface{} `json:"motion_configs,omitempty"`
+	RandomSeed      int64                    `json:"random_seed"`
+	NCPU            int                      `json:"ncpu,omitempty"`
+	PlannerSettings []PlannerSettings        `json:"planner_settings,omitempty"`
+	Plan            []map[string][]float64   `json:"plan,omitempty"`
+	Error           string                   `json:"error,omitempty"`
+}
+
+// MarshalJSON serializes a PlanRecord.
+func (r *PlanRecord) MarshalJSON() ([]byte, error) {
+	if r.Request == nil {
+		return nil, errors.New("cannot serialize a PlanRecord without a request")
+	}
+	fsData, err := frame.MarshalFrameSystemJSON(r.Request.FrameSystem)
+	if err != nil {
+		return nil, err
+	}
+	recordJSON := planRecordJSON{
+		Version:         PlanRecordVersion,
+		FrameSystem:     fsData,
+		SolveFrame:      r.Request.SolveFrame,
+		Seed:            inputMapToFloats(r.Request.Seed),
+		MotionConfigs:   r.Request.MotionConfigs,
+		RandomSeed:      r.Request.RandomSeed,
+		NCPU:            r.Request.NCPU,
+		PlannerSettings: r.Request.PlannerSettings,
+		Error:           r.Error,
+	}
+	for _, goal := range r.Request.Goals {
+		data, err := protojson.Marshal(frame.PoseInFrameToProtobuf(goal))
+		if err != nil {
+			return nil, err
+		}
+		recordJSON.Goals = append(recordJSON.Goals, data)
+	}
+	if r.Request.WorldState != nil {
+		if recordJSON.WorldState, err = protojson.Marshal(r.Request.WorldState); err != nil {
+			return nil, err
+		}
+	}
+	for _, step := range r.Plan {
+		recordJSON.Plan = append(recordJSON.Plan, inputMapToFloats(step))
+	}
+	return json.Marshal(recordJSON)
+}
+
+// UnmarshalJSON deserializes a PlanRecord, returning an error if it was written with an unsupported version.
+func (r *PlanRecord) UnmarshalJSON(data []byte) error {
+	var recordJSON planRecordJSON
+	if err := json.Unmarshal(data, &recordJSON); err != nil {
+		return err
+	}
+	if recordJSON.Version < 1 || recordJSON.Version > PlanRecordVersion {
+		return errors.Errorf("unsupported plan record version %d, latest supported version is %d", recordJSON.Version, PlanRecordVersion)
+	}
+	fs, err := frame.UnmarshalFrameSystemJSON(recordJSON.FrameSystem)
+	if err != nil {
+		return err
+	}
+	req := &PlanRequest{
+		FrameSystem:     fs,
+		SolveFrame:      recordJSON.SolveFrame,
+		Seed:            floatsToInputMap(recordJSON.Seed),
+		MotionConfigs:   recordJSON.MotionConfigs,
+		RandomSeed:      recordJSON.RandomSeed,
+		NCPU:            recordJSON.NCPU,
+		PlannerSettings: recordJSON.PlannerSettings,
+	}
+	for _, goalData := range recordJSON.Goals {
+		goal := &commonpb.PoseInFrame{}
+		if err := protojson.Unmarshal(goalData, goal); err != nil {
+			return err
+		}
+		req.Goals = append(req.Goals, frame.ProtobufToPoseInFrame(goal))
+	}
+	if len(recordJSON.WorldState) > 0 {
+		req.WorldState = &commonpb.WorldState{}
+		if err := protojson.Unmarshal(recordJSON.WorldState, req.WorldState); err != nil {
+			return err
+		}
+	}
+	plan := make([]map[string][]frame.Input, 0, len(recordJSON.Plan))
+	for _, step := range recordJSON.Plan {
+		plan = append(plan, floatsToInputMap(step))
+	}
+	*r = PlanRecord{Request: req, Plan: plan, Error: recordJSON.Error}
+	return nil
+}
+
+// WritePlanRecordFile writes a PlanRecord to the given file.
+func WritePlanRecordFile(r *PlanRecord, filename string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o600)
+}
+
+// ReadPlanRecordFile reads a PlanRecord from the given file.
+func ReadPlanRecordFile(filename string) (*PlanRecord, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read plan record")
+	}
+	r := &PlanRecord{}
+	if err := json.Unmarshal(data, r); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal plan record")
+	}
+	return r, nil
+}
+
+// PlanDiff describes the differences between the results of two PlanRecords.
+type PlanDiff struct {
+	// StepsA and StepsB are the number of steps in each plan
+	StepsA, StepsB int
+
+	// ErrorA and ErrorB are the errors, if any, encountered while producing each plan
+	ErrorA, ErrorB string
+
+	// FirstDivergence is the index of the first step at which the plans differ by more than the tolerance,
+	// or -1 if the plans do not differ
+	FirstDivergence int
+
+	// MaxDeviation is the largest difference between any input of two steps at the same index in both plans
+	MaxDeviation float64
+}
+
+// DiffPlanRecords compares the results of two PlanRecords step by step. Inputs which differ by no more than the
+// given tolerance are considered equal.
+func DiffPlanRecords(a, b *PlanRecord, tolerance float64) *PlanDiff {
+	diff := &PlanDiff{
+		StepsA:          len(a.Plan),
+		StepsB:          len(b.Plan),
+		ErrorA:          a.Error,
+		ErrorB:          b.Error,
+		FirstDivergence: -1,
+	}
+	steps := len(a.Plan)
+	if len(b.Plan) < steps {
+		steps = len(b.Plan)
+	}
+	for i := 0; i < steps; i++ {
+		deviation := inputMapDeviation(a.Plan[i], b.Plan[i])
+		diff.MaxDeviation = math.Max(diff.MaxDeviation, deviation)
+		if deviation > tolerance && diff.FirstDivergence < 0 {
+			diff.FirstDivergence = i
+		}
+	}
+	if len(a.Plan) != len(b.Plan) && diff.FirstDivergence < 0 {
+		diff.FirstDivergence = steps
+	}
+	return diff
+}
+
+// Equal returns true if neither plan differed from the other.
+func (d *PlanDiff) Equal() bool {
+	return d.FirstDivergence < 0 && d.ErrorA == d.ErrorB
+}
+
+// String returns a human readable summary of the PlanDiff.
+func (d *PlanDiff) String() string {
+	if d.Equal() {
+		return fmt.Sprintf("plans are equal (%d steps)", d.StepsA)
+	}
+	var lines []string
+	if d.ErrorA != d.ErrorB {
+		lines = append(lines, fmt.Sprintf("errors differ: %q vs %q", d.ErrorA, d.ErrorB))
+	}
+	if d.StepsA != d.StepsB {
+		lines = append(lines, fmt.Sprintf("step counts differ: %d vs %d", d.StepsA, d.StepsB))
+	}
+	if d.FirstDivergence >= 0 {
+		lines = append(lines, fmt.Sprintf("plans diverge at step %d, max deviation %f", d.FirstDivergence, d.MaxDeviation))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// inputMapDeviation returns the largest difference between inputs of the same frame in two input maps. Frames which are
+// in only one of the maps, or which have a different number of inputs, are infinitely different.
+func inputMapDeviation(a, b map[string][]frame.Input) float64 {
+	if len(a) != len(b) {
+		return math.Inf(1)
+	}
+	deviation := 0.
+	for name, inputsA := range a {
+		inputsB, ok := b[name]
+		if !ok || len(inputsA) != len(inputsB) {
+			return math.Inf(1)
+		}
+		for i := range inputsA {
+			deviation = math.Max(deviation, math.Abs(inputsA[i].Value-inputsB[i].Value))
+		}
+	}
+	return deviation
+}
+
+func inputMapToFloats(inputMap map[string][]frame.Input) map[string][]float64 {
+	floats := make(map[string][]float64, len(inputMap))
+	for name, inputs := range inputMap {
+		floats[name] = frame.InputsToFloats(inputs)
+	}
+	return floats
+}
+
+func floatsToInputMap(floatMap map[string][]float64) map[string][]frame.Input {
+	inputs := make(map[string][]frame.Input, len(floatMap))
+	for name, floats := range floatMap {
+		inputs[name] = frame.FloatsToInputs(floats)
+	}
+	return inputs
+}
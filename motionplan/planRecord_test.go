@@ -0,0 +1,179 @@
+package motionplan
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/test"
+	"google.golang.org/protobuf/proto"
+
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+func makeTestPlanRequest(t *testing.T) *PlanRequest {
+	t.Helper()
+	fs := frame.NewEmptySimpleFrameSystem("test")
+	arm, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(arm, fs.World()), test.ShouldBeNil)
+
+	obstacle, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{X: 500, Z: 200}), r3.Vector{X: 50, Y: 50, Z: 50})
+	test.That(t, err, test.ShouldBeNil)
+	worldState := &commonpb.WorldState{
+		Obstacles: []*commonpb.GeometriesInFrame{
+			frame.GeometriesInFrameToProtobuf(frame.NewGeometriesInFrame(frame.World, map[string]spatial.Geometry{"box": obstacle})),
+		},
+	}
+	goal := frame.NewPoseInFrame(frame.World, spatial.NewPoseFromOrientation(
+		r3.Vector{X: 300, Y: 200, Z: 300},
+		&spatial.OrientationVectorDegrees{OZ: -1},
+	))
+	return &PlanRequest{
+		FrameSystem:   fs,
+		SolveFrame:    "arm",
+		Seed:          map[string][]frame.Input{"arm": frame.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0})},
+		Goals:         []*frame.PoseInFrame{goal},
+		WorldState:    worldState,
+		MotionConfigs: []map[string]interface{}{{"motion_profile": FreeMotionProfile}},
+		RandomSeed:    42,
+		NCPU:          1,
+	}
+}
+
+func TestPlanRecordSerialization(t *testing.T) {
+	req := makeTestPlanRequest(t)
+	req.PlannerSettings = []PlannerSettings{{Planner: RRTConnectPlanner, MaxSolutions: 10, Resolution: 1, Constraints: []string{}}}
+	record := &PlanRecord{
+		Request: req,
+		Plan: []map[string][]frame.Input{
+			{"arm": frame.FloatsToInputs([]float64{0, 0, 0, 0, 0, 0})},
+			{"arm": frame.FloatsToInputs([]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6})},
+		},
+	}
+
+	filename := filepath.Join(t.TempDir(), "plan.json")
+	test.That(t, WritePlanRecordFile(record, filename), test.ShouldBeNil)
+	record2, err := ReadPlanRecordFile(filename)
+	test.That(t, err, test.ShouldBeNil)
+
+	test.That(t, DiffPlanRecords(record, record2, 0).Equal(), test.ShouldBeTrue)
+	req2 := record2.Request
+	test.That(t, req2.SolveFrame, test.ShouldEqual, req.SolveFrame)
+	test.That(t, req2.Seed, test.ShouldResemble, req.Seed)
+	test.That(t, req2.RandomSeed, test.ShouldEqual, req.RandomSeed)
+	test.That(t, req2.NCPU, test.ShouldEqual, req.NCPU)
+	test.That(t, req2.PlannerSettings, test.ShouldResemble, req.PlannerSettings)
+	test.That(t, req2.MotionConfigs, test.ShouldResemble, req.MotionConfigs)
+	test.That(t, proto.Equal(req2.WorldState, req.WorldState), test.ShouldBeTrue)
+	test.That(t, req2.Goals, test.ShouldHaveLength, 1)
+	test.That(t, req2.Goals[0].FrameName(), test.ShouldEqual, frame.World)
+	test.That(t, spatial.PoseAlmostEqual(req2.Goals[0].Pose(), req.Goals[0].Pose()), test.ShouldBeTrue)
+	test.That(t, req2.FrameSystem.Frame("arm").AlmostEquals(req.FrameSystem.Frame("arm")), test.ShouldBeTrue)
+
+	// records from future versions of the format cannot be read
+	test.That(t, os.WriteFile(filename, []byte(`{"version": 3}`), 0o600), test.ShouldBeNil)
+	_, err = ReadPlanRecordFile(filename)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "unsupported plan record version 3")
+}
+
+func TestDiffPlanRecords(t *testing.T) {
+	step := func(values ...float64) map[string][]frame.Input {
+		return map[string][]frame.Input{"arm": frame.FloatsToInputs(values)}
+	}
+	a := &PlanRecord{Plan: []map[string][]frame.Input{step(0, 0), step(1, 1), step(2, 2)}}
+
+	diff := DiffPlanRecords(a, &PlanRecord{Plan: []map[string][]frame.Input{step(0, 0), step(1, 1.001), step(2, 2)}}, 0.01)
+	test.That(t, diff.Equal(), test.ShouldBeTrue)
+	test.That(t, diff.MaxDeviation, test.ShouldAlmostEqual, 0.001)
+
+	diff = DiffPlanRecords(a, &PlanRecord{Plan: []map[string][]frame.Input{step(0, 0), step(1, 1.5), step(2, 2)}}, 0.01)
+	test.That(t, diff.Equal(), test.ShouldBeFalse)
+	test.That(t, diff.FirstDivergence, test.ShouldEqual, 1)
+	test.That(t, diff.MaxDeviation, test.ShouldAlmostEqual, 0.5)
+
+	diff = DiffPlanRecords(a, &PlanRecord{Plan: []map[string][]frame.Input{step(0, 0), step(1, 1)}}, 0.01)
+	test.That(t, diff.Equal(), test.ShouldBeFalse)
+	test.That(t, diff.FirstDivergence, test.ShouldEqual, 2)
+	test.That(t, diff.String(), test.ShouldContainSubstring, "step counts differ: 3 vs 2")
+
+	diff = DiffPlanRecords(a, &PlanRecord{Plan: []map[string][]frame.Input{step(0, 0), {"other": nil}, step(2, 2)}}, 0.01)
+	test.That(t, diff.FirstDivergence, test.ShouldEqual, 1)
+	test.That(t, math.IsInf(diff.MaxDeviation, 1), test.ShouldBeTrue)
+
+	diff = DiffPlanRecords(&PlanRecord{Error: "failed"}, &PlanRecord{}, 0.01)
+	test.That(t, diff.Equal(), test.ShouldBeFalse)
+	test.That(t, diff.String(), test.ShouldContainSubstring, "errors differ")
+}
+
+func TestPlanRecordReplay(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	record := RecordPlan(context.Background(), logger, makeTestPlanRequest(t))
+
+	data, err := record.MarshalJSON()
+	test.That(t, err, test.ShouldBeNil)
+	loaded := &PlanRecord{}
+	test.That(t, loaded.UnmarshalJSON(data), test.ShouldBeNil)
+
+	// replaying a loaded record must reproduce the original result exactly
+	replayed := loaded.Replay(context.Background(), logger)
+	diff := DiffPlanRecords(record, replayed, 1e-6)
+	test.That(t, diff.Equal(), test.ShouldBeTrue)
+	test.That(t, replayed.Request.PlannerSettings, test.ShouldResemble, record.Request.PlannerSettings)
+}
+
+func TestPlanRecordSettings(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	req := makeTestPlanRequest(t)
+	req.MotionConfigs = []map[string]interface{}{{"planner": RRTConnectPlanner, "max_ik_solutions": 7}}
+	record := RecordPlan(context.Background(), logger, req)
+	test.That(t, record.Request.PlannerSettings, test.ShouldHaveLength, 1)
+	settings := record.Request.PlannerSettings[0]
+	test.That(t, settings.Planner, test.ShouldEqual, RRTConnectPlanner)
+	test.That(t, settings.MaxSolutions, test.ShouldEqual, 7)
+	test.That(t, req.PlannerSettings, test.ShouldBeNil)
+
+	// recorded settings are used over those of the motion config
+	motionConfig, err := withPlannerSettings(map[string]interface{}{"planner": CBiRRTPlanner, "other": 1}, settings)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, motionConfig["planner"], test.ShouldEqual, RRTConnectPlanner)
+	test.That(t, motionConfig["max_ik_solutions"], test.ShouldEqual, 7)
+	test.That(t, motionConfig["other"], test.ShouldEqual, 1)
+
+	req.MotionConfigs = []map[string]interface{}{{"planner": "unknown"}}
+	record = RecordPlan(context.Background(), logger, req)
+	test.That(t, record.Error, test.ShouldContainSubstring, "unknown")
+
+	req.MotionConfigs = nil
+	req.PlannerSettings = []PlannerSettings{{Planner: customPlanner}}
+	_, err = req.Plan(context.Background(), logger)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "custom planner")
+}
+
+func TestSetPlanRecorder(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	req := makeTestPlanRequest(t)
+	fss := NewSolvableFrameSystem(req.FrameSystem, logger)
+	var records []*PlanRecord
+	fss.SetPlanRecorder(func(record *PlanRecord) {
+		records = append(records, record)
+	})
+	plan, err := fss.SolveWaypointsWithOptions(context.Background(), req.Seed, req.Goals, req.SolveFrame, req.WorldState, req.MotionConfigs)
+	test.That(t, records, test.ShouldHaveLength, 1)
+	test.That(t, records[0].Plan, test.ShouldResemble, plan)
+	if err != nil {
+		test.That(t, records[0].Error, test.ShouldEqual, err.Error())
+	}
+	test.That(t, records[0].Request.SolveFrame, test.ShouldEqual, req.SolveFrame)
+	test.That(t, records[0].Request.PlannerSettings, test.ShouldHaveLength, 1)
+	test.That(t, records[0].Request.PlannerSettings[0].Planner, test.ShouldEqual, CBiRRTPlanner)
+}
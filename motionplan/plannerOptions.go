@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math"
 
+	"github.com/golang/geo/r3"
 	commonpb "go.viam.com/api/common/v1"
 
 	frame "go.viam.com/rdk/referenceframe"
@@ -39,6 +40,7 @@ const (
 	defaultLinearConstraintName       = "defaultLinearConstraint"
 	defaultPseudolinearConstraintName = "defaultPseudolinearConstraint"
 	defaultOrientationConstraintName  = "defaultOrientationConstraint"
+	defaultPlaneConstraintName        = "defaultPlaneConstraint"
 	defaultCollisionConstraintName    = "defaultCollisionConstraint"
 	defaultJointConstraint            = "defaultJointSwingConstraint"
 
@@ -52,6 +54,7 @@ const (
 	LinearMotionProfile       = "linear"
 	PseudolinearMotionProfile = "pseudolinear"
 	OrientationMotionProfile  = "orientation"
+	PlaneMotionProfile        = "plane"
 )
 
 // defaultDistanceFunc returns the square of the two-norm between the StartInput and EndInput vectors in the given ConstraintInput.
@@ -124,6 +127,39 @@ func plannerSetupFromMoveRequest(
 		constraint, pathDist := NewSlerpOrientationConstraint(from, to, tolerance)
 		opt.AddConstraint(defaultOrientationConstraintName, constraint)
 		opt.pathDist = pathDist
+	case PlaneMotionProfile:
+		var normal struct {
+			X float64 `json:"x"`
+			Y float64 `json:"y"`
+			Z float64 `json:"z"`
+		}
+		jsonString, err := json.Marshal(planningOpts["plane_normal"])
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(jsonString, &normal); err != nil {
+			return nil, errors.New("could not interpret plane_normal field as a vector")
+		}
+		pNorm := r3.Vector{X: normal.X, Y: normal.Y, Z: normal.Z}
+		if pNorm.Norm() < defaultEpsilon {
+			return nil, errors.New("plane motion profile requires a nonzero plane_normal")
+		}
+		planeTol, ok := planningOpts["plane_tolerance"].(float64)
+		if !ok {
+			// Default
+			planeTol = defaultLinearDeviation
+		}
+		orientTol, ok := planningOpts["orient_tolerance"].(float64)
+		if !ok {
+			// Default
+			orientTol = defaultOrientationDeviation
+		}
+		if math.Abs(to.Point().Sub(from.Point()).Dot(pNorm.Normalize())) > planeTol {
+			return nil, errors.New("goal is not within plane_tolerance of the plane through the start position")
+		}
+		constraint, pathDist := NewPlanarInterpolatingConstraint(from, to, pNorm, planeTol, orientTol)
+		opt.AddConstraint(defaultPlaneConstraintName, constraint)
+		opt.pathDist = pathDist
 	case FreeMotionProfile:
 		// No restrictions on motion
 	default:
@@ -0,0 +1,135 @@
+package motionplan
+
+import (
+	"context"
+	"encoding/json"
+	"math/rand"
+
+	"github.com/edaniels/golog"
+	commonpb "go.viam.com/api/common/v1"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+const (
+	// Number of configurations to sample when building a roadmap.
+	defaultRoadmapNodes = 500
+
+	// Number of nearest neighbors to which each configuration in a roadmap is connected.
+	defaultRoadmapNeighbors = 10
+)
+
+type prmOptions struct {
+	// Number of configurations to sample when building a roadmap.
+	RoadmapNodes int `json:"roadmap_nodes"`
+
+	// Number of nearest neighbors to which each configuration in a roadmap is connected.
+	RoadmapNeighbors int `json:"roadmap_neighbors"`
+}
+
+// newPRMOptions creates a struct controlling the building and querying of a roadmap. All values are pre-set to reasonable
+// defaults, but can be tweaked if needed.
+func newPRMOptions(planOpts *PlannerOptions) (*prmOptions, error) {
+	algOpts := &prmOptions{
+		RoadmapNodes:     defaultRoadmapNodes,
+		RoadmapNeighbors: defaultRoadmapNeighbors,
+	}
+	// convert map to json
+	jsonString, err := json.Marshal(planOpts.extra)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(jsonString, algOpts)
+	if err != nil {
+		return nil, err
+	}
+	return algOpts, nil
+}
+
+// prmMotionPlanner is a multi-query Probabilistic Roadmap planner, Kavraki et al 1996
+// https://ieeexplore.ieee.org/document/508439
+// Its roadmap is built once, on the first call to Plan unless one is provided, and reused for all subsequent plans. Roadmap
+// nodes and edges are checked lazily, Bohlin and Kavraki 2000, so that a roadmap can be reused after obstacles change.
+type prmMotionPlanner struct {
+	*planner
+	roadmap *Roadmap
+}
+
+// NewPRMMotionPlanner creates a prmMotionPlanner object.
+func NewPRMMotionPlanner(frame referenceframe.Frame, nCPU int, logger golog.Logger) (MotionPlanner, error) {
+	//nolint:gosec
+	return NewPRMMotionPlannerWithSeed(frame, nCPU, rand.New(rand.NewSource(1)), logger)
+}
+
+// NewPRMMotionPlannerWithSeed creates a prmMotionPlanner object with a user specified random seed.
+func NewPRMMotionPlannerWithSeed(frame referenceframe.Frame, nCPU int, seed *rand.Rand, logger golog.Logger) (MotionPlanner, error) {
+	return NewPRMMotionPlannerWithRoadmap(frame, nCPU, nil, seed, logger)
+}
+
+// NewPRMMotionPlannerWithRoadmap creates a prmMotionPlanner object which plans using an existing roadmap, such as one read
+// from a RoadmapCache. If the roadmap is nil, one will be built on the first call to Plan.
+func NewPRMMotionPlannerWithRoadmap(
+	frame referenceframe.Frame,
+	nCPU int,
+	roadmap *Roadmap,
+	seed *rand.Rand,
+	logger golog.Logger,
+) (MotionPlanner, error) {
+	if roadmap != nil {
+		if err := roadmap.compatible(frame); err != nil {
+			return nil, err
+		}
+	}
+	planner, err := newPlanner(frame, nCPU, seed, logger)
+	if err != nil {
+		return nil, err
+	}
+	return &prmMotionPlanner{planner: planner, roadmap: roadmap}, nil
+}
+
+// Roadmap returns the roadmap used by the planner, or nil if it has not yet been built.
+func (mp *prmMotionPlanner) Roadmap() *Roadmap {
+	return mp.roadmap
+}
+
+func (mp *prmMotionPlanner) Plan(ctx context.Context,
+	goal *commonpb.Pose,
+	seed []referenceframe.Input,
+	planOpts *PlannerOptions,
+) ([][]referenceframe.Input, error) {
+	if planOpts == nil {
+		planOpts = NewBasicPlannerOptions()
+	}
+
+	// get many potential end goals from IK solver
+	solutions, err := getSolutions(ctx, planOpts, mp.solver, goal, seed, mp.Frame())
+	if err != nil {
+		return nil, err
+	}
+	goals := make([]node, 0, len(solutions))
+	for _, solution := range solutions {
+		goals = append(goals, solution)
+	}
+	return mp.planToNodes(ctx, seed, goals, planOpts)
+}
+
+// planToNodes finds a path through the roadmap from the seed to any of the goal configurations, building the roadmap first
+// if needed.
+func (mp *prmMotionPlanner) planToNodes(
+	ctx context.Context,
+	seed []referenceframe.Input,
+	goals []node,
+	planOpts *PlannerOptions,
+) ([][]referenceframe.Input, error) {
+	algOpts, err := newPRMOptions(planOpts)
+	if err != nil {
+		return nil, err
+	}
+	if mp.roadmap == nil {
+		mp.logger.Debugf("building roadmap of %d nodes", algOpts.RoadmapNodes)
+		if mp.roadmap, err = BuildRoadmap(ctx, mp.frame, planOpts, mp.nCPU, mp.randseed); err != nil {
+			return nil, err
+		}
+	}
+	return mp.roadmap.query(ctx, mp.frame, planOpts, seed, goals, algOpts.RoadmapNeighbors)
+}
@@ -0,0 +1,348 @@
+package motionplan
+
+import (
+	"context"
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/test"
+
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+func TestPRMRoadmapReuse(t *testing.T) {
+	ctx := context.Background()
+	config, err := simple2DMap()
+	test.That(t, err, test.ShouldBeNil)
+	config.Options.extra = map[string]interface{}{"roadmap_nodes": 200}
+	goal := &basicNode{q: frame.FloatsToInputs([]float64{90, 90})}
+
+	checkPath := func(path [][]frame.Input, opts *PlannerOptions) {
+		t.Helper()
+		test.That(t, len(path), test.ShouldBeGreaterThanOrEqualTo, 2)
+		test.That(t, path[0], test.ShouldResemble, config.Start)
+		test.That(t, path[len(path)-1], test.ShouldResemble, goal.Q())
+		for i := 1; i < len(path); i++ {
+			ok, _ := opts.CheckConstraintPath(&ConstraintInput{
+				StartInput: path[i-1],
+				EndInput:   path[i],
+				Frame:      config.RobotFrame,
+			}, opts.Resolution)
+			test.That(t, ok, test.ShouldBeTrue)
+		}
+	}
+
+	//nolint:gosec
+	mp, err := NewPRMMotionPlannerWithSeed(config.RobotFrame, nCPU/4, rand.New(rand.NewSource(42)), logger.Sugar())
+	test.That(t, err, test.ShouldBeNil)
+	prm := mp.(*prmMotionPlanner)
+	test.That(t, prm.Roadmap(), test.ShouldBeNil)
+	path, err := prm.planToNodes(ctx, config.Start, []node{goal}, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	checkPath(path, config.Options)
+	nodes, edges := prm.Roadmap().Size()
+	test.That(t, nodes, test.ShouldEqual, 200)
+	test.That(t, edges, test.ShouldBeGreaterThan, 200)
+
+	// a roadmap read from disk plans identically
+	filename := filepath.Join(t.TempDir(), "roadmap.json")
+	test.That(t, WriteRoadmapFile(prm.Roadmap(), filename), test.ShouldBeNil)
+	roadmap, err := ReadRoadmapFile(filename)
+	test.That(t, err, test.ShouldBeNil)
+	nodes2, edges2 := roadmap.Size()
+	test.That(t, nodes2, test.ShouldEqual, nodes)
+	test.That(t, edges2, test.ShouldEqual, edges)
+	//nolint:gosec
+	mp, err = NewPRMMotionPlannerWithRoadmap(config.RobotFrame, nCPU/4, roadmap, rand.New(rand.NewSource(42)), logger.Sugar())
+	test.That(t, err, test.ShouldBeNil)
+	path2, err := mp.(*prmMotionPlanner).planToNodes(ctx, config.Start, []node{goal}, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, path2, test.ShouldResemble, path)
+
+	// after adding an obstacle and invalidating its region, the new plan must avoid it
+	obstacle, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{X: 70, Y: 60}), r3.Vector{X: 20, Y: 20, Z: 1})
+	test.That(t, err, test.ShouldBeNil)
+	wall, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{Y: 50}), r3.Vector{X: 80, Y: 80, Z: 1})
+	test.That(t, err, test.ShouldBeNil)
+	opts := NewBasicPlannerOptions()
+	opts.extra = config.Options.extra
+	opts.AddConstraint("collision", NewCollisionConstraint(
+		config.RobotFrame,
+		config.Start,
+		map[string]spatial.Geometry{"wall": wall, "obstacle": obstacle},
+		nil,
+	))
+	test.That(t, roadmap.InvalidateRegion(obstacle), test.ShouldBeNil)
+	path3, err := mp.(*prmMotionPlanner).planToNodes(ctx, config.Start, []node{goal}, opts)
+	test.That(t, err, test.ShouldBeNil)
+	checkPath(path3, opts)
+
+	// roadmaps cannot be used for other frames
+	otherFrame, err := frame.NewMobile2DFrame("other", []frame.Limit{{-100, 100}, {-100, 100}}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	//nolint:gosec
+	_, err = NewPRMMotionPlannerWithRoadmap(otherFrame, 1, roadmap, rand.New(rand.NewSource(42)), logger.Sugar())
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestRoadmapCache(t *testing.T) {
+	config, err := simple2DMap()
+	test.That(t, err, test.ShouldBeNil)
+	config.Options.extra = map[string]interface{}{"roadmap_nodes": 50, "roadmap_neighbors": 5}
+	//nolint:gosec
+	roadmap, err := BuildRoadmap(context.Background(), config.RobotFrame, config.Options, 2, rand.New(rand.NewSource(1)))
+	test.That(t, err, test.ShouldBeNil)
+
+	fs := frame.NewEmptySimpleFrameSystem("test")
+	test.That(t, fs.AddFrame(config.RobotFrame, fs.World()), test.ShouldBeNil)
+	key, err := RoadmapKey(fs, config.RobotFrame.Name(), nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	key2, err := RoadmapKey(fs, config.RobotFrame.Name(), nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key2, test.ShouldEqual, key)
+	key3, err := RoadmapKey(fs, frame.World, nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key3, test.ShouldNotEqual, key)
+
+	// roadmaps checked against different constraints or options cannot be reused
+	opts := NewBasicPlannerOptions()
+	opts.extra = config.Options.extra
+	key4, err := RoadmapKey(fs, config.RobotFrame.Name(), nil, opts)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key4, test.ShouldNotEqual, key)
+	opts.extra = map[string]interface{}{"roadmap_nodes": 50, "roadmap_neighbors": 6}
+	key5, err := RoadmapKey(fs, config.RobotFrame.Name(), nil, opts)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key5, test.ShouldNotEqual, key4)
+	opts.Resolution++
+	key6, err := RoadmapKey(fs, config.RobotFrame.Name(), nil, opts)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key6, test.ShouldNotEqual, key5)
+	key7, err := RoadmapKey(fs, config.RobotFrame.Name(), map[string][]frame.Input{"other": {{1}}}, opts)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key7, test.ShouldNotEqual, key6)
+
+	dir := t.TempDir()
+	cache := NewRoadmapCache(dir)
+	cached, err := cache.Roadmap(key)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldBeNil)
+	test.That(t, cache.Store(key, roadmap), test.ShouldBeNil)
+
+	// a new cache in the same directory finds the stored roadmap
+	cached, err = NewRoadmapCache(dir).Roadmap(key)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldNotBeNil)
+	test.That(t, cached.compatible(config.RobotFrame), test.ShouldBeNil)
+	nodes, edges := cached.Size()
+	nodes2, edges2 := roadmap.Size()
+	test.That(t, nodes, test.ShouldEqual, nodes2)
+	test.That(t, edges, test.ShouldEqual, edges2)
+}
+
+func TestRoadmapInvalidNodes(t *testing.T) {
+	ctx := context.Background()
+	config, err := simple2DMap()
+	test.That(t, err, test.ShouldBeNil)
+	config.Options.extra = map[string]interface{}{"roadmap_nodes": 100, "roadmap_neighbors": 5}
+	//nolint:gosec
+	roadmap, err := BuildRoadmap(ctx, config.RobotFrame, config.Options, 2, rand.New(rand.NewSource(1)))
+	test.That(t, err, test.ShouldBeNil)
+
+	// edges to nodes inside the obstacle are left unchecked rather than being marked invalid
+	invalidNodes := 0
+	for _, n := range roadmap.nodes {
+		if n.status == invalid {
+			invalidNodes++
+			test.That(t, n.bounds, test.ShouldNotBeNil)
+		}
+	}
+	test.That(t, invalidNodes, test.ShouldBeGreaterThan, 0)
+	for _, e := range roadmap.edges {
+		if roadmap.nodes[e.a].status == invalid || roadmap.nodes[e.b].status == invalid {
+			test.That(t, e.status, test.ShouldEqual, unchecked)
+		}
+	}
+
+	// once the obstacle is removed, every node and edge becomes valid again
+	obstacle, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{Y: 50}), r3.Vector{X: 80, Y: 80, Z: 1})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, roadmap.InvalidateRegion(obstacle), test.ShouldBeNil)
+	opts := NewBasicPlannerOptions()
+	opts.extra = config.Options.extra
+	test.That(t, roadmap.checkAll(ctx, config.RobotFrame, opts, 2), test.ShouldBeNil)
+	for _, n := range roadmap.nodes {
+		test.That(t, n.status, test.ShouldEqual, valid)
+	}
+	for _, e := range roadmap.edges {
+		test.That(t, e.status, test.ShouldEqual, valid)
+	}
+}
+
+func TestPRMRoadmapCacheSelection(t *testing.T) {
+	config, err := simple2DMap()
+	test.That(t, err, test.ShouldBeNil)
+	config.Options.extra = map[string]interface{}{"roadmap_nodes": 50, "roadmap_neighbors": 5}
+	fs := frame.NewEmptySimpleFrameSystem("test")
+	test.That(t, fs.AddFrame(config.RobotFrame, fs.World()), test.ShouldBeNil)
+	fss := NewSolvableFrameSystem(fs, logger.Sugar())
+	solveFrames, err := fss.TracebackFrame(config.RobotFrame)
+	test.That(t, err, test.ShouldBeNil)
+	seedMap := frame.StartPositions(fss)
+	sf, err := newSolverFrame(fss, solveFrames, frame.World, seedMap)
+	test.That(t, err, test.ShouldBeNil)
+	newPRM := func() *prmMotionPlanner {
+		t.Helper()
+		mp, name, err := fss.newPlanner(sf, map[string]interface{}{"planner": PRMPlanner})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, name, test.ShouldEqual, PRMPlanner)
+		prm, ok := mp.(*prmMotionPlanner)
+		test.That(t, ok, test.ShouldBeTrue)
+		return prm
+	}
+
+	// without a cache, roadmaps are not reused
+	key, _, err := fss.cachedRoadmap(newPRM(), sf, &commonpb.WorldState{}, seedMap, nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key, test.ShouldBeEmpty)
+
+	cache := NewRoadmapCache("")
+	fss.SetRoadmapCache(cache)
+	prm := newPRM()
+	key, _, err = fss.cachedRoadmap(prm, sf, &commonpb.WorldState{}, seedMap, nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key, test.ShouldNotBeEmpty)
+	test.That(t, prm.Roadmap(), test.ShouldBeNil)
+	//nolint:gosec
+	roadmap, err := BuildRoadmap(context.Background(), sf, config.Options, 2, rand.New(rand.NewSource(1)))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cache.Store(key, roadmap), test.ShouldBeNil)
+
+	prm = newPRM()
+	motionConfig := map[string]interface{}{"motion_profile": FreeMotionProfile}
+	key2, _, err := fss.cachedRoadmap(prm, sf, &commonpb.WorldState{}, seedMap, motionConfig, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key2, test.ShouldEqual, key)
+	test.That(t, prm.Roadmap(), test.ShouldEqual, roadmap)
+
+	// constraints which depend on the start and goal cannot use a cached roadmap
+	prm = newPRM()
+	motionConfig = map[string]interface{}{"motion_profile": LinearMotionProfile}
+	key, _, err = fss.cachedRoadmap(prm, sf, &commonpb.WorldState{}, seedMap, motionConfig, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key, test.ShouldBeEmpty)
+	test.That(t, prm.Roadmap(), test.ShouldBeNil)
+}
+
+func TestPRMRoadmapCacheObstacles(t *testing.T) {
+	ctx := context.Background()
+	config, err := simple2DMap()
+	test.That(t, err, test.ShouldBeNil)
+	config.Options.extra = map[string]interface{}{"roadmap_nodes": 100, "roadmap_neighbors": 5}
+	fs := frame.NewEmptySimpleFrameSystem("test")
+	test.That(t, fs.AddFrame(config.RobotFrame, fs.World()), test.ShouldBeNil)
+	fss := NewSolvableFrameSystem(fs, logger.Sugar())
+	solveFrames, err := fss.TracebackFrame(config.RobotFrame)
+	test.That(t, err, test.ShouldBeNil)
+	seedMap := frame.StartPositions(fss)
+	sf, err := newSolverFrame(fss, solveFrames, frame.World, seedMap)
+	test.That(t, err, test.ShouldBeNil)
+	dir := t.TempDir()
+	fss.SetRoadmapCache(NewRoadmapCache(dir))
+	newPRM := func() *prmMotionPlanner {
+		t.Helper()
+		mp, _, err := fss.newPlanner(sf, map[string]interface{}{"planner": PRMPlanner})
+		test.That(t, err, test.ShouldBeNil)
+		return mp.(*prmMotionPlanner)
+	}
+	box := func(x, y float64) (spatial.Geometry, *commonpb.WorldState) {
+		t.Helper()
+		g, err := spatial.NewBox(spatial.NewPoseFromPoint(r3.Vector{X: x, Y: y}), r3.Vector{X: 20, Y: 20, Z: 1})
+		test.That(t, err, test.ShouldBeNil)
+		return g, &commonpb.WorldState{Obstacles: []*commonpb.GeometriesInFrame{
+			{ReferenceFrame: frame.World, Geometries: []*commonpb.Geometry{g.ToProtobuf()}},
+		}}
+	}
+	statuses := func(rm *Roadmap) ([]validity, []validity) {
+		nodes := make([]validity, 0, len(rm.nodes))
+		for _, n := range rm.nodes {
+			nodes = append(nodes, n.status)
+		}
+		edges := make([]validity, 0, len(rm.edges))
+		for _, e := range rm.edges {
+			edges = append(edges, e.status)
+		}
+		return nodes, edges
+	}
+
+	before, worldState := box(-50, -60)
+	key, obstacles, err := fss.cachedRoadmap(newPRM(), sf, worldState, seedMap, nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key, test.ShouldNotBeEmpty)
+	test.That(t, len(obstacles), test.ShouldEqual, 1)
+	//nolint:gosec
+	roadmap, err := BuildRoadmap(ctx, sf, config.Options, 2, rand.New(rand.NewSource(1)))
+	test.That(t, err, test.ShouldBeNil)
+	roadmap.setObstacles(obstacles)
+	test.That(t, fss.roadmaps.Store(key, roadmap), test.ShouldBeNil)
+	nodes, edges := statuses(roadmap)
+
+	// the same obstacles leave the cached roadmap as it is
+	prm := newPRM()
+	key2, _, err := fss.cachedRoadmap(prm, sf, worldState, seedMap, nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key2, test.ShouldEqual, key)
+	test.That(t, prm.Roadmap(), test.ShouldEqual, roadmap)
+	nodes2, edges2 := statuses(roadmap)
+	test.That(t, nodes2, test.ShouldResemble, nodes)
+	test.That(t, edges2, test.ShouldResemble, edges)
+
+	// moving an obstacle reuses the cached roadmap, invalidating it only around the old and new positions of the obstacle
+	after, worldState := box(50, -60)
+	prm = newPRM()
+	key2, obstacles, err = fss.cachedRoadmap(prm, sf, worldState, seedMap, nil, config.Options)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, key2, test.ShouldEqual, key)
+	test.That(t, prm.Roadmap(), test.ShouldEqual, roadmap)
+	regions := []spatial.AABB{}
+	for _, g := range []spatial.Geometry{before, after} {
+		region, err := spatial.NewAABB(g)
+		test.That(t, err, test.ShouldBeNil)
+		regions = append(regions, region)
+	}
+	overlaps := func(bounds *spatial.AABB) bool {
+		return bounds != nil && (bounds.Overlaps(regions[0]) || bounds.Overlaps(regions[1]))
+	}
+	invalidated := 0
+	for i, n := range roadmap.nodes {
+		if overlaps(n.bounds) {
+			test.That(t, n.status, test.ShouldEqual, unchecked)
+			invalidated++
+		} else {
+			test.That(t, n.status, test.ShouldEqual, nodes[i])
+		}
+	}
+	test.That(t, invalidated, test.ShouldBeGreaterThan, 0)
+	test.That(t, invalidated, test.ShouldBeLessThan, len(roadmap.nodes))
+	for i, e := range roadmap.edges {
+		if overlaps(e.bounds) {
+			test.That(t, e.status, test.ShouldEqual, unchecked)
+		} else {
+			test.That(t, e.status, test.ShouldEqual, edges[i])
+		}
+	}
+
+	// the obstacles a roadmap was checked against are cached with it
+	test.That(t, fss.roadmaps.Store(key, roadmap), test.ShouldBeNil)
+	cached, err := NewRoadmapCache(dir).Roadmap(key)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, cached, test.ShouldNotBeNil)
+	changed, err := changedGeometries(cached.obstacles, obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, changed, test.ShouldBeEmpty)
+	test.That(t, len(cached.obstacles), test.ShouldEqual, 1)
+}
@@ -0,0 +1,217 @@
+package motionplan
+
+import (
+	"context"
+	"image/color"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/pointcloud"
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+// Number of configurations kept per voxel of a reachability map to seed IK with.
+const defaultReachabilitySeeds = 4
+
+// ReachabilityMap describes the workspace of a frame as a grid of voxels, recording for each voxel whether the frame's end
+// effector was able to reach it, and the greatest manipulability with which it did so. It is built by sampling the frame's
+// configuration space, so a voxel which was not reached may still be reachable if too few samples were taken.
+type ReachabilityMap struct {
+	voxelSize float64
+	voxels    map[pointcloud.VoxelCoords]*reachabilityVoxel
+	maxScore  float64
+}
+
+type reachabilityVoxel struct {
+	samples int
+	score   float64
+
+	// configurations which reached this voxel with the greatest manipulability, in descending order
+	seeds []scoredInputs
+}
+
+type scoredInputs struct {
+	inputs []frame.Input
+	score  float64
+}
+
+// NewReachabilityMap samples the given number of random configurations of a frame, and records the position each places the
+// end effector at in a grid of voxels with the given side length in mm.
+func NewReachabilityMap(
+	ctx context.Context,
+	f frame.Frame,
+	voxelSize float64,
+	samples int,
+	randseed *rand.Rand,
+) (*ReachabilityMap, error) {
+	if voxelSize <= 0 {
+		return nil, errors.Errorf("voxel size must be positive, got %f", voxelSize)
+	}
+	rm := &ReachabilityMap{
+		voxelSize: voxelSize,
+		voxels:    map[pointcloud.VoxelCoords]*reachabilityVoxel{},
+	}
+	for i := 0; i < samples; i++ {
+		if i%1000 == 0 && ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		inputs := frame.RandomFrameInputs(f, randseed)
+		pose, err := f.Transform(inputs)
+		if err != nil {
+			return nil, err
+		}
+		score, err := manipulability(f, inputs)
+		if err != nil {
+			return nil, err
+		}
+		rm.add(pose.Point(), inputs, score)
+	}
+	return rm, nil
+}
+
+func (rm *ReachabilityMap) add(pt r3.Vector, inputs []frame.Input, score float64) {
+	key := rm.voxelCoords(pt)
+	voxel, ok := rm.voxels[key]
+	if !ok {
+		voxel = &reachabilityVoxel{}
+		rm.voxels[key] = voxel
+	}
+	voxel.samples++
+	voxel.score = math.Max(voxel.score, score)
+	rm.maxScore = math.Max(rm.maxScore, score)
+
+	voxel.seeds = append(voxel.seeds, scoredInputs{inputs, score})
+	sort.SliceStable(voxel.seeds, func(i, j int) bool { return voxel.seeds[i].score > voxel.seeds[j].score })
+	if len(voxel.seeds) > defaultReachabilitySeeds {
+		voxel.seeds = voxel.seeds[:defaultReachabilitySeeds]
+	}
+}
+
+func (rm *ReachabilityMap) voxelCoords(pt r3.Vector) pointcloud.VoxelCoords {
+	return pointcloud.VoxelCoords{
+		I: int64(math.Floor(pt.X / rm.voxelSize)),
+		J: int64(math.Floor(pt.Y / rm.voxelSize)),
+		K: int64(math.Floor(pt.Z / rm.voxelSize)),
+	}
+}
+
+func (rm *ReachabilityMap) voxelCenter(key pointcloud.VoxelCoords) r3.Vector {
+	return r3.Vector{
+		X: (float64(key.I) + 0.5) * rm.voxelSize,
+		Y: (float64(key.J) + 0.5) * rm.voxelSize,
+		Z: (float64(key.K) + 0.5) * rm.voxelSize,
+	}
+}
+
+// VoxelSize returns the side length in mm of the voxels of the map.
+func (rm *ReachabilityMap) VoxelSize() float64 {
+	return rm.voxelSize
+}
+
+// Size returns the number of voxels which were reached.
+func (rm *ReachabilityMap) Size() int {
+	return len(rm.voxels)
+}
+
+// Reachable returns whether the end effector was able to reach the voxel containing the given point.
+func (rm *ReachabilityMap) Reachable(pt r3.Vector) bool {
+	_, ok := rm.voxels[rm.voxelCoords(pt)]
+	return ok
+}
+
+// Manipulability returns the greatest manipulability with which the end effector reached the voxel containing the given point,
+// or 0 if it was not reached.
+func (rm *ReachabilityMap) Manipulability(pt r3.Vector) float64 {
+	if voxel, ok := rm.voxels[rm.voxelCoords(pt)]; ok {
+		return voxel.score
+	}
+	return 0
+}
+
+// Solve determines whether the given pose, including its orientation, is reachable. If the position was not reached while
+// building the map this fails immediately. Otherwise, the configurations which reached its voxel are used to seed the IK
+// solver, and the first solution found is returned.
+func (rm *ReachabilityMap) Solve(ctx context.Context, solver InverseKinematics, goal spatial.Pose) ([]frame.Input, error) {
+	voxel, ok := rm.voxels[rm.voxelCoords(goal.Point())]
+	if !ok {
+		return nil, errIKSolve
+	}
+	for _, seed := range voxel.seeds {
+		if solution, err := solveOnce(ctx, solver, goal, seed.inputs); err == nil {
+			return solution, nil
+		} else if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+	return nil, errIKSolve
+}
+
+// solveOnce returns the first solution produced by the IK solver.
+func solveOnce(ctx context.Context, solver InverseKinematics, goal spatial.Pose, seed []frame.Input) ([]frame.Input, error) {
+	solutionGen := make(chan []frame.Input, 1)
+	ikErr := make(chan error, 1)
+	// the solver keeps sending solutions until its context is done, so it must be cancelled before waiting for it to return
+	defer func() { <-ikErr }()
+
+	ctxWithCancel, cancel := context.WithCancel(ctx)
+	defer cancel()
+	utils.PanicCapturingGo(func() {
+		defer close(ikErr)
+		ikErr <- solver.Solve(ctxWithCancel, solutionGen, goal, seed, NewSquaredNormMetric())
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case solution := <-solutionGen:
+		return solution, nil
+	case <-ikErr:
+		// the solver may have sent a solution just before returning
+		select {
+		case solution := <-solutionGen:
+			return solution, nil
+		default:
+			return nil, errIKSolve
+		}
+	}
+}
+
+// PointCloud returns the reached voxels as a point cloud, with a point at the center of each voxel. Points are colored from red
+// to green by their manipulability relative to the greatest in the map, and have the number of samples which reached the
+// voxel as their value.
+func (rm *ReachabilityMap) PointCloud() (pointcloud.PointCloud, error) {
+	pc := pointcloud.NewWithPrealloc(len(rm.voxels))
+	for key, voxel := range rm.voxels {
+		relative := 0.
+		if rm.maxScore > 0 {
+			relative = voxel.score / rm.maxScore
+		}
+		data := pointcloud.NewColoredData(color.NRGBA{
+			R: uint8(255 * (1 - relative)),
+			G: uint8(255 * relative),
+			A: 255,
+		}).SetValue(voxel.samples)
+		if err := pc.Set(rm.voxelCenter(key), data); err != nil {
+			return nil, err
+		}
+	}
+	return pc, nil
+}
+
+// manipulability returns the manipulability of the position of the end effector of a frame at the given configuration.
+func manipulability(f frame.Frame, inputs []frame.Input) (float64, error) {
+	if len(inputs) == 0 {
+		return 0, nil
+	}
+	jac, err := frame.Jacobian(f, inputs)
+	if err != nil {
+		return 0, err
+	}
+	return frame.Manipulability(frame.PositionJacobian(jac))
+}
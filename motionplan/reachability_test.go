@@ -0,0 +1,140 @@
+package motionplan
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+var planarArmJSON = []byte(`{
+	"name": "planar",
+	"links": [
+		{"id": "base", "parent": "world", "translation": {"x": 0, "y": 0, "z": 0}},
+		{"id": "link1", "parent": "joint1", "translation": {"x": 100, "y": 0, "z": 0}},
+		{"id": "link2", "parent": "joint2", "translation": {"x": 50, "y": 0, "z": 0}}
+	],
+	"joints": [
+		{"id": "joint1", "type": "revolute", "parent": "base", "axis": {"x": 0, "y": 0, "z": 1}, "max": 180, "min": -180},
+		{"id": "joint2", "type": "revolute", "parent": "link1", "axis": {"x": 0, "y": 0, "z": 1}, "max": 180, "min": -180}
+	]
+}`)
+
+// echoIK is an InverseKinematics which returns its seed if the seed reaches the goal.
+type echoIK struct {
+	f frame.Frame
+}
+
+func (ik *echoIK) Solve(ctx context.Context, c chan<- []frame.Input, goal spatial.Pose, seed []frame.Input, m Metric) error {
+	pose, err := ik.f.Transform(seed)
+	if err != nil {
+		return err
+	}
+	if spatial.PoseAlmostCoincidentEps(pose, goal, 1) {
+		c <- seed
+	}
+	return nil
+}
+
+// streamingIK is an InverseKinematics which, like nlopt, keeps sending its seed as a solution until its context is done.
+type streamingIK struct{}
+
+func (ik *streamingIK) Solve(ctx context.Context, c chan<- []frame.Input, goal spatial.Pose, seed []frame.Input, m Metric) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case c <- seed:
+		}
+	}
+}
+
+func TestSolveOnce(t *testing.T) {
+	seed := frame.FloatsToInputs([]float64{1, 2})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	solution, err := solveOnce(ctx, &streamingIK{}, spatial.NewZeroPose(), seed)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, solution, test.ShouldResemble, seed)
+	test.That(t, ctx.Err(), test.ShouldBeNil)
+}
+
+func TestManipulability(t *testing.T) {
+	planar, err := frame.UnmarshalModelJSON(planarArmJSON, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	// for a planar two link arm the manipulability is l1 * l2 * |sin(q2)|
+	score, err := manipulability(planar, frame.FloatsToInputs([]float64{0.4, 0.3}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, score, test.ShouldAlmostEqual, 100*50*math.Sin(0.3), 1e-2)
+
+	score, err = manipulability(frame.NewZeroStaticFrame("static"), []frame.Input{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, score, test.ShouldEqual, 0)
+}
+
+func TestReachabilityMap(t *testing.T) {
+	ctx := context.Background()
+	planar, err := frame.UnmarshalModelJSON(planarArmJSON, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	//nolint:gosec
+	_, err = NewReachabilityMap(ctx, planar, 0, 10, rand.New(rand.NewSource(1)))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	//nolint:gosec
+	rm, err := NewReachabilityMap(ctx, planar, 10, 20000, rand.New(rand.NewSource(1)))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rm.VoxelSize(), test.ShouldEqual, 10)
+	test.That(t, rm.Size(), test.ShouldBeGreaterThan, 0)
+
+	// the workspace is the annulus between radii 50 and 150
+	test.That(t, rm.Reachable(r3.Vector{X: 105, Y: 5}), test.ShouldBeTrue)
+	test.That(t, rm.Manipulability(r3.Vector{X: 105, Y: 5}), test.ShouldBeGreaterThan, 0)
+	test.That(t, rm.Reachable(r3.Vector{X: 5, Y: 5}), test.ShouldBeFalse)
+	test.That(t, rm.Reachable(r3.Vector{X: 200}), test.ShouldBeFalse)
+	test.That(t, rm.Reachable(r3.Vector{X: 105, Y: 5, Z: 50}), test.ShouldBeFalse)
+	test.That(t, rm.Manipulability(r3.Vector{X: 200}), test.ShouldEqual, 0)
+
+	// poses are solved starting from the configurations which reached their voxel
+	goal := spatial.NewPoseFromPoint(r3.Vector{X: 105, Y: 5})
+	_, err = rm.Solve(ctx, &echoIK{planar}, spatial.NewPoseFromPoint(r3.Vector{X: 200}))
+	test.That(t, err, test.ShouldBeError, errIKSolve)
+	voxel := rm.voxels[rm.voxelCoords(goal.Point())]
+	seedPose, err := planar.Transform(voxel.seeds[0].inputs)
+	test.That(t, err, test.ShouldBeNil)
+	solution, err := rm.Solve(ctx, &echoIK{planar}, seedPose)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, solution, test.ShouldResemble, voxel.seeds[0].inputs)
+
+	// the map exported as a point cloud has a point per voxel
+	pc, err := rm.PointCloud()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, pc.Size(), test.ShouldEqual, rm.Size())
+	center := rm.voxelCenter(rm.voxelCoords(goal.Point()))
+	data, ok := pc.At(center.X, center.Y, center.Z)
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, data.HasColor(), test.ShouldBeTrue)
+	test.That(t, data.Value(), test.ShouldEqual, voxel.samples)
+}
+
+func TestReachabilityMapXArm(t *testing.T) {
+	xarm, err := frame.ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	//nolint:gosec
+	rm, err := NewReachabilityMap(context.Background(), xarm, 50, 5000, rand.New(rand.NewSource(1)))
+	test.That(t, err, test.ShouldBeNil)
+
+	home, err := xarm.Transform(home6)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, rm.Reachable(r3.Vector{X: 300, Z: 300}), test.ShouldBeTrue)
+	test.That(t, rm.Reachable(home.Point().Add(r3.Vector{X: 5000})), test.ShouldBeFalse)
+}
@@ -0,0 +1,773 @@
+package motionplan
+
+import (
+	"container/heap"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+	"go.viam.com/utils"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	frame "go.viam.com/rdk/referenceframe"
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+// RoadmapVersion is the version of the serialization format written for Roadmaps. Roadmaps written with a different
+// version than this cannot be read.
+const RoadmapVersion = 2
+
+// validity describes whether a roadmap node or edge satisfies the constraints it was last checked against.
+type validity int
+
+const (
+	unchecked validity = iota
+	valid
+	invalid
+)
+
+// Roadmap is a graph of configurations of a frame, and of the motions between them, which can be reused across many
+// motion plans in the same workspace. Nodes and edges are checked against the planner's constraints when the roadmap is
+// built, and each records the axis-aligned box bounding the geometry of the frame over its configuration or motion.
+// When obstacles change, only the nodes and edges whose bounds overlap the change are marked as needing to be checked
+// again, which is done lazily the next time they are part of a candidate path.
+type Roadmap struct {
+	mu        sync.Mutex
+	frameName string
+	dof       []frame.Limit
+	nodes     []*roadmapNode
+	edges     []*roadmapEdge
+
+	// the obstacles and interaction spaces, in the world frame, which the roadmap was last checked against
+	obstacles []spatial.Geometry
+}
+
+type roadmapNode struct {
+	q      []frame.Input
+	bounds *spatial.AABB
+	status validity
+
+	// indices of the edges in the roadmap connected to this node
+	edges []int
+}
+
+type roadmapEdge struct {
+	a, b   int
+	cost   float64
+	bounds *spatial.AABB
+	status validity
+}
+
+// BuildRoadmap samples configurations of the given frame which satisfy the constraints in planOpts, and connects each
+// to its nearest neighbors with the motions between them which also satisfy the constraints. The size of the roadmap is
+// controlled by the "roadmap_nodes" and "roadmap_neighbors" fields of the planner options' extra parameters.
+func BuildRoadmap(
+	ctx context.Context,
+	f frame.Frame,
+	planOpts *PlannerOptions,
+	nCPU int,
+	randseed *rand.Rand,
+) (*Roadmap, error) {
+	if planOpts == nil {
+		planOpts = NewBasicPlannerOptions()
+	}
+	algOpts, err := newPRMOptions(planOpts)
+	if err != nil {
+		return nil, err
+	}
+	rm := &Roadmap{frameName: f.Name(), dof: f.DoF()}
+
+	// sample nodes, keeping those that are invalid as they may become valid if obstacles are removed later
+	for i := 0; i < algOpts.RoadmapNodes; i++ {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		rm.nodes = append(rm.nodes, &roadmapNode{q: frame.RandomFrameInputs(f, randseed)})
+	}
+
+	// connect each node to its nearest neighbors, skipping duplicate edges
+	connected := map[[2]int]bool{}
+	for i, n := range rm.nodes {
+		for _, j := range rm.nearest(n.q, algOpts.RoadmapNeighbors+1) {
+			key := [2]int{i, j}
+			if j < i {
+				key = [2]int{j, i}
+			}
+			if i == j || connected[key] {
+				continue
+			}
+			connected[key] = true
+			rm.addEdge(key[0], key[1])
+		}
+	}
+
+	// check all nodes and edges in parallel
+	if err := rm.checkAll(ctx, f, planOpts, nCPU); err != nil {
+		return nil, err
+	}
+	return rm, nil
+}
+
+// Size returns the number of nodes and edges in the roadmap.
+func (rm *Roadmap) Size() (int, int) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	return len(rm.nodes), len(rm.edges)
+}
+
+// InvalidateRegion marks every node and edge of the roadmap whose bounds overlap any of the given geometries as needing
+// to be checked again before it is used. This should be called with the geometries of any obstacles which have been
+// added, removed, or moved (at both their old and new positions) since the roadmap was built.
+func (rm *Roadmap) InvalidateRegion(geometries ...spatial.Geometry) error {
+	regions := make([]spatial.AABB, 0, len(geometries))
+	for _, geometry := range geometries {
+		region, err := spatial.NewAABB(geometry)
+		if err != nil {
+			return err
+		}
+		regions = append(regions, region)
+	}
+	overlaps := func(bounds *spatial.AABB) bool {
+		if bounds == nil {
+			return false
+		}
+		for _, region := range regions {
+			if bounds.Overlaps(region) {
+				return true
+			}
+		}
+		return false
+	}
+
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	for _, n := range rm.nodes {
+		if overlaps(n.bounds) {
+			n.status = unchecked
+		}
+	}
+	for _, e := range rm.edges {
+		if overlaps(e.bounds) {
+			e.status = unchecked
+		}
+	}
+	return nil
+}
+
+// updateObstacles brings the roadmap up to date with the given obstacles and interaction spaces, in the world frame, by
+// invalidating the regions of those which differ from the ones it was last checked against.
+func (rm *Roadmap) updateObstacles(obstacles []spatial.Geometry) error {
+	rm.mu.Lock()
+	old := rm.obstacles
+	rm.mu.Unlock()
+	changed, err := changedGeometries(old, obstacles)
+	if err != nil {
+		return err
+	}
+	if err := rm.InvalidateRegion(changed...); err != nil {
+		return err
+	}
+	rm.setObstacles(obstacles)
+	return nil
+}
+
+// setObstacles records the obstacles and interaction spaces, in the world frame, which the roadmap has been checked against.
+func (rm *Roadmap) setObstacles(obstacles []spatial.Geometry) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.obstacles = obstacles
+}
+
+// changedGeometries returns the geometries which are in only one of the two sets of geometries, including both positions
+// of any which have moved.
+func changedGeometries(a, b []spatial.Geometry) ([]spatial.Geometry, error) {
+	key := func(g spatial.Geometry) (string, error) {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(g.ToProtobuf())
+		return string(data), err
+	}
+	counts := map[string]int{}
+	for _, g := range a {
+		k, err := key(g)
+		if err != nil {
+			return nil, err
+		}
+		counts[k]++
+	}
+	changed := []spatial.Geometry{}
+	for _, g := range b {
+		k, err := key(g)
+		if err != nil {
+			return nil, err
+		}
+		if counts[k] > 0 {
+			counts[k]--
+			continue
+		}
+		changed = append(changed, g)
+	}
+	for _, g := range a {
+		k, err := key(g)
+		if err != nil {
+			return nil, err
+		}
+		if counts[k] > 0 {
+			counts[k]--
+			changed = append(changed, g)
+		}
+	}
+	return changed, nil
+}
+
+// compatible returns an error if the roadmap was not built for the given frame.
+func (rm *Roadmap) compatible(f frame.Frame) error {
+	dof := f.DoF()
+	if f.Name() != rm.frameName || len(dof) != len(rm.dof) {
+		return errors.Errorf("roadmap was built for frame %q with %d DoF, cannot be used for frame %q with %d DoF",
+			rm.frameName, len(rm.dof), f.Name(), len(dof))
+	}
+	for i, limit := range dof {
+		if limit != rm.dof[i] {
+			return errors.Errorf("roadmap was built with different limits for frame %q", f.Name())
+		}
+	}
+	return nil
+}
+
+func (rm *Roadmap) addEdge(a, b int) int {
+	rm.edges = append(rm.edges, &roadmapEdge{a: a, b: b, cost: inputDist(rm.nodes[a].q, rm.nodes[b].q)})
+	idx := len(rm.edges) - 1
+	rm.nodes[a].edges = append(rm.nodes[a].edges, idx)
+	rm.nodes[b].edges = append(rm.nodes[b].edges, idx)
+	return idx
+}
+
+// nearest returns the indices of the k nodes in the roadmap closest to the given configuration, ignoring invalid nodes.
+func (rm *Roadmap) nearest(q []frame.Input, k int) []int {
+	candidates := make([]int, 0, len(rm.nodes))
+	dists := make([]float64, len(rm.nodes))
+	for i, n := range rm.nodes {
+		if n.status == invalid {
+			continue
+		}
+		dists[i] = inputDist(q, n.q)
+		candidates = append(candidates, i)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return dists[candidates[i]] < dists[candidates[j]] })
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	return candidates
+}
+
+// checkAll checks every unchecked node, and then every unchecked edge between valid nodes, using nCPU threads. Edges to
+// invalid nodes are left unchecked, as they are never part of a path while the node is invalid, and are checked once
+// the node has been invalidated by InvalidateRegion and found to be valid.
+func (rm *Roadmap) checkAll(ctx context.Context, f frame.Frame, planOpts *PlannerOptions, nCPU int) error {
+	if nCPU < 1 {
+		nCPU = 1
+	}
+	parallelCheck := func(n int, check func(int)) error {
+		work := make(chan int, n)
+		for i := 0; i < n; i++ {
+			work <- i
+		}
+		close(work)
+		var wg sync.WaitGroup
+		for i := 0; i < nCPU; i++ {
+			wg.Add(1)
+			utils.PanicCapturingGo(func() {
+				defer wg.Done()
+				for idx := range work {
+					if ctx.Err() != nil {
+						return
+					}
+					check(idx)
+				}
+			})
+		}
+		wg.Wait()
+		return ctx.Err()
+	}
+
+	if err := parallelCheck(len(rm.nodes), func(i int) {
+		if rm.nodes[i].status == unchecked {
+			rm.checkNode(f, planOpts, rm.nodes[i])
+		}
+	}); err != nil {
+		return err
+	}
+	return parallelCheck(len(rm.edges), func(i int) {
+		e := rm.edges[i]
+		if e.status != unchecked {
+			return
+		}
+		if rm.nodes[e.a].status == invalid || rm.nodes[e.b].status == invalid {
+			return
+		}
+		rm.checkEdge(f, planOpts, rm.nodes[e.a].q, rm.nodes[e.b].q, e)
+	})
+}
+
+// checkNode checks whether the node's configuration satisfies the constraints, and records the bounds of the frame's geometry.
+func (rm *Roadmap) checkNode(f frame.Frame, planOpts *PlannerOptions, n *roadmapNode) {
+	n.status = invalid
+	pos, err := f.Transform(n.q)
+	if err != nil {
+		return
+	}
+	n.bounds = geometryBounds(f, n.q)
+	if ok, _ := planOpts.CheckConstraints(&ConstraintInput{
+		StartPos:   pos,
+		EndPos:     pos,
+		StartInput: n.q,
+		EndInput:   n.q,
+		Frame:      f,
+	}); ok {
+		n.status = valid
+	}
+}
+
+// checkEdge checks whether the motion between two configurations satisfies the constraints, and records the bounds of the
+// frame's geometry over the motion.
+func (rm *Roadmap) checkEdge(f frame.Frame, planOpts *PlannerOptions, a, b []frame.Input, e *roadmapEdge) {
+	e.status = invalid
+	startPos, err := f.Transform(a)
+	if err != nil {
+		return
+	}
+	endPos, err := f.Transform(b)
+	if err != nil {
+		return
+	}
+	steps := GetSteps(startPos, endPos, planOpts.Resolution)
+	for i := 0; i <= steps; i++ {
+		e.bounds = unionBounds(e.bounds, geometryBounds(f, frame.InterpolateInputs(a, b, float64(i)/float64(steps))))
+	}
+	if e.bounds != nil {
+		// the frame's geometry may extend slightly beyond the bounds between the interpolated configurations
+		expanded := e.bounds.Expand(planOpts.Resolution)
+		e.bounds = &expanded
+	}
+	if ok, _ := planOpts.CheckConstraintPath(&ConstraintInput{
+		StartPos:   startPos,
+		EndPos:     endPos,
+		StartInput: a,
+		EndInput:   b,
+		Frame:      f,
+	}, planOpts.Resolution); ok {
+		e.status = valid
+	}
+}
+
+// query finds the shortest path through the roadmap from the seed to any of the goals. Nodes and edges which have not
+// been checked are assumed to be valid while searching, and then checked once they are on the shortest path found,
+// after which the search is repeated if any were invalid.
+func (rm *Roadmap) query(
+	ctx context.Context,
+	f frame.Frame,
+	planOpts *PlannerOptions,
+	seed []frame.Input,
+	goals []node,
+	neighbors int,
+) ([][]frame.Input, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	// add temporary nodes for the seed and goals, connected to each other and to their nearest roadmap nodes
+	nRoadmap, eRoadmap := len(rm.nodes), len(rm.edges)
+	defer func() {
+		for _, n := range rm.nodes[:nRoadmap] {
+			for len(n.edges) > 0 && n.edges[len(n.edges)-1] >= eRoadmap {
+				n.edges = n.edges[:len(n.edges)-1]
+			}
+		}
+		rm.nodes = rm.nodes[:nRoadmap]
+		rm.edges = rm.edges[:eRoadmap]
+	}()
+	start := len(rm.nodes)
+	rm.nodes = append(rm.nodes, &roadmapNode{q: seed, status: valid})
+	isGoal := map[int]bool{}
+	for _, goal := range goals {
+		rm.nodes = append(rm.nodes, &roadmapNode{q: goal.Q(), status: valid})
+		goalIdx := len(rm.nodes) - 1
+		isGoal[goalIdx] = true
+		rm.addEdge(start, goalIdx)
+	}
+	for i := start; i < len(rm.nodes); i++ {
+		for _, j := range rm.nearest(rm.nodes[i].q, neighbors) {
+			if j < nRoadmap {
+				rm.addEdge(i, j)
+			}
+		}
+	}
+
+	for {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		path := rm.shortestPath(start, isGoal)
+		if path == nil {
+			return nil, errPlannerFailed
+		}
+		pathValid := true
+		for i, nodeIdx := range path {
+			n := rm.nodes[nodeIdx]
+			if n.status == unchecked {
+				rm.checkNode(f, planOpts, n)
+			}
+			if n.status == invalid {
+				pathValid = false
+				break
+			}
+			if i == 0 {
+				continue
+			}
+			e := rm.edgeBetween(path[i-1], nodeIdx)
+			if e.status == unchecked {
+				rm.checkEdge(f, planOpts, rm.nodes[path[i-1]].q, n.q, e)
+			}
+			if e.status == invalid {
+				pathValid = false
+				break
+			}
+		}
+		if pathValid {
+			inputs := make([][]frame.Input, 0, len(path))
+			for _, nodeIdx := range path {
+				inputs = append(inputs, rm.nodes[nodeIdx].q)
+			}
+			return inputs, nil
+		}
+	}
+}
+
+// edgeBetween returns the lowest cost edge which is not invalid between two nodes.
+func (rm *Roadmap) edgeBetween(a, b int) *roadmapEdge {
+	var best *roadmapEdge
+	for _, edgeIdx := range rm.nodes[a].edges {
+		e := rm.edges[edgeIdx]
+		if (e.a == b || e.b == b) && e.status != invalid && (best == nil || e.cost < best.cost) {
+			best = e
+		}
+	}
+	return best
+}
+
+// shortestPath uses Dijkstra's algorithm to find the lowest cost path from start to any goal which does not contain any
+// invalid nodes or edges. It returns nil if there is no such path.
+func (rm *Roadmap) shortestPath(start int, goals map[int]bool) []int {
+	dist := make([]float64, len(rm.nodes))
+	prev := make([]int, len(rm.nodes))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = -1
+	}
+	dist[start] = 0
+	queue := &nodeQueue{{start, 0}}
+	for queue.Len() > 0 {
+		current := heap.Pop(queue).(queuedNode)
+		if current.dist > dist[current.idx] {
+			continue
+		}
+		if goals[current.idx] {
+			path := []int{}
+			for i := current.idx; i >= 0; i = prev[i] {
+				path = append([]int{i}, path...)
+			}
+			return path
+		}
+		for _, edgeIdx := range rm.nodes[current.idx].edges {
+			e := rm.edges[edgeIdx]
+			next := e.a
+			if next == current.idx {
+				next = e.b
+			}
+			if e.status == invalid || rm.nodes[next].status == invalid {
+				continue
+			}
+			if d := current.dist + e.cost; d < dist[next] {
+				dist[next] = d
+				prev[next] = current.idx
+				heap.Push(queue, queuedNode{next, d})
+			}
+		}
+	}
+	return nil
+}
+
+type queuedNode struct {
+	idx  int
+	dist float64
+}
+
+// nodeQueue is a min-heap of nodes ordered by distance, used by shortestPath.
+type nodeQueue []queuedNode
+
+func (q nodeQueue) Len() int            { return len(q) }
+func (q nodeQueue) Less(i, j int) bool  { return q[i].dist < q[j].dist }
+func (q nodeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *nodeQueue) Push(x interface{}) { *q = append(*q, x.(queuedNode)) }
+
+func (q *nodeQueue) Pop() interface{} {
+	old := *q
+	n := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return n
+}
+
+// geometryBounds returns the box bounding all of the frame's geometry at the given configuration, or nil if it has none.
+func geometryBounds(f frame.Frame, q []frame.Input) *spatial.AABB {
+	gf, err := f.Geometries(q)
+	if gf == nil || err != nil {
+		return nil
+	}
+	var bounds *spatial.AABB
+	for _, geometry := range gf.Geometries() {
+		aabb, err := spatial.NewAABB(geometry)
+		if err != nil {
+			continue
+		}
+		bounds = unionBounds(bounds, &aabb)
+	}
+	return bounds
+}
+
+func unionBounds(a, b *spatial.AABB) *spatial.AABB {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	union := a.Union(*b)
+	return &union
+}
+
+// inputDist returns the euclidean distance between two configurations.
+func inputDist(a, b []frame.Input) float64 {
+	dist := 0.
+	for i := range a {
+		dist += math.Pow(a[i].Value-b[i].Value, 2)
+	}
+	return math.Sqrt(dist)
+}
+
+// roadmapJSON is the on-disk format of a Roadmap.
+type roadmapJSON struct {
+	Version   int               `json:"version"`
+	Frame     string            `json:"frame"`
+	DoF       []frame.Limit     `json:"dof"`
+	Nodes     []roadmapNodeJSON `json:"nodes"`
+	Edges     []roadmapEdgeJSON `json:"edges"`
+	Obstacles json.RawMessage   `json:"obstacles,omitempty"`
+}
+
+type roadmapNodeJSON struct {
+	Q      []float64     `json:"q"`
+	Bounds *spatial.AABB `json:"bounds,omitempty"`
+	Status validity      `json:"status"`
+}
+
+type roadmapEdgeJSON struct {
+	A      int           `json:"a"`
+	B      int           `json:"b"`
+	Bounds *spatial.AABB `json:"bounds,omitempty"`
+	Status validity      `json:"status"`
+}
+
+// MarshalJSON serializes a Roadmap.
+func (rm *Roadmap) MarshalJSON() ([]byte, error) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rmJSON := roadmapJSON{Version: RoadmapVersion, Frame: rm.frameName, DoF: rm.dof}
+	for _, n := range rm.nodes {
+		rmJSON.Nodes = append(rmJSON.Nodes, roadmapNodeJSON{frame.InputsToFloats(n.q), n.bounds, n.status})
+	}
+	for _, e := range rm.edges {
+		rmJSON.Edges = append(rmJSON.Edges, roadmapEdgeJSON{e.a, e.b, e.bounds, e.status})
+	}
+	if len(rm.obstacles) != 0 {
+		geometries := map[string]spatial.Geometry{}
+		for i, g := range rm.obstacles {
+			geometries[strconv.Itoa(i)] = g
+		}
+		data, err := protojson.Marshal(frame.GeometriesInFrameToProtobuf(frame.NewGeometriesInFrame(frame.World, geometries)))
+		if err != nil {
+			return nil, err
+		}
+		rmJSON.Obstacles = data
+	}
+	return json.Marshal(rmJSON)
+}
+
+// UnmarshalJSON deserializes a Roadmap, returning an error if it was written with a different version.
+func (rm *Roadmap) UnmarshalJSON(data []byte) error {
+	var rmJSON roadmapJSON
+	if err := json.Unmarshal(data, &rmJSON); err != nil {
+		return err
+	}
+	if rmJSON.Version != RoadmapVersion {
+		return errors.Errorf("unsupported roadmap version %d, supported version is %d", rmJSON.Version, RoadmapVersion)
+	}
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.frameName = rmJSON.Frame
+	rm.dof = rmJSON.DoF
+	rm.nodes = make([]*roadmapNode, 0, len(rmJSON.Nodes))
+	rm.edges = make([]*roadmapEdge, 0, len(rmJSON.Edges))
+	for _, n := range rmJSON.Nodes {
+		if len(n.Q) != len(rm.dof) {
+			return frame.NewIncorrectInputLengthError(len(n.Q), len(rm.dof))
+		}
+		rm.nodes = append(rm.nodes, &roadmapNode{q: frame.FloatsToInputs(n.Q), bounds: n.Bounds, status: n.Status})
+	}
+	for _, e := range rmJSON.Edges {
+		if e.A < 0 || e.B < 0 || e.A >= len(rm.nodes) || e.B >= len(rm.nodes) {
+			return errors.Errorf("roadmap edge references nonexistent node")
+		}
+		idx := rm.addEdge(e.A, e.B)
+		rm.edges[idx].bounds = e.Bounds
+		rm.edges[idx].status = e.Status
+	}
+	rm.obstacles = nil
+	if len(rmJSON.Obstacles) != 0 {
+		var pbObstacles commonpb.GeometriesInFrame
+		if err := protojson.Unmarshal(rmJSON.Obstacles, &pbObstacles); err != nil {
+			return err
+		}
+		obstacles, err := frame.ProtobufToGeometriesInFrame(&pbObstacles)
+		if err != nil {
+			return err
+		}
+		for _, g := range obstacles.Geometries() {
+			rm.obstacles = append(rm.obstacles, g)
+		}
+	}
+	return nil
+}
+
+// WriteRoadmapFile writes a Roadmap to the given file.
+func WriteRoadmapFile(rm *Roadmap, filename string) error {
+	data, err := json.Marshal(rm)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0o600)
+}
+
+// ReadRoadmapFile reads a Roadmap from the given file.
+func ReadRoadmapFile(filename string) (*Roadmap, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read roadmap")
+	}
+	rm := &Roadmap{}
+	if err := json.Unmarshal(data, rm); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal roadmap")
+	}
+	return rm, nil
+}
+
+// RoadmapKey returns a key identifying a frame to solve for within a frame system, the inputs of the frames which are not
+// solved for, and the constraints and options a roadmap is checked against. Roadmaps built for the same key can be reused
+// for planning. Obstacles are not part of the key, since a reused roadmap is only invalidated where they have changed.
+// Constraints are identified by name, so those whose behavior depends on the start or goal of a plan, such as those of a
+// linear motion profile, must not be used with a cached roadmap.
+func RoadmapKey(
+	fs frame.FrameSystem,
+	solveFrame string,
+	fixedInputs map[string][]frame.Input,
+	planOpts *PlannerOptions,
+) (string, error) {
+	if planOpts == nil {
+		planOpts = NewBasicPlannerOptions()
+	}
+	fsData, err := frame.MarshalFrameSystemJSON(fs)
+	if err != nil {
+		return "", err
+	}
+	inputsData, err := json.Marshal(inputMapToFloats(fixedInputs))
+	if err != nil {
+		return "", err
+	}
+	constraints := planOpts.Constraints()
+	sort.Strings(constraints)
+	optsData, err := json.Marshal(struct {
+		Constraints []string               `json:"constraints"`
+		Resolution  float64                `json:"resolution"`
+		Extra       map[string]interface{} `json:"extra"`
+	}{constraints, planOpts.Resolution, planOpts.extra})
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	for _, data := range [][]byte{fsData, []byte(solveFrame), inputsData, optsData} {
+		hash.Write(data)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// RoadmapCache stores Roadmaps by key, both in memory and as files within a directory so that they persist across restarts.
+type RoadmapCache struct {
+	mu       sync.Mutex
+	dir      string
+	roadmaps map[string]*Roadmap
+}
+
+// NewRoadmapCache creates a RoadmapCache which persists roadmaps in the given directory. If the directory is empty,
+// roadmaps are only cached in memory.
+func NewRoadmapCache(dir string) *RoadmapCache {
+	return &RoadmapCache{dir: dir, roadmaps: map[string]*Roadmap{}}
+}
+
+// Roadmap returns the roadmap stored for the given key, or nil if there is none.
+func (c *RoadmapCache) Roadmap(key string) (*Roadmap, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if rm, ok := c.roadmaps[key]; ok {
+		return rm, nil
+	}
+	if c.dir == "" {
+		return nil, nil
+	}
+	rm, err := ReadRoadmapFile(c.filename(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.roadmaps[key] = rm
+	return rm, nil
+}
+
+// Store saves the roadmap for the given key.
+func (c *RoadmapCache) Store(key string, rm *Roadmap) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.roadmaps[key] = rm
+	if c.dir == "" {
+		return nil
+	}
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return err
+	}
+	return WriteRoadmapFile(rm, c.filename(key))
+}
+
+func (c *RoadmapCache) filename(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
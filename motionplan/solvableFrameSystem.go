@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/rand"
 	"runtime"
 
 	"github.com/edaniels/golog"
@@ -22,11 +23,17 @@ type SolvableFrameSystem struct {
 	logger golog.Logger
 	// TODO(rb): this probably shouldn't live here
 	mpFunc plannerConstructor
+
+	// nCPU and randomSeed are the number of threads and the random seed with which planners are created
+	nCPU       int
+	randomSeed int64
+	recorder   func(*PlanRecord)
+	roadmaps   *RoadmapCache
 }
 
 // NewSolvableFrameSystem will create a new solver for a frame system.
 func NewSolvableFrameSystem(fs frame.FrameSystem, logger golog.Logger) *SolvableFrameSystem {
-	return &SolvableFrameSystem{FrameSystem: fs, logger: logger}
+	return &SolvableFrameSystem{FrameSystem: fs, logger: logger, nCPU: runtime.NumCPU() / 2, randomSeed: 1}
 }
 
 // SolvePose will take a set of starting positions, a goal frame, a frame to solve for, and a pose. The function will
@@ -52,16 +59,50 @@ func (fss *SolvableFrameSystem) SolveWaypointsWithOptions(ctx context.Context,
 	worldState *commonpb.WorldState,
 	motionConfigs []map[string]interface{},
 ) ([]map[string][]frame.Input, error) {
+	steps, settings, err := fss.solveWaypoints(ctx, seedMap, goals, solveFrameName, worldState, motionConfigs)
+	if fss.recorder != nil {
+		record := &PlanRecord{
+			Request: &PlanRequest{
+				FrameSystem:     fss.FrameSystem,
+				SolveFrame:      solveFrameName,
+				Seed:            seedMap,
+				Goals:           goals,
+				WorldState:      worldState,
+				MotionConfigs:   motionConfigs,
+				RandomSeed:      fss.randomSeed,
+				NCPU:            fss.nCPU,
+				PlannerSettings: settings,
+			},
+			Plan: steps,
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+		fss.recorder(record)
+	}
+	return steps, err
+}
+
+// solveWaypoints solves for each of the goals in turn, as SolveWaypointsWithOptions does, and also returns the settings
+// of the planner used for each goal which was planned for.
+func (fss *SolvableFrameSystem) solveWaypoints(ctx context.Context,
+	seedMap map[string][]frame.Input,
+	goals []*frame.PoseInFrame,
+	solveFrameName string,
+	worldState *commonpb.WorldState,
+	motionConfigs []map[string]interface{},
+) ([]map[string][]frame.Input, []PlannerSettings, error) {
 	steps := make([]map[string][]frame.Input, 0, len(goals)*2)
+	settings := make([]PlannerSettings, 0, len(goals))
 
 	// Get parentage of solver frame. This will also verify the frame is in the frame system
 	solveFrame := fss.Frame(solveFrameName)
 	if solveFrame == nil {
-		return nil, fmt.Errorf("frame with name %s not found in frame system", solveFrameName)
+		return nil, nil, fmt.Errorf("frame with name %s not found in frame system", solveFrameName)
 	}
 	solveFrameList, err := fss.TracebackFrame(solveFrame)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	opts := make([]map[string]interface{}, 0, len(goals))
@@ -79,7 +120,7 @@ func (fss *SolvableFrameSystem) SolveWaypointsWithOptions(ctx context.Context,
 				opts = append(opts, motionConfigs[0])
 			}
 		default:
-			return nil, errors.New("goals and motion configs had different lengths")
+			return nil, nil, errors.New("goals and motion configs had different lengths")
 		}
 	} else {
 		opts = motionConfigs
@@ -91,15 +132,18 @@ func (fss *SolvableFrameSystem) SolveWaypointsWithOptions(ctx context.Context,
 		// Create a frame to solve for, and an IK solver with that frame.
 		sf, err := newSolverFrame(fss, solveFrameList, goal.FrameName(), seedMap)
 		if err != nil {
-			return nil, err
+			return nil, settings, err
 		}
 		if len(sf.DoF()) == 0 {
-			return nil, errors.New("solver frame has no degrees of freedom, cannot perform inverse kinematics")
+			return nil, settings, errors.New("solver frame has no degrees of freedom, cannot perform inverse kinematics")
 		}
 
-		resultSlices, err := sf.planSingleWaypoint(ctx, seedMap, goal.Pose(), worldState, opts[i])
+		resultSlices, goalSettings, err := sf.planSingleWaypoint(ctx, seedMap, goal.Pose(), worldState, opts[i])
+		if goalSettings != nil {
+			settings = append(settings, *goalSettings)
+		}
 		if err != nil {
-			return nil, err
+			return nil, settings, err
 		}
 		for j, resultSlice := range resultSlices {
 			stepMap := sf.sliceToMap(resultSlice)
@@ -111,7 +155,7 @@ func (fss *SolvableFrameSystem) SolveWaypointsWithOptions(ctx context.Context,
 		}
 	}
 
-	return steps, nil
+	return steps, settings, nil
 }
 
 // SetPlannerGen sets the function which is used to create the motion planner to solve a requested plan.
@@ -121,6 +165,82 @@ func (fss *SolvableFrameSystem) SetPlannerGen(mpFunc func(frame.Frame, int, golo
 	fss.mpFunc = mpFunc
 }
 
+// SetPlanRecorder sets a function which is called with a record of every plan solved by SolveWaypointsWithOptions,
+// including those which failed, such as to save them to be replayed later.
+func (fss *SolvableFrameSystem) SetPlanRecorder(recorder func(*PlanRecord)) {
+	fss.recorder = recorder
+}
+
+// SetRoadmapCache sets a cache from which the PRM planner reuses roadmaps across plans for the same frame and constraints.
+// A reused roadmap is checked again only where obstacles have changed since it was last used. Roadmaps are only cached
+// for plans whose constraints do not depend on their start or goal, which are those with a free motion profile or none.
+func (fss *SolvableFrameSystem) SetRoadmapCache(cache *RoadmapCache) {
+	fss.roadmaps = cache
+}
+
+// cachedRoadmap gives the PRM planner the roadmap cached for the solver frame and planner options, if any, after
+// invalidating it wherever the obstacles of the world state differ from those it was last checked against. It returns
+// the key to store the planner's roadmap by, and the obstacles in the world frame to record on it. The key is empty if
+// the plan cannot use a cached roadmap.
+func (fss *SolvableFrameSystem) cachedRoadmap(
+	mp *prmMotionPlanner,
+	sf *solverFrame,
+	worldState *commonpb.WorldState,
+	seedMap map[string][]frame.Input,
+	motionConfig map[string]interface{},
+	planOpts *PlannerOptions,
+) (string, []spatial.Geometry, error) {
+	if fss.roadmaps == nil {
+		return "", nil, nil
+	}
+	if profile, ok := motionConfig["motion_profile"]; ok && profile != FreeMotionProfile {
+		return "", nil, nil
+	}
+	key, err := RoadmapKey(fss.FrameSystem, sf.Name(), sf.origSeed, planOpts)
+	if err != nil {
+		return "", nil, err
+	}
+	obstacles := []spatial.Geometry{}
+	for _, gfs := range [][]*commonpb.GeometriesInFrame{worldState.GetObstacles(), worldState.GetInteractionSpaces()} {
+		geometries, err := geometriesInWorldFrame(fss, gfs, seedMap)
+		if err != nil {
+			return "", nil, err
+		}
+		for _, g := range geometries.Geometries() {
+			obstacles = append(obstacles, g)
+		}
+	}
+	roadmap, err := fss.roadmaps.Roadmap(key)
+	if err != nil {
+		return "", nil, err
+	}
+	if roadmap != nil {
+		if err := roadmap.compatible(sf); err != nil {
+			return "", nil, err
+		}
+		if err := roadmap.updateObstacles(obstacles); err != nil {
+			return "", nil, err
+		}
+		mp.roadmap = roadmap
+	}
+	return key, obstacles, nil
+}
+
+// newPlanner creates the planner chosen by the motion config, or by SetPlannerGen if it has been set, and returns its name.
+func (fss *SolvableFrameSystem) newPlanner(f frame.Frame, motionConfig map[string]interface{}) (MotionPlanner, string, error) {
+	if fss.mpFunc != nil {
+		planner, err := fss.mpFunc(f, fss.nCPU, fss.logger)
+		return planner, customPlanner, err
+	}
+	name, err := plannerNameFromMotionConfig(motionConfig)
+	if err != nil {
+		return nil, "", err
+	}
+	//nolint:gosec
+	planner, err := seededPlannerConstructors[name](f, fss.nCPU, rand.New(rand.NewSource(fss.randomSeed)), fss.logger)
+	return planner, name, err
+}
+
 // solverFrames are meant to be ephemerally created each time a frame system solution is created, and fulfills the
 // Frame interface so that it can be passed to inverse kinematics.
 type solverFrame struct {
@@ -229,32 +349,27 @@ func (sf *solverFrame) planSingleWaypoint(ctx context.Context,
 	goalPos spatial.Pose,
 	worldState *commonpb.WorldState,
 	motionConfig map[string]interface{},
-) ([][]frame.Input, error) {
+) ([][]frame.Input, *PlannerSettings, error) {
 	seed, err := sf.mapToSlice(seedMap)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	seedPos, err := sf.Transform(seed)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Build planner
-	var planner MotionPlanner
-	if sf.completeFs.mpFunc != nil {
-		planner, err = sf.completeFs.mpFunc(sf, runtime.NumCPU()/2, sf.completeFs.logger)
-	} else {
-		planner, err = NewCBiRRTMotionPlanner(sf, runtime.NumCPU()/2, sf.completeFs.logger)
-	}
+	planner, plannerName, err := sf.completeFs.newPlanner(sf, motionConfig)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// If we are world rooted, translate the goal pose into the world frame
 	if sf.worldRooted {
 		tf, err := sf.completeFs.Transform(seedMap, frame.NewPoseInFrame(sf.goalFrame.Name(), goalPos), frame.World)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		goalPos = tf.(*frame.PoseInFrame).Pose()
 	}
@@ -278,7 +393,7 @@ func (sf *solverFrame) planSingleWaypoint(ctx context.Context,
 			goals = append(goals, to)
 			opt, err := plannerSetupFromMoveRequest(from, to, sf, sf.completeFs, seedMap, worldState, motionConfig)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			opts = append(opts, opt)
 
@@ -287,22 +402,41 @@ func (sf *solverFrame) planSingleWaypoint(ctx context.Context,
 		goals = append(goals, goalPos)
 		opt, err := plannerSetupFromMoveRequest(from, goalPos, sf, sf.completeFs, seedMap, worldState, motionConfig)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		opts = append(opts, opt)
 	} else {
 		opt, err := plannerSetupFromMoveRequest(seedPos, goalPos, sf, sf.completeFs, seedMap, worldState, motionConfig)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		opts = append(opts, opt)
 	}
 
+	roadmapKey := ""
+	var roadmapObstacles []spatial.Geometry
+	if prm, ok := planner.(*prmMotionPlanner); ok {
+		roadmapKey, roadmapObstacles, err = sf.completeFs.cachedRoadmap(prm, sf, worldState, seedMap, motionConfig, opts[0])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// every waypoint of a goal is planned for with the same settings, differing only in where they are constrained to
+	settings := newPlannerSettings(plannerName, opts[0])
 	resultSlices, err := runPlannerWithWaypoints(ctx, planner, goals, seed, opts, 0)
+	if roadmapKey != "" {
+		if roadmap := planner.(*prmMotionPlanner).Roadmap(); roadmap != nil {
+			roadmap.setObstacles(roadmapObstacles)
+			if storeErr := sf.completeFs.roadmaps.Store(roadmapKey, roadmap); storeErr != nil {
+				sf.completeFs.logger.Warnw("failed to cache roadmap", "error", storeErr)
+			}
+		}
+	}
 	if err != nil {
-		return nil, err
+		return nil, settings, err
 	}
-	return resultSlices, nil
+	return resultSlices, settings, nil
 }
 
 // Name returns the name of the solver referenceframe.
@@ -0,0 +1,282 @@
+package motionplan
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+
+	frame "go.viam.com/rdk/referenceframe"
+)
+
+const (
+	// Maximum number of times segment durations will be stretched while searching for a trajectory within limits.
+	defaultTrajectoryIterations = 200
+
+	// Shortest allowable duration of a trajectory segment, in seconds.
+	minSegmentDuration = 1e-3
+
+	// Velocity, acceleration and jerk may exceed their limits by this proportion due to floating point error.
+	trajectoryLimitTolerance = 1e-6
+)
+
+var errTrajectoryLimits = errors.New("could not find a trajectory satisfying the dynamic limits")
+
+// TrajectoryPoint is the state of every degree of freedom of a frame at a single instant along a Trajectory.
+type TrajectoryPoint struct {
+	Time          time.Duration
+	Positions     []frame.Input
+	Velocities    []float64
+	Accelerations []float64
+}
+
+// TrajectoryFollower is implemented by components which are able to execute a timed trajectory natively, rather than
+// stepping through each waypoint in turn.
+type TrajectoryFollower interface {
+	FollowTrajectory(ctx context.Context, traj *Trajectory) error
+}
+
+// Trajectory is a time-parameterized path through joint space. Each degree of freedom follows a piecewise cubic
+// polynomial through the waypoints of the path, starting and ending at rest, with velocity and acceleration continuous
+// at every waypoint.
+type Trajectory struct {
+	waypoints [][]frame.Input
+	// knots[i] is the time in seconds at which waypoints[i] is reached
+	knots []float64
+	// coefficients[segment][dof] are the polynomial coefficients of the segment, in order of increasing power
+	coefficients [][][4]float64
+}
+
+// TimeParameterize assigns timing to a path of waypoints such that, for every degree of freedom, the velocity,
+// acceleration and jerk limits given are respected. Limits must be given in the same order as the inputs of each waypoint.
+// Consecutive duplicate waypoints are removed.
+func TimeParameterize(path [][]frame.Input, limits []frame.DynamicLimit) (*Trajectory, error) {
+	if len(path) == 0 {
+		return nil, errors.New("cannot time-parameterize an empty path")
+	}
+	for _, step := range path {
+		if len(step) != len(limits) {
+			return nil, frame.NewIncorrectInputLengthError(len(step), len(limits))
+		}
+	}
+
+	waypoints := [][]frame.Input{path[0]}
+	for _, step := range path[1:] {
+		if maxJointDelta(waypoints[len(waypoints)-1], step) > 0 {
+			waypoints = append(waypoints, step)
+		}
+	}
+	traj := &Trajectory{waypoints: waypoints}
+	if len(waypoints) == 1 {
+		traj.knots = []float64{0}
+		return traj, nil
+	}
+
+	// Seed each segment with the time needed to move the slowest joint at its max velocity, then stretch any
+	// segments which violate a limit until all are satisfied.
+	durations := make([]float64, len(waypoints)-1)
+	for i := range durations {
+		durations[i] = minSegmentDuration
+		for j, limit := range limits {
+			if limit.MaxVelocity > 0 {
+				delta := math.Abs(waypoints[i+1][j].Value - waypoints[i][j].Value)
+				durations[i] = math.Max(durations[i], delta/limit.MaxVelocity)
+			}
+		}
+	}
+
+	for iter := 0; iter < defaultTrajectoryIterations; iter++ {
+		traj.fitSpline(durations)
+		satisfied := true
+		for i, segment := range traj.coefficients {
+			scale := 1.
+			for j, coeffs := range segment {
+				scale = math.Max(scale, segmentLimitRatio(coeffs, durations[i], limits[j]))
+			}
+			if scale > 1+trajectoryLimitTolerance {
+				satisfied = false
+				durations[i] *= scale
+			}
+		}
+		if satisfied {
+			return traj, nil
+		}
+	}
+	return nil, errTrajectoryLimits
+}
+
+// Duration returns the total time needed to execute the trajectory.
+func (traj *Trajectory) Duration() time.Duration {
+	return secondsToDuration(traj.knots[len(traj.knots)-1])
+}
+
+// Waypoints returns the waypoints which the trajectory passes through, in order.
+func (traj *Trajectory) Waypoints() [][]frame.Input {
+	return traj.waypoints
+}
+
+// Sample returns the state of the trajectory at the given time. Times outside of the trajectory are clamped to its start
+// or end.
+func (traj *Trajectory) Sample(t time.Duration) TrajectoryPoint {
+	secs := math.Max(0, math.Min(t.Seconds(), traj.knots[len(traj.knots)-1]))
+	dof := len(traj.waypoints[0])
+	point := TrajectoryPoint{
+		Time:          secondsToDuration(secs),
+		Positions:     make([]frame.Input, dof),
+		Velocities:    make([]float64, dof),
+		Accelerations: make([]float64, dof),
+	}
+	if len(traj.coefficients) == 0 {
+		copy(point.Positions, traj.waypoints[0])
+		return point
+	}
+
+	segment := 0
+	for segment < len(traj.coefficients)-1 && secs >= traj.knots[segment+1] {
+		segment++
+	}
+	dt := secs - traj.knots[segment]
+	for j, c := range traj.coefficients[segment] {
+		point.Positions[j] = frame.Input{c[0] + dt*(c[1]+dt*(c[2]+dt*c[3]))}
+		point.Velocities[j] = c[1] + dt*(2*c[2]+dt*3*c[3])
+		point.Accelerations[j] = 2*c[2] + dt*6*c[3]
+	}
+	return point
+}
+
+// Discretize samples the trajectory every step, always including both its start and end.
+func (traj *Trajectory) Discretize(step time.Duration) []TrajectoryPoint {
+	duration := traj.Duration()
+	if step <= 0 || duration == 0 {
+		return []TrajectoryPoint{traj.Sample(0), traj.Sample(duration)}
+	}
+	points := make([]TrajectoryPoint, 0, int(duration/step)+2)
+	for t := time.Duration(0); t < duration; t += step {
+		points = append(points, traj.Sample(t))
+	}
+	return append(points, traj.Sample(duration))
+}
+
+// fitSpline computes, for each degree of freedom, the cubic spline through the waypoints with the given segment
+// durations which has zero velocity at its ends and continuous velocity and acceleration at each waypoint.
+func (traj *Trajectory) fitSpline(durations []float64) {
+	n := len(durations)
+	traj.knots = make([]float64, n+1)
+	for i, h := range durations {
+		traj.knots[i+1] = traj.knots[i] + h
+	}
+	traj.coefficients = make([][][4]float64, n)
+	for i := range traj.coefficients {
+		traj.coefficients[i] = make([][4]float64, len(traj.waypoints[0]))
+	}
+
+	q := make([]float64, n+1)
+	for j := range traj.waypoints[0] {
+		for i, step := range traj.waypoints {
+			q[i] = step[j].Value
+		}
+		v := splineVelocities(q, durations)
+		for i, h := range durations {
+			dq := q[i+1] - q[i]
+			traj.coefficients[i][j] = [4]float64{
+				q[i],
+				v[i],
+				(3*dq/h - 2*v[i] - v[i+1]) / h,
+				(-2*dq/h + v[i] + v[i+1]) / (h * h),
+			}
+		}
+	}
+}
+
+// splineVelocities solves for the velocity at each knot of a clamped cubic spline through q, where durations[i] is the
+// time between q[i] and q[i+1]. The resulting tridiagonal system is solved with the Thomas algorithm.
+func splineVelocities(q, durations []float64) []float64 {
+	n := len(durations)
+	v := make([]float64, n+1)
+	if n < 2 {
+		return v
+	}
+
+	// Unknowns are the interior velocities v[1] through v[n-1]; v[0] and v[n] are zero.
+	m := n - 1
+	lower := make([]float64, m)
+	diag := make([]float64, m)
+	upper := make([]float64, m)
+	rhs := make([]float64, m)
+	for k := 0; k < m; k++ {
+		i := k + 1
+		hPrev, hNext := durations[i-1], durations[i]
+		lower[k] = hNext
+		diag[k] = 2 * (hPrev + hNext)
+		upper[k] = hPrev
+		rhs[k] = 3 * (hPrev/hNext*(q[i+1]-q[i]) + hNext/hPrev*(q[i]-q[i-1]))
+	}
+
+	for k := 1; k < m; k++ {
+		w := lower[k] / diag[k-1]
+		diag[k] -= w * upper[k-1]
+		rhs[k] -= w * rhs[k-1]
+	}
+	v[m] = rhs[m-1] / diag[m-1]
+	for k := m - 2; k >= 0; k-- {
+		v[k+1] = (rhs[k] - upper[k]*v[k+2]) / diag[k]
+	}
+	return v
+}
+
+// segmentLimitRatio returns the factor by which the duration of a cubic segment would need to be multiplied for its
+// velocity, acceleration and jerk to fall within the given limit. Values at or below 1 mean the limit is already met.
+func segmentLimitRatio(c [4]float64, duration float64, limit frame.DynamicLimit) float64 {
+	ratio := 0.
+	if limit.MaxVelocity > 0 {
+		velocity := func(t float64) float64 { return math.Abs(c[1] + t*(2*c[2]+t*3*c[3])) }
+		maxVel := math.Max(velocity(0), velocity(duration))
+		if c[3] != 0 {
+			// Velocity is quadratic, so it may peak within the segment
+			if peak := -c[2] / (3 * c[3]); peak > 0 && peak < duration {
+				maxVel = math.Max(maxVel, velocity(peak))
+			}
+		}
+		// Velocity scales inversely with duration
+		ratio = math.Max(ratio, maxVel/limit.MaxVelocity)
+	}
+	if limit.MaxAcceleration > 0 {
+		// Acceleration is linear, so it is largest at one of the ends of the segment
+		maxAcc := math.Max(math.Abs(2*c[2]), math.Abs(2*c[2]+6*c[3]*duration))
+		ratio = math.Max(ratio, math.Sqrt(maxAcc/limit.MaxAcceleration))
+	}
+	if limit.MaxJerk > 0 {
+		ratio = math.Max(ratio, math.Cbrt(math.Abs(6*c[3])/limit.MaxJerk))
+	}
+	return ratio
+}
+
+// FollowTrajectory will execute the given trajectory on the given component. If the component is a TrajectoryFollower
+// the trajectory is passed to it directly; otherwise each of the trajectory's waypoints is visited in turn.
+func FollowTrajectory(ctx context.Context, ie frame.InputEnabled, traj *Trajectory) error {
+	if follower, ok := ie.(TrajectoryFollower); ok {
+		return follower.FollowTrajectory(ctx, traj)
+	}
+	for _, waypoint := range traj.Waypoints() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := ie.GoToInputs(ctx, waypoint); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func maxJointDelta(from, to []frame.Input) float64 {
+	maxDelta := 0.
+	for i, f := range from {
+		maxDelta = math.Max(maxDelta, math.Abs(to[i].Value-f.Value))
+	}
+	return maxDelta
+}
+
+func secondsToDuration(secs float64) time.Duration {
+	return time.Duration(secs * float64(time.Second))
+}
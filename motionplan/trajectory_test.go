@@ -0,0 +1,74 @@
+package motionplan
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	frame "go.viam.com/rdk/referenceframe"
+)
+
+func TestTimeParameterize(t *testing.T) {
+	limits := []frame.DynamicLimit{
+		{MaxVelocity: 1, MaxAcceleration: 2, MaxJerk: 10},
+		{MaxVelocity: 0.5, MaxAcceleration: 1, MaxJerk: 5},
+	}
+	path := [][]frame.Input{
+		frame.FloatsToInputs([]float64{0, 0}),
+		frame.FloatsToInputs([]float64{0.5, 0.2}),
+		frame.FloatsToInputs([]float64{0.5, 0.2}),
+		frame.FloatsToInputs([]float64{1, 0.8}),
+		frame.FloatsToInputs([]float64{2, 0.6}),
+	}
+	traj, err := TimeParameterize(path, limits)
+	test.That(t, err, test.ShouldBeNil)
+	// duplicate waypoint is removed
+	test.That(t, len(traj.Waypoints()), test.ShouldEqual, 4)
+	test.That(t, traj.Duration(), test.ShouldBeGreaterThan, 2*time.Second)
+
+	// every waypoint is passed through, and the trajectory starts and ends at rest
+	for i, knot := range traj.knots {
+		point := traj.Sample(secondsToDuration(knot))
+		for j, input := range traj.Waypoints()[i] {
+			test.That(t, point.Positions[j].Value, test.ShouldAlmostEqual, input.Value, 1e-6)
+		}
+	}
+	for _, point := range []TrajectoryPoint{traj.Sample(0), traj.Sample(traj.Duration())} {
+		for _, v := range point.Velocities {
+			test.That(t, v, test.ShouldAlmostEqual, 0, 1e-6)
+		}
+	}
+
+	// limits are respected everywhere along the trajectory
+	points := traj.Discretize(time.Millisecond)
+	test.That(t, points[len(points)-1].Time, test.ShouldEqual, traj.Duration())
+	for i, point := range points {
+		for j, limit := range limits {
+			test.That(t, math.Abs(point.Velocities[j]), test.ShouldBeLessThanOrEqualTo, limit.MaxVelocity+1e-6)
+			test.That(t, math.Abs(point.Accelerations[j]), test.ShouldBeLessThanOrEqualTo, limit.MaxAcceleration+1e-6)
+		}
+		if i > 0 {
+			// velocity is continuous at every waypoint
+			for j := range limits {
+				test.That(t, math.Abs(point.Velocities[j]-points[i-1].Velocities[j]), test.ShouldBeLessThan, 0.01)
+			}
+			// acceleration is continuous too, so its rate of change between samples is within the jerk limit
+			if dt := (point.Time - points[i-1].Time).Seconds(); dt > 1e-6 {
+				for j, limit := range limits {
+					jerk := (point.Accelerations[j] - points[i-1].Accelerations[j]) / dt
+					test.That(t, math.Abs(jerk), test.ShouldBeLessThanOrEqualTo, limit.MaxJerk+1e-3)
+				}
+			}
+		}
+	}
+
+	_, err = TimeParameterize(path, limits[:1])
+	test.That(t, err, test.ShouldNotBeNil)
+
+	traj, err = TimeParameterize(path[:1], limits)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, traj.Duration(), test.ShouldEqual, 0)
+	test.That(t, len(traj.Discretize(time.Millisecond)), test.ShouldEqual, 2)
+}
@@ -29,6 +29,14 @@ type Limit struct {
 	Max float64
 }
 
+// DynamicLimit represents the maximum velocity, acceleration and jerk of a single degree of freedom. Units are radians or
+// mm per second, per second squared, and per second cubed respectively. A value of zero means the quantity is unlimited.
+type DynamicLimit struct {
+	MaxVelocity     float64
+	MaxAcceleration float64
+	MaxJerk         float64
+}
+
 func limitsAlmostEqual(a, b []Limit) bool {
 	if len(a) != len(b) {
 		return false
@@ -253,6 +261,9 @@ func (sf *staticFrame) MarshalJSON() ([]byte, error) {
 		"name":      sf.name,
 		"transform": transform,
 	}
+	if sf.geometryCreator != nil {
+		m["geometry"] = sf.geometryCreator
+	}
 	return json.Marshal(m)
 }
 
@@ -335,6 +346,9 @@ func (pf *translationalFrame) MarshalJSON() ([]byte, error) {
 		"transAxis": pf.transAxis,
 		"limit":     pf.limits,
 	}
+	if pf.geometryCreator != nil {
+		m["geometry"] = pf.geometryCreator
+	}
 	return json.Marshal(m)
 }
 
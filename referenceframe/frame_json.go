@@ -32,6 +32,11 @@ func (config FrameMapConfig) ParseConfig() (Frame, error) {
 		return nil, utils.NewUnexpectedTypeError(name, config["name"])
 	}
 
+	geometryCreator, err := config.parseGeometry()
+	if err != nil {
+		return nil, err
+	}
+
 	switch config["type"] {
 	case "static":
 		pose, ok := config["transform"].(map[string]interface{})
@@ -42,10 +47,10 @@ func (config FrameMapConfig) ParseConfig() (Frame, error) {
 		if err != nil {
 			return nil, fmt.Errorf("error decoding transform (%v) %w", config["transform"], err)
 		}
-		return NewStaticFrame(name, transform)
+		return NewStaticFrameWithGeometry(name, transform, geometryCreator)
 	case "translational":
 		var transAxis r3.Vector
-		err := mapstructure.Decode(config["transAxis"], &transAxis)
+		err = mapstructure.Decode(config["transAxis"], &transAxis)
 		if err != nil {
 			return nil, err
 		}
@@ -54,7 +59,7 @@ func (config FrameMapConfig) ParseConfig() (Frame, error) {
 		if err != nil {
 			return nil, err
 		}
-		return NewTranslationalFrame(name, transAxis, limit[0])
+		return NewTranslationalFrameWithGeometry(name, transAxis, limit[0], geometryCreator)
 	case "rotational":
 		rotAxis, ok := config["rotAxis"].(map[string]interface{})
 		if !ok {
@@ -74,7 +79,7 @@ func (config FrameMapConfig) ParseConfig() (Frame, error) {
 			return nil, utils.NewUnexpectedTypeError(axis.RZ, rotAxis["Z"])
 		}
 		var limit []Limit
-		err := mapstructure.Decode(config["limit"], &limit)
+		err = mapstructure.Decode(config["limit"], &limit)
 		if err != nil {
 			return nil, err
 		}
@@ -84,6 +89,23 @@ func (config FrameMapConfig) ParseConfig() (Frame, error) {
 	}
 }
 
+// parseGeometry returns the GeometryCreator described by the optional "geometry" field of the config, or nil if there is none.
+func (config FrameMapConfig) parseGeometry() (spatial.GeometryCreator, error) {
+	geometry, ok := config["geometry"]
+	if !ok || geometry == nil {
+		return nil, nil
+	}
+	jsonValue, err := json.Marshal(geometry)
+	if err != nil {
+		return nil, err
+	}
+	var geometryConfig spatial.GeometryConfig
+	if err := json.Unmarshal(jsonValue, &geometryConfig); err != nil {
+		return nil, err
+	}
+	return geometryConfig.ParseConfig()
+}
+
 func decodePose(config FrameMapConfig) (spatial.Pose, error) {
 	var point r3.Vector
 
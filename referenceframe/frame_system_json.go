@@ -0,0 +1,88 @@
+package referenceframe
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// frameSystemJSON is the serialized form of a FrameSystem, with frames listed so that every parent precedes its children.
+type frameSystemJSON struct {
+	Name   string                `json:"name"`
+	Frames []frameWithParentJSON `json:"frames"`
+}
+
+type frameWithParentJSON struct {
+	Parent string          `json:"parent"`
+	Frame  json.RawMessage `json:"frame"`
+}
+
+// MarshalFrameSystemJSON serializes every frame in a FrameSystem along with the name of its parent. Frames are serialized
+// using their own MarshalJSON methods, so the FrameSystem may only contain frames which support serialization.
+func MarshalFrameSystemJSON(fs FrameSystem) ([]byte, error) {
+	depths := map[string]int{}
+	names := fs.FrameNames()
+	for _, name := range names {
+		frames, err := fs.TracebackFrame(fs.Frame(name))
+		if err != nil {
+			return nil, err
+		}
+		depths[name] = len(frames)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if depths[names[i]] != depths[names[j]] {
+			return depths[names[i]] < depths[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	fsJSON := frameSystemJSON{Name: fs.Name(), Frames: make([]frameWithParentJSON, 0, len(names))}
+	for _, name := range names {
+		frame := fs.Frame(name)
+		parent, err := fs.Parent(frame)
+		if err != nil {
+			return nil, err
+		}
+		data, err := frame.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot serialize frame %q", name)
+		}
+		fsJSON.Frames = append(fsJSON.Frames, frameWithParentJSON{Parent: parent.Name(), Frame: data})
+	}
+	return json.Marshal(fsJSON)
+}
+
+// UnmarshalFrameSystemJSON deserializes a FrameSystem written by MarshalFrameSystemJSON. Frames serialized by a Model are
+// restored as models, and all other frames are restored with UnmarshalFrameJSON.
+func UnmarshalFrameSystemJSON(data []byte) (FrameSystem, error) {
+	var fsJSON frameSystemJSON
+	if err := json.Unmarshal(data, &fsJSON); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal frame system json")
+	}
+	fs := NewEmptySimpleFrameSystem(fsJSON.Name)
+	for _, entry := range fsJSON.Frames {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.Frame, &fields); err != nil {
+			return nil, err
+		}
+		var frame Frame
+		var err error
+		if _, ok := fields["kinematic_param_type"]; ok {
+			frame, err = UnmarshalModelJSON(entry.Frame, "")
+		} else {
+			frame, err = UnmarshalFrameJSON(entry.Frame)
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := fs.Frame(entry.Parent)
+		if parent == nil {
+			return nil, NewParentFrameMissingError()
+		}
+		if err := fs.AddFrame(frame, parent); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
@@ -0,0 +1,67 @@
+package referenceframe
+
+import (
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+func TestFrameSystemSerialization(t *testing.T) {
+	fs := NewEmptySimpleFrameSystem("test")
+	bc, err := spatial.NewBoxCreator(r3.Vector{10, 20, 30}, spatial.NewZeroPose())
+	test.That(t, err, test.ShouldBeNil)
+	table, err := NewStaticFrameWithGeometry("table", spatial.NewPoseFromPoint(r3.Vector{0, 0, 500}), bc)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(table, fs.World()), test.ShouldBeNil)
+	gantry, err := NewTranslationalFrameWithGeometry("gantry", r3.Vector{1, 0, 0}, Limit{-100, 100}, bc)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gantry, table), test.ShouldBeNil)
+	arm, err := ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "arm")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(arm, gantry), test.ShouldBeNil)
+
+	data, err := MarshalFrameSystemJSON(fs)
+	test.That(t, err, test.ShouldBeNil)
+	fs2, err := UnmarshalFrameSystemJSON(data)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs2.Name(), test.ShouldEqual, fs.Name())
+	test.That(t, fs2.FrameNames(), test.ShouldHaveLength, len(fs.FrameNames()))
+
+	for _, name := range fs.FrameNames() {
+		f2 := fs2.Frame(name)
+		test.That(t, f2, test.ShouldNotBeNil)
+		test.That(t, fs.Frame(name).AlmostEquals(f2), test.ShouldBeTrue)
+		parent, err := fs.Parent(fs.Frame(name))
+		test.That(t, err, test.ShouldBeNil)
+		parent2, err := fs2.Parent(f2)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, parent2.Name(), test.ShouldEqual, parent.Name())
+	}
+
+	// geometries and transforms through the whole system must survive serialization
+	inputs := StartPositions(fs)
+	inputs["gantry"] = FloatsToInputs([]float64{50})
+	inputs["arm"] = FloatsToInputs([]float64{0.1, 0.2, 0.3, 0.4, 0.5, 0.6})
+	geometries, err := table.Geometries([]Input{})
+	test.That(t, err, test.ShouldBeNil)
+	geometries2, err := fs2.Frame("table").Geometries([]Input{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, geometries2.Geometries()["table"].AlmostEqual(geometries.Geometries()["table"]), test.ShouldBeTrue)
+
+	armPose, err := fs.Transform(inputs, NewPoseInFrame("arm", spatial.NewZeroPose()), World)
+	test.That(t, err, test.ShouldBeNil)
+	armPose2, err := fs2.Transform(inputs, NewPoseInFrame("arm", spatial.NewZeroPose()), World)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.PoseAlmostEqual(
+		armPose.(*PoseInFrame).Pose(),
+		armPose2.(*PoseInFrame).Pose(),
+	), test.ShouldBeTrue)
+
+	// frames whose parents are missing cannot be deserialized
+	_, err = UnmarshalFrameSystemJSON([]byte(`{"name": "bad", "frames": [{"parent": "missing", "frame": {"type": "static"}}]}`))
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,159 @@
+package referenceframe
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	"gonum.org/v1/gonum/mat"
+	"gonum.org/v1/gonum/num/quat"
+
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+// Step used to numerically differentiate the pose of a frame with respect to its inputs.
+const jacobianStep = 1e-6
+
+// Jacobian returns the 6xN geometric Jacobian of a frame at the given inputs, relating the rates of change of its N inputs to
+// the velocity of the end of the frame in the frame's parent. The first three rows give the linear velocity in mm per unit
+// of input, and the last three the angular velocity in radians per unit of input. Since it is computed numerically from
+// Transform, it applies to any frame, including models built from JSON or DH parameters.
+func Jacobian(f Frame, inputs []Input) (*mat.Dense, error) {
+	limits := f.DoF()
+	if len(inputs) != len(limits) {
+		return nil, NewIncorrectInputLengthError(len(inputs), len(limits))
+	}
+	if len(inputs) == 0 {
+		return nil, errors.New("cannot compute the jacobian of a frame with no degrees of freedom")
+	}
+	jac := mat.NewDense(6, len(inputs), nil)
+	for i := range inputs {
+		// use central differences, except at limits where only one side of the input is valid
+		lower := math.Max(inputs[i].Value-jacobianStep, limits[i].Min)
+		upper := math.Min(inputs[i].Value+jacobianStep, limits[i].Max)
+		if upper <= lower {
+			continue
+		}
+		from := make([]Input, len(inputs))
+		copy(from, inputs)
+		from[i].Value = lower
+		to := make([]Input, len(inputs))
+		copy(to, inputs)
+		to[i].Value = upper
+
+		fromPose, err := f.Transform(from)
+		if err != nil {
+			return nil, err
+		}
+		toPose, err := f.Transform(to)
+		if err != nil {
+			return nil, err
+		}
+		scale := 1 / (upper - lower)
+		linear := toPose.Point().Sub(fromPose.Point()).Mul(scale)
+		// the rotation between the two poses, expressed in the parent frame. It is too small for QuatToR3AA, but for small
+		// angles the imaginary part of its quaternion is half of its axis angle representation.
+		q := spatial.Compose(toPose, spatial.PoseInverse(fromPose)).Orientation().Quaternion()
+		if q.Real < 0 {
+			q = quat.Scale(-1, q)
+		}
+		angular := r3.Vector{X: q.Imag, Y: q.Jmag, Z: q.Kmag}.Mul(2 * scale)
+		jac.SetCol(i, []float64{linear.X, linear.Y, linear.Z, angular.X, angular.Y, angular.Z})
+	}
+	return jac, nil
+}
+
+// PositionJacobian returns the first three rows of a Jacobian, which relate the rates of change of the inputs of a frame to
+// the linear velocity of its end.
+func PositionJacobian(jacobian *mat.Dense) mat.Matrix {
+	_, c := jacobian.Dims()
+	return jacobian.Slice(0, 3, 0, c)
+}
+
+// Manipulability returns the manipulability measure of Yoshikawa for a Jacobian, which is the product of its singular values,
+// or equivalently sqrt(det(J * J^T)) when it has no more rows than columns. It is zero at singularities, where the end of the
+// frame cannot move in some direction, and grows with the ease of moving it in every direction. Since the rows of a full
+// Jacobian have different units, the measure of its PositionJacobian is often more meaningful.
+func Manipulability(jacobian mat.Matrix) (float64, error) {
+	values, err := singularValues(jacobian)
+	if err != nil {
+		return 0, err
+	}
+	product := 1.
+	for _, value := range values {
+		product *= value
+	}
+	return product, nil
+}
+
+// ConditionNumber returns the ratio of the largest to the smallest singular value of a Jacobian, which is 1 when the end of
+// the frame moves equally easily in all directions and grows without bound as it approaches a singularity.
+func ConditionNumber(jacobian mat.Matrix) (float64, error) {
+	values, err := singularValues(jacobian)
+	if err != nil {
+		return 0, err
+	}
+	smallest := values[len(values)-1]
+	if smallest == 0 {
+		return math.Inf(1), nil
+	}
+	return values[0] / smallest, nil
+}
+
+// IsSingular returns whether a Jacobian is within epsilon of losing rank, that is, whether its smallest singular value is less
+// than epsilon. At a singularity the end of the frame cannot move in some direction however its inputs change.
+func IsSingular(jacobian mat.Matrix, epsilon float64) (bool, error) {
+	values, err := singularValues(jacobian)
+	if err != nil {
+		return false, err
+	}
+	return values[len(values)-1] < epsilon, nil
+}
+
+// InputVelocities returns the rates of change of the inputs of a frame which best produce the given velocity of its end,
+// whose length must match the number of rows of the Jacobian. It uses damped least squares, so that the rates remain bounded
+// near singularities at the cost of accuracy there; a damping of zero gives the least squares solution.
+func InputVelocities(jacobian mat.Matrix, velocity []float64, damping float64) ([]float64, error) {
+	r, c := jacobian.Dims()
+	if len(velocity) != r {
+		return nil, errors.Errorf("velocity has %d elements but the jacobian has %d rows", len(velocity), r)
+	}
+	v := mat.NewVecDense(r, velocity)
+	var rates mat.VecDense
+	if r <= c {
+		// J^T * (J * J^T + damping^2 * I)^-1 * v
+		var x mat.VecDense
+		if err := x.SolveVec(dampedGram(jacobian, jacobian.T(), damping), v); err != nil {
+			return nil, errors.Wrap(err, "cannot solve for input velocities at a singularity without damping")
+		}
+		rates.MulVec(jacobian.T(), &x)
+	} else {
+		// (J^T * J + damping^2 * I)^-1 * J^T * v
+		var jtv mat.VecDense
+		jtv.MulVec(jacobian.T(), v)
+		if err := rates.SolveVec(dampedGram(jacobian.T(), jacobian, damping), &jtv); err != nil {
+			return nil, errors.Wrap(err, "cannot solve for input velocities at a singularity without damping")
+		}
+	}
+	return rates.RawVector().Data, nil
+}
+
+// dampedGram returns a * b + damping^2 * I, for a and b whose product is square.
+func dampedGram(a, b mat.Matrix, damping float64) *mat.Dense {
+	var gram mat.Dense
+	gram.Mul(a, b)
+	n, _ := gram.Dims()
+	for i := 0; i < n; i++ {
+		gram.Set(i, i, gram.At(i, i)+damping*damping)
+	}
+	return &gram
+}
+
+// singularValues returns the singular values of a matrix in descending order.
+func singularValues(m mat.Matrix) ([]float64, error) {
+	var svd mat.SVD
+	if !svd.Factorize(m, mat.SVDNone) {
+		return nil, errors.New("could not factorize jacobian")
+	}
+	return svd.Values(nil), nil
+}
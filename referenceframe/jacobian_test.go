@@ -0,0 +1,124 @@
+package referenceframe
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"go.viam.com/test"
+	"gonum.org/v1/gonum/mat"
+
+	"go.viam.com/rdk/utils"
+)
+
+// a planar arm with links of 100 and 50 mm.
+var planarArmJSON = []byte(`{
+	"name": "planar",
+	"links": [
+		{"id": "base", "parent": "world", "translation": {"x": 0, "y": 0, "z": 0}},
+		{"id": "link1", "parent": "joint1", "translation": {"x": 100, "y": 0, "z": 0}},
+		{"id": "link2", "parent": "joint2", "translation": {"x": 50, "y": 0, "z": 0}}
+	],
+	"joints": [
+		{"id": "joint1", "type": "revolute", "parent": "base", "axis": {"x": 0, "y": 0, "z": 1}, "max": 180, "min": -180},
+		{"id": "joint2", "type": "revolute", "parent": "link1", "axis": {"x": 0, "y": 0, "z": 1}, "max": 180, "min": -180}
+	]
+}`)
+
+func TestJacobian(t *testing.T) {
+	planar, err := UnmarshalModelJSON(planarArmJSON, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	_, err = Jacobian(planar, FloatsToInputs([]float64{0}))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	q1, q2 := 0.4, 1.1
+	jac, err := Jacobian(planar, FloatsToInputs([]float64{q1, q2}))
+	test.That(t, err, test.ShouldBeNil)
+	expected := mat.NewDense(6, 2, []float64{
+		-100*math.Sin(q1) - 50*math.Sin(q1+q2), -50 * math.Sin(q1+q2),
+		100*math.Cos(q1) + 50*math.Cos(q1+q2), 50 * math.Cos(q1+q2),
+		0, 0,
+		0, 0,
+		0, 0,
+		1, 1,
+	})
+	test.That(t, mat.EqualApprox(jac, expected, 1e-4), test.ShouldBeTrue)
+
+	// inputs at their limits are differentiated from one side
+	jac, err = Jacobian(planar, FloatsToInputs([]float64{math.Pi, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, jac.At(5, 0), test.ShouldAlmostEqual, 1, 1e-4)
+	test.That(t, jac.At(1, 0), test.ShouldAlmostEqual, -150, 1e-2)
+}
+
+func TestManipulability(t *testing.T) {
+	planar, err := UnmarshalModelJSON(planarArmJSON, "")
+	test.That(t, err, test.ShouldBeNil)
+
+	// for a planar two link arm the manipulability is l1 * l2 * |sin(q2)|
+	for _, q2 := range []float64{0, 0.3, math.Pi / 2, 2, math.Pi} {
+		jac, err := Jacobian(planar, FloatsToInputs([]float64{0.4, q2}))
+		test.That(t, err, test.ShouldBeNil)
+		score, err := Manipulability(PositionJacobian(jac))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, score, test.ShouldAlmostEqual, 100*50*math.Abs(math.Sin(q2)), 1e-2)
+	}
+
+	// the arm is singular when fully extended or folded
+	for q2, singular := range map[float64]bool{0: true, math.Pi / 2: false, math.Pi: true} {
+		jac, err := Jacobian(planar, FloatsToInputs([]float64{0.4, q2}))
+		test.That(t, err, test.ShouldBeNil)
+		isSingular, err := IsSingular(PositionJacobian(jac), 1e-3)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, isSingular, test.ShouldEqual, singular)
+		condition, err := ConditionNumber(PositionJacobian(jac))
+		test.That(t, err, test.ShouldBeNil)
+		if singular {
+			test.That(t, condition, test.ShouldBeGreaterThan, 1e6)
+		} else {
+			test.That(t, condition, test.ShouldBeLessThan, 10)
+		}
+	}
+}
+
+func TestInputVelocities(t *testing.T) {
+	planar, err := UnmarshalModelJSON(planarArmJSON, "")
+	test.That(t, err, test.ShouldBeNil)
+	ur5e, err := ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e_DH.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+
+	// velocities which the frame can produce are produced exactly
+	//nolint:gosec
+	randseed := rand.New(rand.NewSource(1))
+	for _, model := range []Model{planar, ur5e} {
+		jac, err := Jacobian(model, RandomFrameInputs(model, randseed))
+		test.That(t, err, test.ShouldBeNil)
+		_, c := jac.Dims()
+		expected := make([]float64, c)
+		for i := range expected {
+			expected[i] = randseed.Float64() - 0.5
+		}
+		var velocity mat.VecDense
+		velocity.MulVec(jac, mat.NewVecDense(c, expected))
+
+		rates, err := InputVelocities(jac, velocity.RawVector().Data, 0)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, mat.EqualApprox(mat.NewVecDense(c, rates), mat.NewVecDense(c, expected), 1e-6), test.ShouldBeTrue)
+	}
+
+	// at a singularity only damped solutions exist
+	jac, err := Jacobian(planar, FloatsToInputs([]float64{0, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	velocity := []float64{10, 0, 0}
+	_, err = InputVelocities(PositionJacobian(jac), velocity, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+	rates, err := InputVelocities(PositionJacobian(jac), velocity, 0.1)
+	test.That(t, err, test.ShouldBeNil)
+	for _, rate := range rates {
+		test.That(t, math.IsNaN(rate) || math.IsInf(rate, 0), test.ShouldBeFalse)
+	}
+
+	_, err = InputVelocities(jac, velocity, 0)
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"math"
 	"math/rand"
+	"path/filepath"
 	"strings"
 	"sync"
 
+	"github.com/pkg/errors"
 	"go.uber.org/multierr"
 	pb "go.viam.com/api/component/arm/v1"
 
@@ -25,11 +27,18 @@ type Model interface {
 	ChangeName(string)
 }
 
+// DynamicLimiter is implemented by models which declare velocity, acceleration and jerk limits for their degrees of freedom.
+type DynamicLimiter interface {
+	DynamicLimits() []DynamicLimit
+}
+
 // SimpleModel TODO.
 type SimpleModel struct {
 	*baseFrame
 	// OrdTransforms is the list of transforms ordered from end effector to base
 	OrdTransforms []Frame
+	// dynamicLimits holds per-DoF velocity/acceleration/jerk limits, and is empty if the model did not declare them
+	dynamicLimits []DynamicLimit
 	poseCache     *sync.Map
 	lock          sync.RWMutex
 }
@@ -42,6 +51,21 @@ func NewSimpleModel(name string) *SimpleModel {
 	}
 }
 
+// ParseModelFile reads a kinematics model from a JSON or URDF file, chosen by the extension of the file name, which is
+// ".urdf" or ".xml" for URDF. endEffector names the link of a URDF file at which the model ends, and may be empty if the
+// URDF has only one end; it cannot be given for JSON files, whose models always have one.
+func ParseModelFile(filename, modelName, endEffector string) (Model, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".urdf", ".xml":
+		return ParseModelURDFFile(filename, modelName, endEffector)
+	default:
+		if endEffector != "" {
+			return nil, errors.Errorf("an end effector cannot be chosen for model file %q, only for urdf files", filename)
+		}
+		return ParseModelJSONFile(filename, modelName)
+	}
+}
+
 // GenerateRandomConfiguration generates a list of radian joint positions that are random but valid for each joint.
 func GenerateRandomConfiguration(m Model, randSeed *rand.Rand) []float64 {
 	limits := m.DoF()
@@ -159,6 +183,21 @@ func (m *SimpleModel) DoF() []Limit {
 	return limits
 }
 
+// DynamicLimits returns the velocity, acceleration and jerk limits of each degree of freedom in the model, in the same
+// order as DoF. If the model did not declare any dynamic limits, nil is returned.
+func (m *SimpleModel) DynamicLimits() []DynamicLimit {
+	return m.dynamicLimits
+}
+
+// SetDynamicLimits sets the velocity, acceleration and jerk limits of each degree of freedom in the model.
+func (m *SimpleModel) SetDynamicLimits(limits []DynamicLimit) error {
+	if len(limits) != len(m.DoF()) {
+		return NewIncorrectInputLengthError(len(limits), len(m.DoF()))
+	}
+	m.dynamicLimits = limits
+	return nil
+}
+
 // MarshalJSON serializes a Model.
 func (m *SimpleModel) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]interface{}{
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"math"
 	"os"
+	"path/filepath"
 
 	"github.com/golang/geo/r3"
 	"github.com/pkg/errors"
@@ -29,6 +30,7 @@ type ModelConfig struct {
 		Axis   spatial.AxisConfig `json:"axis"`
 		Max    float64            `json:"max"` // in mm or degs
 		Min    float64            `json:"min"` // in mm or degs
+		DynamicLimitConfig
 	} `json:"joints"`
 	DHParams []struct {
 		ID       string                 `json:"id"`
@@ -39,10 +41,32 @@ type ModelConfig struct {
 		Max      float64                `json:"max"` // in mm or degs
 		Min      float64                `json:"min"` // in mm or degs
 		Geometry spatial.GeometryConfig `json:"geometry"`
+		DynamicLimitConfig
 	} `json:"dhParams"`
 	RawFrames []FrameMapConfig `json:"frames"`
 }
 
+// DynamicLimitConfig holds the optional velocity, acceleration and jerk limits of a joint as declared in a kinematics
+// JSON file. Units are mm or degs per second, per second squared and per second cubed. Zero values mean unlimited.
+type DynamicLimitConfig struct {
+	MaxVelocity     float64 `json:"max_vel"`
+	MaxAcceleration float64 `json:"max_acc"`
+	MaxJerk         float64 `json:"max_jerk"`
+}
+
+// ParseConfig converts the DynamicLimitConfig into a DynamicLimit, converting degrees to radians for revolute joints.
+func (config DynamicLimitConfig) ParseConfig(revolute bool) DynamicLimit {
+	scale := 1.
+	if revolute {
+		scale = math.Pi / 180
+	}
+	return DynamicLimit{
+		MaxVelocity:     config.MaxVelocity * scale,
+		MaxAcceleration: config.MaxAcceleration * scale,
+		MaxJerk:         config.MaxJerk * scale,
+	}
+}
+
 // ParseConfig converts the ModelConfig struct into a full Model with the name modelName.
 func (config *ModelConfig) ParseConfig(modelName string) (Model, error) {
 	var err error
@@ -56,6 +80,10 @@ func (config *ModelConfig) ParseConfig(modelName string) (Model, error) {
 	// Make a map of parents for each element for post-process, to allow items to be processed out of order
 	parentMap := map[string]string{}
 
+	// Dynamic limits declared for each joint, to be ordered to match the model DoF once the transforms are ordered
+	dynamicLimits := map[string]DynamicLimit{}
+	hasDynamicLimits := false
+
 	switch config.KinParamType {
 	case "SVA", "":
 		for _, link := range config.Links {
@@ -90,6 +118,8 @@ func (config *ModelConfig) ParseConfig(modelName string) (Model, error) {
 		// Now we add all of the transforms. Will eventually support: "cylindrical|fixed|helical|prismatic|revolute|spherical"
 		for _, joint := range config.Joints {
 			parentMap[joint.ID] = joint.Parent
+			dynamicLimits[joint.ID] = joint.DynamicLimitConfig.ParseConfig(joint.Type == "revolute")
+			hasDynamicLimits = hasDynamicLimits || joint.DynamicLimitConfig != DynamicLimitConfig{}
 			switch joint.Type {
 			case "revolute":
 				transforms[joint.ID], err = NewRotationalFrame(joint.ID, joint.Axis.ParseConfig(),
@@ -109,6 +139,8 @@ func (config *ModelConfig) ParseConfig(modelName string) (Model, error) {
 			// Joint part of DH param
 			jointID := dh.ID + "_j"
 			parentMap[jointID] = dh.Parent
+			dynamicLimits[jointID] = dh.DynamicLimitConfig.ParseConfig(true)
+			hasDynamicLimits = hasDynamicLimits || dh.DynamicLimitConfig != DynamicLimitConfig{}
 			transforms[jointID], err = NewRotationalFrame(jointID, spatial.R4AA{RX: 0, RY: 0, RZ: 1},
 				Limit{Min: dh.Min * math.Pi / 180, Max: dh.Max * math.Pi / 180})
 			if err != nil {
@@ -191,6 +223,18 @@ func (config *ModelConfig) ParseConfig(modelName string) (Model, error) {
 		orderedTransforms[i], orderedTransforms[j] = orderedTransforms[j], orderedTransforms[i]
 	}
 	model.OrdTransforms = orderedTransforms
+
+	if hasDynamicLimits {
+		limits := make([]DynamicLimit, 0, len(orderedTransforms))
+		for _, transform := range orderedTransforms {
+			if len(transform.DoF()) > 0 {
+				limits = append(limits, dynamicLimits[transform.Name()])
+			}
+		}
+		if err := model.SetDynamicLimits(limits); err != nil {
+			return nil, err
+		}
+	}
 	return model, nil
 }
 
@@ -201,7 +245,30 @@ func ParseModelJSONFile(filename, modelName string) (Model, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to read json file")
 	}
-	return UnmarshalModelJSON(jsonData, modelName)
+	m := &ModelConfig{}
+
+	// empty data probably means that the robot component has no model information
+	if len(jsonData) == 0 {
+		return nil, ErrNoModelInformation
+	}
+
+	if err := json.Unmarshal(jsonData, m); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal json file")
+	}
+
+	// mesh files are referenced relative to the location of the model file
+	resolveMeshFile := func(geometry *spatial.GeometryConfig) {
+		if geometry.MeshFile != "" && !filepath.IsAbs(geometry.MeshFile) {
+			geometry.MeshFile = filepath.Join(filepath.Dir(filename), geometry.MeshFile)
+		}
+	}
+	for i := range m.Links {
+		resolveMeshFile(&m.Links[i].Geometry)
+	}
+	for i := range m.DHParams {
+		resolveMeshFile(&m.DHParams[i].Geometry)
+	}
+	return m.ParseConfig(modelName)
 }
 
 // ErrNoModelInformation is used when there is no model information.
@@ -1,6 +1,8 @@
 package referenceframe
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"go.viam.com/test"
@@ -48,4 +50,28 @@ func TestParseJSONFile(t *testing.T) {
 			test.That(t, err, test.ShouldNotBeNil)
 		})
 	}
+
+	emptyFile := filepath.Join(t.TempDir(), "empty.json")
+	test.That(t, os.WriteFile(emptyFile, nil, 0o600), test.ShouldBeNil)
+	_, err := ParseModelJSONFile(emptyFile, "")
+	test.That(t, err, test.ShouldBeError, ErrNoModelInformation)
+}
+
+func TestParseDynamicLimits(t *testing.T) {
+	model, err := ParseModelJSONFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	limiter, ok := model.(DynamicLimiter)
+	test.That(t, ok, test.ShouldBeTrue)
+	limits := limiter.DynamicLimits()
+	test.That(t, len(limits), test.ShouldEqual, len(model.DoF()))
+	test.That(t, limits[0].MaxVelocity, test.ShouldAlmostEqual, utils.DegToRad(180))
+	test.That(t, limits[0].MaxAcceleration, test.ShouldAlmostEqual, utils.DegToRad(1145))
+
+	model, err = ParseModelJSONFile(utils.ResolveFile("components/arm/universalrobots/ur5e_DH.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(model.(DynamicLimiter).DynamicLimits()), test.ShouldEqual, 6)
+
+	model, err = ParseModelJSONFile(utils.ResolveFile("motionplan/testjson/varm.json"), "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, model.(DynamicLimiter).DynamicLimits(), test.ShouldBeNil)
 }
@@ -0,0 +1,621 @@
+package referenceframe
+
+import (
+	"encoding/xml"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+
+	spatial "go.viam.com/rdk/spatialmath"
+)
+
+// URDF lengths are in meters, while frames use mm.
+const urdfMetersToMM = 1000.
+
+// urdfRobot represents the supported elements of a URDF file. Visual elements are written for use in other tools but
+// ignored when reading, as only collision geometries are used by frames.
+type urdfRobot struct {
+	XMLName xml.Name    `xml:"robot"`
+	Name    string      `xml:"name,attr"`
+	Links   []urdfLink  `xml:"link"`
+	Joints  []urdfJoint `xml:"joint"`
+}
+
+type urdfLink struct {
+	Name       string      `xml:"name,attr"`
+	Visuals    []urdfShape `xml:"visual"`
+	Collisions []urdfShape `xml:"collision"`
+}
+
+type urdfShape struct {
+	Name     string       `xml:"name,attr,omitempty"`
+	Origin   *urdfOrigin  `xml:"origin"`
+	Geometry urdfGeometry `xml:"geometry"`
+}
+
+type urdfOrigin struct {
+	XYZ string `xml:"xyz,attr,omitempty"`
+	RPY string `xml:"rpy,attr,omitempty"`
+}
+
+type urdfGeometry struct {
+	Box      *urdfBox      `xml:"box"`
+	Sphere   *urdfSphere   `xml:"sphere"`
+	Cylinder *urdfCylinder `xml:"cylinder"`
+	Mesh     *urdfMesh     `xml:"mesh"`
+}
+
+type urdfBox struct {
+	Size string `xml:"size,attr"`
+}
+
+type urdfSphere struct {
+	Radius float64 `xml:"radius,attr"`
+}
+
+type urdfCylinder struct {
+	Radius float64 `xml:"radius,attr"`
+	Length float64 `xml:"length,attr"`
+}
+
+type urdfMesh struct {
+	Filename string `xml:"filename,attr"`
+	Scale    string `xml:"scale,attr,omitempty"`
+}
+
+type urdfJoint struct {
+	Name   string      `xml:"name,attr"`
+	Type   string      `xml:"type,attr"`
+	Origin *urdfOrigin `xml:"origin"`
+	Parent urdfLinkRef `xml:"parent"`
+	Child  urdfLinkRef `xml:"child"`
+	Axis   *urdfAxis   `xml:"axis"`
+	Limit  *urdfLimit  `xml:"limit"`
+}
+
+type urdfLinkRef struct {
+	Link string `xml:"link,attr"`
+}
+
+type urdfAxis struct {
+	XYZ string `xml:"xyz,attr"`
+}
+
+type urdfLimit struct {
+	Lower    float64 `xml:"lower,attr"`
+	Upper    float64 `xml:"upper,attr"`
+	Effort   float64 `xml:"effort,attr"`
+	Velocity float64 `xml:"velocity,attr"`
+}
+
+// ParseModelURDFFile will read a given URDF file and then parse the contained robot description, as UnmarshalModelURDF does.
+// Relative and package:// mesh file names are resolved against the directory of the file.
+func ParseModelURDFFile(filename, modelName, endEffector string) (Model, error) {
+	//nolint:gosec
+	xmlData, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read urdf file")
+	}
+	if len(xmlData) == 0 {
+		return nil, ErrNoModelInformation
+	}
+	robot := &urdfRobot{}
+	if err := xml.Unmarshal(xmlData, robot); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal urdf file")
+	}
+	return robot.parseModel(modelName, endEffector, filepath.Dir(filename))
+}
+
+// UnmarshalModelURDF will parse the given URDF data into a kinematics model. modelName sets the name of the model, and the
+// name of the robot in the URDF is used if it is empty. Mesh file names are resolved against the working directory.
+//
+// The links and joints must form a tree, and the model is the chain of links from its root to the end effector link. The
+// end effector may be empty if the tree has only one leaf link, which is then the end effector. Revolute, continuous and
+// prismatic joints along the chain become degrees of freedom of the model, in order, and fixed joints become static
+// offsets. Their limits and velocity limits are kept, converted from meters to mm. Collision geometries become the
+// geometries of the frames of their links; cylinders are represented by the smallest capsules enclosing them and meshes
+// must be STL or OBJ files. The geometries of links off the chain which are attached to it by fixed joints become
+// geometries of the link of the chain they are attached to, while links attached by other joints are left out, as their
+// joints are not part of the model.
+func UnmarshalModelURDF(xmlData []byte, modelName, endEffector string) (Model, error) {
+	if len(xmlData) == 0 {
+		return nil, ErrNoModelInformation
+	}
+	robot := &urdfRobot{}
+	if err := xml.Unmarshal(xmlData, robot); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal urdf file")
+	}
+	return robot.parseModel(modelName, endEffector, "")
+}
+
+func (robot *urdfRobot) parseModel(modelName, endEffector, meshDir string) (Model, error) {
+	if modelName == "" {
+		modelName = robot.Name
+	}
+	links := make(map[string]*urdfLink, len(robot.Links))
+	for i := range robot.Links {
+		links[robot.Links[i].Name] = &robot.Links[i]
+	}
+	parentJoints := map[string]*urdfJoint{}
+	childJoints := map[string][]*urdfJoint{}
+	for i := range robot.Joints {
+		joint := &robot.Joints[i]
+		if links[joint.Parent.Link] == nil || links[joint.Child.Link] == nil {
+			return nil, errors.Errorf("joint %q connects links which do not exist", joint.Name)
+		}
+		if parentJoints[joint.Child.Link] != nil {
+			return nil, errors.Errorf("link %q is the child of more than one joint", joint.Child.Link)
+		}
+		parentJoints[joint.Child.Link] = joint
+		childJoints[joint.Parent.Link] = append(childJoints[joint.Parent.Link], joint)
+	}
+
+	var root *urdfLink
+	leaves := []string{}
+	for i := range robot.Links {
+		name := robot.Links[i].Name
+		if parentJoints[name] == nil {
+			if root != nil {
+				return nil, errors.New("urdf must have exactly one root link")
+			}
+			root = &robot.Links[i]
+		}
+		if len(childJoints[name]) == 0 {
+			leaves = append(leaves, name)
+		}
+	}
+	if root == nil {
+		return nil, errors.New("urdf must have exactly one root link")
+	}
+	if endEffector == "" {
+		if len(leaves) != 1 {
+			return nil, errors.Errorf("urdf has more than one end effector, one of %q must be chosen", leaves)
+		}
+		endEffector = leaves[0]
+	}
+	if links[endEffector] == nil {
+		return nil, errors.Errorf("end effector link %q does not exist", endEffector)
+	}
+
+	// find the chain of joints from the root to the end effector
+	chain := []*urdfJoint{}
+	seen := map[string]bool{}
+	for name := endEffector; name != root.Name; {
+		if seen[name] {
+			return nil, errors.New("infinite loop finding path from end effector to world")
+		}
+		seen[name] = true
+		joint := parentJoints[name]
+		chain = append([]*urdfJoint{joint}, chain...)
+		name = joint.Parent.Link
+	}
+
+	model := NewSimpleModel(modelName)
+	dynamicLimits := []DynamicLimit{}
+	hasDynamicLimits := false
+	link := root
+	for i := 0; i <= len(chain); i++ {
+		var joint *urdfJoint
+		if i < len(chain) {
+			joint = chain[i]
+		}
+
+		// the origin of the next joint is folded into the frame of the link. Geometries of the frames of a model are placed
+		// before their transforms, so the collision geometries of the link are still relative to its origin.
+		linkPose := spatial.NewZeroPose()
+		if joint != nil {
+			var err error
+			if linkPose, err = joint.Origin.parse(); err != nil {
+				return nil, errors.Wrapf(err, "joint %q", joint.Name)
+			}
+		}
+		creators, err := robot.linkGeometries(link, spatial.NewZeroPose(), joint, links, childJoints, meshDir)
+		if err != nil {
+			return nil, err
+		}
+		// frames hold at most one geometry, so links with several are given zero frames for all but the last of them
+		for i := 0; i < len(creators)-1; i++ {
+			f, err := NewStaticFrameWithGeometry(link.Name+"_collision_"+strconv.Itoa(i), spatial.NewZeroPose(), creators[i])
+			if err != nil {
+				return nil, err
+			}
+			model.OrdTransforms = append(model.OrdTransforms, f)
+		}
+		var linkCreator spatial.GeometryCreator
+		if len(creators) > 0 {
+			linkCreator = creators[len(creators)-1]
+		}
+		linkFrame, err := NewStaticFrameWithGeometry(link.Name, linkPose, linkCreator)
+		if err != nil {
+			return nil, err
+		}
+		model.OrdTransforms = append(model.OrdTransforms, linkFrame)
+
+		if joint == nil {
+			break
+		}
+		jointFrame, limit, err := joint.parse()
+		if err != nil {
+			return nil, err
+		}
+		if jointFrame != nil {
+			model.OrdTransforms = append(model.OrdTransforms, jointFrame)
+			dynamicLimits = append(dynamicLimits, limit)
+			hasDynamicLimits = hasDynamicLimits || limit != DynamicLimit{}
+		}
+		link = links[joint.Child.Link]
+	}
+
+	if hasDynamicLimits {
+		if err := model.SetDynamicLimits(dynamicLimits); err != nil {
+			return nil, err
+		}
+	}
+	return model, nil
+}
+
+// linkGeometries returns the collision geometries of a link at the given pose, followed by those of the links attached to it
+// by fixed joints, other than the joint continuing the chain of the model, relative to the origin of the link.
+func (robot *urdfRobot) linkGeometries(
+	link *urdfLink,
+	pose spatial.Pose,
+	chainJoint *urdfJoint,
+	links map[string]*urdfLink,
+	childJoints map[string][]*urdfJoint,
+	meshDir string,
+) ([]spatial.GeometryCreator, error) {
+	creators := make([]spatial.GeometryCreator, 0, len(link.Collisions))
+	for _, collision := range link.Collisions {
+		offset, err := collision.Origin.parse()
+		if err != nil {
+			return nil, errors.Wrapf(err, "link %q", link.Name)
+		}
+		creator, err := collision.Geometry.parse(spatial.Compose(pose, offset), meshDir)
+		if err != nil {
+			return nil, errors.Wrapf(err, "link %q", link.Name)
+		}
+		creators = append(creators, creator)
+	}
+	for _, joint := range childJoints[link.Name] {
+		if joint == chainJoint || joint.Type != "fixed" {
+			continue
+		}
+		offset, err := joint.Origin.parse()
+		if err != nil {
+			return nil, errors.Wrapf(err, "joint %q", joint.Name)
+		}
+		attached, err := robot.linkGeometries(links[joint.Child.Link], spatial.Compose(pose, offset), nil, links, childJoints, meshDir)
+		if err != nil {
+			return nil, err
+		}
+		creators = append(creators, attached...)
+	}
+	return creators, nil
+}
+
+// parse returns the frame for the motion of a joint, which is nil for fixed joints, and its velocity limit.
+func (joint *urdfJoint) parse() (Frame, DynamicLimit, error) {
+	axis := r3.Vector{X: 1}
+	if joint.Axis != nil {
+		var err error
+		if axis, err = parseURDFVector(joint.Axis.XYZ); err != nil {
+			return nil, DynamicLimit{}, errors.Wrapf(err, "joint %q", joint.Name)
+		}
+	}
+	limitRequired := func() error {
+		if joint.Limit == nil {
+			return errors.Errorf("%s joint %q must have a limit", joint.Type, joint.Name)
+		}
+		return nil
+	}
+	var velocity float64
+	if joint.Limit != nil {
+		velocity = joint.Limit.Velocity
+	}
+
+	switch joint.Type {
+	case "fixed":
+		return nil, DynamicLimit{}, nil
+	case "revolute":
+		if err := limitRequired(); err != nil {
+			return nil, DynamicLimit{}, err
+		}
+		f, err := NewRotationalFrame(joint.Name, spatial.R4AA{RX: axis.X, RY: axis.Y, RZ: axis.Z},
+			Limit{Min: joint.Limit.Lower, Max: joint.Limit.Upper})
+		return f, DynamicLimit{MaxVelocity: velocity}, err
+	case "continuous":
+		f, err := NewRotationalFrame(joint.Name, spatial.R4AA{RX: axis.X, RY: axis.Y, RZ: axis.Z},
+			Limit{Min: -2 * math.Pi, Max: 2 * math.Pi})
+		return f, DynamicLimit{MaxVelocity: velocity}, err
+	case "prismatic":
+		if err := limitRequired(); err != nil {
+			return nil, DynamicLimit{}, err
+		}
+		f, err := NewTranslationalFrame(joint.Name, axis,
+			Limit{Min: joint.Limit.Lower * urdfMetersToMM, Max: joint.Limit.Upper * urdfMetersToMM})
+		return f, DynamicLimit{MaxVelocity: velocity * urdfMetersToMM}, err
+	default:
+		return nil, DynamicLimit{}, errors.Errorf("unsupported joint type detected: %v", joint.Type)
+	}
+}
+
+// parse returns the pose described by an origin, which is the zero pose if it is absent.
+func (origin *urdfOrigin) parse() (spatial.Pose, error) {
+	if origin == nil {
+		return spatial.NewZeroPose(), nil
+	}
+	xyz, err := parseURDFVector(origin.XYZ)
+	if err != nil {
+		return nil, err
+	}
+	rpy, err := parseURDFVector(origin.RPY)
+	if err != nil {
+		return nil, err
+	}
+	return spatial.NewPoseFromOrientation(
+		xyz.Mul(urdfMetersToMM),
+		&spatial.EulerAngles{Roll: rpy.X, Pitch: rpy.Y, Yaw: rpy.Z},
+	), nil
+}
+
+func (geometry *urdfGeometry) parse(offset spatial.Pose, meshDir string) (spatial.GeometryCreator, error) {
+	switch {
+	case geometry.Box != nil:
+		size, err := parseURDFVector(geometry.Box.Size)
+		if err != nil {
+			return nil, err
+		}
+		return spatial.NewBoxCreator(size.Mul(urdfMetersToMM), offset)
+	case geometry.Sphere != nil:
+		return spatial.NewSphereCreator(geometry.Sphere.Radius*urdfMetersToMM, offset)
+	case geometry.Cylinder != nil:
+		radius := geometry.Cylinder.Radius * urdfMetersToMM
+		return spatial.NewCapsuleCreator(radius, geometry.Cylinder.Length*urdfMetersToMM+2*radius, offset)
+	case geometry.Mesh != nil:
+		scale := r3.Vector{X: 1, Y: 1, Z: 1}
+		if geometry.Mesh.Scale != "" {
+			var err error
+			if scale, err = parseURDFVector(geometry.Mesh.Scale); err != nil {
+				return nil, err
+			}
+		}
+		if scale.X != scale.Y || scale.X != scale.Z {
+			return nil, errors.Errorf("mesh %q has a non-uniform scale, which is not supported", geometry.Mesh.Filename)
+		}
+		return spatial.NewScaledMeshCreator(resolveURDFMeshFile(geometry.Mesh.Filename, meshDir), scale.X*urdfMetersToMM, offset)
+	default:
+		return nil, errors.New("collision geometry must be a box, sphere, cylinder or mesh")
+	}
+}
+
+// resolveURDFMeshFile returns the path of a mesh file named in a URDF file in dir. File names of the form
+// package://package/path are looked for in a directory named after the package in dir or any of its parents, falling back
+// to path relative to dir.
+func resolveURDFMeshFile(filename, dir string) string {
+	filename = strings.TrimPrefix(filename, "file://")
+	if !strings.HasPrefix(filename, "package://") {
+		if filepath.IsAbs(filename) {
+			return filename
+		}
+		return filepath.Join(dir, filename)
+	}
+	pkg, path, _ := strings.Cut(strings.TrimPrefix(filename, "package://"), "/")
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return filepath.Join(dir, path)
+	}
+	for search := absDir; ; search = filepath.Dir(search) {
+		if filepath.Base(search) == pkg {
+			if _, err := os.Stat(filepath.Join(search, path)); err == nil {
+				return filepath.Join(search, path)
+			}
+		}
+		if _, err := os.Stat(filepath.Join(search, pkg, path)); err == nil {
+			return filepath.Join(search, pkg, path)
+		}
+		if filepath.Dir(search) == search {
+			return filepath.Join(dir, path)
+		}
+	}
+}
+
+// MarshalFrameSystemURDF describes a frame system as a URDF robot, so that it can be used by tools which read URDF. Every
+// frame becomes a link of the same name, joined to the link of its parent frame, with the world frame as the root link.
+// The frames of models are expanded into links named "model:frame", except for the last which keeps the name of the model
+// so that the frames attached to the model are joined to its end. Lengths are converted from mm to meters.
+//
+// Static, rotational and translational frames are supported, and models made of them. Capsules are written as the
+// cylinders enclosing them and points, which have no extent, are left out.
+func MarshalFrameSystemURDF(fs FrameSystem) ([]byte, error) {
+	robot := &urdfRobot{Name: fs.Name(), Links: []urdfLink{{Name: World}}}
+
+	children := map[string][]Frame{}
+	for _, name := range fs.FrameNames() {
+		f := fs.Frame(name)
+		parent, err := fs.Parent(f)
+		if err != nil {
+			return nil, err
+		}
+		children[parent.Name()] = append(children[parent.Name()], f)
+	}
+	// write the frames in topological order, so that the file reads from the world outward
+	queue := []string{World}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		sort.Slice(children[parent], func(i, j int) bool { return children[parent][i].Name() < children[parent][j].Name() })
+		for _, f := range children[parent] {
+			if err := robot.addFrame(f, parent, f.Name(), nil, false); err != nil {
+				return nil, err
+			}
+			queue = append(queue, f.Name())
+		}
+	}
+
+	out, err := xml.MarshalIndent(robot, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), out...), nil
+}
+
+// addFrame adds a link with the given name, and a joint of the same name connecting it to its parent link, describing the
+// frame. Models are added as a chain of links. The velocity limits of the degrees of freedom of the frame may be given.
+// Geometries of the frames of models are placed before their transforms, so they are given to the parent link instead.
+func (robot *urdfRobot) addFrame(f Frame, parent, name string, limits []DynamicLimit, geometryOnParent bool) error {
+	joint := urdfJoint{Name: name, Type: "fixed"}
+	joint.Parent.Link = parent
+	joint.Child.Link = name
+	link := urdfLink{Name: name}
+	var creator spatial.GeometryCreator
+
+	switch frame := f.(type) {
+	case *SimpleModel:
+		if len(frame.OrdTransforms) == 0 {
+			break
+		}
+		dof := 0
+		for i, sub := range frame.OrdTransforms {
+			subName := name + ":" + sub.Name()
+			if i == len(frame.OrdTransforms)-1 {
+				subName = name
+			}
+			var subLimits []DynamicLimit
+			if frame.dynamicLimits != nil {
+				subLimits = frame.dynamicLimits[dof : dof+len(sub.DoF())]
+			}
+			dof += len(sub.DoF())
+			if err := robot.addFrame(sub, parent, subName, subLimits, true); err != nil {
+				return err
+			}
+			parent = subName
+		}
+		return nil
+	case *staticFrame:
+		joint.Origin = newURDFOrigin(frame.transform)
+		creator = frame.geometryCreator
+	case *rotationalFrame:
+		joint.Type = "revolute"
+		joint.setAxisAndLimit(frame.rotAxis, frame.limits[0], 1, limits)
+	case *translationalFrame:
+		joint.Type = "prismatic"
+		joint.setAxisAndLimit(frame.transAxis, frame.limits[0], urdfMetersToMM, limits)
+		creator = frame.geometryCreator
+	default:
+		return errors.Errorf("cannot describe frame %q of type %T in urdf", f.Name(), f)
+	}
+
+	if creator != nil {
+		shape, err := newURDFShape(creator)
+		if err != nil {
+			return errors.Wrapf(err, "frame %q", f.Name())
+		}
+		if shape != nil {
+			target := &link
+			if geometryOnParent {
+				target = robot.link(parent)
+			}
+			target.Visuals = append(target.Visuals, *shape)
+			target.Collisions = append(target.Collisions, *shape)
+		}
+	}
+	robot.Links = append(robot.Links, link)
+	robot.Joints = append(robot.Joints, joint)
+	return nil
+}
+
+// link returns the link with the given name, which must have been added.
+func (robot *urdfRobot) link(name string) *urdfLink {
+	for i := range robot.Links {
+		if robot.Links[i].Name == name {
+			return &robot.Links[i]
+		}
+	}
+	return nil
+}
+
+// setAxisAndLimit sets the axis and limit of a joint, whose lengths are scaled down by scale to convert them to meters.
+func (joint *urdfJoint) setAxisAndLimit(axis r3.Vector, limit Limit, scale float64, limits []DynamicLimit) {
+	joint.Axis = &urdfAxis{XYZ: formatURDFVector(axis)}
+	joint.Limit = &urdfLimit{Lower: limit.Min / scale, Upper: limit.Max / scale}
+	if len(limits) > 0 {
+		joint.Limit.Velocity = limits[0].MaxVelocity / scale
+	}
+}
+
+// newURDFOrigin returns the URDF description of a pose, or nil for the zero pose.
+func newURDFOrigin(pose spatial.Pose) *urdfOrigin {
+	if spatial.PoseAlmostEqual(pose, spatial.NewZeroPose()) {
+		return nil
+	}
+	rpy := spatial.QuatToEulerAngles(pose.Orientation().Quaternion())
+	return &urdfOrigin{
+		XYZ: formatURDFVector(pose.Point().Mul(1 / urdfMetersToMM)),
+		RPY: formatURDFVector(r3.Vector{X: rpy.Roll, Y: rpy.Pitch, Z: rpy.Yaw}),
+	}
+}
+
+// newURDFShape returns the URDF description of a geometry, or nil if it has no extent.
+func newURDFShape(creator spatial.GeometryCreator) (*urdfShape, error) {
+	config, err := spatial.NewGeometryConfig(creator)
+	if err != nil {
+		return nil, err
+	}
+	shape := &urdfShape{Origin: newURDFOrigin(creator.Offset())}
+	switch config.Type {
+	case spatial.BoxType:
+		shape.Geometry.Box = &urdfBox{Size: formatURDFVector(r3.Vector{X: config.X, Y: config.Y, Z: config.Z}.Mul(1 / urdfMetersToMM))}
+	case spatial.SphereType:
+		shape.Geometry.Sphere = &urdfSphere{Radius: config.R / urdfMetersToMM}
+	case spatial.CapsuleType:
+		shape.Geometry.Cylinder = &urdfCylinder{Radius: config.R / urdfMetersToMM, Length: config.L / urdfMetersToMM}
+	case spatial.MeshType:
+		scale := 1.
+		if config.MeshScale != 0 {
+			scale = config.MeshScale
+		}
+		scale /= urdfMetersToMM
+		shape.Geometry.Mesh = &urdfMesh{Filename: config.MeshFile, Scale: formatURDFVector(r3.Vector{X: scale, Y: scale, Z: scale})}
+	case spatial.PointType, spatial.UnknownType:
+		return nil, nil
+	}
+	return shape, nil
+}
+
+// parseURDFVector parses a vector of three space separated numbers, as used by URDF attributes. An empty string is the zero
+// vector.
+func parseURDFVector(s string) (r3.Vector, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return r3.Vector{}, nil
+	}
+	if len(fields) != 3 {
+		return r3.Vector{}, errors.Errorf("expected 3 values, got %q", s)
+	}
+	values := make([]float64, 3)
+	for i, field := range fields {
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return r3.Vector{}, errors.Wrapf(err, "invalid value in %q", s)
+		}
+		values[i] = value
+	}
+	return r3.Vector{X: values[0], Y: values[1], Z: values[2]}, nil
+}
+
+// formatURDFVector formats a vector as three space separated numbers, writing floating point noise as zero.
+func formatURDFVector(v r3.Vector) string {
+	format := func(value float64) string {
+		if math.Abs(value) < 1e-12 {
+			value = 0
+		}
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	return strings.Join([]string{format(v.X), format(v.Y), format(v.Z)}, " ")
+}
@@ -0,0 +1,282 @@
+package referenceframe
+
+import (
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	spatial "go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+// a planar arm with links of 100 and 50 mm, like planarArmJSON, with collision geometries and a tool on a fixed joint.
+var planarArmURDF = []byte(`<?xml version="1.0"?>
+<robot name="planar">
+	<link name="base">
+		<collision><geometry><box size="0.02 0.02 0.02"/></geometry></collision>
+	</link>
+	<link name="link1">
+		<visual><geometry><box size="1 1 1"/></geometry></visual>
+		<collision>
+			<origin xyz="0.05 0 0" rpy="0 1.5707963267948966 0"/>
+			<geometry><cylinder radius="0.01" length="0.1"/></geometry>
+		</collision>
+	</link>
+	<link name="link2"/>
+	<link name="tool">
+		<collision><geometry><sphere radius="0.005"/></geometry></collision>
+	</link>
+	<joint name="joint1" type="revolute">
+		<parent link="base"/>
+		<child link="link1"/>
+		<axis xyz="0 0 1"/>
+		<limit lower="-3.141592653589793" upper="3.141592653589793" effort="10" velocity="1"/>
+	</joint>
+	<joint name="joint2" type="revolute">
+		<origin xyz="0.1 0 0"/>
+		<parent link="link1"/>
+		<child link="link2"/>
+		<axis xyz="0 0 1"/>
+		<limit lower="-3.141592653589793" upper="3.141592653589793" effort="10" velocity="2"/>
+	</joint>
+	<joint name="tool_joint" type="fixed">
+		<origin xyz="0.05 0 0"/>
+		<parent link="link2"/>
+		<child link="tool"/>
+	</joint>
+</robot>`)
+
+func TestUnmarshalModelURDF(t *testing.T) {
+	model, err := UnmarshalModelURDF(planarArmURDF, "", "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, model.Name(), test.ShouldEqual, "planar")
+	test.That(t, len(model.DoF()), test.ShouldEqual, 2)
+	test.That(t, model.DoF()[0].Max, test.ShouldAlmostEqual, math.Pi)
+	test.That(t, model.(*SimpleModel).DynamicLimits(), test.ShouldResemble, []DynamicLimit{{MaxVelocity: 1}, {MaxVelocity: 2}})
+
+	q1, q2 := 0.4, 1.1
+	pose, err := model.Transform(FloatsToInputs([]float64{q1, q2}))
+	test.That(t, err, test.ShouldBeNil)
+	expected := spatial.NewPoseFromOrientation(
+		r3.Vector{X: 100*math.Cos(q1) + 50*math.Cos(q1+q2), Y: 100*math.Sin(q1) + 50*math.Sin(q1+q2)},
+		&spatial.R4AA{Theta: q1 + q2, RZ: 1},
+	)
+	test.That(t, spatial.PoseAlmostEqual(pose, expected), test.ShouldBeTrue)
+
+	// links without collision geometries are reported as errors alongside the geometries of the others
+	geometries, _ := model.Geometries(FloatsToInputs([]float64{0, 0}))
+	test.That(t, geometries, test.ShouldNotBeNil)
+	test.That(t, geometries.Geometries(), test.ShouldHaveLength, 3)
+	link1 := geometries.Geometries()["planar:link1"]
+	test.That(t, spatial.R3VectorAlmostEqual(link1.Pose().Point(), r3.Vector{X: 50}, 1e-8), test.ShouldBeTrue)
+	// the cylinder lies along x, so it reaches from the base to the second joint
+	test.That(t, collidesWithPoint(t, link1, r3.Vector{X: 105}), test.ShouldBeTrue)
+	test.That(t, collidesWithPoint(t, link1, r3.Vector{Z: 15}), test.ShouldBeFalse)
+	tool := geometries.Geometries()["planar:tool"]
+	test.That(t, spatial.R3VectorAlmostEqual(tool.Pose().Point(), r3.Vector{X: 150}, 1e-8), test.ShouldBeTrue)
+
+	model, err = UnmarshalModelURDF(planarArmURDF, "renamed", "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, model.Name(), test.ShouldEqual, "renamed")
+
+	_, err = UnmarshalModelURDF(nil, "", "")
+	test.That(t, err, test.ShouldEqual, ErrNoModelInformation)
+}
+
+func TestUnmarshalModelURDFErrors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		replace, with, err string
+	}{
+		"branching chain": {
+			`<link name="tool">`,
+			`<link name="finger"/><joint name="finger_joint" type="fixed"><parent link="link2"/><child link="finger"/></joint>
+			<link name="tool">`,
+			"more than one end effector",
+		},
+		"unsupported joint": {`type="fixed"`, `type="floating"`, "unsupported joint type"},
+		"missing limit": {
+			`<limit lower="-3.141592653589793" upper="3.141592653589793" effort="10" velocity="2"/>`, "", "must have a limit",
+		},
+		"missing link":          {`<child link="tool"/>`, `<child link="gripper"/>`, "links which do not exist"},
+		"invalid vector":        {`xyz="0.05 0 0"/>`, `xyz="0.05 0"/>`, "expected 3 values"},
+		"unsupported geometry":  {`<sphere radius="0.005"/>`, "", "collision geometry must be"},
+		"missing mesh":          {`<sphere radius="0.005"/>`, `<mesh filename="missing.stl"/>`, "failed to read mesh file"},
+		"non-uniform mesh":      {`<sphere radius="0.005"/>`, `<mesh filename="a.stl" scale="1 2 1"/>`, "non-uniform scale"},
+		"continuous is allowed": {`type="revolute"`, `type="continuous"`, ""},
+		"loop": {
+			`<child link="link1"/>`, `<child link="link1"/></joint><joint name="loop" type="fixed"><parent link="link2"/><child link="base"/>`,
+			"exactly one root link",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data := strings.Replace(string(planarArmURDF), tc.replace, tc.with, 1)
+			_, err := UnmarshalModelURDF([]byte(data), "", "")
+			if tc.err == "" {
+				test.That(t, err, test.ShouldBeNil)
+				return
+			}
+			test.That(t, err, test.ShouldNotBeNil)
+			test.That(t, err.Error(), test.ShouldContainSubstring, tc.err)
+		})
+	}
+}
+
+func TestUnmarshalModelURDFTree(t *testing.T) {
+	// a finger fixed to the second link, and a sensor on a joint of its own on the first link
+	data := []byte(strings.Replace(string(planarArmURDF), `<link name="tool">`, `
+	<link name="finger"><collision><geometry><sphere radius="0.002"/></geometry></collision></link>
+	<joint name="finger_joint" type="fixed">
+		<origin xyz="0 0.02 0"/>
+		<parent link="link2"/>
+		<child link="finger"/>
+	</joint>
+	<link name="sensor"><collision><geometry><sphere radius="0.002"/></geometry></collision></link>
+	<joint name="sensor_joint" type="revolute">
+		<parent link="link1"/>
+		<child link="sensor"/>
+		<limit lower="-1" upper="1" effort="1" velocity="1"/>
+	</joint>
+	<link name="tool">`, 1))
+	_, err := UnmarshalModelURDF(data, "", "")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "more than one end effector")
+	_, err = UnmarshalModelURDF(data, "", "gripper")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "does not exist")
+
+	model, err := UnmarshalModelURDF(data, "", "tool")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(model.DoF()), test.ShouldEqual, 2)
+	pose, err := model.Transform(FloatsToInputs([]float64{0, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.R3VectorAlmostEqual(pose.Point(), r3.Vector{X: 150}, 1e-8), test.ShouldBeTrue)
+	geometries, _ := model.Geometries(FloatsToInputs([]float64{0, math.Pi / 2}))
+	test.That(t, geometries, test.ShouldNotBeNil)
+	// the finger moves with the second link, while the sensor, whose joint is not part of the model, is left out
+	test.That(t, geometries.Geometries(), test.ShouldHaveLength, 4)
+	finger := geometries.Geometries()["planar:link2"]
+	test.That(t, finger, test.ShouldNotBeNil)
+	test.That(t, spatial.R3VectorAlmostEqual(finger.Pose().Point(), r3.Vector{X: 80}, 1e-8), test.ShouldBeTrue)
+
+	// choosing a link along the chain ends the model there, with the links after it fixed to it
+	model, err = UnmarshalModelURDF(data, "", "sensor")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(model.DoF()), test.ShouldEqual, 2)
+	test.That(t, model.DoF()[1], test.ShouldResemble, Limit{Min: -1, Max: 1})
+	pose, err = model.Transform(FloatsToInputs([]float64{0, 0}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatial.R3VectorAlmostEqual(pose.Point(), r3.Vector{}, 1e-8), test.ShouldBeTrue)
+}
+
+func TestParseModelFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "planar.urdf")
+	test.That(t, os.WriteFile(filename, planarArmURDF, 0o600), test.ShouldBeNil)
+	model, err := ParseModelFile(filename, "arm", "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, model.Name(), test.ShouldEqual, "arm")
+	test.That(t, len(model.DoF()), test.ShouldEqual, 2)
+	model, err = ParseModelFile(filename, "arm", "link2")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(model.DoF()), test.ShouldEqual, 2)
+
+	model, err = ParseModelFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "arm", "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(model.DoF()), test.ShouldEqual, 6)
+	_, err = ParseModelFile(utils.ResolveFile("components/arm/xarm/xarm6_kinematics.json"), "arm", "link2")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestParseModelURDFFile(t *testing.T) {
+	// meshes referenced by package are found relative to the file, and scaled from meters
+	dir := t.TempDir()
+	mesh, err := os.ReadFile(utils.ResolveFile("spatialmath/data/cube.stl"))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, os.MkdirAll(filepath.Join(dir, "planar", "meshes"), 0o750), test.ShouldBeNil)
+	test.That(t, os.MkdirAll(filepath.Join(dir, "planar", "urdf"), 0o750), test.ShouldBeNil)
+	test.That(t, os.WriteFile(filepath.Join(dir, "planar", "meshes", "cube.stl"), mesh, 0o600), test.ShouldBeNil)
+	data := strings.Replace(
+		string(planarArmURDF), `<sphere radius="0.005"/>`, `<mesh filename="package://planar/meshes/cube.stl" scale="0.001 0.001 0.001"/>`, 1,
+	)
+	filename := filepath.Join(dir, "planar", "urdf", "planar.urdf")
+	test.That(t, os.WriteFile(filename, []byte(data), 0o600), test.ShouldBeNil)
+
+	model, err := ParseModelURDFFile(filename, "", "")
+	test.That(t, err, test.ShouldBeNil)
+	// links without collision geometries are reported as errors alongside the geometries of the others
+	geometries, _ := model.Geometries(FloatsToInputs([]float64{0, 0}))
+	test.That(t, geometries, test.ShouldNotBeNil)
+	tool := geometries.Geometries()["planar:tool"]
+	// the cube is 100 units across, so scaled from meters to mm with a scale of 0.001 it is 100mm across
+	test.That(t, collidesWithPoint(t, tool, r3.Vector{X: 195}), test.ShouldBeTrue)
+	test.That(t, collidesWithPoint(t, tool, r3.Vector{X: 205}), test.ShouldBeFalse)
+
+	_, err = ParseModelURDFFile(filepath.Join(dir, "missing.urdf"), "", "")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestMarshalFrameSystemURDF(t *testing.T) {
+	model, err := UnmarshalModelURDF(planarArmURDF, "arm", "")
+	test.That(t, err, test.ShouldBeNil)
+	fs := NewEmptySimpleFrameSystem("robot")
+	origin, err := NewStaticFrame("arm_origin", spatial.NewPoseFromOrientation(r3.Vector{X: 10, Y: 20, Z: 30}, &spatial.R4AA{Theta: 1, RX: 1}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(origin, fs.World()), test.ShouldBeNil)
+	test.That(t, fs.AddFrame(model, origin), test.ShouldBeNil)
+	box, err := spatial.NewBoxCreator(r3.Vector{X: 10, Y: 10, Z: 10}, spatial.NewPoseFromPoint(r3.Vector{Z: 5}))
+	test.That(t, err, test.ShouldBeNil)
+	gantry, err := NewTranslationalFrameWithGeometry("gantry", r3.Vector{Y: 1}, Limit{Min: 0, Max: 500}, box)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+
+	data, err := MarshalFrameSystemURDF(fs)
+	test.That(t, err, test.ShouldBeNil)
+	text := string(data)
+	test.That(t, text, test.ShouldContainSubstring, `<robot name="robot">`)
+	test.That(t, text, test.ShouldContainSubstring, `<joint name="arm:joint1" type="revolute">`)
+	test.That(t, text, test.ShouldContainSubstring, `<limit lower="0" upper="0.5" effort="0" velocity="0"></limit>`)
+	test.That(t, text, test.ShouldContainSubstring, `<parent link="arm_origin"></parent>`)
+	test.That(t, text, test.ShouldContainSubstring, `<cylinder radius="0.01" length="0.12"></cylinder>`)
+
+	// removing the gantry, the frame system is a serial chain from the world, so it can be read back as a model
+	fs.RemoveFrame(gantry)
+	data, err = MarshalFrameSystemURDF(fs)
+	test.That(t, err, test.ShouldBeNil)
+	chain, err := UnmarshalModelURDF(data, "", "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, chain.(*SimpleModel).DynamicLimits(), test.ShouldResemble, model.(*SimpleModel).DynamicLimits())
+	//nolint:gosec
+	randseed := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		inputs := RandomFrameInputs(model, randseed)
+		expected, err := fs.Transform(map[string][]Input{"arm": inputs}, NewPoseInFrame("arm", spatial.NewZeroPose()), World)
+		test.That(t, err, test.ShouldBeNil)
+		pose, err := chain.Transform(inputs)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, spatial.PoseAlmostEqual(pose, expected.(*PoseInFrame).Pose()), test.ShouldBeTrue)
+	}
+	// links without collision geometries are reported as errors alongside the geometries of the others
+	geometries, _ := chain.Geometries(make([]Input, 2))
+	test.That(t, geometries, test.ShouldNotBeNil)
+	test.That(t, geometries.Geometries(), test.ShouldHaveLength, 3)
+
+	// frames which cannot be described are an error
+	mobile, err := NewMobile2DFrame("base", []Limit{{-1, 1}, {-1, 1}}, nil)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(mobile, fs.World()), test.ShouldBeNil)
+	_, err = MarshalFrameSystemURDF(fs)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func collidesWithPoint(t *testing.T, geometry spatial.Geometry, point r3.Vector) bool {
+	t.Helper()
+	collides, err := geometry.CollidesWith(spatial.NewPoint(point))
+	test.That(t, err, test.ShouldBeNil)
+	return collides
+}
@@ -1,6 +1,7 @@
 package resource
 
 import (
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
@@ -429,6 +430,31 @@ func (g *Graph) CopyNodeAndChildren(node Name, origin *Graph) error {
 	return nil
 }
 
+// Edge is a dependency of one resource, the child, on another, its parent.
+type Edge struct {
+	Child  Name
+	Parent Name
+}
+
+// Edges returns every dependency in the graph, ordered by child then parent.
+func (g *Graph) Edges() []Edge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	edges := []Edge{}
+	for child, parents := range g.parents {
+		for parent := range parents {
+			edges = append(edges, Edge{Child: child, Parent: parent})
+		}
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Child != edges[j].Child {
+			return edges[i].Child.String() < edges[j].Child.String()
+		}
+		return edges[i].Parent.String() < edges[j].Parent.String()
+	})
+	return edges
+}
+
 // TopologicalSort returns an array of nodes' Name ordered by fewest edges first.
 func (g *Graph) TopologicalSort() []Name {
 	ordered := []Name{}
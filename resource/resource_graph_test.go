@@ -142,6 +142,17 @@ func TestGetParentsAndChildren(t *testing.T) {
 			test.That(t, g.AddChildren(component.Name, dep), test.ShouldBeNil)
 		}
 	}
+	edges := g.Edges()
+	test.That(t, len(edges), test.ShouldEqual, 8)
+	test.That(t, edges[0], test.ShouldResemble, Edge{
+		Child:  NewName("namespace", "atype", "asubtype", "B"),
+		Parent: NewName("namespace", "atype", "asubtype", "A"),
+	})
+	test.That(t, edges[len(edges)-1], test.ShouldResemble, Edge{
+		Child:  NewName("namespace", "atype", "asubtype", "F"),
+		Parent: NewName("namespace", "atype", "asubtype", "E"),
+	})
+
 	out := g.GetAllChildrenOf(NewName("namespace", "atype", "asubtype", "A"))
 	test.That(t, len(out), test.ShouldEqual, 2)
 	test.That(t, out, test.ShouldContain,
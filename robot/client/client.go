@@ -19,8 +19,11 @@ import (
 	"go.viam.com/utils/pexec"
 	"go.viam.com/utils/rpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	reflectpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/discovery"
@@ -32,6 +35,7 @@ import (
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
+	robotserver "go.viam.com/rdk/robot/server"
 )
 
 var (
@@ -624,6 +628,19 @@ func (rc *RobotClient) TransformPose(
 	return referenceframe.ProtobufToPoseInFrame(resp.Pose), nil
 }
 
+// TransformPoseAtTime will transform the pose of the requested poseInFrame to the desired frame in the robot's frame system,
+// as it was at the given time in the recent past. The time is sent in the metadata of a TransformPose request.
+func (rc *RobotClient) TransformPoseAtTime(
+	ctx context.Context,
+	query *referenceframe.PoseInFrame,
+	destination string,
+	additionalTransforms []*commonpb.Transform,
+	t time.Time,
+) (*referenceframe.PoseInFrame, error) {
+	ctx = metadata.AppendToOutgoingContext(ctx, robot.TransformPoseTimeMetadataKey, t.Format(time.RFC3339Nano))
+	return rc.TransformPose(ctx, query, destination, additionalTransforms)
+}
+
 // Status takes a list of resource names and returns their corresponding statuses. If no names are passed in, return all statuses.
 func (rc *RobotClient) Status(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
 	names := make([]*commonpb.ResourceName, 0, len(resourceNames))
@@ -647,6 +664,15 @@ func (rc *RobotClient) Status(ctx context.Context, resourceNames []resource.Name
 	return statuses, nil
 }
 
+// ResourceGraph returns the graph of the dependencies between the resources of the robot, with the state of each.
+func (rc *RobotClient) ResourceGraph(ctx context.Context) (robot.ResourceGraph, error) {
+	var resp structpb.Struct
+	if err := rc.conn.Invoke(ctx, robotserver.GetResourceGraphMethod, &emptypb.Empty{}, &resp); err != nil {
+		return robot.ResourceGraph{}, err
+	}
+	return robotserver.ResourceGraphFromProto(&resp)
+}
+
 // StopAll cancels all current and outstanding operations for the robot and stops all actuators and movement.
 func (rc *RobotClient) StopAll(ctx context.Context, extra map[resource.Name]map[string]interface{}) error {
 	e := []*pb.StopExtraParameters{}
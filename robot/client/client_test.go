@@ -1029,6 +1029,50 @@ func TestClientDiscovery(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+// graphRobot is a robot which describes its resource graph.
+type graphRobot struct {
+	*inject.Robot
+	graph robot.ResourceGraph
+}
+
+func (r *graphRobot) ResourceGraph(ctx context.Context) (robot.ResourceGraph, error) {
+	return r.graph, nil
+}
+
+func TestClientResourceGraph(t *testing.T) {
+	injectRobot := &inject.Robot{}
+	injectRobot.ResourceRPCSubtypesFunc = func() []resource.RPCSubtype { return nil }
+	injectRobot.ResourceNamesFunc = func() []resource.Name { return nil }
+	graph := robot.ResourceGraph{
+		Nodes: []robot.ResourceGraphNode{
+			{Name: "rdk:component:base/base1", State: robot.ResourceStatePending, LastReason: "added"},
+			{Name: "rdk:component:motor/left", State: robot.ResourceStateReady, LastAction: "built"},
+		},
+		Edges: []robot.ResourceGraphEdge{{From: "rdk:component:base/base1", To: "rdk:component:motor/left"}},
+	}
+
+	gServer := grpc.NewServer()
+	robotServer := server.New(&graphRobot{Robot: injectRobot, graph: graph})
+	pb.RegisterRobotServiceServer(gServer, robotServer)
+	gServer.RegisterService(&server.ResourceGraphServiceDesc, robotServer)
+	listener, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	logger := golog.NewTestLogger(t)
+
+	go gServer.Serve(listener)
+	defer gServer.Stop()
+
+	client, err := New(context.Background(), listener.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	got, err := client.ResourceGraph(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, got, test.ShouldResemble, graph)
+
+	err = client.Close(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+}
+
 func ensurePartsAreEqual(part, otherPart *config.FrameSystemPart) error {
 	if part.Name != otherPart.Name {
 		return errors.Errorf("part had name %s while other part had name %s", part.Name, otherPart.Name)
@@ -1425,6 +1469,65 @@ func TestClientStopAll(t *testing.T) {
 	test.That(t, err, test.ShouldBeNil)
 }
 
+func TestClientTransformPoseAtTime(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	listener1, err := net.Listen("tcp", "localhost:0")
+	test.That(t, err, test.ShouldBeNil)
+	gServer1 := grpc.NewServer()
+	resourcesFunc := func() []resource.Name { return []resource.Name{} }
+	var transformTime time.Time
+	transformedPose := referenceframe.NewPoseInFrame("frame", spatialmath.NewPoseFromPoint(r3.Vector{X: 1, Y: 2, Z: 3}))
+	injectRobot1 := &inject.Robot{
+		ResourceNamesFunc:       resourcesFunc,
+		ResourceRPCSubtypesFunc: func() []resource.RPCSubtype { return nil },
+		TransformPoseFunc: func(
+			ctx context.Context,
+			pose *referenceframe.PoseInFrame,
+			dst string,
+			additionalTransforms []*commonpb.Transform,
+		) (*referenceframe.PoseInFrame, error) {
+			return nil, errors.New("pose transformed at the current time")
+		},
+		TransformPoseAtTimeFunc: func(
+			ctx context.Context,
+			pose *referenceframe.PoseInFrame,
+			dst string,
+			additionalTransforms []*commonpb.Transform,
+			t time.Time,
+		) (*referenceframe.PoseInFrame, error) {
+			transformTime = t
+			return transformedPose, nil
+		},
+	}
+	pb.RegisterRobotServiceServer(gServer1, server.New(injectRobot1))
+
+	go gServer1.Serve(listener1)
+	defer gServer1.Stop()
+
+	client, err := New(context.Background(), listener1.Addr().String(), logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	at := time.Now().Add(-time.Second)
+	pose, err := client.TransformPoseAtTime(
+		context.Background(),
+		referenceframe.NewPoseInFrame("world", spatialmath.NewZeroPose()),
+		"frame",
+		nil,
+		at,
+	)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, transformTime.Equal(at), test.ShouldBeTrue)
+	test.That(t, pose.FrameName(), test.ShouldEqual, "frame")
+	test.That(t, spatialmath.PoseAlmostEqual(pose.Pose(), transformedPose.Pose()), test.ShouldBeTrue)
+
+	_, err = client.TransformPose(context.Background(), referenceframe.NewPoseInFrame("world", spatialmath.NewZeroPose()), "frame", nil)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "current time")
+
+	err = client.Close(context.Background())
+	test.That(t, err, test.ShouldBeNil)
+}
+
 func TestRemoteClientMatch(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	listener1, err := net.Listen("tcp", "localhost:0")
@@ -3,12 +3,15 @@ package framesystem
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
 	commonpb "go.viam.com/api/common/v1"
+	goutils "go.viam.com/utils"
 
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/referenceframe"
@@ -30,6 +33,14 @@ type Service interface {
 		ctx context.Context, pose *referenceframe.PoseInFrame, dst string,
 		additionalTransforms []*commonpb.Transform,
 	) (*referenceframe.PoseInFrame, error)
+	// TransformPoseAtTime is TransformPose using the inputs of the robot at the given time in the recent past, as recorded
+	// in the service's history, rather than its current inputs.
+	TransformPoseAtTime(
+		ctx context.Context, pose *referenceframe.PoseInFrame, dst string,
+		additionalTransforms []*commonpb.Transform, t time.Time,
+	) (*referenceframe.PoseInFrame, error)
+	// History returns the recorded inputs of the robot, which are only recorded if the robot's config enables it.
+	History() *InputHistory
 }
 
 // RobotFsCurrentInputs will get present inputs for a framesystem from a robot and return a map of those inputs, as well as a map of the
@@ -73,11 +84,16 @@ func RobotFsCurrentInputs(
 	return input, resources, nil
 }
 
-// New returns a new frame system service for the given robot.
+// New returns a new frame system service for the given robot. If the robot's config enables the frame system history, the
+// service records the inputs of the robot from when it is updated until it is closed.
 func New(ctx context.Context, r robot.Robot, logger golog.Logger) Service {
+	cancelCtx, cancel := context.WithCancel(context.Background())
 	return &frameSystemService{
-		r:      r,
-		logger: logger,
+		r:         r,
+		logger:    logger,
+		history:   NewInputHistory(DefaultHistoryDuration),
+		cancelCtx: cancelCtx,
+		cancel:    cancel,
 	}
 }
 
@@ -89,6 +105,16 @@ type frameSystemService struct {
 	localParts  framesystemparts.Parts             // gotten from the local robot's config.Config
 	offsetParts map[string]*config.FrameSystemPart // gotten from local robot's config.Remote
 	logger      golog.Logger
+
+	// the frame system as of the last update, whose inputs are recorded in the history
+	fs      referenceframe.FrameSystem
+	history *InputHistory
+	// the history config being recorded under and how to stop recording, both nil while the history is off
+	historyConfig           *config.FrameSystemHistoryConfig
+	stopRecording           func()
+	cancelCtx               context.Context
+	cancel                  func()
+	activeBackgroundWorkers sync.WaitGroup
 }
 
 // Update will rebuild the frame system from the newly updated robot.
@@ -115,9 +141,104 @@ func (svc *frameSystemService) Update(ctx context.Context, resources map[resourc
 		return err
 	}
 	svc.logger.Debugf("updated robot frame system:\n%v", sortedParts.String())
+	// the history is not essential, so a frame system which cannot be built only prevents its inputs being recorded
+	fs, err := NewFrameSystemFromParts(LocalFrameSystemName, "", sortedParts, svc.logger)
+	if err != nil {
+		svc.logger.Debugw("cannot record the inputs of the frame system", "error", err)
+	}
+	local, ok := svc.r.(robot.LocalRobot)
+	if !ok {
+		return robot.NewUnimplementedLocalInterfaceError(svc.r)
+	}
+	cfg, err := local.Config(ctx)
+	if err != nil {
+		return err
+	}
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.fs = fs
+	if svc.cancelCtx.Err() != nil {
+		return nil
+	}
+	svc.updateRecording(cfg.FrameSystem.History)
+	return nil
+}
+
+// updateRecording starts, stops or restarts recording the inputs of the robot so that it follows the given history config.
+// It must be called with the lock held.
+func (svc *frameSystemService) updateRecording(historyConfig *config.FrameSystemHistoryConfig) {
+	if reflect.DeepEqual(historyConfig, svc.historyConfig) {
+		return
+	}
+	if svc.stopRecording != nil {
+		svc.stopRecording()
+		svc.stopRecording = nil
+	}
+	svc.historyConfig = historyConfig
+	if historyConfig == nil {
+		svc.history = NewInputHistory(DefaultHistoryDuration)
+		return
+	}
+
+	interval, duration := historyConfig.Interval, historyConfig.Duration
+	if interval == 0 {
+		interval = DefaultHistoryInterval
+	}
+	if duration == 0 {
+		duration = DefaultHistoryDuration
+	}
+	history := NewInputHistory(duration)
+	svc.history = history
+	ctx, cancel := context.WithCancel(svc.cancelCtx)
+	svc.stopRecording = cancel
+	svc.activeBackgroundWorkers.Add(1)
+	goutils.ManagedGo(func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			svc.recordInputs(ctx, history)
+		}
+	}, svc.activeBackgroundWorkers.Done)
+}
+
+// Close stops recording the inputs of the robot.
+func (svc *frameSystemService) Close(ctx context.Context) error {
+	svc.mu.Lock()
+	svc.cancel()
+	svc.mu.Unlock()
+	svc.activeBackgroundWorkers.Wait()
 	return nil
 }
 
+// History returns the recorded inputs of the robot, which are empty while the history is off.
+func (svc *frameSystemService) History() *InputHistory {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.history
+}
+
+// recordInputs adds the current inputs of the robot to the history. Failures are only logged, since they are expected while
+// the robot is being reconfigured, and the history is simply left without inputs for this time.
+func (svc *frameSystemService) recordInputs(ctx context.Context, history *InputHistory) {
+	svc.mu.RLock()
+	fs := svc.fs
+	svc.mu.RUnlock()
+	if fs == nil {
+		return
+	}
+	inputs, _, err := RobotFsCurrentInputs(ctx, svc.r, fs)
+	if err != nil {
+		svc.logger.Debugw("failed to record frame system inputs", "error", err)
+		return
+	}
+	history.Add(time.Now(), inputs)
+}
+
 // Config returns the info of each individual part that makes up the frame system
 // The output of this function is to be sent over GRPC to the client, so the client
 // can build its frame system. requests the remote components from the remote's frame system service.
@@ -155,10 +276,61 @@ func (svc *frameSystemService) TransformPose(
 ) (*referenceframe.PoseInFrame, error) {
 	ctx, span := trace.StartSpan(ctx, "services::framesystem::TransformPose")
 	defer span.End()
+	return svc.transformPose(ctx, pose, dst, additionalTransforms, func(fs referenceframe.FrameSystem) (map[string][]referenceframe.Input, error) {
+		input, _, err := RobotFsCurrentInputs(ctx, svc.r, fs)
+		return input, err
+	})
+}
+
+// TransformPoseAtTime will transform the pose of the requested poseInFrame to the desired frame in the robot's frame system,
+// as it was at the given time. The inputs of the robot at that time are interpolated from its recorded history.
+func (svc *frameSystemService) TransformPoseAtTime(
+	ctx context.Context,
+	pose *referenceframe.PoseInFrame,
+	dst string,
+	additionalTransforms []*commonpb.Transform,
+	t time.Time,
+) (*referenceframe.PoseInFrame, error) {
+	ctx, span := trace.StartSpan(ctx, "services::framesystem::TransformPoseAtTime")
+	defer span.End()
+	svc.mu.RLock()
+	enabled, history := svc.historyConfig != nil, svc.history
+	svc.mu.RUnlock()
+	if !enabled {
+		return nil, errors.New("the frame system history is not enabled in the robot's config")
+	}
+	return svc.transformPose(ctx, pose, dst, additionalTransforms, func(fs referenceframe.FrameSystem) (map[string][]referenceframe.Input, error) {
+		past, err := history.InputsAt(t)
+		if err != nil {
+			return nil, err
+		}
+		input := referenceframe.StartPositions(fs)
+		for name, original := range input {
+			if len(original) == 0 {
+				continue
+			}
+			pastInputs, ok := past[name]
+			if !ok {
+				return nil, errors.Errorf("no history of the inputs of %q at time %s", name, t.Format(time.RFC3339Nano))
+			}
+			input[name] = pastInputs
+		}
+		return input, nil
+	})
+}
+
+// transformPose transforms the pose in the robot's frame system, with the inputs of the frame system given by a function.
+func (svc *frameSystemService) transformPose(
+	ctx context.Context,
+	pose *referenceframe.PoseInFrame,
+	dst string,
+	additionalTransforms []*commonpb.Transform,
+	inputs func(referenceframe.FrameSystem) (map[string][]referenceframe.Input, error),
+) (*referenceframe.PoseInFrame, error) {
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
 
-	// get the frame system and initial inputs
+	// get the frame system and inputs
 	allParts, err := svc.Config(ctx, additionalTransforms)
 	if err != nil {
 		return nil, err
@@ -167,31 +339,9 @@ func (svc *frameSystemService) TransformPose(
 	if err != nil {
 		return nil, err
 	}
-	input := referenceframe.StartPositions(fs)
-
-	// build maps of relevant components and inputs from initial inputs
-	for name, original := range input {
-		// determine frames to skip
-		if len(original) == 0 {
-			continue
-		}
-
-		// add component to map
-		components := robot.AllResourcesByName(svc.r, name)
-		if len(components) != 1 {
-			return nil, fmt.Errorf("got %d resources instead of 1 for (%s)", len(components), name)
-		}
-		component, ok := components[0].(referenceframe.InputEnabled)
-		if !ok {
-			return nil, fmt.Errorf("%v(%T) is not InputEnabled", name, components[0])
-		}
-
-		// add input to map
-		pos, err := component.CurrentInputs(ctx)
-		if err != nil {
-			return nil, err
-		}
-		input[name] = pos
+	input, err := inputs(fs)
+	if err != nil {
+		return nil, err
 	}
 
 	tf, err := fs.Transform(input, pose, dst)
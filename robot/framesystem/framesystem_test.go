@@ -81,6 +81,46 @@ func TestNewFrameSystemFromParts(t *testing.T) {
 	test.That(t, resFrame, test.ShouldResemble, frameSys.World())
 }
 
+func TestPartsToURDF(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	model, err := referenceframe.UnmarshalModelJSON([]byte(`{
+		"name": "slider",
+		"links": [{"id": "base", "parent": "world", "translation": {"x": 0, "y": 0, "z": 0}}],
+		"joints": [{"id": "slide", "type": "prismatic", "parent": "base", "axis": {"x": 1, "y": 0, "z": 0}, "max": 100, "min": 0}]
+	}`), "")
+	test.That(t, err, test.ShouldBeNil)
+	fsConfigs := []*config.FrameSystemPart{
+		{
+			Name: "gantry",
+			FrameConfig: &config.Frame{
+				Parent:      referenceframe.World,
+				Translation: r3.Vector{X: 1000},
+			},
+			ModelFrame: model,
+		},
+		{
+			Name: "camera",
+			FrameConfig: &config.Frame{
+				Parent:      "gantry",
+				Translation: r3.Vector{Z: 50},
+			},
+		},
+	}
+	urdf, err := framesystem.PartsToURDF("robot", fsConfigs, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	// the parts form a serial chain, so the URDF can be read back as a model reaching the camera
+	chain, err := referenceframe.UnmarshalModelURDF(urdf, "", "")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, chain.Name(), test.ShouldEqual, "robot")
+	pose, err := chain.Transform([]referenceframe.Input{{Value: 30}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(pose.Point(), r3.Vector{X: 1030, Z: 50}, 1e-8), test.ShouldBeTrue)
+
+	_, err = framesystem.PartsToURDF("robot", []*config.FrameSystemPart{fsConfigs[1]}, logger)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
 func TestNewFrameSystemFromPartsBadConfig(t *testing.T) {
 	logger := golog.NewTestLogger(t)
 	badFSConfigs := []*config.FrameSystemPart{
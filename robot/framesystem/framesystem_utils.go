@@ -98,6 +98,16 @@ func NewFrameSystemFromParts(
 	return fs, nil
 }
 
+// PartsToURDF describes the frame system assembled from a collection of parts as a URDF robot with the given name, so that
+// the robot can be used by tools which read URDF.
+func PartsToURDF(name string, parts framesystemparts.Parts, logger golog.Logger) ([]byte, error) {
+	fs, err := NewFrameSystemFromParts(name, "", parts, logger)
+	if err != nil {
+		return nil, err
+	}
+	return referenceframe.MarshalFrameSystemURDF(fs)
+}
+
 // combineParts combines the local, remote, and offset parts into one slice.
 // Renaming of the remote parts does not happen in this function.
 func combineParts(
@@ -0,0 +1,112 @@
+package framesystem
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/referenceframe"
+)
+
+const (
+	// DefaultHistoryDuration is how far back the frame system service remembers the inputs of the robot.
+	DefaultHistoryDuration = 10 * time.Second
+
+	// DefaultHistoryInterval is how often the frame system service records the inputs of the robot.
+	DefaultHistoryInterval = 100 * time.Millisecond
+)
+
+// InputHistory is a buffer of the inputs of a frame system over a window of time, from which the inputs at any time within
+// the window are interpolated. It is safe for concurrent use.
+type InputHistory struct {
+	mu       sync.RWMutex
+	duration time.Duration
+	samples  []inputSample
+}
+
+type inputSample struct {
+	time   time.Time
+	inputs map[string][]referenceframe.Input
+}
+
+// NewInputHistory returns an empty history which remembers inputs for the given duration after the latest of them.
+func NewInputHistory(duration time.Duration) *InputHistory {
+	return &InputHistory{duration: duration}
+}
+
+// Add records the inputs of the frame system at the given time, and forgets any which are now too old.
+func (h *InputHistory) Add(t time.Time, inputs map[string][]referenceframe.Input) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	i := sort.Search(len(h.samples), func(i int) bool { return h.samples[i].time.After(t) })
+	h.samples = append(h.samples, inputSample{})
+	copy(h.samples[i+1:], h.samples[i:])
+	h.samples[i] = inputSample{time: t, inputs: inputs}
+
+	oldest := h.samples[len(h.samples)-1].time.Add(-h.duration)
+	expired := sort.Search(len(h.samples), func(i int) bool { return !h.samples[i].time.Before(oldest) })
+	h.samples = h.samples[expired:]
+}
+
+// Span returns the times of the earliest and latest inputs in the history, and false if it is empty.
+func (h *InputHistory) Span() (time.Time, time.Time, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.samples) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	return h.samples[0].time, h.samples[len(h.samples)-1].time, true
+}
+
+// InputsAt returns the inputs of the frame system at the given time, interpolating linearly between the inputs recorded
+// immediately before and after it. Times outside of the history are an error, as inputs are never extrapolated.
+func (h *InputHistory) InputsAt(t time.Time) (map[string][]referenceframe.Input, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if len(h.samples) == 0 {
+		return nil, errors.New("frame system history is empty")
+	}
+	first, last := h.samples[0], h.samples[len(h.samples)-1]
+	if t.Before(first.time) || t.After(last.time) {
+		return nil, errors.Errorf(
+			"time %s is outside of the frame system history, which spans %s to %s",
+			t.Format(time.RFC3339Nano), first.time.Format(time.RFC3339Nano), last.time.Format(time.RFC3339Nano),
+		)
+	}
+	// the first sample after t, which exists unless t is the time of the last sample
+	i := sort.Search(len(h.samples), func(i int) bool { return h.samples[i].time.After(t) })
+	if i == len(h.samples) {
+		return copyInputs(last.inputs), nil
+	}
+	before, after := h.samples[i-1], h.samples[i]
+	by := float64(t.Sub(before.time)) / float64(after.time.Sub(before.time))
+	inputs := make(map[string][]referenceframe.Input, len(before.inputs))
+	for name, from := range before.inputs {
+		to, ok := after.inputs[name]
+		if !ok || len(to) != len(from) {
+			// the frame changed between samples, so use whichever sample is closer
+			if by >= 0.5 && ok {
+				from = to
+			}
+			inputs[name] = append([]referenceframe.Input{}, from...)
+			continue
+		}
+		inputs[name] = referenceframe.InterpolateInputs(from, to, by)
+	}
+	for name, to := range after.inputs {
+		if _, ok := inputs[name]; !ok {
+			inputs[name] = append([]referenceframe.Input{}, to...)
+		}
+	}
+	return inputs, nil
+}
+
+func copyInputs(inputs map[string][]referenceframe.Input) map[string][]referenceframe.Input {
+	copied := make(map[string][]referenceframe.Input, len(inputs))
+	for name, values := range inputs {
+		copied[name] = append([]referenceframe.Input{}, values...)
+	}
+	return copied
+}
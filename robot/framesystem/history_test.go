@@ -0,0 +1,78 @@
+package framesystem_test
+
+import (
+	"testing"
+	"time"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/robot/framesystem"
+)
+
+func TestInputHistory(t *testing.T) {
+	history := framesystem.NewInputHistory(time.Second)
+	start := time.Now()
+
+	_, _, ok := history.Span()
+	test.That(t, ok, test.ShouldBeFalse)
+	_, err := history.InputsAt(start)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// samples may arrive out of order
+	history.Add(start.Add(200*time.Millisecond), map[string][]referenceframe.Input{"arm": {{2}, {20}}, "gantry": {{5}}})
+	history.Add(start, map[string][]referenceframe.Input{"arm": {{0}, {0}}})
+	history.Add(start.Add(100*time.Millisecond), map[string][]referenceframe.Input{"arm": {{1}, {10}}})
+
+	earliest, latest, ok := history.Span()
+	test.That(t, ok, test.ShouldBeTrue)
+	test.That(t, earliest, test.ShouldEqual, start)
+	test.That(t, latest, test.ShouldEqual, start.Add(200*time.Millisecond))
+
+	t.Run("interpolation", func(t *testing.T) {
+		inputs, err := history.InputsAt(start.Add(50 * time.Millisecond))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inputs["arm"][0].Value, test.ShouldAlmostEqual, 0.5)
+		test.That(t, inputs["arm"][1].Value, test.ShouldAlmostEqual, 5)
+
+		inputs, err = history.InputsAt(start.Add(175 * time.Millisecond))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inputs["arm"][0].Value, test.ShouldAlmostEqual, 1.75)
+		test.That(t, inputs["arm"][1].Value, test.ShouldAlmostEqual, 17.5)
+		// frames which were only recorded later are taken from the later sample
+		test.That(t, inputs["gantry"][0].Value, test.ShouldAlmostEqual, 5)
+	})
+
+	t.Run("exact times", func(t *testing.T) {
+		inputs, err := history.InputsAt(start)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inputs["arm"][1].Value, test.ShouldAlmostEqual, 0)
+
+		inputs, err = history.InputsAt(latest)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inputs["arm"][1].Value, test.ShouldAlmostEqual, 20)
+
+		// returned inputs do not alias the history
+		inputs["arm"][1].Value = 100
+		inputs, err = history.InputsAt(latest)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inputs["arm"][1].Value, test.ShouldAlmostEqual, 20)
+	})
+
+	t.Run("outside of the history", func(t *testing.T) {
+		_, err := history.InputsAt(start.Add(-time.Millisecond))
+		test.That(t, err, test.ShouldNotBeNil)
+		_, err = history.InputsAt(latest.Add(time.Millisecond))
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("old samples are forgotten", func(t *testing.T) {
+		history.Add(start.Add(1150*time.Millisecond), map[string][]referenceframe.Input{"arm": {{3}, {30}}})
+		earliest, latest, ok := history.Span()
+		test.That(t, ok, test.ShouldBeTrue)
+		test.That(t, earliest, test.ShouldEqual, start.Add(200*time.Millisecond))
+		test.That(t, latest, test.ShouldEqual, start.Add(1150*time.Millisecond))
+		_, err := history.InputsAt(start.Add(100 * time.Millisecond))
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
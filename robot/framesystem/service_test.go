@@ -4,6 +4,7 @@ import (
 	"context"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
@@ -11,6 +12,8 @@ import (
 	// register.
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/test"
+	goutils "go.viam.com/utils"
+	"go.viam.com/utils/testutils"
 
 	"go.viam.com/rdk/components/base"
 	"go.viam.com/rdk/components/gripper"
@@ -177,6 +180,71 @@ func TestFrameSystemFromConfig(t *testing.T) {
 	pointAlmostEqual(t, transformPose.Pose().Point(), gripperPt)
 }
 
+func TestFrameSystemHistory(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+	gripperPose := referenceframe.NewPoseInFrame("pieceGripper", spatialmath.NewZeroPose())
+
+	t.Run("disabled", func(t *testing.T) {
+		cfg, err := config.Read(ctx, rdkutils.ResolveFile("robot/impl/data/fake.json"), logger)
+		test.That(t, err, test.ShouldBeNil)
+		r, err := robotimpl.New(ctx, cfg, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer r.Close(ctx)
+
+		service := framesystem.New(ctx, r, logger)
+		defer func() {
+			test.That(t, goutils.TryClose(ctx, service), test.ShouldBeNil)
+		}()
+		test.That(t, service.(resource.Updateable).Update(ctx, nil), test.ShouldBeNil)
+		time.Sleep(3 * framesystem.DefaultHistoryInterval)
+		_, _, ok := service.History().Span()
+		test.That(t, ok, test.ShouldBeFalse)
+
+		_, err = r.TransformPoseAtTime(ctx, gripperPose, referenceframe.World, nil, time.Now())
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "not enabled")
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		cfg, err := config.Read(ctx, rdkutils.ResolveFile("robot/impl/data/fake.json"), logger)
+		test.That(t, err, test.ShouldBeNil)
+		cfg.FrameSystem.History = &config.FrameSystemHistoryConfig{Interval: 10 * time.Millisecond, Duration: time.Second}
+		r, err := robotimpl.New(ctx, cfg, logger)
+		test.That(t, err, test.ShouldBeNil)
+		defer r.Close(ctx)
+
+		service := framesystem.New(ctx, r, logger)
+		defer func() {
+			test.That(t, goutils.TryClose(ctx, service), test.ShouldBeNil)
+		}()
+		test.That(t, service.(resource.Updateable).Update(ctx, nil), test.ShouldBeNil)
+		var latest time.Time
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			var ok bool
+			_, latest, ok = service.History().Span()
+			test.That(tb, ok, test.ShouldBeTrue)
+		})
+		pose, err := service.TransformPoseAtTime(ctx, gripperPose, referenceframe.World, nil, latest)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, pose.FrameName(), test.ShouldEqual, referenceframe.World)
+
+		// the robot records its own history, until its config turns it off
+		testutils.WaitForAssertion(t, func(tb testing.TB) {
+			tb.Helper()
+			_, err := r.TransformPoseAtTime(ctx, gripperPose, referenceframe.World, nil, time.Now().Add(-5*time.Millisecond))
+			test.That(tb, err, test.ShouldBeNil)
+		})
+		disabled, err := config.Read(ctx, rdkutils.ResolveFile("robot/impl/data/fake.json"), logger)
+		test.That(t, err, test.ShouldBeNil)
+		r.Reconfigure(ctx, disabled)
+		_, err = r.TransformPoseAtTime(ctx, gripperPose, referenceframe.World, nil, time.Now())
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "not enabled")
+	})
+}
+
 // All of these config files should fail.
 func TestWrongFrameSystems(t *testing.T) {
 	// use impl/data/fake_wrongconfig*.json as config input
@@ -0,0 +1,31 @@
+package robot
+
+import "time"
+
+// HealthState describes whether a resource of a robot is working.
+type HealthState string
+
+// The states of health of a resource.
+const (
+	// HealthStateHealthy is a resource which was built and has not reported errors since.
+	HealthStateHealthy HealthState = "healthy"
+	// HealthStateDegraded is a resource which was built, but which has since been reporting errors.
+	HealthStateDegraded HealthState = "degraded"
+	// HealthStateFailed is a resource which could not be built.
+	HealthStateFailed HealthState = "failed"
+)
+
+// Health describes how well a resource of a robot is working.
+type Health struct {
+	State HealthState `json:"state"`
+	// LastError is the last error from building the resource, or reported by it, if it is not healthy.
+	LastError string `json:"last_error,omitempty"`
+	// Err is the error LastError describes, so that it can be returned as it was reported.
+	Err error `json:"-"`
+	// FailureCount is how many times in a row the resource has failed to build, or reported an error.
+	FailureCount int       `json:"failure_count,omitempty"`
+	LastFailure  time.Time `json:"last_failure,omitempty"`
+	// NextRestart is when the robot next tries to build a failed resource, according to its restart policy. It is zero when
+	// the resource will not be retried before the next reconfiguration, or has no restart policy.
+	NextRestart time.Time `json:"next_restart,omitempty"`
+}
@@ -0,0 +1,147 @@
+package robotimpl
+
+import (
+	"time"
+
+	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// resourceHealth tracks how well a resource is working, and when to next try to build it if it failed to be built.
+type resourceHealth struct {
+	state       robot.HealthState
+	lastErr     error
+	failures    int
+	lastFailure time.Time
+	nextRestart time.Time
+}
+
+// health returns the health of the named resource, which is healthy if nothing has gone wrong with it.
+func (manager *resourceManager) health(name resource.Name) robot.Health {
+	manager.healthMu.Lock()
+	defer manager.healthMu.Unlock()
+	h, ok := manager.healths[name]
+	if !ok {
+		return robot.Health{State: robot.HealthStateHealthy}
+	}
+	health := robot.Health{
+		State:        h.state,
+		FailureCount: h.failures,
+		LastFailure:  h.lastFailure,
+		NextRestart:  h.nextRestart,
+	}
+	if h.lastErr != nil {
+		health.LastError = h.lastErr.Error()
+		health.Err = h.lastErr
+	}
+	return health
+}
+
+// recordBuildFailure marks the named resource as failed, and schedules the next attempt to build it according to its restart
+// policy, if it has one which is not yet exhausted.
+func (manager *resourceManager) recordBuildFailure(name resource.Name, policy *config.RestartPolicy, err error) {
+	manager.healthMu.Lock()
+	defer manager.healthMu.Unlock()
+	h := manager.healths[name]
+	if h == nil || h.state != robot.HealthStateFailed {
+		h = &resourceHealth{}
+		manager.healths[name] = h
+	}
+	h.state = robot.HealthStateFailed
+	h.lastErr = err
+	h.failures++
+	h.lastFailure = time.Now()
+	h.nextRestart = time.Time{}
+	if policy == nil {
+		return
+	}
+	if policy.Exhausted(h.failures) {
+		manager.logger.Errorw("giving up on building resource until the robot is reconfigured", "resource", name, "failures", h.failures)
+		return
+	}
+	h.nextRestart = h.lastFailure.Add(policy.Backoff(h.failures))
+	select {
+	case manager.restartScheduled <- struct{}{}:
+	default:
+	}
+}
+
+// recordError marks the named resource as degraded, because it reported an error.
+func (manager *resourceManager) recordError(name resource.Name, err error) {
+	manager.healthMu.Lock()
+	defer manager.healthMu.Unlock()
+	h := manager.healths[name]
+	if h == nil || h.state != robot.HealthStateDegraded {
+		h = &resourceHealth{}
+		manager.healths[name] = h
+	}
+	h.state = robot.HealthStateDegraded
+	h.lastErr = err
+	h.failures++
+	h.lastFailure = time.Now()
+}
+
+// resetHealth marks the named resources as healthy, because they were built, stopped reporting errors, or were reconfigured.
+func (manager *resourceManager) resetHealth(names ...resource.Name) {
+	manager.healthMu.Lock()
+	defer manager.healthMu.Unlock()
+	for _, name := range names {
+		delete(manager.healths, name)
+	}
+}
+
+// shouldBuild returns whether a resource which is waiting to be built should be tried now. A resource which has failed to be
+// built is tried again whenever the robot completes its config, unless it has a restart policy, in which case it is only tried
+// once its backoff has passed, and not at all once the policy is exhausted.
+func (manager *resourceManager) shouldBuild(name resource.Name, policy *config.RestartPolicy) bool {
+	if policy == nil {
+		return true
+	}
+	manager.healthMu.Lock()
+	defer manager.healthMu.Unlock()
+	h, ok := manager.healths[name]
+	if !ok || h.state != robot.HealthStateFailed {
+		return true
+	}
+	if h.nextRestart.IsZero() || time.Now().Before(h.nextRestart) {
+		return false
+	}
+	// the restart is no longer due once it is tried; the next is scheduled if it fails again
+	h.nextRestart = time.Time{}
+	return true
+}
+
+// nextRestart returns when the next failed resource is due to be tried again, if any are.
+func (manager *resourceManager) nextRestart() (time.Time, bool) {
+	manager.healthMu.Lock()
+	defer manager.healthMu.Unlock()
+	var next time.Time
+	for _, h := range manager.healths {
+		if h.nextRestart.IsZero() {
+			continue
+		}
+		if next.IsZero() || h.nextRestart.Before(next) {
+			next = h.nextRestart
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// failedResourceNames returns the names of the local resources which have failed to be built.
+func (manager *resourceManager) failedResourceNames() []resource.Name {
+	manager.healthMu.Lock()
+	defer manager.healthMu.Unlock()
+	names := []resource.Name{}
+	for name, h := range manager.healths {
+		if h.state != robot.HealthStateFailed {
+			continue
+		}
+		if iface, ok := manager.resources.Node(name); ok {
+			if _, isPlaceholder := iface.(*resourcePlaceholder); isPlaceholder {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -45,7 +45,9 @@ var _ = robot.LocalRobot(&localRobot{})
 // localRobot satisfies robot.LocalRobot and defers most
 // logic to its manager.
 type localRobot struct {
-	mu         sync.Mutex
+	mu sync.Mutex
+	// configLock is held while the config and resources of the robot are changed, or compared against a new config.
+	configLock sync.Mutex
 	manager    *resourceManager
 	config     *config.Config
 	operations *operation.Manager
@@ -243,15 +245,23 @@ func (r *localRobot) Status(ctx context.Context, resourceNames []resource.Name)
 		}
 		resources[name] = resource
 	}
+	// resources which failed to be built have no status, but are included so that their health can be seen
+	failed := make(map[resource.Name]struct{})
+	for _, name := range r.manager.failedResourceNames() {
+		failed[name] = struct{}{}
+	}
 	r.mu.Unlock()
 
 	namesToDedupe := resourceNames
 	// if no names, return all
 	if len(namesToDedupe) == 0 {
-		namesToDedupe = make([]resource.Name, 0, len(resources))
+		namesToDedupe = make([]resource.Name, 0, len(resources)+len(failed))
 		for name := range resources {
 			namesToDedupe = append(namesToDedupe, name)
 		}
+		for name := range failed {
+			namesToDedupe = append(namesToDedupe, name)
+		}
 	}
 
 	// dedupe resourceNames
@@ -310,6 +320,11 @@ func (r *localRobot) Status(ctx context.Context, resourceNames []resource.Name)
 	for name := range deduped {
 		resourceStatus, ok := remoteStatuses[name]
 		if !ok {
+			if _, ok := failed[name]; ok {
+				health := r.manager.health(name)
+				statuses = append(statuses, robot.Status{Name: name, Status: map[string]interface{}{}, Health: &health})
+				continue
+			}
 			resource, ok := resources[name]
 			if !ok {
 				return nil, utils.NewResourceNotFoundError(name)
@@ -322,10 +337,15 @@ func (r *localRobot) Status(ctx context.Context, resourceNames []resource.Name)
 			if subtype != nil && subtype.Status != nil {
 				status, err = subtype.Status(ctx, resource)
 				if err != nil {
-					return nil, errors.Wrapf(err, "failed to get status from %q", name)
+					// a resource which cannot report its status is degraded until it can again
+					r.manager.recordError(name, errors.Wrapf(err, "failed to get status from %q", name))
+					status = map[string]interface{}{}
+				} else {
+					r.manager.resetHealth(name)
 				}
 			}
-			resourceStatus = robot.Status{Name: name, Status: status}
+			health := r.manager.health(name)
+			resourceStatus = robot.Status{Name: name, Status: status, Health: &health}
 		}
 		statuses = append(statuses, resourceStatus)
 	}
@@ -333,17 +353,28 @@ func (r *localRobot) Status(ctx context.Context, resourceNames []resource.Name)
 }
 
 func (r *localRobot) updateDefaultServiceNames(cfg *config.Config) *config.Config {
+	cfg, names := withDefaultServices(cfg)
+	for subtype, name := range names {
+		r.defaultServicesNames[subtype] = name
+	}
+	return cfg
+}
+
+// withDefaultServices adds the default services to the config which it does not already define, and returns it along with the
+// names of the default service of each subtype.
+func withDefaultServices(cfg *config.Config) (*config.Config, map[resource.Subtype]resource.Name) {
+	names := make(map[resource.Subtype]resource.Name, len(resource.DefaultServices))
 	// See if default service already exists in the config
 	seen := make(map[resource.Subtype]bool)
 	for _, name := range resource.DefaultServices {
 		seen[name.Subtype] = false
-		r.defaultServicesNames[name.Subtype] = name
+		names[name.Subtype] = name
 	}
 	// Mark default service subtypes in the map as true
 	for _, val := range cfg.Services {
 		if _, ok := seen[val.ResourceName().Subtype]; ok {
 			seen[val.ResourceName().Subtype] = true
-			r.defaultServicesNames[val.ResourceName().Subtype] = val.ResourceName()
+			names[val.ResourceName().Subtype] = val.ResourceName()
 		}
 	}
 	// default services added if they are not already defined in the config
@@ -359,7 +390,7 @@ func (r *localRobot) updateDefaultServiceNames(cfg *config.Config) *config.Confi
 		}
 		cfg.Services = append(cfg.Services, svcCfg)
 	}
-	return cfg
+	return cfg, names
 }
 
 func newWithResources(
@@ -436,22 +467,41 @@ func newWithResources(
 
 	r.activeBackgroundWorkers.Add(1)
 	r.configTimer = time.NewTicker(25 * time.Second)
-	// this goroutine tries to complete the config if any resources are still unconfigured, it execute on a timer or via a channel
+	// this goroutine tries to complete the config if any resources are still unconfigured, it execute on a timer or via a channel,
+	// or when a failed resource is due to be restarted
 	goutils.ManagedGo(func() {
 		for {
 			if closeCtx.Err() != nil {
 				return
 			}
+			var restart <-chan time.Time
+			stopRestart := func() bool { return false }
+			if next, ok := r.manager.nextRestart(); ok {
+				restartTimer := time.NewTimer(time.Until(next))
+				restart, stopRestart = restartTimer.C, restartTimer.Stop
+			}
+			var restartScheduled bool
 			select {
 			case <-closeCtx.Done():
+				stopRestart()
 				return
+			case <-r.manager.restartScheduled:
+				restartScheduled = true
+			case <-restart:
 			case <-r.triggerConfig:
 			case <-r.configTimer.C:
 			}
+			stopRestart()
+			if restartScheduled {
+				// wait for whichever restart is due first
+				continue
+			}
+			r.configLock.Lock()
 			if r.manager.anyResourcesNotConfigured() {
 				r.manager.completeConfig(closeCtx, r)
 				r.updateDefaultServices(ctx)
 			}
+			r.configLock.Unlock()
 			if r.manager.updateRemotesResourceNames(ctx, r) {
 				r.updateDefaultServices(ctx)
 			}
@@ -639,6 +689,23 @@ func (r *localRobot) TransformPose(
 	return framesystem.TransformPose(ctx, pose, dst, additionalTransforms)
 }
 
+// TransformPoseAtTime will transform the pose of the requested poseInFrame to the desired frame in the robot's frame system,
+// as it was at the given time in the recent past.
+func (r *localRobot) TransformPoseAtTime(
+	ctx context.Context,
+	pose *referenceframe.PoseInFrame,
+	dst string,
+	additionalTransforms []*commonpb.Transform,
+	t time.Time,
+) (*referenceframe.PoseInFrame, error) {
+	framesystem, err := r.fsService()
+	if err != nil {
+		return nil, err
+	}
+
+	return framesystem.TransformPoseAtTime(ctx, pose, dst, additionalTransforms, t)
+}
+
 // RobotFromConfigPath is a helper to read and process a config given its path and then create a robot based on it.
 func RobotFromConfigPath(ctx context.Context, cfgPath string, logger golog.Logger, opts ...Option) (robot.LocalRobot, error) {
 	cfg, err := config.Read(ctx, cfgPath, logger)
@@ -730,6 +797,8 @@ func dialRobotClient(ctx context.Context,
 // a best effort to remove no longer in use parts, but if it fails to do so, they could
 // possibly leak resources.
 func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config) {
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
 	var allErrs error
 
 	newConfig = r.updateDefaultServiceNames(newConfig)
@@ -782,6 +851,88 @@ func (r *localRobot) Reconfigure(ctx context.Context, newConfig *config.Config)
 	}
 }
 
+// DryRunReconfigure returns what reconfiguring the robot with the given config would do to its resources, without doing it.
+func (r *localRobot) DryRunReconfigure(ctx context.Context, newConfig *config.Config) (*config.ReconfigurePlan, error) {
+	r.configLock.Lock()
+	defer r.configLock.Unlock()
+
+	// the given config must not be touched, as it may still be used to reconfigure
+	cfg := *newConfig
+	cfg.Services = append([]config.Service{}, newConfig.Services...)
+	withDefaults, _ := withDefaultServices(&cfg)
+	diff, err := config.DiffConfigs(*r.config, *withDefaults, false)
+	if err != nil {
+		return nil, err
+	}
+	if diff.ResourcesEqual {
+		return config.NewReconfigurePlan(nil, nil, nil, nil), nil
+	}
+
+	// dependents returns the local resources which would be rebuilt or removed along with the named one
+	dependents := func(name resource.Name) []resource.Name {
+		sg, err := r.manager.resources.SubGraphFrom(name)
+		if err != nil {
+			return nil
+		}
+		names := []resource.Name{}
+		for _, n := range sg.Names() {
+			if n == name || n.ContainsRemoteNames() || n.Subtype == remoteSubtype {
+				continue
+			}
+			names = append(names, n)
+		}
+		return names
+	}
+
+	var added, modified, rebuilt, removed []resource.Name
+	for _, c := range diff.Added.Components {
+		added = append(added, c.ResourceName())
+	}
+	for _, s := range diff.Added.Services {
+		added = append(added, s.ResourceName())
+	}
+	for _, c := range diff.Modified.Components {
+		rName := c.ResourceName()
+		iface, _ := r.manager.resources.Node(rName)
+		if wrap, ok := iface.(*resourcePlaceholder); ok {
+			iface = wrap.real
+		}
+		if iface == nil {
+			// it was never built, so it will be built as if it were new
+			modified = append(modified, rName)
+			continue
+		}
+		action := config.Rebuild
+		if obj, ok := iface.(config.ComponentUpdate); ok {
+			action = obj.UpdateAction(&c)
+		}
+		switch action {
+		case config.None:
+			continue
+		case config.Reconfigure:
+			modified = append(modified, rName)
+		case config.Rebuild:
+			rebuilt = append(rebuilt, rName)
+		}
+		rebuilt = append(rebuilt, dependents(rName)...)
+	}
+	for _, s := range diff.Modified.Services {
+		modified = append(modified, s.ResourceName())
+	}
+	for _, c := range diff.Removed.Components {
+		removed = append(removed, c.ResourceName())
+		removed = append(removed, dependents(c.ResourceName())...)
+	}
+	for _, s := range diff.Removed.Services {
+		removed = append(removed, s.ResourceName())
+		removed = append(removed, dependents(s.ResourceName())...)
+	}
+	for _, remote := range diff.Removed.Remotes {
+		removed = append(removed, dependents(fromRemoteNameToRemoteNodeName(remote.Name))...)
+	}
+	return config.NewReconfigurePlan(added, modified, rebuilt, removed), nil
+}
+
 // checkMaxInstance checks to see if the local robot has reached the maximum number of a specific service type that are local.
 func (r *localRobot) checkMaxInstance(subtype resource.Subtype, max int) error {
 	maxInstance := 0
@@ -9,6 +9,7 @@ import (
 	"net"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -1050,7 +1051,8 @@ func TestStatus(t *testing.T) {
 		},
 	)
 
-	statuses := []robot.Status{{Name: button1, Status: map[string]interface{}{}}}
+	healthy := &robot.Health{State: robot.HealthStateHealthy}
+	statuses := []robot.Status{{Name: button1, Status: map[string]interface{}{}, Health: healthy}}
 	logger := golog.NewTestLogger(t)
 	resourceNames := []resource.Name{working1, button1, fail1}
 	resourceMap := map[resource.Name]interface{}{working1: "resource", button1: "resource", fail1: "resource"}
@@ -1086,8 +1088,24 @@ func TestStatus(t *testing.T) {
 		}()
 		test.That(t, err, test.ShouldBeNil)
 
-		_, err = r.Status(context.Background(), []resource.Name{fail1})
-		test.That(t, err, test.ShouldBeError, errors.Wrapf(errFailed, "failed to get status from %q", fail1))
+		// a resource which cannot report its status is degraded until it can again
+		resp, err := r.Status(context.Background(), []resource.Name{fail1})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(resp), test.ShouldEqual, 1)
+		test.That(t, resp[0].Status, test.ShouldResemble, map[string]interface{}{})
+		test.That(t, resp[0].Health.State, test.ShouldEqual, robot.HealthStateDegraded)
+		test.That(t, resp[0].Health.LastError, test.ShouldEqual, errors.Wrapf(errFailed, "failed to get status from %q", fail1).Error())
+		test.That(t, errors.Cause(resp[0].Health.Err), test.ShouldEqual, errFailed)
+		test.That(t, resp[0].Health.FailureCount, test.ShouldEqual, 1)
+
+		resp, err = r.Status(context.Background(), []resource.Name{fail1})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp[0].Health.FailureCount, test.ShouldEqual, 2)
+
+		// a resource which does not exist is still an error
+		missing := arm.Named("missing")
+		_, err = r.Status(context.Background(), []resource.Name{fail1, missing})
+		test.That(t, err, test.ShouldBeError, rutils.NewResourceNotFoundError(missing))
 	})
 
 	t.Run("many status", func(t *testing.T) {
@@ -1124,8 +1142,16 @@ func TestStatus(t *testing.T) {
 		test.That(t, resp[0].Status, test.ShouldResemble, expected[resp[0].Name])
 		test.That(t, resp[1].Status, test.ShouldResemble, expected[resp[1].Name])
 
-		_, err = r.Status(context.Background(), resourceNames)
-		test.That(t, err, test.ShouldBeError, errors.Wrapf(errFailed, "failed to get status from %q", fail1))
+		resp, err = r.Status(context.Background(), resourceNames)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(resp), test.ShouldEqual, 3)
+		for _, status := range resp {
+			if status.Name == fail1 {
+				test.That(t, status.Health.State, test.ShouldEqual, robot.HealthStateDegraded)
+			} else {
+				test.That(t, status.Health, test.ShouldResemble, healthy)
+			}
+		}
 	})
 
 	t.Run("get all status", func(t *testing.T) {
@@ -1161,6 +1187,93 @@ func TestStatus(t *testing.T) {
 	})
 }
 
+func TestRestartPolicy(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	flakySubtype := resource.NewSubtype(resource.Namespace("acme"), resource.ResourceTypeComponent, resource.SubtypeName("flaky"))
+	flaky1 := resource.NameFromSubtype(flakySubtype, "flaky1")
+	broken1 := resource.NameFromSubtype(flakySubtype, "broken1")
+
+	var mu sync.Mutex
+	flakyFailures := 2
+	registry.RegisterComponent(flakySubtype, "flaky", registry.Component{
+		Constructor: func(ctx context.Context, deps registry.Dependencies, config config.Component, logger golog.Logger) (interface{}, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if flakyFailures > 0 {
+				flakyFailures--
+				return nil, errors.New("not plugged in")
+			}
+			return "flaky", nil
+		},
+	})
+	registry.RegisterComponent(flakySubtype, "broken", registry.Component{
+		Constructor: func(ctx context.Context, deps registry.Dependencies, config config.Component, logger golog.Logger) (interface{}, error) {
+			return nil, errors.New("broken")
+		},
+	})
+
+	cfg := &config.Config{Components: []config.Component{
+		{
+			Name:          "flaky1",
+			Namespace:     "acme",
+			Type:          "flaky",
+			Model:         "flaky",
+			RestartPolicy: &config.RestartPolicy{InitialBackoff: 10 * time.Millisecond, MaxBackoff: 20 * time.Millisecond},
+		},
+		{
+			Name:          "broken1",
+			Namespace:     "acme",
+			Type:          "flaky",
+			Model:         "broken",
+			RestartPolicy: &config.RestartPolicy{MaxRestarts: 1, InitialBackoff: 10 * time.Millisecond},
+		},
+	}}
+	for _, conf := range cfg.Components {
+		_, err := conf.Validate("path")
+		test.That(t, err, test.ShouldBeNil)
+	}
+	r, err := robotimpl.New(context.Background(), cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	resp, err := r.Status(context.Background(), []resource.Name{flaky1})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp[0].Health.State, test.ShouldEqual, robot.HealthStateFailed)
+	test.That(t, resp[0].Health.LastError, test.ShouldContainSubstring, "not plugged in")
+	test.That(t, resp[0].Health.NextRestart.IsZero(), test.ShouldBeFalse)
+
+	// the flaky component is built once it stops failing, without reconfiguring the robot
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		resp, err := r.Status(context.Background(), []resource.Name{flaky1})
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, resp[0].Health, test.ShouldResemble, &robot.Health{State: robot.HealthStateHealthy})
+	})
+	_, err = r.ResourceByName(flaky1)
+	test.That(t, err, test.ShouldBeNil)
+
+	// the broken component is given up on once it has been restarted as many times as its policy allows
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		resp, err := r.Status(context.Background(), []resource.Name{})
+		test.That(tb, err, test.ShouldBeNil)
+		var broken *robot.Health
+		for _, status := range resp {
+			if status.Name == broken1 {
+				broken = status.Health
+			}
+		}
+		test.That(tb, broken, test.ShouldNotBeNil)
+		test.That(tb, broken.State, test.ShouldEqual, robot.HealthStateFailed)
+		test.That(tb, broken.FailureCount, test.ShouldEqual, 2)
+		test.That(tb, broken.NextRestart.IsZero(), test.ShouldBeTrue)
+	})
+	_, err = r.ResourceByName(broken1)
+	test.That(t, err, test.ShouldBeError, rutils.NewResourceNotFoundError(broken1))
+}
+
 func TestStatusRemote(t *testing.T) {
 	// set up remotes
 	listener1 := testutils.ReserveRandomListener(t)
@@ -1461,6 +1574,83 @@ func TestResourceStartsOnReconfigure(t *testing.T) {
 	test.That(t, yesSvc, test.ShouldNotBeNil)
 }
 
+func TestDryRunReconfigure(t *testing.T) {
+	logger := golog.NewTestLogger(t)
+	ctx := context.Background()
+
+	cfg := &config.Config{
+		Components: []config.Component{
+			{
+				Namespace: resource.ResourceNamespaceRDK,
+				Name:      "arm1",
+				Type:      arm.SubtypeName,
+				Model:     "fake",
+				DependsOn: []string{"base1"},
+			},
+			{
+				Namespace: resource.ResourceNamespaceRDK,
+				Name:      "base1",
+				Type:      base.SubtypeName,
+				Model:     "fake",
+			},
+			{
+				Namespace: resource.ResourceNamespaceRDK,
+				Name:      "base2",
+				Type:      base.SubtypeName,
+				Model:     "fake",
+			},
+		},
+	}
+	newCfg := &config.Config{
+		Components: []config.Component{
+			cfg.Components[0],
+			{
+				Namespace:  resource.ResourceNamespaceRDK,
+				Name:       "base1",
+				Type:       base.SubtypeName,
+				Model:      "fake",
+				Attributes: config.AttributeMap{"one": 1},
+			},
+			{
+				Namespace: resource.ResourceNamespaceRDK,
+				Name:      "base3",
+				Type:      base.SubtypeName,
+				Model:     "fake",
+			},
+		},
+	}
+	r, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(context.Background()), test.ShouldBeNil)
+	}()
+
+	plan, err := r.DryRunReconfigure(ctx, cfg)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, plan.Empty(), test.ShouldBeTrue)
+
+	plan, err = r.DryRunReconfigure(ctx, newCfg)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, plan.Added, test.ShouldResemble, []resource.Name{base.Named("base3")})
+	test.That(t, plan.Modified, test.ShouldResemble, []resource.Name{base.Named("base1")})
+	test.That(t, plan.Rebuilt, test.ShouldResemble, []resource.Name{arm.Named("arm1")})
+	test.That(t, plan.Removed, test.ShouldResemble, []resource.Name{base.Named("base2")})
+	test.That(t, newCfg.Services, test.ShouldBeEmpty)
+
+	// nothing was actually changed
+	_, err = r.ResourceByName(base.Named("base2"))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = r.ResourceByName(base.Named("base3"))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	r.Reconfigure(ctx, newCfg)
+	_, err = r.ResourceByName(base.Named("base3"))
+	test.That(t, err, test.ShouldBeNil)
+	plan, err = r.DryRunReconfigure(ctx, newCfg)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, plan.Empty(), test.ShouldBeTrue)
+}
+
 func TestConfigProcess(t *testing.T) {
 	logger, logs := golog.NewObservedTestLogger(t)
 
@@ -1847,3 +2037,61 @@ func TestCheckMaxInstanceSkipRemote(t *testing.T) {
 	_, err = r.ResourceByName(datamanager.Named("remote:builtin"))
 	test.That(t, err, test.ShouldBeNil)
 }
+
+func TestResourceGraphDuringReconfigure(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	armConfig := func(name string, dependsOn ...string) config.Component {
+		return config.Component{
+			Namespace: resource.ResourceNamespaceRDK,
+			Name:      name,
+			Type:      arm.SubtypeName,
+			Model:     "fake",
+			DependsOn: dependsOn,
+		}
+	}
+	cfg1 := &config.Config{Components: []config.Component{armConfig("arm1")}}
+	cfg2 := &config.Config{Components: []config.Component{armConfig("arm1"), armConfig("arm2", "arm1")}}
+
+	r, err := robotimpl.New(ctx, cfg1, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer func() {
+		test.That(t, r.Close(context.Background()), test.ShouldBeNil)
+	}()
+	introspector, ok := r.(robot.GraphIntrospector)
+	test.That(t, ok, test.ShouldBeTrue)
+
+	// the graph is described while the robot is reconfigured, without racing with it
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			_, err := introspector.ResourceGraph(ctx)
+			test.That(t, err, test.ShouldBeNil)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		r.Reconfigure(ctx, cfg2)
+		r.Reconfigure(ctx, cfg1)
+	}
+	close(done)
+	wg.Wait()
+
+	graph, err := introspector.ResourceGraph(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	var node *robot.ResourceGraphNode
+	for i := range graph.Nodes {
+		if graph.Nodes[i].Name == arm.Named("arm1").String() {
+			node = &graph.Nodes[i]
+		}
+	}
+	test.That(t, node, test.ShouldNotBeNil)
+	test.That(t, node.State, test.ShouldEqual, robot.ResourceStateReady)
+}
@@ -0,0 +1,88 @@
+package robotimpl
+
+import (
+	"context"
+	"time"
+
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
+)
+
+// recordUpdate records the outcome of configuring a resource from its placeholder; the action taken if it was configured, or
+// the error if it could not be.
+func (manager *resourceManager) recordUpdate(name resource.Name, wrap *resourcePlaceholder, action string, err error) {
+	manager.updatesMu.Lock()
+	defer manager.updatesMu.Unlock()
+	wrap.err = err
+	update := manager.updates[name]
+	if err == nil {
+		update.action = action
+	}
+	update.reason = wrap.reason
+	update.err = err
+	update.at = time.Now()
+	manager.updates[name] = update
+}
+
+// setReason records why a placeholder needs its resource to be configured.
+func (manager *resourceManager) setReason(wrap *resourcePlaceholder, reason string) {
+	manager.updatesMu.Lock()
+	defer manager.updatesMu.Unlock()
+	wrap.reason = reason
+}
+
+// forgetUpdates drops the records of resources which have been removed.
+func (manager *resourceManager) forgetUpdates(names []resource.Name) {
+	manager.updatesMu.Lock()
+	defer manager.updatesMu.Unlock()
+	for _, name := range names {
+		delete(manager.updates, name)
+	}
+}
+
+// resourceGraph describes the resources in the graph, and the dependencies between them.
+func (manager *resourceManager) resourceGraph() robot.ResourceGraph {
+	manager.updatesMu.Lock()
+	defer manager.updatesMu.Unlock()
+
+	graph := robot.ResourceGraph{Nodes: []robot.ResourceGraphNode{}, Edges: []robot.ResourceGraphEdge{}}
+	// nodes are listed in the order they are built in
+	for _, name := range manager.resources.ReverseTopologicalSort() {
+		iface, _ := manager.resources.Node(name)
+		node := robot.ResourceGraphNode{Name: name.String(), Remote: string(name.Remote)}
+		switch wrap, isPlaceholder := iface.(*resourcePlaceholder); {
+		case name.ResourceType == unknownTypeName:
+			node.State = robot.ResourceStateUnresolved
+		case isPlaceholder && wrap.err != nil:
+			node.State = robot.ResourceStateFailed
+		case isPlaceholder:
+			node.State = robot.ResourceStatePending
+			node.LastReason = wrap.reason
+		case iface == nil:
+			node.State = robot.ResourceStateMissing
+		default:
+			node.State = robot.ResourceStateReady
+		}
+		if update, ok := manager.updates[name]; ok {
+			node.LastAction = update.action
+			if node.LastReason == "" {
+				node.LastReason = update.reason
+			}
+			if update.err != nil {
+				node.LastError = update.err.Error()
+			}
+			node.UpdatedAt = update.at
+		}
+		graph.Nodes = append(graph.Nodes, node)
+	}
+	for _, edge := range manager.resources.Edges() {
+		graph.Edges = append(graph.Edges, robot.ResourceGraphEdge{From: edge.Child.String(), To: edge.Parent.String()})
+	}
+	return graph
+}
+
+// ResourceGraph returns the graph of the dependencies between the resources of the robot, and what the last reconfiguration
+// of each did.
+func (r *localRobot) ResourceGraph(ctx context.Context) (robot.ResourceGraph, error) {
+	return r.manager.resourceGraph(), nil
+}
@@ -3,9 +3,11 @@ package robotimpl
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"os"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/jhump/protoreflect/desc"
@@ -45,15 +47,44 @@ type resourceManager struct {
 	opts           resourceManagerOptions
 	logger         golog.Logger
 	configLock     *sync.Mutex
+
+	updatesMu sync.Mutex
+	updates   map[resource.Name]resourceUpdate
+
+	healthMu sync.Mutex
+	healths  map[resource.Name]*resourceHealth
+	// restartScheduled is signaled when a failed resource is scheduled to be built again.
+	restartScheduled chan struct{}
 }
 
 // resourcePlaceholder we use resourcePlaceholder during a reconfiguration
 // it holds the former resource interface (nil if added) and it's most recent configuration.
+// reason records why the resource needs to be configured, and err why it could not be last time, if it could not. Both are
+// guarded by the updatesMu of the manager, since the resource graph is described while the robot is being reconfigured.
 type resourcePlaceholder struct {
 	real   interface{}
 	config interface{}
+	reason string
+	err    error
+}
+
+// resourceUpdate records what the last reconfiguration of a resource did to it, so that the resource graph can be inspected.
+type resourceUpdate struct {
+	action string
+	reason string
+	err    error
+	at     time.Time
 }
 
+// the actions a reconfiguration can take on a resource.
+const (
+	actionBuilt        = "built"
+	actionReconfigured = "reconfigured"
+	actionRebuilt      = "rebuilt"
+	actionUnchanged    = "unchanged"
+	actionConnected    = "connected"
+)
+
 type resourceManagerOptions struct {
 	debug              bool
 	fromCommand        bool
@@ -74,11 +105,14 @@ func newResourceManager(
 	logger golog.Logger,
 ) *resourceManager {
 	return &resourceManager{
-		resources:      resource.NewGraph(),
-		processManager: pexec.NewProcessManager(logger),
-		opts:           opts,
-		logger:         logger,
-		configLock:     &sync.Mutex{},
+		resources:        resource.NewGraph(),
+		processManager:   pexec.NewProcessManager(logger),
+		opts:             opts,
+		logger:           logger,
+		configLock:       &sync.Mutex{},
+		updates:          map[resource.Name]resourceUpdate{},
+		healths:          map[resource.Name]*resourceHealth{},
+		restartScheduled: make(chan struct{}, 1),
 	}
 }
 
@@ -360,26 +394,43 @@ func (manager *resourceManager) completeConfig(
 		}
 		manager.logger.Infow("we are now handling the resource ", "resource", r)
 		if c, ok := wrap.config.(config.Component); ok {
-			iface, err := manager.processComponent(ctx, r, c, wrap.real, robot)
+			if !manager.shouldBuild(r, c.RestartPolicy) {
+				continue
+			}
+			iface, action, err := manager.processComponent(ctx, r, c, wrap.real, robot)
 			if err != nil {
 				manager.logger.Errorw("error building component", "error", err)
+				manager.recordUpdate(r, wrap, "", err)
+				manager.recordBuildFailure(r, c.RestartPolicy, err)
 				continue
 			}
 			manager.resources.AddNode(r, iface)
+			manager.recordUpdate(r, wrap, action, nil)
+			manager.resetHealth(r)
 		} else if s, ok := wrap.config.(config.Service); ok {
 			iface, err := manager.processService(ctx, s, wrap.real, robot)
 			if err != nil {
 				manager.logger.Errorw("error building service", "error", err)
+				manager.recordUpdate(r, wrap, "", err)
+				manager.recordBuildFailure(r, nil, err)
 				continue
 			}
 			manager.resources.AddNode(r, iface)
+			manager.resetHealth(r)
+			action := actionBuilt
+			if wrap.real != nil {
+				action = actionReconfigured
+			}
+			manager.recordUpdate(r, wrap, action, nil)
 		} else if rc, ok := wrap.config.(config.Remote); ok {
 			rr, err := manager.processRemote(ctx, rc)
 			if err != nil {
 				manager.logger.Errorw("error connecting to remote", "error", err)
+				manager.recordUpdate(r, wrap, "", err)
 				continue
 			}
 			manager.addRemote(ctx, rr, rc, robot)
+			manager.recordUpdate(r, wrap, actionConnected, nil)
 			rr.SetParentNotifier(func() {
 				rName := rc.Name
 				if robot.closeContext.Err() != nil {
@@ -542,8 +593,10 @@ func (manager *resourceManager) markChildrenForUpdate(ctx context.Context, rName
 		wrapper := &resourcePlaceholder{
 			real:   nil,
 			config: originalConfig,
+			reason: fmt.Sprintf("dependency %s was updated", rName),
 		}
 		manager.resources.AddNode(x, wrapper)
+		manager.resetHealth(x)
 	}
 	return nil
 }
@@ -553,9 +606,10 @@ func (manager *resourceManager) processComponent(ctx context.Context,
 	conf config.Component,
 	old interface{},
 	r *localRobot,
-) (interface{}, error) {
+) (interface{}, string, error) {
 	if old == nil {
-		return r.newResource(ctx, conf)
+		nr, err := r.newResource(ctx, conf)
+		return nr, actionBuilt, err
 	}
 	obj, canValidate := old.(config.ComponentUpdate)
 	res := config.Rebuild
@@ -564,34 +618,34 @@ func (manager *resourceManager) processComponent(ctx context.Context,
 	}
 	switch res {
 	case config.None:
-		return old, nil
+		return old, actionUnchanged, nil
 	case config.Reconfigure:
 		if err := manager.markChildrenForUpdate(ctx, rName, r); err != nil {
-			return old, err
+			return old, "", err
 		}
 		nr, err := r.newResource(ctx, conf)
 		if err != nil {
-			return old, err
+			return old, "", err
 		}
 		rr, err := resource.ReconfigureResource(ctx, old, nr)
 		if err != nil {
-			return old, err
+			return old, "", err
 		}
-		return rr, nil
+		return rr, actionReconfigured, nil
 	case config.Rebuild:
 		if err := manager.markChildrenForUpdate(ctx, rName, r); err != nil {
-			return old, err
+			return old, "", err
 		}
 		if err := utils.TryClose(ctx, old); err != nil {
-			return old, err
+			return old, "", err
 		}
 		nr, err := r.newResource(ctx, conf)
 		if err != nil {
-			return old, err
+			return old, "", err
 		}
-		return nr, nil
+		return nr, actionRebuilt, nil
 	default:
-		return old, errors.New("un-handeled case of reconfigure action")
+		return old, "", errors.New("un-handeled case of reconfigure action")
 	}
 }
 
@@ -610,6 +664,13 @@ func (manager *resourceManager) wrapResource(name resource.Name, config interfac
 			config: config,
 		}
 	}
+	if wrapper.real == nil {
+		manager.setReason(wrapper, "added")
+	} else {
+		manager.setReason(wrapper, "config modified")
+	}
+	// a new config gets a fresh start, however often the last one failed
+	manager.resetHealth(name)
 	// the first thing we need to do is seek if the resource name already exists as an unknownType, if so
 	// we need to replace it
 	if old, ok := manager.resources.FindNodeByName(name.Name); ok && old.ResourceType == unknownTypeName {
@@ -819,6 +880,8 @@ func (manager *resourceManager) FilterFromConfig(ctx context.Context, conf *conf
 		}
 	}
 	manager.resources.MergeRemove(filtered.resources)
+	manager.forgetUpdates(filtered.resources.Names())
+	manager.resetHealth(filtered.resources.Names()...)
 	return filtered, nil
 }
 
@@ -6,6 +6,7 @@ import (
 	"crypto/x509"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/google/go-cmp/cmp"
@@ -491,6 +492,7 @@ func TestManagerAdd(t *testing.T) {
 		componentName resource.Name,
 		grabPose *referenceframe.PoseInFrame,
 		worldState *commonpb.WorldState,
+		constraints *motion.Constraints,
 		extra map[string]interface{},
 	) (bool, error) {
 		return false, nil
@@ -1742,6 +1744,16 @@ func (rr *dummyRobot) TransformPose(
 	panic("change to return nil")
 }
 
+func (rr *dummyRobot) TransformPoseAtTime(
+	ctx context.Context,
+	pose *referenceframe.PoseInFrame,
+	dst string,
+	additionalTransforms []*commonpb.Transform,
+	t time.Time,
+) (*referenceframe.PoseInFrame, error) {
+	panic("change to return nil")
+}
+
 func (rr *dummyRobot) Status(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
 	panic("change to return nil")
 }
@@ -0,0 +1,109 @@
+package robot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ResourceState describes where a resource in the graph of a robot is in being built.
+type ResourceState string
+
+// The states of a resource in the graph of a robot.
+const (
+	// ResourceStateReady is a resource which has been built and can be used.
+	ResourceStateReady ResourceState = "ready"
+	// ResourceStatePending is a resource waiting to be built or rebuilt by the next reconfiguration.
+	ResourceStatePending ResourceState = "pending"
+	// ResourceStateFailed is a resource which failed to build, and is retried by the next reconfiguration.
+	ResourceStateFailed ResourceState = "failed"
+	// ResourceStateMissing is a resource which is depended on but has not been configured, or a remote which has not connected.
+	ResourceStateMissing ResourceState = "missing"
+	// ResourceStateUnresolved is a dependency on a resource of a remote which has not been found on any remote yet.
+	ResourceStateUnresolved ResourceState = "unresolved"
+)
+
+// ResourceGraphNode describes a resource in the graph of a robot, along with what last happened to it.
+type ResourceGraphNode struct {
+	Name  string        `json:"name"`
+	State ResourceState `json:"state"`
+	// Remote is the remote the resource came from, if any.
+	Remote string `json:"remote,omitempty"`
+	// LastAction is what the last reconfiguration did to the resource; built, reconfigured, rebuilt or left unchanged.
+	LastAction string `json:"last_action,omitempty"`
+	// LastReason is why the resource was last configured; because it was added, because its config was modified, or because
+	// a resource it depends on was.
+	LastReason string    `json:"last_reason,omitempty"`
+	LastError  string    `json:"last_error,omitempty"`
+	UpdatedAt  time.Time `json:"updated_at,omitempty"`
+}
+
+// ResourceGraphEdge is a dependency of one resource on another.
+type ResourceGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ResourceGraph is the graph of the dependencies between the resources of a robot, from which they are built in order.
+type ResourceGraph struct {
+	Nodes []ResourceGraphNode `json:"nodes"`
+	Edges []ResourceGraphEdge `json:"edges"`
+}
+
+// A GraphIntrospector is a robot which can describe the graph of its resources.
+type GraphIntrospector interface {
+	// ResourceGraph returns the current graph of the resources of the robot.
+	ResourceGraph(ctx context.Context) (ResourceGraph, error)
+}
+
+// resourceStateColors are the colors resources are drawn in by DOT.
+var resourceStateColors = map[ResourceState]string{
+	ResourceStateReady:      "darkgreen",
+	ResourceStatePending:    "orange",
+	ResourceStateFailed:     "red",
+	ResourceStateMissing:    "gray",
+	ResourceStateUnresolved: "gray",
+}
+
+// DOT returns the graph in the Graphviz DOT language, with an edge from each resource to each resource it depends on.
+// Resources are colored by their state and grouped into a cluster for each remote.
+func (g ResourceGraph) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph resources {\n")
+	b.WriteString("\tnode [shape=box];\n")
+
+	byRemote := map[string][]ResourceGraphNode{}
+	for _, node := range g.Nodes {
+		byRemote[node.Remote] = append(byRemote[node.Remote], node)
+	}
+	remotes := make([]string, 0, len(byRemote))
+	for remote := range byRemote {
+		remotes = append(remotes, remote)
+	}
+	sort.Strings(remotes)
+
+	for i, remote := range remotes {
+		indent := "\t"
+		if remote != "" {
+			fmt.Fprintf(&b, "\tsubgraph cluster_%d {\n\t\tlabel=%q;\n", i, remote)
+			indent = "\t\t"
+		}
+		for _, node := range byRemote[remote] {
+			label := fmt.Sprintf("%s\n%s", node.Name, node.State)
+			if node.LastError != "" {
+				label += "\n" + node.LastError
+			}
+			fmt.Fprintf(&b, "%s%q [label=%q, color=%q];\n", indent, node.Name, label, resourceStateColors[node.State])
+		}
+		if remote != "" {
+			b.WriteString("\t}\n")
+		}
+	}
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%q -> %q;\n", edge.From, edge.To)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,27 @@
+package robot_test
+
+import (
+	"testing"
+
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/robot"
+)
+
+func TestResourceGraphDOT(t *testing.T) {
+	graph := robot.ResourceGraph{
+		Nodes: []robot.ResourceGraphNode{
+			{Name: "rdk:component:motor/left", State: robot.ResourceStateReady},
+			{Name: "rdk:component:base/base1", State: robot.ResourceStateFailed, LastError: "no motors"},
+			{Name: "remote1:rdk:component:camera/cam", State: robot.ResourceStateMissing, Remote: "remote1"},
+		},
+		Edges: []robot.ResourceGraphEdge{{From: "rdk:component:base/base1", To: "rdk:component:motor/left"}},
+	}
+	dot := graph.DOT()
+	test.That(t, dot, test.ShouldStartWith, "digraph resources {\n")
+	test.That(t, dot, test.ShouldContainSubstring, `"rdk:component:motor/left" [label="rdk:component:motor/left\nready", color="darkgreen"];`)
+	test.That(t, dot, test.ShouldContainSubstring, `label="rdk:component:base/base1\nfailed\nno motors", color="red"`)
+	test.That(t, dot, test.ShouldContainSubstring, "subgraph cluster_1 {\n\t\tlabel=\"remote1\";\n\t\t\"remote1:rdk:component:camera/cam\"")
+	test.That(t, dot, test.ShouldContainSubstring, "\t\"rdk:component:base/base1\" -> \"rdk:component:motor/left\";\n")
+	test.That(t, dot, test.ShouldEndWith, "}\n")
+}
@@ -3,6 +3,7 @@ package robot
 
 import (
 	"context"
+	"time"
 
 	"github.com/edaniels/golog"
 	commonpb "go.viam.com/api/common/v1"
@@ -18,6 +19,10 @@ import (
 	"go.viam.com/rdk/utils"
 )
 
+// TransformPoseTimeMetadataKey is the key of the gRPC metadata which carries the time of a TransformPose request, in RFC 3339
+// format with nanoseconds, when the pose is to be transformed as of a time in the past.
+const TransformPoseTimeMetadataKey = "viam-transform-pose-time"
+
 // NewUnimplementedLocalInterfaceError is used when there is a failed interface check.
 func NewUnimplementedLocalInterfaceError(actual interface{}) error {
 	return utils.NewUnimplementedInterfaceError((LocalRobot)(nil), actual)
@@ -64,7 +69,19 @@ type Robot interface {
 		additionalTransforms []*commonpb.Transform,
 	) (*referenceframe.PoseInFrame, error)
 
+	// TransformPoseAtTime will transform the pose of the requested poseInFrame to the desired frame in the robot's frame system,
+	// as it was at the given time in the recent past.
+	TransformPoseAtTime(
+		ctx context.Context,
+		pose *referenceframe.PoseInFrame,
+		dst string,
+		additionalTransforms []*commonpb.Transform,
+		t time.Time,
+	) (*referenceframe.PoseInFrame, error)
+
 	// Status takes a list of resource names and returns their corresponding statuses. If no names are passed in, return all statuses.
+	// A resource which cannot report its status does not fail the call; it is returned as degraded, with the error in its health.
+	// A resource which failed to be built is returned as failed. Names of resources which do not exist are still an error.
 	Status(ctx context.Context, resourceNames []resource.Name) ([]Status, error)
 
 	// Close attempts to cleanly close down all constituent parts of the robot.
@@ -92,6 +109,10 @@ type LocalRobot interface {
 	// on the given new config.
 	Reconfigure(ctx context.Context, newConfig *config.Config)
 
+	// DryRunReconfigure returns what reconfiguring the robot with the given config would do
+	// to its resources, without doing it.
+	DryRunReconfigure(ctx context.Context, newConfig *config.Config) (*config.ReconfigurePlan, error)
+
 	// StartWeb starts the web server, will return an error if server is already up.
 	StartWeb(ctx context.Context, o weboptions.Options) error
 
@@ -110,9 +131,11 @@ type RemoteRobot interface {
 // Status holds a resource name and its corresponding status. Status is expected to be comprised of string keys
 // and values comprised of primitives, list of primitives, maps with string keys (or at least can be decomposed into one),
 // or lists of the forementioned type of maps. Results with other types of data are not guaranteed.
+// Health is the health of the resource, if the robot tracks it; it is not known for resources of remotes.
 type Status struct {
 	Name   resource.Name
 	Status interface{}
+	Health *Health
 }
 
 // AllResourcesByName returns an array of all resources that have this simple name.
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/rdk/robot"
+)
+
+// ResourceGraphServiceName is the name of the gRPC service which describes the resource graph of a robot. The robot protos do
+// not describe it, so it uses well known messages, and carries the graph as a struct of its JSON form. It is named under
+// the rdk rather than the API's packages, which are not ours to add to.
+const ResourceGraphServiceName = "rdk.robot.v1.ResourceGraphService"
+
+// GetResourceGraphMethod is the full name of the method which returns the resource graph of a robot.
+const GetResourceGraphMethod = "/" + ResourceGraphServiceName + "/GetResourceGraph"
+
+// ResourceGraphServer is the server of the resource graph service.
+type ResourceGraphServer interface {
+	GetResourceGraph(ctx context.Context, req *emptypb.Empty) (*structpb.Struct, error)
+}
+
+// ResourceGraphServiceDesc describes the resource graph service to an rpc.Server, alongside the robot service.
+var ResourceGraphServiceDesc = grpc.ServiceDesc{
+	ServiceName: ResourceGraphServiceName,
+	HandlerType: (*ResourceGraphServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetResourceGraph",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (
+				interface{}, error,
+			) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(ResourceGraphServer).GetResourceGraph(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: GetResourceGraphMethod}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(ResourceGraphServer).GetResourceGraph(ctx, req.(*emptypb.Empty))
+				}
+				return interceptor(ctx, in, info, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// GetResourceGraph returns the graph of the dependencies between the resources of the robot, with the state of each.
+func (s *Server) GetResourceGraph(ctx context.Context, req *emptypb.Empty) (*structpb.Struct, error) {
+	introspector, ok := s.r.(robot.GraphIntrospector)
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "robot cannot describe its resource graph")
+	}
+	graph, err := introspector.ResourceGraph(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ResourceGraphToProto(graph)
+}
+
+// ResourceGraphToProto converts a resource graph to the struct it is sent as.
+func ResourceGraphToProto(graph robot.ResourceGraph) (*structpb.Struct, error) {
+	encoded, err := json.Marshal(graph)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(encoded, &m); err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(m)
+}
+
+// ResourceGraphFromProto converts the struct a resource graph is sent as back to the graph.
+func ResourceGraphFromProto(pbGraph *structpb.Struct) (robot.ResourceGraph, error) {
+	var graph robot.ResourceGraph
+	encoded, err := pbGraph.MarshalJSON()
+	if err != nil {
+		return graph, err
+	}
+	err = json.Unmarshal(encoded, &graph)
+	return graph, err
+}
@@ -12,6 +12,7 @@ import (
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/robot/v1"
 	"go.viam.com/utils"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
@@ -192,13 +193,28 @@ func (s *Server) FrameSystemConfig(
 	return &pb.FrameSystemConfigResponse{FrameSystemConfigs: configs}, nil
 }
 
-// TransformPose will transform the pose of the requested poseInFrame to the desired frame in the robot's frame system.
+// TransformPose will transform the pose of the requested poseInFrame to the desired frame in the robot's frame system. If the
+// request's metadata has a time, the pose is transformed as of that time.
 func (s *Server) TransformPose(ctx context.Context, req *pb.TransformPoseRequest) (*pb.TransformPoseResponse, error) {
 	dst := req.Destination
 	pF := referenceframe.ProtobufToPoseInFrame(req.Source)
-	transformedPose, err := s.r.TransformPose(ctx, pF, dst, req.GetSupplementalTransforms())
+	var transformedPose *referenceframe.PoseInFrame
+	var err error
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(robot.TransformPoseTimeMetadataKey)) > 0 {
+		var t time.Time
+		t, err = time.Parse(time.RFC3339Nano, md.Get(robot.TransformPoseTimeMetadataKey)[0])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid time in TransformPose metadata")
+		}
+		transformedPose, err = s.r.TransformPoseAtTime(ctx, pF, dst, req.GetSupplementalTransforms(), t)
+	} else {
+		transformedPose, err = s.r.TransformPose(ctx, pF, dst, req.GetSupplementalTransforms())
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	return &pb.TransformPoseResponse{Pose: referenceframe.PoseInFrameToProtobuf(transformedPose)}, err
+	return &pb.TransformPoseResponse{Pose: referenceframe.PoseInFrameToProtobuf(transformedPose)}, nil
 }
 
 // GetStatus takes a list of resource names and returns their corresponding statuses. If no names are passed in, return all statuses.
@@ -215,6 +231,17 @@ func (s *Server) GetStatus(ctx context.Context, req *pb.GetStatusRequest) (*pb.G
 
 	statusesP := make([]*pb.Status, 0, len(statuses))
 	for _, status := range statuses {
+		// the API has no place for the health of a resource, so a resource which is not healthy is reported as an error,
+		// unless it failed to be built and was not asked for by name
+		if status.Health != nil && status.Health.State != robot.HealthStateHealthy {
+			if status.Health.State == robot.HealthStateFailed && len(resourceNames) == 0 {
+				continue
+			}
+			if status.Health.Err != nil {
+				return nil, status.Health.Err
+			}
+			return nil, errors.New(status.Health.LastError)
+		}
 		statusP, err := protoutils.StructToStructPb(status.Status)
 		if err != nil {
 			return nil, err
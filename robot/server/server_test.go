@@ -14,7 +14,10 @@ import (
 	pb "go.viam.com/api/robot/v1"
 	"go.viam.com/test"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
 
 	"go.viam.com/rdk/components/arm"
 	"go.viam.com/rdk/components/movementsensor"
@@ -351,6 +354,43 @@ func TestServerGetStatus(t *testing.T) {
 		test.That(t, observed, test.ShouldResemble, expected)
 	})
 
+	t.Run("unhealthy statuses", func(t *testing.T) {
+		injectRobot := &inject.Robot{}
+		server := server.New(injectRobot)
+		aStatus := robot.Status{Name: arm.Named("arm"), Status: struct{}{}, Health: &robot.Health{State: robot.HealthStateHealthy}}
+		fStatus := robot.Status{
+			Name:   arm.Named("failed"),
+			Status: struct{}{},
+			Health: &robot.Health{State: robot.HealthStateFailed, LastError: "no arm"},
+		}
+		statuses := []robot.Status{aStatus, fStatus}
+		injectRobot.StatusFunc = func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
+			return statuses, nil
+		}
+
+		// a resource which failed to be built is left out of all statuses
+		resp, err := server.GetStatus(context.Background(), &pb.GetStatusRequest{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, len(resp.Status), test.ShouldEqual, 1)
+		test.That(t, protoutils.ResourceNameFromProto(resp.Status[0].Name), test.ShouldResemble, aStatus.Name)
+
+		// but is an error when asked for
+		req := &pb.GetStatusRequest{ResourceNames: []*commonpb.ResourceName{protoutils.ResourceNameToProto(fStatus.Name)}}
+		_, err = server.GetStatus(context.Background(), req)
+		test.That(t, err, test.ShouldBeError, errors.New("no arm"))
+
+		// as is a resource which is failing to report its status, with the error it reported
+		statusErr := status.Error(codes.Unavailable, "can't get status")
+		statuses = []robot.Status{aStatus, {
+			Name:   arm.Named("degraded"),
+			Status: struct{}{},
+			Health: &robot.Health{State: robot.HealthStateDegraded, LastError: statusErr.Error(), Err: statusErr},
+		}}
+		_, err = server.GetStatus(context.Background(), &pb.GetStatusRequest{})
+		test.That(t, err, test.ShouldEqual, statusErr)
+		test.That(t, status.Code(err), test.ShouldEqual, codes.Unavailable)
+	})
+
 	t.Run("failed StreamStatus", func(t *testing.T) {
 		injectRobot := &inject.Robot{}
 		server := server.New(injectRobot)
@@ -374,7 +414,7 @@ func TestServerGetStatus(t *testing.T) {
 		injectRobot := &inject.Robot{}
 		server := server.New(injectRobot)
 		injectRobot.StatusFunc = func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
-			return []robot.Status{{arm.Named("arm"), struct{}{}}}, nil
+			return []robot.Status{{Name: arm.Named("arm"), Status: struct{}{}}}, nil
 		}
 
 		cancelCtx, cancel := context.WithCancel(context.Background())
@@ -395,7 +435,7 @@ func TestServerGetStatus(t *testing.T) {
 		injectRobot := &inject.Robot{}
 		server := server.New(injectRobot)
 		injectRobot.StatusFunc = func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
-			return []robot.Status{{arm.Named("arm"), struct{}{}}}, nil
+			return []robot.Status{{Name: arm.Named("arm"), Status: struct{}{}}}, nil
 		}
 
 		timeoutCtx, cancel := context.WithTimeout(context.Background(), time.Second)
@@ -414,7 +454,7 @@ func TestServerGetStatus(t *testing.T) {
 		injectRobot := &inject.Robot{}
 		server := server.New(injectRobot)
 		injectRobot.StatusFunc = func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
-			return []robot.Status{{arm.Named("arm"), struct{}{}}}, nil
+			return []robot.Status{{Name: arm.Named("arm"), Status: struct{}{}}}, nil
 		}
 
 		cancelCtx, cancel := context.WithCancel(context.Background())
@@ -473,3 +513,34 @@ func (x *statusStreamServer) Send(m *pb.StreamStatusResponse) error {
 	x.messageCh <- m
 	return nil
 }
+
+// graphRobot is a robot which describes its resource graph.
+type graphRobot struct {
+	*inject.Robot
+	graph robot.ResourceGraph
+}
+
+func (r *graphRobot) ResourceGraph(ctx context.Context) (robot.ResourceGraph, error) {
+	return r.graph, nil
+}
+
+func TestServerGetResourceGraph(t *testing.T) {
+	graph := robot.ResourceGraph{
+		Nodes: []robot.ResourceGraphNode{
+			{Name: "rdk:component:base/base1", State: robot.ResourceStateFailed, LastError: "no motor"},
+			{Name: "rdk:component:motor/left", State: robot.ResourceStateReady, UpdatedAt: time.Unix(100, 0).UTC()},
+		},
+		Edges: []robot.ResourceGraphEdge{{From: "rdk:component:base/base1", To: "rdk:component:motor/left"}},
+	}
+	srv := server.New(&graphRobot{Robot: &inject.Robot{}, graph: graph}).(server.ResourceGraphServer)
+	resp, err := srv.GetResourceGraph(context.Background(), &emptypb.Empty{})
+	test.That(t, err, test.ShouldBeNil)
+	got, err := server.ResourceGraphFromProto(resp)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, got, test.ShouldResemble, graph)
+
+	// a robot which cannot describe its graph says so
+	srv = server.New(&inject.Robot{}).(server.ResourceGraphServer)
+	_, err = srv.GetResourceGraph(context.Background(), &emptypb.Empty{})
+	test.That(t, status.Code(err), test.ShouldEqual, codes.Unimplemented)
+}
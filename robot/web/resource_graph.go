@@ -0,0 +1,38 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"go.viam.com/rdk/robot"
+)
+
+// serveResourceGraph writes the resource graph of the robot as JSON, or in the Graphviz DOT language when the format query
+// parameter is "dot".
+func (svc *webService) serveResourceGraph(w http.ResponseWriter, r *http.Request) {
+	introspector, ok := svc.r.(robot.GraphIntrospector)
+	if !ok {
+		http.Error(w, "robot cannot describe its resource graph", http.StatusNotImplemented)
+		return
+	}
+	graph, err := introspector.ResourceGraph(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "dot":
+		w.Header().Set("Content-Type", "text/vnd.graphviz")
+		if _, err := w.Write([]byte(graph.DOT())); err != nil {
+			svc.logger.Debugw("failed to write resource graph", "error", err)
+		}
+	case "", "json":
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(graph); err != nil {
+			svc.logger.Debugw("failed to write resource graph", "error", err)
+		}
+	default:
+		http.Error(w, "unknown format "+format, http.StatusBadRequest)
+	}
+}
@@ -568,14 +568,18 @@ func (svc *webService) runWeb(ctx context.Context, options weboptions.Options) (
 		options.SignalingAddress = listenerAddr
 	}
 
+	robotServer := grpcserver.New(svc.r)
 	if err := svc.rpcServer.RegisterServiceServer(
 		ctx,
 		&pb.RobotService_ServiceDesc,
-		grpcserver.New(svc.r),
+		robotServer,
 		pb.RegisterRobotServiceHandlerFromEndpoint,
 	); err != nil {
 		return err
 	}
+	if err := svc.rpcServer.RegisterServiceServer(ctx, &grpcserver.ResourceGraphServiceDesc, robotServer); err != nil {
+		return err
+	}
 
 	if err := svc.initResources(); err != nil {
 		return err
@@ -913,6 +917,10 @@ func (svc *webService) initMux(options weboptions.Options) (*goji.Mux, error) {
 		mux.HandleFunc(pat.New("/debug/pprof/trace"), pprof.Trace)
 	}
 
+	if options.Debug {
+		mux.HandleFunc(pat.Get("/debug/resource_graph"), svc.serveResourceGraph)
+	}
+
 	prefix := "/viam"
 	addPrefix := func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,369 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+	goutils "go.viam.com/utils"
+
+	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/motionplan"
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot/framesystem"
+	"go.viam.com/rdk/services/slam"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
+)
+
+const (
+	// BaseKinematicsDifferentialDrive plans paths of straight lines for a base, which turns in place between them.
+	BaseKinematicsDifferentialDrive = "differential_drive"
+	// BaseKinematicsDubins plans paths for a base which only drives forwards, and turns along arcs no tighter than its turning
+	// radius.
+	BaseKinematicsDubins = "dubins"
+
+	defaultBaseLinearMmPerSec    = 200.
+	defaultBaseAngularDegsPerSec = 60.
+	defaultSlamMapResolutionMm   = 100.
+
+	// Distance beyond the start and goal of a base within which a path between them is searched for.
+	baseBoundsMarginMm = 2000.
+	// Separation of the points along a Dubins path which are checked for collisions.
+	dubinsPointSeparationMm = 10.
+	// Points of a SLAM map less than this far above the base are taken to be the ground it drives on.
+	slamGroundClearanceMm = 50.
+	// Cells of a SLAM map beyond this many from the base are not avoided, to bound the cost of checking for collisions.
+	maxSlamObstacles = 2000
+	// Motions of a base shorter than these are not driven.
+	baseMinDistanceMm = 0.5
+	baseMinAngleRad   = 1e-3
+)
+
+// baseMotion is a single motion of a base. It turns in place when it has no distance, drives straight when it has no angle, and
+// otherwise drives along an arc.
+type baseMotion struct {
+	distanceMm float64 // distance driven forwards
+	angleRad   float64 // change in heading, counterclockwise
+}
+
+// moveBase plans a collision free path in the plane for a base to the destination, and drives it along the path. The base
+// starts from its pose as localized by the configured SLAM service, whose map is also avoided.
+func (ms *builtIn) moveBase(
+	ctx context.Context,
+	componentName resource.Name,
+	destination *referenceframe.PoseInFrame,
+	worldState *commonpb.WorldState,
+	extra map[string]interface{},
+) (bool, error) {
+	b, err := base.FromRobot(ms.r, componentName.Name)
+	if err != nil {
+		return false, err
+	}
+	frameSys, err := framesystem.RobotFrameSystem(ctx, ms.r, worldState.GetTransforms())
+	if err != nil {
+		return false, err
+	}
+	fsInputs, _, err := framesystem.RobotFsCurrentInputs(ctx, ms.r, frameSys)
+	if err != nil {
+		return false, err
+	}
+	tf, err := frameSys.Transform(fsInputs, destination, referenceframe.World)
+	if err != nil {
+		return false, err
+	}
+	goal := tf.(*referenceframe.PoseInFrame).Pose()
+
+	start, obstacles, err := ms.localizeBase(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, gfProto := range worldState.GetObstacles() {
+		gf, err := referenceframe.ProtobufToGeometriesInFrame(gfProto)
+		if err != nil {
+			return false, err
+		}
+		tf, err := frameSys.Transform(fsInputs, gf, referenceframe.World)
+		if err != nil {
+			return false, err
+		}
+		for name, geometry := range tf.(*referenceframe.GeometriesInFrame).Geometries() {
+			obstacles[name] = geometry
+		}
+	}
+
+	radius, err := baseFootprintRadius(ctx, b, frameSys.Frame(componentName.Name))
+	if err != nil {
+		return false, err
+	}
+	motions, err := ms.planBase(ctx, componentName.Name, start, goal, radius, obstacles)
+	if err != nil {
+		return false, err
+	}
+
+	mmPerSec := ms.config.BaseLinearMmPerSec
+	if mmPerSec == 0 {
+		mmPerSec = defaultBaseLinearMmPerSec
+	}
+	degsPerSec := ms.config.BaseAngularDegsPerSec
+	if degsPerSec == 0 {
+		degsPerSec = defaultBaseAngularDegsPerSec
+	}
+	if err := executeBaseMotions(ctx, b, motions, mmPerSec, degsPerSec, extra); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// localizeBase returns the pose of the base in the world, and the obstacles known to the SLAM service which localized it. The
+// frame system only knows where a base was configured to be rather than where it has driven to, so a base cannot be moved
+// without a SLAM service.
+func (ms *builtIn) localizeBase(ctx context.Context) (spatialmath.Pose, map[string]spatialmath.Geometry, error) {
+	if ms.config.SlamService == "" {
+		return nil, nil, errors.New("moving a base requires a slam_service to localize it")
+	}
+
+	svc, err := slam.FromRobot(ms.r, ms.config.SlamService)
+	if err != nil {
+		return nil, nil, err
+	}
+	position, err := svc.Position(ctx, ms.config.SlamService)
+	if err != nil {
+		return nil, nil, err
+	}
+	_, _, object, err := svc.GetMap(ctx, ms.config.SlamService, utils.MimeTypePCD, nil, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if object == nil || object.PointCloud == nil {
+		return nil, nil, errors.Errorf("slam service %q did not return a point cloud map", ms.config.SlamService)
+	}
+	resolution := ms.config.SlamMapResolutionMm
+	if resolution == 0 {
+		resolution = defaultSlamMapResolutionMm
+	}
+	obstacles, err := pointCloudObstacles(object.PointCloud, resolution, position.Pose().Point())
+	if err != nil {
+		return nil, nil, err
+	}
+	return position.Pose(), obstacles, nil
+}
+
+// pointCloudObstacles divides space into cubes with sides of the given resolution, and returns those which contain any of the
+// points of the cloud above the ground the base at origin drives on. Only the maxSlamObstacles cells nearest the base in the
+// plane are returned.
+func pointCloudObstacles(cloud pointcloud.PointCloud, resolution float64, origin r3.Vector) (map[string]spatialmath.Geometry, error) {
+	occupied := map[[3]int64]bool{}
+	cloud.Iterate(0, 0, func(p r3.Vector, d pointcloud.Data) bool {
+		if p.Z < origin.Z+slamGroundClearanceMm {
+			return true
+		}
+		occupied[[3]int64{int64(math.Floor(p.X / resolution)), int64(math.Floor(p.Y / resolution)), int64(math.Floor(p.Z / resolution))}] = true
+		return true
+	})
+	center := func(cell [3]int64) r3.Vector {
+		return r3.Vector{X: float64(cell[0]) + 0.5, Y: float64(cell[1]) + 0.5, Z: float64(cell[2]) + 0.5}.Mul(resolution)
+	}
+	planeDistance := func(cell [3]int64) float64 {
+		c := center(cell)
+		return math.Hypot(c.X-origin.X, c.Y-origin.Y)
+	}
+	cells := make([][3]int64, 0, len(occupied))
+	for cell := range occupied {
+		cells = append(cells, cell)
+	}
+	if len(cells) > maxSlamObstacles {
+		sort.Slice(cells, func(i, j int) bool { return planeDistance(cells[i]) < planeDistance(cells[j]) })
+		cells = cells[:maxSlamObstacles]
+	}
+	obstacles := make(map[string]spatialmath.Geometry, len(cells))
+	for _, cell := range cells {
+		box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(center(cell)), r3.Vector{X: resolution, Y: resolution, Z: resolution})
+		if err != nil {
+			return nil, err
+		}
+		obstacles[fmt.Sprintf("slam_%d_%d_%d", cell[0], cell[1], cell[2])] = box
+	}
+	return obstacles, nil
+}
+
+// baseFootprintRadius returns the radius of the circle about the origin of the base, in the plane it drives in, which encloses
+// the geometries of its frame. When its frame has no geometries, half the width of the base is used.
+func baseFootprintRadius(ctx context.Context, b base.Base, f referenceframe.Frame) (float64, error) {
+	radius := 0.
+	if f != nil {
+		// frames without geometries report an error, which leaves the radius to be found from the width of the base
+		geometries, _ := f.Geometries(make([]referenceframe.Input, len(f.DoF())))
+		if geometries != nil {
+			for _, geometry := range geometries.Geometries() {
+				pb := geometry.ToProtobuf()
+				if sphere := pb.GetSphere(); sphere != nil {
+					center := spatialmath.NewPoseFromProtobuf(pb.Center).Point()
+					radius = math.Max(radius, math.Hypot(center.X, center.Y)+sphere.RadiusMm)
+					continue
+				}
+				// other geometries are represented by the boxes which contain them
+				enclosing, err := spatialmath.NewGeometryFromProto(pb)
+				if err != nil {
+					return 0, err
+				}
+				for _, vertex := range enclosing.Vertices() {
+					radius = math.Max(radius, math.Hypot(vertex.X, vertex.Y))
+				}
+			}
+		}
+	}
+	if radius > 0 {
+		return radius, nil
+	}
+	if localBase, ok := b.(base.LocalBase); ok {
+		width, err := localBase.Width(ctx)
+		if err != nil {
+			return 0, err
+		}
+		radius = float64(width) / 2
+	}
+	if radius <= 0 {
+		return 0, errors.New("the size of the base is unknown, it must have a geometry in its frame to plan around obstacles")
+	}
+	return radius, nil
+}
+
+// baseHeading returns the direction, counterclockwise from the x axis, in which a base at the given pose drives forwards
+// along its y axis.
+func baseHeading(pose spatialmath.Pose) float64 {
+	return pose.Orientation().EulerAngles().Yaw + math.Pi/2
+}
+
+// planBase plans the motions which drive a base, whose footprint is a circle of the given radius, from start to goal without
+// hitting any of the obstacles.
+func (ms *builtIn) planBase(
+	ctx context.Context,
+	name string,
+	start, goal spatialmath.Pose,
+	radius float64,
+	obstacles map[string]spatialmath.Geometry,
+) ([]baseMotion, error) {
+	startPt, goalPt := start.Point(), goal.Point()
+	limits := []referenceframe.Limit{
+		{Min: math.Min(startPt.X, goalPt.X) - baseBoundsMarginMm, Max: math.Max(startPt.X, goalPt.X) + baseBoundsMarginMm},
+		{Min: math.Min(startPt.Y, goalPt.Y) - baseBoundsMarginMm, Max: math.Max(startPt.Y, goalPt.Y) + baseBoundsMarginMm},
+	}
+	// the footprint is a circle since the frame of a mobile base does not turn with it
+	footprint, err := spatialmath.NewSphereCreator(radius, spatialmath.NewPoseFromPoint(r3.Vector{Z: startPt.Z}))
+	if err != nil {
+		return nil, err
+	}
+	frame, err := referenceframe.NewMobile2DFrame(name, limits, footprint)
+	if err != nil {
+		return nil, err
+	}
+	startInputs := []referenceframe.Input{{startPt.X}, {startPt.Y}}
+	opt := motionplan.NewBasicPlannerOptions()
+	opt.AddConstraint("collision", motionplan.NewCollisionConstraint(frame, startInputs, obstacles, map[string]spatialmath.Geometry{}))
+
+	if ms.config.BaseKinematics == BaseKinematicsDubins {
+		d := motionplan.Dubins{Radius: ms.config.BaseTurningRadiusMm, PointSeparation: dubinsPointSeparationMm}
+		planner, err := motionplan.NewDubinsRRTMotionPlanner(frame, runtime.NumCPU()/2, ms.logger, d)
+		if err != nil {
+			return nil, err
+		}
+		path, err := planner.Plan(
+			ctx,
+			&commonpb.Pose{X: goalPt.X, Y: goalPt.Y, Theta: baseHeading(goal)},
+			append(startInputs, referenceframe.Input{Value: baseHeading(start)}),
+			opt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		return dubinsMotions(path, d), nil
+	}
+
+	planner, err := motionplan.NewCBiRRTMotionPlanner(frame, runtime.NumCPU()/2, ms.logger)
+	if err != nil {
+		return nil, err
+	}
+	opt.SetMetric(motionplan.NewPositionOnlyMetric())
+	path, err := planner.Plan(ctx, spatialmath.PoseToProtobuf(spatialmath.NewPoseFromPoint(r3.Vector{X: goalPt.X, Y: goalPt.Y})), startInputs, opt)
+	if err != nil {
+		return nil, err
+	}
+	return differentialDriveMotions(path, baseHeading(start), baseHeading(goal)), nil
+}
+
+// differentialDriveMotions returns the motions which drive a base straight between each of the points of a path in turn,
+// turning in place to face each point, and finally to face the goal heading.
+func differentialDriveMotions(path [][]referenceframe.Input, startHeading, goalHeading float64) []baseMotion {
+	motions := []baseMotion{}
+	heading := startHeading
+	if len(path) > 0 {
+		from := path[0]
+		for _, to := range path[1:] {
+			dx, dy := to[0].Value-from[0].Value, to[1].Value-from[1].Value
+			distance := math.Hypot(dx, dy)
+			if distance < baseMinDistanceMm {
+				continue
+			}
+			direction := math.Atan2(dy, dx)
+			motions = append(motions, baseMotion{angleRad: math.Remainder(direction-heading, 2*math.Pi)}, baseMotion{distanceMm: distance})
+			heading = direction
+			from = to
+		}
+	}
+	return append(motions, baseMotion{angleRad: math.Remainder(goalHeading-heading, 2*math.Pi)})
+}
+
+// dubinsMotions returns the motions which drive a base along the Dubins paths between each of the points of a path in turn.
+func dubinsMotions(path [][]referenceframe.Input, d motionplan.Dubins) []baseMotion {
+	motions := []baseMotion{}
+	for _, attr := range motionplan.GetDubinTrajectoryFromPath(path, d) {
+		for _, segment := range attr.Segments(d.Radius) {
+			motions = append(motions, baseMotion{distanceMm: segment.Length, angleRad: segment.Turn})
+		}
+	}
+	return motions
+}
+
+// executeBaseMotions drives the base through each of the motions in turn, driving arcs at the given linear speed and turning in
+// place at the given angular speed.
+func executeBaseMotions(
+	ctx context.Context,
+	b base.Base,
+	motions []baseMotion,
+	mmPerSec, degsPerSec float64,
+	extra map[string]interface{},
+) error {
+	for _, motion := range motions {
+		turns := math.Abs(motion.angleRad) >= baseMinAngleRad
+		var err error
+		switch {
+		case motion.distanceMm < baseMinDistanceMm && !turns:
+			continue
+		case motion.distanceMm < baseMinDistanceMm:
+			err = b.Spin(ctx, utils.RadToDeg(motion.angleRad), degsPerSec, extra)
+		case !turns:
+			err = b.MoveStraight(ctx, int(math.Round(motion.distanceMm)), mmPerSec, extra)
+		default:
+			// the heading changes at the rate which turns the base through the angle of the arc by its end
+			seconds := motion.distanceMm / mmPerSec
+			err = b.SetVelocity(ctx, r3.Vector{Y: mmPerSec}, r3.Vector{Z: utils.RadToDeg(motion.angleRad) / seconds}, extra)
+			if err == nil && !goutils.SelectContextOrWait(ctx, time.Duration(seconds*float64(time.Second))) {
+				err = ctx.Err()
+			}
+		}
+		if err != nil {
+			goutils.UncheckedError(b.Stop(ctx, extra))
+			return err
+		}
+	}
+	return b.Stop(ctx, extra)
+}
@@ -0,0 +1,194 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/pointcloud"
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+	"go.viam.com/rdk/utils"
+)
+
+// driveBase returns the point and heading reached by a base which drives through the motions, along with the points it passes
+// through every 10mm.
+func driveBase(start r3.Vector, heading float64, motions []baseMotion) (r3.Vector, float64, []r3.Vector) {
+	position := start
+	passed := []r3.Vector{position}
+	for _, motion := range motions {
+		steps := int(math.Ceil(motion.distanceMm / 10))
+		if steps == 0 {
+			heading += motion.angleRad
+			continue
+		}
+		distance, angle := motion.distanceMm/float64(steps), motion.angleRad/float64(steps)
+		for i := 0; i < steps; i++ {
+			if math.Abs(angle) < 1e-9 {
+				position = position.Add(r3.Vector{X: math.Cos(heading), Y: math.Sin(heading)}.Mul(distance))
+			} else {
+				position = position.Add(r3.Vector{
+					X: math.Sin(heading+angle) - math.Sin(heading),
+					Y: math.Cos(heading) - math.Cos(heading+angle),
+				}.Mul(distance / angle))
+			}
+			heading += angle
+			passed = append(passed, position)
+		}
+	}
+	return position, math.Remainder(heading, 2*math.Pi), passed
+}
+
+func TestDifferentialDriveMotions(t *testing.T) {
+	path := [][]referenceframe.Input{{{0}, {0}}, {{0}, {1000}}, {{0}, {1000.1}}, {{1000}, {1000}}}
+	motions := differentialDriveMotions(path, math.Pi/2, 0)
+	test.That(t, motions, test.ShouldHaveLength, 5)
+	test.That(t, motions[0].angleRad, test.ShouldAlmostEqual, 0)
+	test.That(t, motions[1].distanceMm, test.ShouldAlmostEqual, 1000)
+	test.That(t, motions[2].angleRad, test.ShouldAlmostEqual, -math.Pi/2)
+
+	end, heading, _ := driveBase(r3.Vector{}, math.Pi/2, motions)
+	test.That(t, spatialmath.R3VectorAlmostEqual(end, r3.Vector{X: 1000, Y: 1000}, 1), test.ShouldBeTrue)
+	test.That(t, heading, test.ShouldAlmostEqual, 0)
+
+	// turns take the shorter way around
+	motions = differentialDriveMotions(path[:1], 0.1, 2*math.Pi-0.1)
+	test.That(t, motions, test.ShouldHaveLength, 1)
+	test.That(t, motions[0].angleRad, test.ShouldAlmostEqual, -0.2)
+}
+
+func TestPlanBaseDubins(t *testing.T) {
+	ms := &builtIn{
+		config: Config{BaseKinematics: BaseKinematicsDubins, BaseTurningRadiusMm: 200},
+		logger: golog.NewTestLogger(t),
+	}
+	// a wall is in the way of driving straight ahead from the origin
+	wall, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{Y: 1000}), r3.Vector{X: 600, Y: 100, Z: 100})
+	test.That(t, err, test.ShouldBeNil)
+	obstacles := map[string]spatialmath.Geometry{"wall": wall}
+	start := spatialmath.NewZeroPose()
+	goal := spatialmath.NewPoseFromOrientation(r3.Vector{Y: 2000}, &spatialmath.OrientationVectorDegrees{OZ: 1, Theta: -90})
+
+	motions, err := ms.planBase(context.Background(), "base", start, goal, 100, obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	end, heading, passed := driveBase(r3.Vector{}, baseHeading(start), motions)
+	test.That(t, spatialmath.R3VectorAlmostEqual(end, goal.Point(), 1), test.ShouldBeTrue)
+	test.That(t, heading, test.ShouldAlmostEqual, math.Remainder(baseHeading(goal), 2*math.Pi))
+	for _, point := range passed {
+		footprint, err := spatialmath.NewSphere(point, 100)
+		test.That(t, err, test.ShouldBeNil)
+		collides, err := footprint.CollidesWith(wall)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, collides, test.ShouldBeFalse)
+	}
+}
+
+func TestExecuteBaseMotions(t *testing.T) {
+	var calls []string
+	injectBase := &inject.Base{}
+	injectBase.SpinFunc = func(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+		calls = append(calls, "spin")
+		test.That(t, angleDeg, test.ShouldAlmostEqual, 90)
+		test.That(t, degsPerSec, test.ShouldAlmostEqual, 45)
+		return nil
+	}
+	injectBase.MoveStraightFunc = func(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+		calls = append(calls, "straight")
+		test.That(t, distanceMm, test.ShouldEqual, 100)
+		test.That(t, mmPerSec, test.ShouldAlmostEqual, 1000)
+		return nil
+	}
+	injectBase.SetVelocityFunc = func(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+		calls = append(calls, "velocity")
+		test.That(t, linear.Y, test.ShouldAlmostEqual, 1000)
+		test.That(t, angular.Z, test.ShouldAlmostEqual, -utils.RadToDeg(0.1)/0.01)
+		return nil
+	}
+	injectBase.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		calls = append(calls, "stop")
+		return nil
+	}
+
+	motions := []baseMotion{{angleRad: math.Pi / 2}, {distanceMm: 100}, {distanceMm: 10, angleRad: -0.1}, {distanceMm: 0.1}}
+	test.That(t, executeBaseMotions(context.Background(), injectBase, motions, 1000, 45, nil), test.ShouldBeNil)
+	test.That(t, calls, test.ShouldResemble, []string{"spin", "straight", "velocity", "stop"})
+
+	// the base is stopped when a motion fails
+	calls = nil
+	errMotion := errors.New("stuck")
+	injectBase.MoveStraightFunc = func(ctx context.Context, distanceMm int, mmPerSec float64, extra map[string]interface{}) error {
+		calls = append(calls, "straight")
+		return errMotion
+	}
+	test.That(t, executeBaseMotions(context.Background(), injectBase, motions, 1000, 45, nil), test.ShouldEqual, errMotion)
+	test.That(t, calls, test.ShouldResemble, []string{"spin", "straight", "stop"})
+}
+
+func TestBaseFootprintRadius(t *testing.T) {
+	injectBase := &inject.Base{}
+	injectBase.WidthFunc = func(ctx context.Context) (int, error) {
+		return 300, nil
+	}
+	box, err := spatialmath.NewBoxCreator(r3.Vector{X: 200, Y: 400, Z: 100}, spatialmath.NewPoseFromPoint(r3.Vector{X: 50, Z: 50}))
+	test.That(t, err, test.ShouldBeNil)
+	frame, err := referenceframe.NewStaticFrameWithGeometry("base", spatialmath.NewZeroPose(), box)
+	test.That(t, err, test.ShouldBeNil)
+
+	radius, err := baseFootprintRadius(context.Background(), injectBase, frame)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, radius, test.ShouldAlmostEqual, math.Hypot(150, 200))
+
+	// without geometries the width of the base is used
+	frame, err = referenceframe.NewStaticFrame("base", spatialmath.NewZeroPose())
+	test.That(t, err, test.ShouldBeNil)
+	radius, err = baseFootprintRadius(context.Background(), injectBase, frame)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, radius, test.ShouldAlmostEqual, 150)
+
+	injectBase.WidthFunc = func(ctx context.Context) (int, error) {
+		return 0, nil
+	}
+	_, err = baseFootprintRadius(context.Background(), injectBase, nil)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestPointCloudObstacles(t *testing.T) {
+	cloud := pointcloud.New()
+	test.That(t, cloud.Set(r3.Vector{X: 10, Y: 10, Z: 160}, nil), test.ShouldBeNil)
+	test.That(t, cloud.Set(r3.Vector{X: 90, Y: 20, Z: 180}, nil), test.ShouldBeNil)
+	test.That(t, cloud.Set(r3.Vector{X: -10, Y: 10, Z: 160}, nil), test.ShouldBeNil)
+	// the ground the base drives on is not an obstacle
+	test.That(t, cloud.Set(r3.Vector{X: 500, Y: 500, Z: 120}, nil), test.ShouldBeNil)
+
+	obstacles, err := pointCloudObstacles(cloud, 100, r3.Vector{Z: 100})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, obstacles, test.ShouldHaveLength, 2)
+	cell := obstacles["slam_-1_0_1"]
+	test.That(t, cell, test.ShouldNotBeNil)
+	test.That(t, spatialmath.R3VectorAlmostEqual(cell.Pose().Point(), r3.Vector{X: -50, Y: 50, Z: 150}, 1e-8), test.ShouldBeTrue)
+
+	// only the cells nearest the base are kept from a large map
+	cloud = pointcloud.New()
+	for i := 0; i < maxSlamObstacles+10; i++ {
+		test.That(t, cloud.Set(r3.Vector{X: float64(i) * 100, Z: 200}, nil), test.ShouldBeNil)
+	}
+	obstacles, err = pointCloudObstacles(cloud, 100, r3.Vector{})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, obstacles, test.ShouldHaveLength, maxSlamObstacles)
+	test.That(t, obstacles, test.ShouldContainKey, "slam_0_0_2")
+	test.That(t, obstacles, test.ShouldNotContainKey, fmt.Sprintf("slam_%d_0_2", maxSlamObstacles))
+}
+
+func TestLocalizeBaseWithoutSlam(t *testing.T) {
+	ms := &builtIn{}
+	_, _, err := ms.localizeBase(context.Background())
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "slam_service")
+}
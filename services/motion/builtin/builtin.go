@@ -4,12 +4,19 @@ package builtin
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/edaniels/golog"
 	"github.com/golang/geo/r3"
+	"github.com/mitchellh/mapstructure"
+	"github.com/pkg/errors"
+	goutils "go.viam.com/utils"
 
 	commonpb "go.viam.com/api/common/v1"
 	"go.viam.com/rdk/components/arm"
+	"go.viam.com/rdk/components/base"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/motionplan"
 	"go.viam.com/rdk/operation"
@@ -20,6 +27,7 @@ import (
 	"go.viam.com/rdk/robot/framesystem"
 	"go.viam.com/rdk/services/motion"
 	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/utils"
 )
 
 func init() {
@@ -29,19 +37,106 @@ func init() {
 		},
 	})
 	resource.AddDefaultService(motion.Named(resource.DefaultModelName))
+	cType := config.ServiceType(motion.SubtypeName)
+	config.RegisterServiceAttributeMapConverter(cType, func(attributes config.AttributeMap) (interface{}, error) {
+		var conf Config
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{TagName: "json", Result: &conf})
+		if err != nil {
+			return nil, err
+		}
+		if err := decoder.Decode(attributes); err != nil {
+			return nil, err
+		}
+		return &conf, nil
+	}, &Config{})
+}
+
+// Config describes how to configure the service. When obstacle sources are given, they are watched while a motion executes,
+// and the motion is stopped, or replanned, when an obstacle they observe is in the path of the moving component.
+// Bases are planned for with the given kinematics, and are localized by the SLAM service, when one is given, whose map is then
+// also treated as obstacles. When a plan record directory is given, every plan made for a component other than a base is
+// written to it as a plan record, which can be replayed with the motionplan package. When a roadmap cache directory is
+// given, the roadmaps of the "prm" planner are kept in it and reused across moves through the same static world.
+type Config struct {
+	ObstacleSources            []ObstacleSourceConfig `json:"obstacle_sources"`
+	OnObstacle                 string                 `json:"on_obstacle"`
+	MaxReplans                 int                    `json:"max_replans"`
+	ObstaclePollingFrequencyHz float64                `json:"obstacle_polling_frequency_hz"`
+	BaseKinematics             string                 `json:"base_kinematics"`
+	BaseTurningRadiusMm        float64                `json:"base_turning_radius_mm"`
+	BaseLinearMmPerSec         float64                `json:"base_linear_mm_per_sec"`
+	BaseAngularDegsPerSec      float64                `json:"base_angular_degs_per_sec"`
+	SlamService                string                 `json:"slam_service"`
+	SlamMapResolutionMm        float64                `json:"slam_map_resolution_mm"`
+	PlanRecordDir              string                 `json:"plan_record_dir"`
+	RoadmapCacheDir            string                 `json:"roadmap_cache_dir"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (config *Config) Validate(path string) error {
+	for i, source := range config.ObstacleSources {
+		if err := source.Validate(fmt.Sprintf("%s.obstacle_sources.%d", path, i)); err != nil {
+			return err
+		}
+	}
+	switch config.OnObstacle {
+	case "", OnObstacleStop, OnObstacleReplan:
+	default:
+		return goutils.NewConfigValidationError(path,
+			errors.Errorf("on_obstacle must be %q or %q, got %q", OnObstacleStop, OnObstacleReplan, config.OnObstacle))
+	}
+	if config.MaxReplans < 0 {
+		return goutils.NewConfigValidationError(path, errors.New("max_replans cannot be negative"))
+	}
+	if config.ObstaclePollingFrequencyHz < 0 {
+		return goutils.NewConfigValidationError(path, errors.New("obstacle_polling_frequency_hz cannot be negative"))
+	}
+	switch config.BaseKinematics {
+	case "", BaseKinematicsDifferentialDrive:
+	case BaseKinematicsDubins:
+		if config.BaseTurningRadiusMm <= 0 {
+			return goutils.NewConfigValidationError(path,
+				errors.Errorf("base_turning_radius_mm must be positive for %q base_kinematics", BaseKinematicsDubins))
+		}
+	default:
+		return goutils.NewConfigValidationError(path,
+			errors.Errorf("base_kinematics must be %q or %q, got %q",
+				BaseKinematicsDifferentialDrive, BaseKinematicsDubins, config.BaseKinematics))
+	}
+	if config.BaseLinearMmPerSec < 0 || config.BaseAngularDegsPerSec < 0 {
+		return goutils.NewConfigValidationError(path, errors.New("base speeds cannot be negative"))
+	}
+	if config.SlamMapResolutionMm < 0 {
+		return goutils.NewConfigValidationError(path, errors.New("slam_map_resolution_mm cannot be negative"))
+	}
+	return nil
 }
 
 // NewBuiltIn returns a new move and grab service for the given robot.
 func NewBuiltIn(ctx context.Context, r robot.Robot, config config.Service, logger golog.Logger) (motion.Service, error) {
-	return &builtIn{
+	svcConfig, ok := config.ConvertedAttributes.(*Config)
+	if !ok {
+		svcConfig = &Config{}
+	}
+	if err := svcConfig.Validate(""); err != nil {
+		return nil, err
+	}
+	ms := &builtIn{
 		r:      r,
+		config: *svcConfig,
 		logger: logger,
-	}, nil
+	}
+	if svcConfig.RoadmapCacheDir != "" {
+		ms.roadmaps = motionplan.NewRoadmapCache(svcConfig.RoadmapCacheDir)
+	}
+	return ms, nil
 }
 
 type builtIn struct {
-	r      robot.Robot
-	logger golog.Logger
+	r        robot.Robot
+	config   Config
+	logger   golog.Logger
+	roadmaps *motionplan.RoadmapCache
 }
 
 // Move takes a goal location and will plan and execute a movement to move a component specified by its name to that destination.
@@ -50,9 +145,16 @@ func (ms *builtIn) Move(
 	componentName resource.Name,
 	destination *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *motion.Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
 	operation.CancelOtherWithLabel(ctx, "motion-service")
+	if componentName.Subtype == base.Subtype {
+		if constraints != nil {
+			return false, errors.New("constraints are not supported when moving a base")
+		}
+		return ms.moveBase(ctx, componentName, destination, worldState, extra)
+	}
 	logger := ms.r.Logger()
 
 	// get goal frame
@@ -64,6 +166,12 @@ func (ms *builtIn) Move(
 		return false, err
 	}
 	solver := motionplan.NewSolvableFrameSystem(frameSys, logger)
+	if ms.config.PlanRecordDir != "" {
+		solver.SetPlanRecorder(ms.writePlanRecord)
+	}
+	if ms.roadmaps != nil {
+		solver.SetRoadmapCache(ms.roadmaps)
+	}
 
 	// build maps of relevant components and inputs from initial inputs
 	fsInputs, resources, err := framesystem.RobotFsCurrentInputs(ctx, ms.r, solver)
@@ -81,20 +189,171 @@ func (ms *builtIn) Move(
 	}
 	goalPose, _ := tf.(*referenceframe.PoseInFrame)
 
-	// the goal is to move the component to goalPose which is specified in coordinates of goalFrameName
-	output, err := solver.SolveWaypointsWithOptions(ctx,
-		fsInputs,
-		[]*referenceframe.PoseInFrame{goalPose},
-		componentName.Name,
-		worldState,
-		[]map[string]interface{}{extra},
-	)
+	motionConfig, err := motionConfigFromConstraints(constraints, extra)
 	if err != nil {
 		return false, err
 	}
 
-	// move all the components
-	for _, step := range output {
+	var monitor *obstacleMonitor
+	if len(ms.config.ObstacleSources) > 0 {
+		sources, err := ms.obstacleSources()
+		if err != nil {
+			return false, err
+		}
+		frequency := ms.config.ObstaclePollingFrequencyHz
+		if frequency == 0 {
+			frequency = defaultObstaclePollingFrequencyHz
+		}
+		monitor = &obstacleMonitor{fs: solver, sources: sources, interval: time.Duration(float64(time.Second) / frequency)}
+	}
+	maxReplans := 0
+	if ms.config.OnObstacle == OnObstacleReplan {
+		maxReplans = ms.config.MaxReplans
+		if maxReplans == 0 {
+			maxReplans = defaultMaxReplans
+		}
+	}
+
+	for replans := 0; ; replans++ {
+		// the goal is to move the component to goalPose which is specified in coordinates of goalFrameName
+		output, err := solver.SolveWaypointsWithOptions(ctx,
+			fsInputs,
+			[]*referenceframe.PoseInFrame{goalPose},
+			componentName.Name,
+			worldState,
+			[]map[string]interface{}{motionConfig},
+		)
+		if err != nil {
+			return false, err
+		}
+
+		// move all the components
+		if monitor == nil {
+			if err := executePlan(ctx, solver, resources, output); err != nil {
+				return false, err
+			}
+			return true, nil
+		}
+		inPath, err := monitor.execute(ctx, resources, output)
+		if err != nil {
+			return false, err
+		}
+		if len(inPath) == 0 {
+			return true, nil
+		}
+		if replans >= maxReplans {
+			return false, errObstacleInPath
+		}
+
+		// plan again from where the components stopped, avoiding the obstacles which were in the way
+		logger.Debugf("replanning around %d obstacles observed in the path of %q", len(inPath), componentName.Name)
+		worldState = worldStateWithObstacles(worldState, inPath)
+		fsInputs, resources, err = framesystem.RobotFsCurrentInputs(ctx, ms.r, solver)
+		if err != nil {
+			return false, err
+		}
+	}
+}
+
+// writePlanRecord writes a plan record to a new file in the configured plan record directory. Failing to do so does not
+// fail the motion, so it is only logged.
+func (ms *builtIn) writePlanRecord(record *motionplan.PlanRecord) {
+	if err := os.MkdirAll(ms.config.PlanRecordDir, 0o700); err != nil {
+		ms.logger.Warnw("failed to create plan record directory", "error", err)
+		return
+	}
+	filename := filepath.Join(
+		ms.config.PlanRecordDir,
+		fmt.Sprintf("plan_%s_%s.json", record.Request.SolveFrame, time.Now().UTC().Format("20060102T150405.000000000")),
+	)
+	if err := motionplan.WritePlanRecordFile(record, filename); err != nil {
+		ms.logger.Warnw("failed to write plan record", "error", err)
+	}
+}
+
+// worldStateWithObstacles returns a copy of the world state with additional obstacles, which are given in the world frame.
+func worldStateWithObstacles(worldState *commonpb.WorldState, obstacles map[string]spatialmath.Geometry) *commonpb.WorldState {
+	return &commonpb.WorldState{
+		Obstacles: append(
+			append([]*commonpb.GeometriesInFrame{}, worldState.GetObstacles()...),
+			referenceframe.GeometriesInFrameToProtobuf(referenceframe.NewGeometriesInFrame(referenceframe.World, obstacles)),
+		),
+		InteractionSpaces: worldState.GetInteractionSpaces(),
+		Transforms:        worldState.GetTransforms(),
+	}
+}
+
+// motionConfigFromConstraints returns the configuration for the motion planner which enforces the given constraints, in addition
+// to any planner options given in extra.
+func motionConfigFromConstraints(constraints *motion.Constraints, extra map[string]interface{}) (map[string]interface{}, error) {
+	if err := constraints.Validate(); err != nil {
+		return nil, err
+	}
+	motionConfig := make(map[string]interface{}, len(extra))
+	for k, v := range extra {
+		motionConfig[k] = v
+	}
+	// zero tolerances are left unset so that the planner uses its defaults
+	setTolerance := func(key string, tolerance float64) {
+		if tolerance > 0 {
+			motionConfig[key] = tolerance
+		}
+	}
+	switch {
+	case constraints == nil:
+	case constraints.Linear != nil:
+		motionConfig["motion_profile"] = motionplan.LinearMotionProfile
+		setTolerance("line_tolerance", constraints.Linear.LineToleranceMm)
+		setTolerance("orient_tolerance", utils.DegToRad(constraints.Linear.OrientationToleranceDegs))
+	case constraints.Orientation != nil:
+		motionConfig["motion_profile"] = motionplan.OrientationMotionProfile
+		setTolerance("tolerance", utils.DegToRad(constraints.Orientation.OrientationToleranceDegs))
+	case constraints.Plane != nil:
+		motionConfig["motion_profile"] = motionplan.PlaneMotionProfile
+		motionConfig["plane_normal"] = map[string]interface{}{
+			"x": constraints.Plane.Normal.X,
+			"y": constraints.Plane.Normal.Y,
+			"z": constraints.Plane.Normal.Z,
+		}
+		setTolerance("plane_tolerance", constraints.Plane.PlaneToleranceMm)
+		setTolerance("orient_tolerance", utils.DegToRad(constraints.Plane.OrientationToleranceDegs))
+	}
+	return motionConfig, nil
+}
+
+// executePlan moves the components in the frame system through each step of the plan. When only a single component
+// moves and its frame declares dynamic limits, it is moved along a timed trajectory instead.
+func executePlan(
+	ctx context.Context,
+	fs referenceframe.FrameSystem,
+	resources map[string]referenceframe.InputEnabled,
+	plan []map[string][]referenceframe.Input,
+) error {
+	moving := map[string]bool{}
+	for _, step := range plan {
+		for name, inputs := range step {
+			if len(inputs) > 0 {
+				moving[name] = true
+			}
+		}
+	}
+	if len(moving) == 1 {
+		for name := range moving {
+			if limiter, ok := fs.Frame(name).(referenceframe.DynamicLimiter); ok && len(limiter.DynamicLimits()) > 0 {
+				path := make([][]referenceframe.Input, 0, len(plan))
+				for _, step := range plan {
+					path = append(path, step[name])
+				}
+				traj, err := motionplan.TimeParameterize(path, limiter.DynamicLimits())
+				if err != nil {
+					return err
+				}
+				return motionplan.FollowTrajectory(ctx, resources[name], traj)
+			}
+		}
+	}
+
+	for _, step := range plan {
 		// TODO(erh): what order? parallel?
 		for name, inputs := range step {
 			if len(inputs) == 0 {
@@ -102,24 +361,29 @@ func (ms *builtIn) Move(
 			}
 			err := resources[name].GoToInputs(ctx, inputs)
 			if err != nil {
-				return false, err
+				return err
 			}
 		}
 	}
-	return true, nil
+	return nil
 }
 
 // MoveSingleComponent will pass through a move command to a component with a MoveToPosition method that takes a pose. Arms are the only
 // component that supports this. This method will transform the destination pose, given in an arbitrary frame, into the pose of the arm.
 // The arm will then move its most distal link to that pose. If you instead wish to move any other component than the arm end to that pose,
 // then you must manually adjust the given destination by the transform from the arm end to the intended component.
+// Arms cannot follow constraints on their own, so if any are given the motion is instead planned and executed as it is by Move.
 func (ms *builtIn) MoveSingleComponent(
 	ctx context.Context,
 	componentName resource.Name,
 	destination *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *motion.Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
+	if constraints != nil {
+		return ms.Move(ctx, componentName, destination, worldState, constraints, extra)
+	}
 	operation.CancelOtherWithLabel(ctx, "motion-service")
 	logger := ms.r.Logger()
 
@@ -3,6 +3,8 @@ package builtin_test
 import (
 	"context"
 	"math"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/edaniels/golog"
@@ -17,6 +19,7 @@ import (
 	commonpb "go.viam.com/api/common/v1"
 	_ "go.viam.com/rdk/components/register"
 	"go.viam.com/rdk/config"
+	"go.viam.com/rdk/motionplan"
 	"go.viam.com/rdk/referenceframe"
 	framesystemparts "go.viam.com/rdk/robot/framesystem/parts"
 	robotimpl "go.viam.com/rdk/robot/impl"
@@ -44,7 +47,7 @@ func TestMoveFailures(t *testing.T) {
 	ms := setupMotionServiceFromConfig(t, "../data/arm_gantry.json")
 	t.Run("fail on not finding gripper", func(t *testing.T) {
 		grabPose := referenceframe.NewPoseInFrame("fakeCamera", spatialmath.NewPoseFromPoint(r3.Vector{10.0, 10.0, 10.0}))
-		_, err = ms.Move(context.Background(), camera.Named("fake"), grabPose, &commonpb.WorldState{}, map[string]interface{}{})
+		_, err = ms.Move(context.Background(), camera.Named("fake"), grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
 		test.That(t, err, test.ShouldNotBeNil)
 	})
 
@@ -66,7 +69,7 @@ func TestMoveFailures(t *testing.T) {
 			Transforms: transformMsgs,
 		}
 		poseInFrame := referenceframe.NewPoseInFrame("frame2", spatialmath.NewZeroPose())
-		_, err = ms.Move(context.Background(), arm.Named("arm1"), poseInFrame, worldState, map[string]interface{}{})
+		_, err = ms.Move(context.Background(), arm.Named("arm1"), poseInFrame, worldState, nil, map[string]interface{}{})
 		test.That(t, err, test.ShouldBeError, framesystemparts.NewMissingParentError("frame2", "noParent"))
 	})
 }
@@ -77,19 +80,19 @@ func TestMove1(t *testing.T) {
 
 	t.Run("succeeds when all frame info in config", func(t *testing.T) {
 		grabPose := referenceframe.NewPoseInFrame("c", spatialmath.NewPoseFromPoint(r3.Vector{0, -30, -50}))
-		_, err = ms.Move(context.Background(), gripper.Named("pieceGripper"), grabPose, &commonpb.WorldState{}, map[string]interface{}{})
+		_, err = ms.Move(context.Background(), gripper.Named("pieceGripper"), grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
 		test.That(t, err, test.ShouldBeNil)
 	})
 
 	t.Run("succeeds when mobile component can be solved for destinations in own frame", func(t *testing.T) {
 		grabPose := referenceframe.NewPoseInFrame("pieceArm", spatialmath.NewPoseFromPoint(r3.Vector{0, -30, -50}))
-		_, err = ms.Move(context.Background(), gripper.Named("pieceArm"), grabPose, &commonpb.WorldState{}, map[string]interface{}{})
+		_, err = ms.Move(context.Background(), gripper.Named("pieceArm"), grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
 		test.That(t, err, test.ShouldBeNil)
 	})
 
 	t.Run("succeeds when immobile component can be solved for destinations in own frame", func(t *testing.T) {
 		grabPose := referenceframe.NewPoseInFrame("pieceGripper", spatialmath.NewPoseFromPoint(r3.Vector{0, -30, -50}))
-		_, err = ms.Move(context.Background(), gripper.Named("pieceGripper"), grabPose, &commonpb.WorldState{}, map[string]interface{}{})
+		_, err = ms.Move(context.Background(), gripper.Named("pieceGripper"), grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
 		test.That(t, err, test.ShouldBeNil)
 	})
 
@@ -119,11 +122,59 @@ func TestMove1(t *testing.T) {
 			Transforms: transformMsgs,
 		}
 		grabPose := referenceframe.NewPoseInFrame("testFrame2", spatialmath.NewPoseFromPoint(r3.Vector{-20, -130, -40}))
-		_, err = ms.Move(context.Background(), gripper.Named("pieceGripper"), grabPose, worldState, map[string]interface{}{})
+		_, err = ms.Move(context.Background(), gripper.Named("pieceGripper"), grabPose, worldState, nil, map[string]interface{}{})
 		test.That(t, err, test.ShouldBeNil)
 	})
 }
 
+func TestMovePlanRecords(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg, err := config.Read(ctx, "../data/moving_arm.json", logger)
+	test.That(t, err, test.ShouldBeNil)
+	myRobot, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer myRobot.Close(context.Background())
+	dir := filepath.Join(t.TempDir(), "plans")
+	ms, err := builtin.NewBuiltIn(ctx, myRobot, config.Service{ConvertedAttributes: &builtin.Config{PlanRecordDir: dir}}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	grabPose := referenceframe.NewPoseInFrame("c", spatialmath.NewPoseFromPoint(r3.Vector{0, -30, -50}))
+	_, err = ms.Move(ctx, gripper.Named("pieceGripper"), grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
+	test.That(t, err, test.ShouldBeNil)
+
+	files, err := os.ReadDir(dir)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, files, test.ShouldHaveLength, 1)
+	record, err := motionplan.ReadPlanRecordFile(filepath.Join(dir, files[0].Name()))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, record.Error, test.ShouldBeEmpty)
+	test.That(t, record.Request.SolveFrame, test.ShouldEqual, "pieceGripper")
+	test.That(t, record.Request.PlannerSettings, test.ShouldHaveLength, 1)
+	test.That(t, record.Plan, test.ShouldNotBeEmpty)
+}
+
+func TestMoveRoadmapCache(t *testing.T) {
+	ctx := context.Background()
+	logger := golog.NewTestLogger(t)
+	cfg, err := config.Read(ctx, "../data/moving_arm.json", logger)
+	test.That(t, err, test.ShouldBeNil)
+	myRobot, err := robotimpl.New(ctx, cfg, logger)
+	test.That(t, err, test.ShouldBeNil)
+	defer myRobot.Close(context.Background())
+	dir := filepath.Join(t.TempDir(), "roadmaps")
+	ms, err := builtin.NewBuiltIn(ctx, myRobot, config.Service{ConvertedAttributes: &builtin.Config{RoadmapCacheDir: dir}}, logger)
+	test.That(t, err, test.ShouldBeNil)
+
+	extra := map[string]interface{}{"planner": motionplan.PRMPlanner, "roadmap_nodes": 50}
+	grabPose := referenceframe.NewPoseInFrame("c", spatialmath.NewPoseFromPoint(r3.Vector{0, -30, -50}))
+	_, err = ms.Move(ctx, gripper.Named("pieceGripper"), grabPose, &commonpb.WorldState{}, nil, extra)
+	test.That(t, err, test.ShouldBeNil)
+	files, err := os.ReadDir(dir)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, files, test.ShouldHaveLength, 1)
+}
+
 func TestMoveWithObstacles(t *testing.T) {
 	var err error
 	ms := setupMotionServiceFromConfig(t, "../data/moving_arm.json")
@@ -171,6 +222,7 @@ func TestMoveWithObstacles(t *testing.T) {
 			gripper.Named("pieceArm"),
 			grabPose,
 			&commonpb.WorldState{Obstacles: obsMsgs},
+			nil,
 			map[string]interface{}{},
 		)
 		// This fails due to a large obstacle being in the way
@@ -189,6 +241,7 @@ func TestMoveSingleComponent(t *testing.T) {
 			arm.Named("pieceArm"),
 			grabPose,
 			&commonpb.WorldState{},
+			nil,
 			map[string]interface{}{},
 		)
 		// Gripper is not an arm and cannot move
@@ -201,6 +254,7 @@ func TestMoveSingleComponent(t *testing.T) {
 			gripper.Named("pieceGripper"),
 			grabPose,
 			&commonpb.WorldState{},
+			nil,
 			map[string]interface{}{},
 		)
 		// Gripper is not an arm and cannot move
@@ -234,7 +288,7 @@ func TestMoveSingleComponent(t *testing.T) {
 		)
 
 		grabPose := referenceframe.NewPoseInFrame("testFrame2", poseToGrab)
-		_, err = ms.MoveSingleComponent(context.Background(), arm.Named("pieceArm"), grabPose, worldState, map[string]interface{}{})
+		_, err = ms.MoveSingleComponent(context.Background(), arm.Named("pieceArm"), grabPose, worldState, nil, map[string]interface{}{})
 		test.That(t, err, test.ShouldBeNil)
 	})
 }
@@ -243,7 +297,7 @@ func TestMultiplePieces(t *testing.T) {
 	var err error
 	ms := setupMotionServiceFromConfig(t, "../data/fake_tomato.json")
 	grabPose := referenceframe.NewPoseInFrame("c", spatialmath.NewPoseFromPoint(r3.Vector{-0, -30, -50}))
-	_, err = ms.Move(context.Background(), gripper.Named("gr"), grabPose, &commonpb.WorldState{}, map[string]interface{}{})
+	_, err = ms.Move(context.Background(), gripper.Named("gr"), grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
 	test.That(t, err, test.ShouldBeNil)
 }
 
@@ -329,3 +383,47 @@ func TestGetPose(t *testing.T) {
 	test.That(t, err, test.ShouldBeError, framesystemparts.NewMissingParentError("testFrame", "noParent"))
 	test.That(t, pose, test.ShouldBeNil)
 }
+
+func TestMoveWithConstraints(t *testing.T) {
+	ms := setupMotionServiceFromConfig(t, "../data/moving_arm.json")
+	start, err := ms.GetPose(context.Background(), arm.Named("pieceArm"), referenceframe.World, nil, map[string]interface{}{})
+	test.That(t, err, test.ShouldBeNil)
+	offsetGoal := func(offset r3.Vector) *referenceframe.PoseInFrame {
+		return referenceframe.NewPoseInFrame(
+			referenceframe.World,
+			spatialmath.NewPoseFromOrientation(start.Pose().Point().Add(offset), start.Pose().Orientation()),
+		)
+	}
+
+	t.Run("linear", func(t *testing.T) {
+		goal := offsetGoal(r3.Vector{X: 50})
+		constraints := &motion.Constraints{Linear: &motion.LinearConstraint{LineToleranceMm: 1, OrientationToleranceDegs: 1}}
+		_, err := ms.Move(context.Background(), arm.Named("pieceArm"), goal, &commonpb.WorldState{}, constraints, nil)
+		test.That(t, err, test.ShouldBeNil)
+		end, err := ms.GetPose(context.Background(), arm.Named("pieceArm"), referenceframe.World, nil, map[string]interface{}{})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, end.Pose().Point().Distance(goal.Pose().Point()), test.ShouldBeLessThan, 1)
+		start = end
+	})
+
+	t.Run("plane through start and goal", func(t *testing.T) {
+		goal := offsetGoal(r3.Vector{Y: 30})
+		constraints := &motion.Constraints{Plane: &motion.PlaneConstraint{Normal: r3.Vector{Z: 1}, PlaneToleranceMm: 1}}
+		_, err := ms.MoveSingleComponent(context.Background(), arm.Named("pieceArm"), goal, &commonpb.WorldState{}, constraints, nil)
+		test.That(t, err, test.ShouldBeNil)
+	})
+
+	t.Run("fails for goal outside plane", func(t *testing.T) {
+		goal := offsetGoal(r3.Vector{Z: 30})
+		constraints := &motion.Constraints{Plane: &motion.PlaneConstraint{Normal: r3.Vector{Z: 1}, PlaneToleranceMm: 1}}
+		_, err := ms.Move(context.Background(), arm.Named("pieceArm"), goal, &commonpb.WorldState{}, constraints, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "plane_tolerance")
+	})
+
+	t.Run("fails for invalid constraints", func(t *testing.T) {
+		constraints := &motion.Constraints{Linear: &motion.LinearConstraint{}, Plane: &motion.PlaneConstraint{Normal: r3.Vector{Z: 1}}}
+		_, err := ms.Move(context.Background(), arm.Named("pieceArm"), offsetGoal(r3.Vector{}), &commonpb.WorldState{}, constraints, nil)
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
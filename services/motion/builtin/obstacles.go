@@ -0,0 +1,364 @@
+package builtin
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/vision"
+	"go.viam.com/rdk/spatialmath"
+)
+
+const (
+	// OnObstacleStop stops the motion when a newly observed obstacle is in the path of the component.
+	OnObstacleStop = "stop"
+	// OnObstacleReplan stops the motion and plans a new one around a newly observed obstacle in the path of the component.
+	OnObstacleReplan = "replan"
+
+	defaultObstaclePollingFrequencyHz = 5.
+	defaultMaxReplans                 = 3
+
+	// Largest change in any input, as a fraction of its range, between configurations at which the remaining path is checked
+	// for collisions.
+	obstacleCheckResolution = 0.005
+)
+
+var errObstacleInPath = errors.New("motion stopped because an obstacle was observed in the path of the component")
+
+// ObstacleSourceConfig describes a segmenter of a vision service whose objects, as seen by a camera, are obstacles.
+type ObstacleSourceConfig struct {
+	VisionService string `json:"vision_service"`
+	Segmenter     string `json:"segmenter"`
+	Camera        string `json:"camera"`
+}
+
+// Validate ensures all parts of the config are valid.
+func (config *ObstacleSourceConfig) Validate(path string) error {
+	if config.VisionService == "" {
+		return utils.NewConfigValidationFieldRequiredError(path, "vision_service")
+	}
+	if config.Segmenter == "" {
+		return utils.NewConfigValidationFieldRequiredError(path, "segmenter")
+	}
+	if config.Camera == "" {
+		return utils.NewConfigValidationFieldRequiredError(path, "camera")
+	}
+	return nil
+}
+
+// obstacleSource provides the geometries of the obstacles it currently observes.
+type obstacleSource interface {
+	Obstacles(ctx context.Context) (*referenceframe.GeometriesInFrame, error)
+}
+
+// segmenterSource observes the objects found by a segmenter in the point clouds of a camera.
+type segmenterSource struct {
+	vision    vision.Service
+	segmenter string
+	camera    string
+}
+
+func (s *segmenterSource) Obstacles(ctx context.Context) (*referenceframe.GeometriesInFrame, error) {
+	objects, err := s.vision.GetObjectPointClouds(ctx, s.camera, s.segmenter)
+	if err != nil {
+		return nil, err
+	}
+	geometries := make(map[string]spatialmath.Geometry, len(objects))
+	for i, object := range objects {
+		if object.Geometry != nil {
+			geometries[fmt.Sprintf("%s_%d", s.segmenter, i)] = object.Geometry
+		}
+	}
+	return referenceframe.NewGeometriesInFrame(s.camera, geometries), nil
+}
+
+// obstacleSources returns the configured sources of obstacles. Vision services are looked up on each call since they may
+// have been reconfigured since the motion service was created.
+func (ms *builtIn) obstacleSources() ([]obstacleSource, error) {
+	sources := make([]obstacleSource, 0, len(ms.config.ObstacleSources))
+	for _, sourceConfig := range ms.config.ObstacleSources {
+		visionService, err := vision.FromRobot(ms.r, sourceConfig.VisionService)
+		if err != nil {
+			return nil, err
+		}
+		sources = append(sources, &segmenterSource{
+			vision:    visionService,
+			segmenter: sourceConfig.Segmenter,
+			camera:    sourceConfig.Camera,
+		})
+	}
+	return sources, nil
+}
+
+// obstacleMonitor watches obstacle sources while a plan executes, and stops it when an obstacle is observed in its path.
+type obstacleMonitor struct {
+	fs       referenceframe.FrameSystem
+	sources  []obstacleSource
+	interval time.Duration
+}
+
+// execute moves the components through the plan, checking the remainder of the plan against the observed obstacles at every
+// interval. If any of them would be hit, the components are stopped and the obstacles in the path are returned.
+func (om *obstacleMonitor) execute(
+	ctx context.Context,
+	resources map[string]referenceframe.InputEnabled,
+	plan []map[string][]referenceframe.Input,
+) (map[string]spatialmath.Geometry, error) {
+	execCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan error, 1)
+	utils.PanicCapturingGo(func() {
+		done <- executePlan(execCtx, om.fs, resources, plan)
+	})
+	stop := func() {
+		cancel()
+		<-done
+		stopResources(ctx, resources)
+	}
+
+	ticker := time.NewTicker(om.interval)
+	defer ticker.Stop()
+	progress := 0
+	for {
+		select {
+		case err := <-done:
+			return nil, err
+		case <-ticker.C:
+		}
+		inputs, err := currentInputs(ctx, om.fs, resources)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		obstacles, err := om.observe(ctx, inputs)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		progress = planProgress(plan, inputs, progress)
+		remaining := append([]map[string][]referenceframe.Input{inputs}, plan[progress+1:]...)
+		inPath, err := pathCollisions(om.fs, remaining, obstacles)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+		if len(inPath) > 0 {
+			stop()
+			return inPath, nil
+		}
+	}
+}
+
+// observe returns the obstacles observed by all sources in the world frame. Obstacles which already intersect the robot at its
+// current inputs are left out, as they are most likely observations of the robot itself.
+func (om *obstacleMonitor) observe(
+	ctx context.Context,
+	inputs map[string][]referenceframe.Input,
+) (map[string]spatialmath.Geometry, error) {
+	robotGeometries, err := frameSystemGeometries(om.fs, inputs)
+	if err != nil {
+		return nil, err
+	}
+	obstacles := map[string]spatialmath.Geometry{}
+	for i, source := range om.sources {
+		observed, err := source.Obstacles(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tf, err := om.fs.Transform(inputs, observed, referenceframe.World)
+		if err != nil {
+			return nil, err
+		}
+		for name, geometry := range tf.(*referenceframe.GeometriesInFrame).Geometries() {
+			collides, err := collidesWithAny(geometry, robotGeometries)
+			if err != nil {
+				return nil, err
+			}
+			if !collides {
+				obstacles[fmt.Sprintf("%d_%s", i, name)] = geometry
+			}
+		}
+	}
+	return obstacles, nil
+}
+
+// pathCollisions returns the obstacles which the geometries of the frame system would collide with while moving along the path.
+// Motion between consecutive steps is interpolated such that no input changes by more than obstacleCheckResolution of its range
+// at a time.
+func pathCollisions(
+	fs referenceframe.FrameSystem,
+	path []map[string][]referenceframe.Input,
+	obstacles map[string]spatialmath.Geometry,
+) (map[string]spatialmath.Geometry, error) {
+	collisions := map[string]spatialmath.Geometry{}
+	if len(obstacles) == 0 {
+		return collisions, nil
+	}
+	check := func(inputs map[string][]referenceframe.Input) error {
+		geometries, err := frameSystemGeometries(fs, inputs)
+		if err != nil {
+			return err
+		}
+		for name, obstacle := range obstacles {
+			collides, err := collidesWithAny(obstacle, geometries)
+			if err != nil {
+				return err
+			}
+			if collides {
+				collisions[name] = obstacle
+			}
+		}
+		return nil
+	}
+	for i, step := range path {
+		if i == 0 {
+			if err := check(step); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		prev := path[i-1]
+		n := interpolationSteps(fs, prev, step)
+		for j := 1; j <= n; j++ {
+			inputs := make(map[string][]referenceframe.Input, len(step))
+			for name, to := range step {
+				from, ok := prev[name]
+				if !ok || len(from) != len(to) {
+					from = to
+				}
+				inputs[name] = referenceframe.InterpolateInputs(from, to, float64(j)/float64(n))
+			}
+			if err := check(inputs); err != nil {
+				return nil, err
+			}
+		}
+		if len(collisions) > 0 {
+			// later steps will not be reached
+			break
+		}
+	}
+	return collisions, nil
+}
+
+// frameSystemGeometries returns all geometries of the frame system at the given inputs, in the world frame.
+func frameSystemGeometries(
+	fs referenceframe.FrameSystem,
+	inputs map[string][]referenceframe.Input,
+) (map[string]spatialmath.Geometry, error) {
+	geometries := map[string]spatialmath.Geometry{}
+	for _, name := range fs.FrameNames() {
+		f := fs.Frame(name)
+		frameInputs, err := referenceframe.GetFrameInputs(f, inputs)
+		if err != nil {
+			return nil, err
+		}
+		// frames without geometries return an error alongside no geometries, which is not a failure here
+		gf, _ := f.Geometries(frameInputs)
+		if gf == nil {
+			continue
+		}
+		tf, err := fs.Transform(inputs, gf, referenceframe.World)
+		if err != nil {
+			return nil, err
+		}
+		for geometryName, geometry := range tf.(*referenceframe.GeometriesInFrame).Geometries() {
+			geometries[geometryName] = geometry
+		}
+	}
+	return geometries, nil
+}
+
+func collidesWithAny(geometry spatialmath.Geometry, others map[string]spatialmath.Geometry) (bool, error) {
+	for _, other := range others {
+		collides, err := geometry.CollidesWith(other)
+		if err != nil {
+			return false, err
+		}
+		if collides {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// planProgress returns the index of the step of the plan, at or after the given one, which is closest to the inputs.
+func planProgress(plan []map[string][]referenceframe.Input, inputs map[string][]referenceframe.Input, from int) int {
+	closest, closestDist := from, math.Inf(1)
+	for i := from; i < len(plan); i++ {
+		if dist := maxInputChange(inputs, plan[i]); dist < closestDist {
+			closest, closestDist = i, dist
+		}
+	}
+	return closest
+}
+
+// interpolationSteps returns the number of steps needed to move between two configurations without any input changing by
+// more than obstacleCheckResolution of its range in a single step.
+func interpolationSteps(fs referenceframe.FrameSystem, from, to map[string][]referenceframe.Input) int {
+	steps := 1
+	for name, toInputs := range to {
+		fromInputs, ok := from[name]
+		f := fs.Frame(name)
+		if !ok || f == nil || len(fromInputs) != len(toInputs) {
+			continue
+		}
+		for i, limit := range f.DoF() {
+			change := math.Abs(toInputs[i].Value-fromInputs[i].Value) / (limit.Max - limit.Min)
+			if !math.IsNaN(change) {
+				steps = int(math.Max(float64(steps), math.Ceil(change/obstacleCheckResolution)))
+			}
+		}
+	}
+	return steps
+}
+
+// maxInputChange returns the largest difference between corresponding inputs of two configurations.
+func maxInputChange(a, b map[string][]referenceframe.Input) float64 {
+	largest := 0.
+	for name, bInputs := range b {
+		aInputs, ok := a[name]
+		if !ok || len(aInputs) != len(bInputs) {
+			continue
+		}
+		for i := range bInputs {
+			largest = math.Max(largest, math.Abs(aInputs[i].Value-bInputs[i].Value))
+		}
+	}
+	return largest
+}
+
+// currentInputs returns the current inputs of all resources, and the starting positions of all other frames.
+func currentInputs(
+	ctx context.Context,
+	fs referenceframe.FrameSystem,
+	resources map[string]referenceframe.InputEnabled,
+) (map[string][]referenceframe.Input, error) {
+	inputs := referenceframe.StartPositions(fs)
+	for name, r := range resources {
+		current, err := r.CurrentInputs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		inputs[name] = current
+	}
+	return inputs, nil
+}
+
+// stopResources stops all resources which can be stopped, reporting rather than returning errors so that every resource is
+// given the chance to stop.
+func stopResources(ctx context.Context, resources map[string]referenceframe.InputEnabled) {
+	for _, r := range resources {
+		switch stoppable := r.(type) {
+		case resource.Stoppable:
+			utils.UncheckedError(stoppable.Stop(ctx, nil))
+		case resource.OldStoppable:
+			utils.UncheckedError(stoppable.Stop(ctx))
+		}
+	}
+}
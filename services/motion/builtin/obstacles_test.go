@@ -0,0 +1,180 @@
+package builtin
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/referenceframe"
+	"go.viam.com/rdk/spatialmath"
+)
+
+// fakeAxis is a single axis which moves towards its goal 10mm at a time.
+type fakeAxis struct {
+	mu       sync.Mutex
+	position float64
+	stopped  bool
+}
+
+func (a *fakeAxis) CurrentInputs(ctx context.Context) ([]referenceframe.Input, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return []referenceframe.Input{{a.position}}, nil
+}
+
+func (a *fakeAxis) GoToInputs(ctx context.Context, goal []referenceframe.Input) error {
+	for {
+		a.mu.Lock()
+		switch {
+		case goal[0].Value > a.position+10:
+			a.position += 10
+		case goal[0].Value < a.position-10:
+			a.position -= 10
+		default:
+			a.position = goal[0].Value
+		}
+		done := a.position == goal[0].Value
+		a.mu.Unlock()
+		if done {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func (a *fakeAxis) Stop(ctx context.Context, extra map[string]interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.stopped = true
+	return nil
+}
+
+type fakeObstacleSource struct {
+	obstacles *referenceframe.GeometriesInFrame
+}
+
+func (s *fakeObstacleSource) Obstacles(ctx context.Context) (*referenceframe.GeometriesInFrame, error) {
+	return s.obstacles, nil
+}
+
+// gantryFrameSystem has a gantry moving along x with a 20mm cube on it, and a camera 100mm along x from the world origin.
+func gantryFrameSystem(t *testing.T) referenceframe.FrameSystem {
+	t.Helper()
+	fs := referenceframe.NewEmptySimpleFrameSystem("test")
+	box, err := spatialmath.NewBoxCreator(r3.Vector{20, 20, 20}, spatialmath.NewZeroPose())
+	test.That(t, err, test.ShouldBeNil)
+	gantry, err := referenceframe.NewTranslationalFrameWithGeometry(
+		"gantry", r3.Vector{X: 1}, referenceframe.Limit{Min: -1000, Max: 1000}, box)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(gantry, fs.World()), test.ShouldBeNil)
+	camera, err := referenceframe.NewStaticFrame("camera", spatialmath.NewPoseFromPoint(r3.Vector{X: 100}))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, fs.AddFrame(camera, fs.World()), test.ShouldBeNil)
+	return fs
+}
+
+func gantryPlan(positions ...float64) []map[string][]referenceframe.Input {
+	plan := make([]map[string][]referenceframe.Input, 0, len(positions))
+	for _, position := range positions {
+		plan = append(plan, map[string][]referenceframe.Input{"gantry": {{position}}, "camera": {}})
+	}
+	return plan
+}
+
+// obstacleAt returns an obstacle source which sees a 20mm cube at the given position along x in the world frame.
+func obstacleAt(t *testing.T, x float64) obstacleSource {
+	t.Helper()
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: x - 100}), r3.Vector{20, 20, 20})
+	test.That(t, err, test.ShouldBeNil)
+	return &fakeObstacleSource{referenceframe.NewGeometriesInFrame("camera", map[string]spatialmath.Geometry{"box": box})}
+}
+
+func TestPathCollisions(t *testing.T) {
+	fs := gantryFrameSystem(t)
+	box, err := spatialmath.NewBox(spatialmath.NewPoseFromPoint(r3.Vector{X: 500}), r3.Vector{20, 20, 20})
+	test.That(t, err, test.ShouldBeNil)
+	obstacles := map[string]spatialmath.Geometry{"box": box}
+
+	inPath, err := pathCollisions(fs, gantryPlan(0, 300), obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inPath, test.ShouldBeEmpty)
+
+	// motion between steps is checked, not just the steps themselves
+	inPath, err = pathCollisions(fs, gantryPlan(0, 300, 1000), obstacles)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, inPath, test.ShouldContainKey, "box")
+
+	test.That(t, planProgress(gantryPlan(0, 300, 1000), gantryPlan(350)[0], 0), test.ShouldEqual, 1)
+	test.That(t, planProgress(gantryPlan(0, 300, 1000), gantryPlan(350)[0], 2), test.ShouldEqual, 2)
+}
+
+func TestObstacleMonitor(t *testing.T) {
+	ctx := context.Background()
+	fs := gantryFrameSystem(t)
+
+	t.Run("no obstacle in path", func(t *testing.T) {
+		axis := &fakeAxis{}
+		monitor := &obstacleMonitor{fs: fs, sources: []obstacleSource{obstacleAt(t, -500)}, interval: time.Millisecond}
+		inPath, err := monitor.execute(ctx, map[string]referenceframe.InputEnabled{"gantry": axis}, gantryPlan(0, 300))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inPath, test.ShouldBeEmpty)
+		test.That(t, axis.position, test.ShouldEqual, 300)
+		test.That(t, axis.stopped, test.ShouldBeFalse)
+	})
+
+	t.Run("obstacle in path", func(t *testing.T) {
+		axis := &fakeAxis{}
+		monitor := &obstacleMonitor{fs: fs, sources: []obstacleSource{obstacleAt(t, 500)}, interval: time.Millisecond}
+		inPath, err := monitor.execute(ctx, map[string]referenceframe.InputEnabled{"gantry": axis}, gantryPlan(0, 1000))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inPath, test.ShouldContainKey, "0_box")
+		test.That(t, axis.position, test.ShouldBeLessThan, 500)
+		test.That(t, axis.stopped, test.ShouldBeTrue)
+	})
+
+	t.Run("obstacles touching the robot are ignored", func(t *testing.T) {
+		axis := &fakeAxis{}
+		monitor := &obstacleMonitor{fs: fs, sources: []obstacleSource{obstacleAt(t, 0)}, interval: time.Millisecond}
+		inPath, err := monitor.execute(ctx, map[string]referenceframe.InputEnabled{"gantry": axis}, gantryPlan(0, 300))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, inPath, test.ShouldBeEmpty)
+		test.That(t, axis.position, test.ShouldEqual, 300)
+	})
+}
+
+func TestConfigValidate(t *testing.T) {
+	conf := &Config{}
+	test.That(t, conf.Validate("path"), test.ShouldBeNil)
+
+	conf.OnObstacle = "swerve"
+	test.That(t, conf.Validate("path"), test.ShouldNotBeNil)
+	conf.OnObstacle = OnObstacleReplan
+	test.That(t, conf.Validate("path"), test.ShouldBeNil)
+
+	conf.ObstacleSources = []ObstacleSourceConfig{{VisionService: "vision", Camera: "camera"}}
+	err := conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "segmenter")
+	conf.ObstacleSources[0].Segmenter = "segmenter"
+	test.That(t, conf.Validate("path"), test.ShouldBeNil)
+
+	conf.BaseKinematics = BaseKinematicsDubins
+	err = conf.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "base_turning_radius_mm")
+	conf.BaseTurningRadiusMm = 200
+	test.That(t, conf.Validate("path"), test.ShouldBeNil)
+	conf.BaseKinematics = "hover"
+	test.That(t, conf.Validate("path"), test.ShouldNotBeNil)
+	conf.BaseKinematics = BaseKinematicsDifferentialDrive
+	conf.BaseLinearMmPerSec = -1
+	test.That(t, conf.Validate("path"), test.ShouldNotBeNil)
+}
@@ -39,8 +39,13 @@ func (c *client) Move(
 	componentName resource.Name,
 	destination *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
+	extra, err := constraintsToExtra(constraints, extra)
+	if err != nil {
+		return false, err
+	}
 	ext, err := protoutils.StructToStructPb(extra)
 	if err != nil {
 		return false, err
@@ -63,8 +68,13 @@ func (c *client) MoveSingleComponent(
 	componentName resource.Name,
 	destination *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
+	extra, err := constraintsToExtra(constraints, extra)
+	if err != nil {
+		return false, err
+	}
 	ext, err := protoutils.StructToStructPb(extra)
 	if err != nil {
 		return false, err
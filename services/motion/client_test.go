@@ -69,14 +69,19 @@ func TestClient(t *testing.T) {
 		client := motion.NewClientFromConn(context.Background(), conn, testMotionServiceName, logger)
 
 		receivedTransforms := make(map[string]*commonpb.Transform)
+		var receivedConstraints *motion.Constraints
+		var receivedExtra map[string]interface{}
 		success := true
 		injectMS.MoveFunc = func(
 			ctx context.Context,
 			componentName resource.Name,
 			destination *referenceframe.PoseInFrame,
 			worldState *commonpb.WorldState,
+			constraints *motion.Constraints,
 			extra map[string]interface{},
 		) (bool, error) {
+			receivedConstraints = constraints
+			receivedExtra = extra
 			return success, nil
 		}
 		injectMS.GetPoseFunc = func(
@@ -95,10 +100,40 @@ func TestClient(t *testing.T) {
 
 		result, err := client.Move(
 			context.Background(), resourceName, grabPose,
-			&commonpb.WorldState{}, map[string]interface{}{},
+			&commonpb.WorldState{}, nil, map[string]interface{}{},
 		)
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, result, test.ShouldEqual, success)
+		test.That(t, receivedConstraints, test.ShouldBeNil)
+
+		// constraints are sent alongside, but separately from, the extra parameters
+		constraints := &motion.Constraints{
+			Plane: &motion.PlaneConstraint{Normal: r3.Vector{Z: 1}, PlaneToleranceMm: 0.5, OrientationToleranceDegs: 2},
+		}
+		result, err = client.MoveSingleComponent(
+			context.Background(), resourceName, grabPose,
+			&commonpb.WorldState{}, constraints, map[string]interface{}{"foo": "bar"},
+		)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, result, test.ShouldEqual, success)
+		test.That(t, receivedConstraints, test.ShouldResemble, constraints)
+		test.That(t, receivedExtra, test.ShouldResemble, map[string]interface{}{"foo": "bar"})
+
+		// the key constraints are sent under cannot be used by the caller
+		_, err = client.Move(
+			context.Background(), resourceName, grabPose,
+			&commonpb.WorldState{}, nil, map[string]interface{}{"__rdk_constraints": map[string]interface{}{}},
+		)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "reserved key")
+		result, err = client.Move(
+			context.Background(), resourceName, grabPose,
+			&commonpb.WorldState{}, nil, map[string]interface{}{"constraints": "mine"},
+		)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, result, test.ShouldEqual, success)
+		test.That(t, receivedConstraints, test.ShouldBeNil)
+		test.That(t, receivedExtra, test.ShouldResemble, map[string]interface{}{"constraints": "mine"})
 
 		testPose := spatialmath.NewPoseFromOrientation(
 			r3.Vector{X: 1., Y: 2., Z: 3.},
@@ -165,6 +200,7 @@ func TestClient(t *testing.T) {
 			componentName resource.Name,
 			grabPose *referenceframe.PoseInFrame,
 			worldState *commonpb.WorldState,
+			constraints *motion.Constraints,
 			extra map[string]interface{},
 		) (bool, error) {
 			return false, passedErr
@@ -180,7 +216,7 @@ func TestClient(t *testing.T) {
 			return nil, passedErr
 		}
 
-		resp, err := client2.Move(context.Background(), resourceName, grabPose, &commonpb.WorldState{}, map[string]interface{}{})
+		resp, err := client2.Move(context.Background(), resourceName, grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
 		test.That(t, err.Error(), test.ShouldContainSubstring, passedErr.Error())
 		test.That(t, resp, test.ShouldEqual, false)
 		_, err = client2.GetPose(context.Background(), arm.Named("arm1"), "foo", nil, map[string]interface{}{})
@@ -0,0 +1,128 @@
+package motion
+
+import (
+	"encoding/json"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+)
+
+// constraintsKey is the key within a request's extra parameters under which Constraints are sent over the network. It is
+// reserved, so that it cannot be mistaken for a parameter of the caller's.
+const constraintsKey = "__rdk_constraints"
+
+// Constraints restrict the path that a component takes to its destination. At most one kind of constraint may be given;
+// when none are given, the component may take any path which avoids obstacles.
+type Constraints struct {
+	// Linear requires the component to move in a straight line to its destination.
+	Linear *LinearConstraint `json:"linear,omitempty"`
+
+	// Orientation requires the component's orientation to stay on the shortest arc between its starting and final
+	// orientations, which locks its orientation if the two are the same.
+	Orientation *OrientationConstraint `json:"orientation,omitempty"`
+
+	// Plane requires the component to stay within a plane through its starting position.
+	Plane *PlaneConstraint `json:"plane,omitempty"`
+}
+
+// LinearConstraint specifies a straight-line motion. Tolerances which are zero use the planner's defaults.
+type LinearConstraint struct {
+	LineToleranceMm          float64 `json:"line_tolerance_mm,omitempty"`
+	OrientationToleranceDegs float64 `json:"orientation_tolerance_degs,omitempty"`
+}
+
+// OrientationConstraint specifies a motion whose orientation is interpolated between the start and goal. A tolerance of zero
+// uses the planner's default.
+type OrientationConstraint struct {
+	OrientationToleranceDegs float64 `json:"orientation_tolerance_degs,omitempty"`
+}
+
+// PlaneConstraint specifies a motion within the plane, given by its normal in the world frame, which passes through the
+// starting position. Tolerances which are zero use the planner's defaults.
+type PlaneConstraint struct {
+	Normal                   r3.Vector `json:"normal"`
+	PlaneToleranceMm         float64   `json:"plane_tolerance_mm,omitempty"`
+	OrientationToleranceDegs float64   `json:"orientation_tolerance_degs,omitempty"`
+}
+
+// Validate returns an error if the constraints cannot be satisfied by any motion.
+func (c *Constraints) Validate() error {
+	if c == nil {
+		return nil
+	}
+	set := 0
+	for _, isSet := range []bool{c.Linear != nil, c.Orientation != nil, c.Plane != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		return errors.New("at most one of linear, orientation, or plane constraints may be given")
+	}
+	var tolerances []float64
+	switch {
+	case c.Linear != nil:
+		tolerances = []float64{c.Linear.LineToleranceMm, c.Linear.OrientationToleranceDegs}
+	case c.Orientation != nil:
+		tolerances = []float64{c.Orientation.OrientationToleranceDegs}
+	case c.Plane != nil:
+		if c.Plane.Normal.Norm() == 0 {
+			return errors.New("plane constraint must have a nonzero normal")
+		}
+		tolerances = []float64{c.Plane.PlaneToleranceMm, c.Plane.OrientationToleranceDegs}
+	}
+	for _, tolerance := range tolerances {
+		if tolerance < 0 {
+			return errors.Errorf("constraint tolerances cannot be negative, got %f", tolerance)
+		}
+	}
+	return nil
+}
+
+// constraintsToExtra returns a copy of extra with the constraints added, so they can be sent in a request's extra parameters.
+// It returns an error if extra already uses the key reserved for constraints.
+func constraintsToExtra(constraints *Constraints, extra map[string]interface{}) (map[string]interface{}, error) {
+	if _, ok := extra[constraintsKey]; ok {
+		return nil, errors.Errorf("extra parameters cannot contain the reserved key %q", constraintsKey)
+	}
+	if constraints == nil {
+		return extra, nil
+	}
+	data, err := json.Marshal(constraints)
+	if err != nil {
+		return nil, err
+	}
+	var constraintsMap map[string]interface{}
+	if err := json.Unmarshal(data, &constraintsMap); err != nil {
+		return nil, err
+	}
+	withConstraints := make(map[string]interface{}, len(extra)+1)
+	for k, v := range extra {
+		withConstraints[k] = v
+	}
+	withConstraints[constraintsKey] = constraintsMap
+	return withConstraints, nil
+}
+
+// constraintsFromExtra extracts constraints from a request's extra parameters, returning them along with the remaining parameters.
+func constraintsFromExtra(extra map[string]interface{}) (*Constraints, map[string]interface{}, error) {
+	constraintsMap, ok := extra[constraintsKey]
+	if !ok {
+		return nil, extra, nil
+	}
+	data, err := json.Marshal(constraintsMap)
+	if err != nil {
+		return nil, nil, err
+	}
+	constraints := &Constraints{}
+	if err := json.Unmarshal(data, constraints); err != nil {
+		return nil, nil, errors.Wrap(err, "could not parse motion constraints")
+	}
+	remaining := make(map[string]interface{}, len(extra)-1)
+	for k, v := range extra {
+		if k != constraintsKey {
+			remaining[k] = v
+		}
+	}
+	return constraints, remaining, nil
+}
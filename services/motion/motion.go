@@ -44,6 +44,7 @@ type Service interface {
 		componentName resource.Name,
 		destination *referenceframe.PoseInFrame,
 		worldState *commonpb.WorldState,
+		constraints *Constraints,
 		extra map[string]interface{},
 	) (bool, error)
 	MoveSingleComponent(
@@ -51,6 +52,7 @@ type Service interface {
 		componentName resource.Name,
 		destination *referenceframe.PoseInFrame,
 		worldState *commonpb.WorldState,
+		constraints *Constraints,
 		extra map[string]interface{},
 	) (bool, error)
 	GetPose(
@@ -111,11 +113,12 @@ func (svc *reconfigurableMotionService) Move(
 	componentName resource.Name,
 	destination *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
-	return svc.actual.Move(ctx, componentName, destination, worldState, extra)
+	return svc.actual.Move(ctx, componentName, destination, worldState, constraints, extra)
 }
 
 func (svc *reconfigurableMotionService) MoveSingleComponent(
@@ -123,11 +126,12 @@ func (svc *reconfigurableMotionService) MoveSingleComponent(
 	componentName resource.Name,
 	destination *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
-	return svc.actual.MoveSingleComponent(ctx, componentName, destination, worldState, extra)
+	return svc.actual.MoveSingleComponent(ctx, componentName, destination, worldState, constraints, extra)
 }
 
 func (svc *reconfigurableMotionService) GetPose(
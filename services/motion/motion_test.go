@@ -45,6 +45,7 @@ func (m *mock) Move(
 	gripperName resource.Name,
 	grabPose *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *motion.Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
 	m.grabCount++
@@ -56,6 +57,7 @@ func (m *mock) MoveSingleComponent(
 	gripperName resource.Name,
 	grabPose *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *motion.Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
 	m.grabCount++
@@ -85,7 +87,7 @@ func TestFromRobot(t *testing.T) {
 	test.That(t, svc, test.ShouldNotBeNil)
 
 	grabPose := referenceframe.NewPoseInFrame("", spatialmath.NewZeroPose())
-	result, err := svc.Move(context.Background(), gripper.Named("fake"), grabPose, &commonpb.WorldState{}, map[string]interface{}{})
+	result, err := svc.Move(context.Background(), gripper.Named("fake"), grabPose, &commonpb.WorldState{}, nil, map[string]interface{}{})
 	test.That(t, err, test.ShouldBeNil)
 	test.That(t, result, test.ShouldEqual, false)
 	test.That(t, svc1.grabCount, test.ShouldEqual, 1)
@@ -148,3 +150,25 @@ func TestReconfigurable(t *testing.T) {
 	test.That(t, err, test.ShouldNotBeNil)
 	test.That(t, err, test.ShouldBeError, rutils.NewUnexpectedTypeError(reconfSvc1, nil))
 }
+
+func TestConstraintsValidate(t *testing.T) {
+	var constraints *motion.Constraints
+	test.That(t, constraints.Validate(), test.ShouldBeNil)
+	test.That(t, (&motion.Constraints{}).Validate(), test.ShouldBeNil)
+	test.That(t, (&motion.Constraints{Linear: &motion.LinearConstraint{LineToleranceMm: 1}}).Validate(), test.ShouldBeNil)
+
+	err := (&motion.Constraints{
+		Linear:      &motion.LinearConstraint{},
+		Orientation: &motion.OrientationConstraint{},
+	}).Validate()
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "at most one")
+
+	err = (&motion.Constraints{Orientation: &motion.OrientationConstraint{OrientationToleranceDegs: -1}}).Validate()
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "cannot be negative")
+
+	err = (&motion.Constraints{Plane: &motion.PlaneConstraint{}}).Validate()
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "nonzero normal")
+}
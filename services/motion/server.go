@@ -41,12 +41,17 @@ func (server *subtypeServer) Move(ctx context.Context, req *pb.MoveRequest) (*pb
 	if err != nil {
 		return nil, err
 	}
+	constraints, extra, err := constraintsFromExtra(req.Extra.AsMap())
+	if err != nil {
+		return nil, err
+	}
 	success, err := svc.Move(
 		ctx,
 		protoutils.ResourceNameFromProto(req.GetComponentName()),
 		referenceframe.ProtobufToPoseInFrame(req.GetDestination()),
 		req.GetWorldState(),
-		req.Extra.AsMap(),
+		constraints,
+		extra,
 	)
 	return &pb.MoveResponse{Success: success}, err
 }
@@ -59,12 +64,17 @@ func (server *subtypeServer) MoveSingleComponent(
 	if err != nil {
 		return nil, err
 	}
+	constraints, extra, err := constraintsFromExtra(req.Extra.AsMap())
+	if err != nil {
+		return nil, err
+	}
 	success, err := svc.MoveSingleComponent(
 		ctx,
 		protoutils.ResourceNameFromProto(req.GetComponentName()),
 		referenceframe.ProtobufToPoseInFrame(req.GetDestination()),
 		req.GetWorldState(),
-		req.Extra.AsMap(),
+		constraints,
+		extra,
 	)
 	return &pb.MoveSingleComponentResponse{Success: success}, err
 }
@@ -60,6 +60,7 @@ func TestServerMove(t *testing.T) {
 		componentName resource.Name,
 		destination *referenceframe.PoseInFrame,
 		worldState *commonpb.WorldState,
+		constraints *motion.Constraints,
 		extra map[string]interface{},
 	) (bool, error) {
 		return false, passedErr
@@ -74,6 +75,7 @@ func TestServerMove(t *testing.T) {
 		componentName resource.Name,
 		destination *referenceframe.PoseInFrame,
 		worldState *commonpb.WorldState,
+		constraints *motion.Constraints,
 		extra map[string]interface{},
 	) (bool, error) {
 		return true, nil
@@ -94,6 +96,7 @@ func TestServerMove(t *testing.T) {
 		componentName resource.Name,
 		destination *referenceframe.PoseInFrame,
 		worldState *commonpb.WorldState,
+		constraints *motion.Constraints,
 		extra map[string]interface{},
 	) (bool, error) {
 		return true, nil
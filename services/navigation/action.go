@@ -0,0 +1,68 @@
+package navigation
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"go.viam.com/utils"
+
+	"go.viam.com/rdk/resource"
+)
+
+// WaypointActionType is what a waypoint action does.
+type WaypointActionType string
+
+// The set of known waypoint action types.
+const (
+	// WaypointActionDwell waits at the waypoint for DwellSec seconds.
+	WaypointActionDwell = WaypointActionType("dwell")
+	// WaypointActionFaceHeading spins the base in place to face the compass heading HeadingDeg.
+	WaypointActionFaceHeading = WaypointActionType("face_heading")
+	// WaypointActionDoCommand sends Command to the DoCommand of Resource.
+	WaypointActionDoCommand = WaypointActionType("do_command")
+	// WaypointActionSyncData syncs the data captured so far by the data manager service named by Resource. The data
+	// manager captures data on its own schedule, so this does not capture any more.
+	WaypointActionSyncData = WaypointActionType("sync_data")
+)
+
+// A WaypointAction is something to do once a waypoint is reached, before moving on to the next.
+type WaypointAction struct {
+	Type       WaypointActionType     `bson:"type" json:"type"`
+	DwellSec   float64                `bson:"dwell_sec,omitempty" json:"dwell_sec,omitempty"`
+	HeadingDeg float64                `bson:"heading_deg,omitempty" json:"heading_deg,omitempty"`
+	Resource   string                 `bson:"resource,omitempty" json:"resource,omitempty"`
+	Command    map[string]interface{} `bson:"command,omitempty" json:"command,omitempty"`
+}
+
+// Validate ensures the action is of a known type and has what that type needs.
+func (action *WaypointAction) Validate(path string) error {
+	switch action.Type {
+	case WaypointActionDwell:
+		if action.DwellSec <= 0 {
+			return utils.NewConfigValidationError(path, errors.Errorf("dwell_sec must be positive, got %v", action.DwellSec))
+		}
+	case WaypointActionFaceHeading:
+		if action.HeadingDeg < 0 || action.HeadingDeg >= 360 {
+			return utils.NewConfigValidationError(path, errors.Errorf("heading_deg must be in [0, 360), got %v", action.HeadingDeg))
+		}
+	case WaypointActionDoCommand:
+		if action.Resource == "" {
+			return utils.NewConfigValidationFieldRequiredError(path, "resource")
+		}
+		if _, err := resource.NewFromString(action.Resource); err != nil {
+			return utils.NewConfigValidationError(fmt.Sprintf("%s.%s", path, "resource"), err)
+		}
+		if len(action.Command) == 0 {
+			return utils.NewConfigValidationFieldRequiredError(path, "command")
+		}
+	case WaypointActionSyncData:
+		if action.Resource == "" {
+			return utils.NewConfigValidationFieldRequiredError(path, "resource")
+		}
+	case "":
+		return utils.NewConfigValidationFieldRequiredError(path, "type")
+	default:
+		return utils.NewConfigValidationError(path, errors.Errorf("unknown waypoint action type %q", action.Type))
+	}
+	return nil
+}
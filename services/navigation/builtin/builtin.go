@@ -5,29 +5,38 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"reflect"
 	"sync"
 	"time"
 
 	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
 	geo "github.com/kellydunn/golang-geo"
 	"github.com/mitchellh/mapstructure"
 	"github.com/pkg/errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/multierr"
 	"go.viam.com/utils"
 
 	"go.viam.com/rdk/components/base"
+	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/components/movementsensor"
 	"go.viam.com/rdk/config"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/robot"
+	"go.viam.com/rdk/services/datamanager"
 	"go.viam.com/rdk/services/navigation"
 	rdkutils "go.viam.com/rdk/utils"
 )
 
 const (
-	mmPerSecDefault  = 500
-	degPerSecDefault = 45
+	mmPerSecDefault        = 500
+	degPerSecDefault       = 45
+	arrivalRadiusMDefault  = 5
+	lookaheadMDefault      = 5
+	geofenceMarginMDefault = 1
+	navLoopInterval        = 100 * time.Millisecond
 )
 
 func init() {
@@ -58,6 +67,23 @@ type Config struct {
 	MovementSensorName string                 `json:"movement_sensor"`
 	DegPerSecDefault   float64                `json:"degs_per_sec"`
 	MMPerSecDefault    float64                `json:"mm_per_sec"`
+	// ArrivalRadiusM is how close the base must get to a waypoint for it to be reached.
+	ArrivalRadiusM float64 `json:"arrival_radius_m"`
+	// LookaheadM is how far ahead on the path to a waypoint the base heads for; the further, the smoother but less
+	// closely it follows the path.
+	LookaheadM float64 `json:"lookahead_m"`
+	// GeofenceMarginM is how far from the edges of geofences the path to a waypoint is kept.
+	GeofenceMarginM float64 `json:"geofence_margin_m"`
+}
+
+// Validate ensures all parts of the config are valid, and returns the base and movement sensor it depends on.
+func (config *Config) Validate(path string) ([]string, error) {
+	navConfig := navigation.Config{
+		Store:              config.Store,
+		BaseName:           config.BaseName,
+		MovementSensorName: config.MovementSensorName,
+	}
+	return navConfig.Validate(path)
 }
 
 // NewBuiltIn returns a new navigation service for the given robot.
@@ -85,6 +111,12 @@ func NewBuiltIn(ctx context.Context, r robot.Robot, config config.Service, logge
 		if err != nil {
 			return nil, err
 		}
+	case navigation.StoreTypeFile:
+		var err error
+		store, err = navigation.NewFileNavigationStore(svcConfig.Store.Config)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("unknown store type %q", svcConfig.Store.Type)
 	}
@@ -98,6 +130,32 @@ func NewBuiltIn(ctx context.Context, r robot.Robot, config config.Service, logge
 	if spinSpeed == 0 {
 		spinSpeed = degPerSecDefault
 	}
+	arrivalRadius := svcConfig.ArrivalRadiusM
+	if arrivalRadius < 0 {
+		return nil, errors.Errorf("arrival_radius_m must be positive, got %v", arrivalRadius)
+	}
+	if arrivalRadius == 0 {
+		arrivalRadius = arrivalRadiusMDefault
+	}
+	lookahead := svcConfig.LookaheadM
+	if lookahead < 0 {
+		return nil, errors.Errorf("lookahead_m must be positive, got %v", lookahead)
+	}
+	if lookahead == 0 {
+		lookahead = lookaheadMDefault
+	}
+	geofenceMargin := svcConfig.GeofenceMarginM
+	if geofenceMargin < 0 {
+		return nil, errors.Errorf("geofence_margin_m must be positive, got %v", geofenceMargin)
+	}
+	if geofenceMargin == 0 {
+		geofenceMargin = geofenceMarginMDefault
+	}
+
+	headingSource, err := headingSourceOf(ctx, movementSensor)
+	if err != nil {
+		logger.Warnw("failed to get movement sensor properties; estimating heading from gps track", "error", err)
+	}
 
 	cancelCtx, cancelFunc := context.WithCancel(context.Background())
 	navSvc := &builtIn{
@@ -105,8 +163,13 @@ func NewBuiltIn(ctx context.Context, r robot.Robot, config config.Service, logge
 		store:            store,
 		base:             base1,
 		movementSensor:   movementSensor,
+		headingSource:    headingSource,
 		mmPerSecDefault:  straightSpeed,
 		degPerSecDefault: spinSpeed,
+		arrivalRadiusM:   arrivalRadius,
+		geofenceMarginM:  geofenceMargin,
+		pursuit:          purePursuit{lookaheadM: lookahead, mmPerSec: straightSpeed, degPerSec: spinSpeed},
+		progress:         navigation.MissionProgress{State: navigation.MissionStateIdle, Action: -1},
 		logger:           logger,
 		cancelCtx:        cancelCtx,
 		cancelFunc:       cancelFunc,
@@ -115,16 +178,29 @@ func NewBuiltIn(ctx context.Context, r robot.Robot, config config.Service, logge
 }
 
 type builtIn struct {
-	mu    sync.RWMutex
+	// mu is held while switching modes.
+	mu    sync.Mutex
 	r     robot.Robot
 	store navigation.NavStore
-	mode  navigation.Mode
+
+	// stateMu guards the mode and progress, which navigating changes once a mission completes.
+	stateMu  sync.Mutex
+	mode     navigation.Mode
+	progress navigation.MissionProgress
+	// waypointState is kept while paused so a mission resumes where it left off.
+	waypointState waypointState
+	// geofencesChanged is whether the geofences may have changed since navigating last read them.
+	geofencesChanged bool
 
 	base           base.Base
 	movementSensor movementsensor.MovementSensor
+	headingSource  headingSource
 
 	mmPerSecDefault         float64
 	degPerSecDefault        float64
+	arrivalRadiusM          float64
+	geofenceMarginM         float64
+	pursuit                 purePursuit
 	logger                  golog.Logger
 	cancelCtx               context.Context
 	cancelFunc              func()
@@ -132,17 +208,23 @@ type builtIn struct {
 }
 
 func (svc *builtIn) Mode(ctx context.Context) (navigation.Mode, error) {
-	svc.mu.RLock()
-	defer svc.mu.RUnlock()
+	svc.stateMu.Lock()
+	defer svc.stateMu.Unlock()
 	return svc.mode, nil
 }
 
 func (svc *builtIn) SetMode(ctx context.Context, mode navigation.Mode) error {
 	svc.mu.Lock()
 	defer svc.mu.Unlock()
-	if svc.mode == mode {
+	svc.stateMu.Lock()
+	current := svc.mode
+	svc.stateMu.Unlock()
+	if current == mode {
 		return nil
 	}
+	if mode == navigation.ModePaused && current != navigation.ModeWaypoint {
+		return errors.New("can only pause while navigating to waypoints")
+	}
 
 	// switch modes
 	svc.cancelFunc()
@@ -151,12 +233,33 @@ func (svc *builtIn) SetMode(ctx context.Context, mode navigation.Mode) error {
 	svc.cancelCtx = cancelCtx
 	svc.cancelFunc = cancelFunc
 
-	svc.mode = navigation.ModeManual
-	if mode == navigation.ModeWaypoint {
+	svc.stateMu.Lock()
+	defer svc.stateMu.Unlock()
+	switch mode {
+	case navigation.ModeWaypoint:
+		if svc.mode != navigation.ModePaused {
+			svc.waypointState = waypointState{}
+			svc.progress = navigation.MissionProgress{Action: -1}
+		}
+		svc.geofencesChanged = true
 		if err := svc.startWaypoint(); err != nil {
+			svc.mode = navigation.ModeManual
 			return err
 		}
+		svc.progress.State = navigation.MissionStateRunning
 		svc.mode = mode
+	case navigation.ModePaused:
+		if svc.progress.State != navigation.MissionStateRunning {
+			// the mission completed while switching
+			return errors.New("can only pause while navigating to waypoints")
+		}
+		svc.progress.State = navigation.MissionStatePaused
+		svc.mode = mode
+	default:
+		if svc.progress.State == navigation.MissionStateRunning || svc.progress.State == navigation.MissionStatePaused {
+			svc.progress.State = navigation.MissionStateAborted
+		}
+		svc.mode = navigation.ModeManual
 	}
 	return nil
 }
@@ -165,85 +268,314 @@ func (svc *builtIn) startWaypoint() error {
 	svc.activeBackgroundWorkers.Add(1)
 	utils.PanicCapturingGo(func() {
 		defer svc.activeBackgroundWorkers.Done()
+		defer func() {
+			if err := svc.base.Stop(context.Background(), nil); err != nil {
+				svc.logger.Errorw("failed to stop base", "error", err)
+			}
+		}()
 
-		path := []*geo.Point{}
 		for {
-			if !utils.SelectContextOrWait(svc.cancelCtx, 500*time.Millisecond) {
+			if !utils.SelectContextOrWait(svc.cancelCtx, navLoopInterval) {
 				return
 			}
-
-			currentLoc, _, err := svc.movementSensor.Position(svc.cancelCtx)
-			if err != nil {
-				svc.logger.Errorw("failed to get gps location", "error", err)
-				continue
+			if err := svc.navStep(svc.cancelCtx, &svc.waypointState); err != nil && !errors.Is(err, context.Canceled) {
+				svc.logger.Debugf("error navigating: %s", err)
 			}
-
-			if len(path) <= 1 || currentLoc.GreatCircleDistance(path[len(path)-1]) > .0001 {
-				// gps often updates less frequently
-				path = append(path, currentLoc)
-				if len(path) > 2 {
-					path = path[len(path)-2:]
-				}
+			svc.stateMu.Lock()
+			completed := svc.progress.State == navigation.MissionStateCompleted
+			svc.stateMu.Unlock()
+			if completed {
+				return
 			}
+		}
+	})
+	return nil
+}
 
-			navOnce := func(ctx context.Context) error {
-				if len(path) <= 1 {
-					return errors.New("not enough gps data")
-				}
+// headingSource is where the heading of the base comes from.
+type headingSource int
 
-				pathLen := len(path)
-				currentBearing := fixAngle(path[pathLen-2].BearingTo(path[pathLen-1]))
+const (
+	// headingSourceTrack estimates the heading from the last two distinct positions.
+	headingSourceTrack = headingSource(iota)
+	headingSourceCompass
+	headingSourceOrientation
+)
 
-				bearingToGoal, distanceToGoal, err := svc.waypointDirectionAndDistanceToGo(ctx, currentLoc)
-				if err != nil {
-					return err
-				}
+// headingSourceOf returns where to get the heading of the base from the movement sensor, which is from its track if the
+// sensor cannot report it.
+func headingSourceOf(ctx context.Context, movementSensor movementsensor.MovementSensor) (headingSource, error) {
+	props, err := movementSensor.Properties(ctx)
+	if err != nil {
+		return headingSourceTrack, err
+	}
+	switch {
+	case props.CompassHeadingSupported:
+		return headingSourceCompass, nil
+	case props.OrientationSupported:
+		return headingSourceOrientation, nil
+	default:
+		return headingSourceTrack, nil
+	}
+}
 
-				if distanceToGoal < .005 {
-					svc.logger.Debug("i made it")
-					return svc.waypointReached(ctx)
-				}
+// waypointState is what is remembered between steps of navigating to waypoints.
+type waypointState struct {
+	// path is the path to the current waypoint, goalID, around the geofences, and leg is which of its legs the base is on.
+	path   []*geo.Point
+	leg    int
+	goalID primitive.ObjectID
+	// geofences are the geofences the path was planned around, which are read once per waypoint, or when they change.
+	geofences       []navigation.Geofence
+	geofencesLoaded bool
+	// lastReached is the last waypoint reached, which the path to the next one starts from.
+	lastReached *geo.Point
+	// track is the last two distinct positions of the base.
+	track []*geo.Point
+	// driving is whether the base has been set moving since it was last stopped.
+	driving bool
+	// action is the index of the next action to do at the waypoint once reached.
+	action int
+}
 
-				bearingDelta := computeBearing(bearingToGoal, currentBearing)
-				steeringDir := -bearingDelta / 180.0
+// navStep steers the base along the path to the next waypoint for one step, marking the waypoint visited once reached.
+func (svc *builtIn) navStep(ctx context.Context, state *waypointState) error {
+	currentLoc, _, err := svc.movementSensor.Position(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get gps location")
+	}
+	if len(state.track) == 0 || currentLoc.GreatCircleDistance(state.track[len(state.track)-1]) > .0001 {
+		// gps often updates less frequently
+		state.track = append(state.track, currentLoc)
+		if len(state.track) > 2 {
+			state.track = state.track[len(state.track)-2:]
+		}
+	}
 
-				svc.logger.Debugf("currentBearing: %0.0f bearingToGoal: %0.0f distanceToGoal: %0.3f bearingDelta: %0.1f steeringDir: %0.2f",
-					currentBearing, bearingToGoal, distanceToGoal, bearingDelta, steeringDir)
+	wp, err := svc.nextWaypoint(ctx)
+	if err != nil {
+		return multierr.Combine(err, svc.stopDriving(ctx, state))
+	}
+	geofences := state.geofences
+	if !state.geofencesLoaded || wp.ID != state.goalID || svc.takeGeofencesChanged() {
+		geofences, err = svc.store.Geofences(ctx)
+		if err != nil {
+			return multierr.Combine(err, svc.stopDriving(ctx, state))
+		}
+	}
+	if !navigation.WithinGeofences(currentLoc, geofences) {
+		return multierr.Combine(
+			errors.Errorf("location %v is outside the area allowed by the geofences", currentLoc),
+			svc.stopDriving(ctx, state),
+		)
+	}
 
-				// TODO(erh->erd): maybe need an arc/stroke abstraction?
-				// - Remember that we added -1*bearingDelta instead of steeringDir
-				// - Test both naval/land to prove it works
-				if err := svc.base.Spin(ctx, -1*bearingDelta, svc.degPerSecDefault, nil); err != nil {
-					return fmt.Errorf("error turning: %w", err)
-				}
+	if wp.ID != state.goalID || !state.geofencesLoaded || !reflect.DeepEqual(geofences, state.geofences) {
+		start := currentLoc
+		if wp.ID != state.goalID && state.lastReached != nil {
+			start = state.lastReached
+		}
+		path, err := planAroundGeofences(start, wp.ToPoint(), geofences, svc.geofenceMarginM)
+		if err != nil {
+			return multierr.Combine(err, svc.stopDriving(ctx, state))
+		}
+		if wp.ID != state.goalID {
+			state.action = 0
+			svc.stateMu.Lock()
+			svc.progress.Waypoint = &wp
+			svc.progress.Action = -1
+			svc.stateMu.Unlock()
+		}
+		state.path = path
+		state.leg = 0
+		state.goalID = wp.ID
+		state.geofences = geofences
+		state.geofencesLoaded = true
+	}
 
-				distanceMm := distanceToGoal * 1000 * 1000
-				distanceMm = math.Min(distanceMm, 10*1000)
+	// move onto the next leg once at the end of this one, or stop at the waypoint at the end of the last
+	goal := state.path[state.leg+1]
+	distanceToGoalM := currentLoc.GreatCircleDistance(goal) * 1000
+	pursuit := svc.pursuit
+	if len(state.path) > 2 {
+		// the path turns at corners the margin away from the geofences, so it keeps close enough to them not to cut
+		// across the geofences as it turns
+		pursuit.lookaheadM = math.Min(pursuit.lookaheadM, svc.geofenceMarginM/2)
+	}
+	if state.leg+2 < len(state.path) && distanceToGoalM <= math.Min(svc.arrivalRadiusM, svc.geofenceMarginM/2) {
+		state.leg++
+		return nil
+	}
+	if state.leg+2 == len(state.path) && distanceToGoalM <= svc.arrivalRadiusM {
+		svc.logger.Debug("i made it")
+		if err := svc.stopDriving(ctx, state); err != nil {
+			return err
+		}
+		if err := svc.doActions(ctx, state, wp); err != nil {
+			return err
+		}
+		if err := svc.waypointReached(ctx); err != nil {
+			return err
+		}
+		state.lastReached = goal
+		return svc.updateProgressReached(ctx)
+	}
+	start := state.path[state.leg]
 
-				if err := svc.base.MoveStraight(ctx, int(distanceMm), svc.mmPerSecDefault, nil); err != nil {
-					return fmt.Errorf("error moving %w", err)
-				}
+	heading, ok, err := svc.heading(ctx, state.track)
+	if err != nil {
+		return multierr.Combine(err, svc.stopDriving(ctx, state))
+	}
+	state.driving = true
+	if !ok {
+		// drive straight until the track shows which way the base is heading
+		return svc.base.SetVelocity(ctx, r3.Vector{Y: svc.mmPerSecDefault}, r3.Vector{}, nil)
+	}
 
-				return nil
-			}
+	linear, angular := pursuit.velocities(currentLoc, heading, start, goal)
+	if linear == 0 && svc.headingSource == headingSourceTrack {
+		// the heading is only known while moving, so turn around along a tight arc rather than on the spot
+		linear = svc.mmPerSecDefault / 4
+	}
+	svc.logger.Debugf("heading: %0.0f distanceToGoal: %0.1fm crossTrackError: %0.2fm linear: %0.0f angular: %0.1f",
+		heading, distanceToGoalM, crossTrackErrorM(currentLoc, start, goal), linear, angular)
+	return svc.base.SetVelocity(ctx, r3.Vector{Y: linear}, r3.Vector{Z: angular}, nil)
+}
 
-			if err := navOnce(svc.cancelCtx); err != nil {
-				svc.logger.Infof("error navigating: %s", err)
+// doActions does the actions of the waypoint reached in order, carrying on from the one interrupted if navigating was.
+// An action which fails is skipped rather than holding up the rest of the mission.
+func (svc *builtIn) doActions(ctx context.Context, state *waypointState, wp navigation.Waypoint) error {
+	for ; state.action < len(wp.Actions); state.action++ {
+		svc.stateMu.Lock()
+		svc.progress.Action = state.action
+		svc.stateMu.Unlock()
+
+		action := wp.Actions[state.action]
+		if err := svc.doAction(ctx, state, action); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
 			}
+			svc.logger.Warnw("failed to do waypoint action", "waypoint", wp.ID.Hex(), "action", action.Type, "error", err)
+			svc.stateMu.Lock()
+			svc.progress.ActionErr = err
+			svc.stateMu.Unlock()
 		}
-	})
+	}
 	return nil
 }
 
-func (svc *builtIn) waypointDirectionAndDistanceToGo(ctx context.Context, currentLoc *geo.Point) (float64, float64, error) {
-	wp, err := svc.nextWaypoint(ctx)
+func (svc *builtIn) doAction(ctx context.Context, state *waypointState, action navigation.WaypointAction) error {
+	switch action.Type {
+	case navigation.WaypointActionDwell:
+		if !utils.SelectContextOrWait(ctx, time.Duration(action.DwellSec*float64(time.Second))) {
+			return ctx.Err()
+		}
+		return nil
+	case navigation.WaypointActionFaceHeading:
+		heading, ok, err := svc.heading(ctx, state.track)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.New("heading of the base is not known yet")
+		}
+		// spin counterclockwise by however far clockwise of the heading to face
+		if err := svc.base.Spin(ctx, -computeBearing(heading, action.HeadingDeg), svc.degPerSecDefault, nil); err != nil {
+			return err
+		}
+		if svc.headingSource == headingSourceTrack {
+			// the track no longer shows which way the base is heading
+			state.track = state.track[len(state.track)-1:]
+		}
+		return nil
+	case navigation.WaypointActionDoCommand:
+		name, err := resource.NewFromString(action.Resource)
+		if err != nil {
+			return err
+		}
+		res, err := svc.r.ResourceByName(name)
+		if err != nil {
+			return err
+		}
+		doer, ok := res.(generic.Generic)
+		if !ok {
+			return generic.NewUnimplementedInterfaceError(res)
+		}
+		resp, err := doer.DoCommand(ctx, action.Command)
+		if err != nil {
+			return err
+		}
+		svc.logger.Debugw("did waypoint command", "resource", action.Resource, "response", resp)
+		return nil
+	case navigation.WaypointActionSyncData:
+		dataManager, err := datamanager.FromRobot(svc.r, action.Resource)
+		if err != nil {
+			return err
+		}
+		return dataManager.Sync(ctx)
+	default:
+		return errors.Errorf("unknown waypoint action type %q", action.Type)
+	}
+}
+
+// updateProgressReached records that a waypoint was reached, completing the mission if it was the last.
+func (svc *builtIn) updateProgressReached(ctx context.Context) error {
+	remaining, err := svc.store.Waypoints(ctx)
 	if err != nil {
-		return 0, 0, err
+		return err
+	}
+	svc.stateMu.Lock()
+	defer svc.stateMu.Unlock()
+	svc.progress.WaypointsReached++
+	svc.progress.Action = -1
+	if len(remaining) == 0 {
+		svc.progress.Waypoint = nil
+		svc.progress.State = navigation.MissionStateCompleted
+		svc.mode = navigation.ModeManual
 	}
+	return nil
+}
 
-	goal := wp.ToPoint()
+// takeGeofencesChanged returns whether the geofences may have changed since it was last called.
+func (svc *builtIn) takeGeofencesChanged() bool {
+	svc.stateMu.Lock()
+	defer svc.stateMu.Unlock()
+	changed := svc.geofencesChanged
+	svc.geofencesChanged = false
+	return changed
+}
 
-	return fixAngle(currentLoc.BearingTo(goal)), currentLoc.GreatCircleDistance(goal), nil
+// stopDriving stops the base if it was set moving.
+func (svc *builtIn) stopDriving(ctx context.Context, state *waypointState) error {
+	if !state.driving {
+		return nil
+	}
+	state.driving = false
+	return svc.base.Stop(ctx, nil)
+}
+
+// heading returns the compass heading of the base, and whether it is known yet.
+func (svc *builtIn) heading(ctx context.Context, track []*geo.Point) (float64, bool, error) {
+	switch svc.headingSource {
+	case headingSourceCompass:
+		heading, err := svc.movementSensor.CompassHeading(ctx)
+		if err != nil {
+			return 0, false, err
+		}
+		return heading, true, nil
+	case headingSourceOrientation:
+		orientation, err := svc.movementSensor.Orientation(ctx)
+		if err != nil {
+			return 0, false, err
+		}
+		// the yaw of the orientation turns counterclockwise from north
+		return fixAngle(-rdkutils.RadToDeg(orientation.EulerAngles().Yaw)), true, nil
+	default:
+		if len(track) < 2 {
+			return 0, false, nil
+		}
+		return fixAngle(track[len(track)-2].BearingTo(track[len(track)-1])), true, nil
+	}
 }
 
 func (svc *builtIn) Location(ctx context.Context) (*geo.Point, error) {
@@ -273,6 +605,47 @@ func (svc *builtIn) RemoveWaypoint(ctx context.Context, id primitive.ObjectID) e
 	return svc.store.RemoveWaypoint(ctx, id)
 }
 
+func (svc *builtIn) Geofences(ctx context.Context) ([]navigation.Geofence, error) {
+	return svc.store.Geofences(ctx)
+}
+
+func (svc *builtIn) AddGeofence(ctx context.Context, kind navigation.GeofenceKind, boundary []*geo.Point) error {
+	if _, err := svc.store.AddGeofence(ctx, navigation.NewGeofence(kind, boundary)); err != nil {
+		return err
+	}
+	svc.stateMu.Lock()
+	svc.geofencesChanged = true
+	svc.stateMu.Unlock()
+	return nil
+}
+
+func (svc *builtIn) RemoveGeofence(ctx context.Context, id primitive.ObjectID) error {
+	if err := svc.store.RemoveGeofence(ctx, id); err != nil {
+		return err
+	}
+	svc.stateMu.Lock()
+	svc.geofencesChanged = true
+	svc.stateMu.Unlock()
+	return nil
+}
+
+func (svc *builtIn) Progress(ctx context.Context) (navigation.MissionProgress, error) {
+	remaining, err := svc.store.Waypoints(ctx)
+	if err != nil {
+		return navigation.MissionProgress{}, err
+	}
+	svc.stateMu.Lock()
+	defer svc.stateMu.Unlock()
+	progress := svc.progress
+	progress.WaypointsRemaining = len(remaining)
+	return progress, nil
+}
+
+// DoCommand takes the navigation commands which the navigation API has no method for.
+func (svc *builtIn) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	return navigation.DoCommand(ctx, svc, cmd)
+}
+
 func (svc *builtIn) nextWaypoint(ctx context.Context) (navigation.Waypoint, error) {
 	return svc.store.NextWaypoint(ctx)
 }
@@ -0,0 +1,609 @@
+package builtin
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edaniels/golog"
+	"github.com/golang/geo/r3"
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/test"
+	"go.viam.com/utils/testutils"
+
+	"go.viam.com/rdk/components/camera"
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/components/movementsensor"
+	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/services/navigation"
+	"go.viam.com/rdk/spatialmath"
+	"go.viam.com/rdk/testutils/inject"
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+var camera1 = camera.Named("camera1")
+
+// simulatedRover is a base driven by velocity, with a gps and compass reporting exactly where it is.
+type simulatedRover struct {
+	mu       sync.Mutex
+	location *geo.Point
+	heading  float64
+	linear   float64
+	angular  float64
+	stops    int
+}
+
+// step moves the rover along for the given time at the velocity it was last set to.
+func (sim *simulatedRover) step(dt time.Duration) {
+	sim.mu.Lock()
+	defer sim.mu.Unlock()
+	sim.heading = fixAngle(sim.heading - sim.angular*dt.Seconds())
+	sim.location = sim.location.PointAtDistanceAndBearing(sim.linear*dt.Seconds()/1e6, sim.heading)
+}
+
+func (sim *simulatedRover) base() *inject.Base {
+	b := &inject.Base{}
+	b.SetVelocityFunc = func(ctx context.Context, linear, angular r3.Vector, extra map[string]interface{}) error {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		sim.linear = linear.Y
+		sim.angular = angular.Z
+		return nil
+	}
+	b.SpinFunc = func(ctx context.Context, angleDeg, degsPerSec float64, extra map[string]interface{}) error {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		sim.heading = fixAngle(sim.heading - angleDeg)
+		return nil
+	}
+	b.StopFunc = func(ctx context.Context, extra map[string]interface{}) error {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		sim.linear = 0
+		sim.angular = 0
+		sim.stops++
+		return nil
+	}
+	return b
+}
+
+func (sim *simulatedRover) movementSensor() *inject.MovementSensor {
+	ms := &inject.MovementSensor{}
+	ms.PositionFunc = func(ctx context.Context) (*geo.Point, float64, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		return sim.location, 0, nil
+	}
+	ms.CompassHeadingFunc = func(ctx context.Context) (float64, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		return sim.heading, nil
+	}
+	ms.OrientationFunc = func(ctx context.Context) (spatialmath.Orientation, error) {
+		sim.mu.Lock()
+		defer sim.mu.Unlock()
+		return &spatialmath.EulerAngles{Yaw: rdkutils.DegToRad(-sim.heading)}, nil
+	}
+	return ms
+}
+
+func newSimulatedNavigation(t *testing.T, sim *simulatedRover, source headingSource) *builtIn {
+	t.Helper()
+	cancelCtx, cancelFunc := context.WithCancel(context.Background())
+	svc := &builtIn{
+		store:            navigation.NewMemoryNavigationStore(),
+		base:             sim.base(),
+		movementSensor:   sim.movementSensor(),
+		headingSource:    source,
+		mmPerSecDefault:  mmPerSecDefault,
+		degPerSecDefault: degPerSecDefault,
+		arrivalRadiusM:   1,
+		geofenceMarginM:  2,
+		pursuit:          purePursuit{lookaheadM: 3, mmPerSec: mmPerSecDefault, degPerSec: degPerSecDefault},
+		progress:         navigation.MissionProgress{State: navigation.MissionStateIdle, Action: -1},
+		logger:           golog.NewTestLogger(t),
+		cancelCtx:        cancelCtx,
+		cancelFunc:       cancelFunc,
+	}
+	t.Cleanup(func() {
+		test.That(t, svc.Close(context.Background()), test.ShouldBeNil)
+	})
+	return svc
+}
+
+func TestNavStepTracksPath(t *testing.T) {
+	origin := geo.NewPoint(40.7, -74)
+	// a square route, starting off facing the wrong way
+	route := []*geo.Point{
+		origin.PointAtDistanceAndBearing(.03, 0),
+		origin.PointAtDistanceAndBearing(.03, 0).PointAtDistanceAndBearing(.03, 90),
+		origin.PointAtDistanceAndBearing(.03, 90),
+		origin,
+	}
+
+	for _, tc := range []struct {
+		name   string
+		source headingSource
+	}{
+		{"compass", headingSourceCompass},
+		{"orientation", headingSourceOrientation},
+		{"track", headingSourceTrack},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			sim := &simulatedRover{location: origin, heading: 200}
+			svc := newSimulatedNavigation(t, sim, tc.source)
+			for _, pt := range route {
+				_, err := svc.store.AddWaypoint(ctx, pt)
+				test.That(t, err, test.ShouldBeNil)
+			}
+
+			const dt = 100 * time.Millisecond
+			var state waypointState
+			var maxErr float64
+			for i := 0; i < 10000; i++ {
+				err := svc.navStep(ctx, &state)
+				if err != nil {
+					test.That(t, err.Error(), test.ShouldContainSubstring, "no more waypoints")
+					break
+				}
+				sim.step(dt)
+
+				// once the rover has turned onto the path of each leg, it should stay close to it
+				wp, err := svc.store.NextWaypoint(ctx)
+				if err != nil {
+					continue
+				}
+				if wp.ID != state.goalID {
+					continue
+				}
+				start, goal := state.path[state.leg], state.path[state.leg+1]
+				if sim.location.GreatCircleDistance(start) > .008 && sim.location.GreatCircleDistance(goal) > .002 {
+					maxErr = math.Max(maxErr, crossTrackErrorM(sim.location, start, goal))
+				}
+			}
+
+			wps, err := svc.store.Waypoints(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, wps, test.ShouldBeEmpty)
+			test.That(t, sim.location.GreatCircleDistance(origin)*1000, test.ShouldBeLessThanOrEqualTo, 1)
+			test.That(t, maxErr, test.ShouldBeLessThan, .5)
+			test.That(t, sim.linear, test.ShouldEqual, 0)
+			test.That(t, sim.angular, test.ShouldEqual, 0)
+		})
+	}
+}
+
+func TestNavStepNoWaypoints(t *testing.T) {
+	ctx := context.Background()
+	sim := &simulatedRover{location: geo.NewPoint(40.7, -74)}
+	svc := newSimulatedNavigation(t, sim, headingSourceCompass)
+
+	var state waypointState
+	err := svc.navStep(ctx, &state)
+	test.That(t, err, test.ShouldNotBeNil)
+	// an idle base is not stopped over and over
+	test.That(t, sim.stops, test.ShouldEqual, 0)
+
+	_, err = svc.store.AddWaypoint(ctx, sim.location.PointAtDistanceAndBearing(.01, 0))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+	test.That(t, sim.linear, test.ShouldEqual, mmPerSecDefault)
+	test.That(t, sim.angular, test.ShouldAlmostEqual, 0, 1e-6)
+
+	wps, err := svc.store.Waypoints(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, svc.store.RemoveWaypoint(ctx, wps[0].ID), test.ShouldBeNil)
+	test.That(t, svc.navStep(ctx, &state), test.ShouldNotBeNil)
+	test.That(t, sim.stops, test.ShouldEqual, 1)
+	test.That(t, sim.linear, test.ShouldEqual, 0)
+
+	svc.movementSensor.(*inject.MovementSensor).PositionFunc = func(ctx context.Context) (*geo.Point, float64, error) {
+		return nil, 0, errors.New("no fix")
+	}
+	test.That(t, svc.navStep(ctx, &state), test.ShouldNotBeNil)
+}
+
+func TestPurePursuitVelocities(t *testing.T) {
+	pp := purePursuit{lookaheadM: 5, mmPerSec: 500, degPerSec: 45}
+	start := geo.NewPoint(40.7, -74)
+	goal := start.PointAtDistanceAndBearing(.1, 0)
+
+	// on the path and facing along it, the base drives straight
+	linear, angular := pp.velocities(start, 0, start, goal)
+	test.That(t, linear, test.ShouldEqual, 500)
+	test.That(t, angular, test.ShouldAlmostEqual, 0, 1e-6)
+
+	// to the left of the path, it turns right (clockwise) back onto it
+	left := start.PointAtDistanceAndBearing(.002, 270)
+	linear, angular = pp.velocities(left, 0, start, goal)
+	test.That(t, linear, test.ShouldEqual, 500)
+	test.That(t, angular, test.ShouldBeLessThan, 0)
+
+	// facing away, it spins towards the path rather than driving away from it
+	linear, angular = pp.velocities(start, 170, start, goal)
+	test.That(t, linear, test.ShouldEqual, 0)
+	test.That(t, angular, test.ShouldEqual, 45)
+
+	// a sharp turn is taken more slowly rather than spinning faster than allowed
+	pp.lookaheadM = 1
+	linear, angular = pp.velocities(start, 80, start, goal)
+	test.That(t, linear, test.ShouldBeLessThan, 500)
+	test.That(t, angular, test.ShouldEqual, 45)
+}
+
+func TestNewBuiltInHeadingSource(t *testing.T) {
+	for _, tc := range []struct {
+		props  *movementsensor.Properties
+		source headingSource
+	}{
+		{&movementsensor.Properties{PositionSupported: true, CompassHeadingSupported: true}, headingSourceCompass},
+		{&movementsensor.Properties{PositionSupported: true, OrientationSupported: true}, headingSourceOrientation},
+		{&movementsensor.Properties{PositionSupported: true}, headingSourceTrack},
+	} {
+		ms := &inject.MovementSensor{}
+		ms.PropertiesFunc = func(ctx context.Context) (*movementsensor.Properties, error) {
+			return tc.props, nil
+		}
+		source, err := headingSourceOf(context.Background(), ms)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, source, test.ShouldEqual, tc.source)
+	}
+}
+
+// squareGeofence returns a geofence of the given kind bounded by the square with sides of the given length in km, with
+// its southwest corner at the given point.
+func squareGeofence(kind navigation.GeofenceKind, southwest *geo.Point, sideKm float64) navigation.Geofence {
+	return navigation.NewGeofence(kind, []*geo.Point{
+		southwest,
+		southwest.PointAtDistanceAndBearing(sideKm, 90),
+		southwest.PointAtDistanceAndBearing(sideKm, 90).PointAtDistanceAndBearing(sideKm, 0),
+		southwest.PointAtDistanceAndBearing(sideKm, 0),
+	})
+}
+
+func TestPlanAroundGeofences(t *testing.T) {
+	start := geo.NewPoint(40.7, -74)
+	goal := start.PointAtDistanceAndBearing(.05, 0)
+
+	path, err := planAroundGeofences(start, goal, nil, 1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, path, test.ShouldResemble, []*geo.Point{start, goal})
+
+	// a forbidden square across the straight path is driven around, keeping the margin from it
+	forbidden := squareGeofence(
+		navigation.GeofenceKindForbidden,
+		start.PointAtDistanceAndBearing(.02, 0).PointAtDistanceAndBearing(.006, 270),
+		.01,
+	)
+	geofences := []navigation.Geofence{forbidden}
+	path, err = planAroundGeofences(start, goal, geofences, 1)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, len(path), test.ShouldEqual, 4)
+	test.That(t, path[0], test.ShouldEqual, start)
+	test.That(t, path[len(path)-1], test.ShouldEqual, goal)
+	for i := 0; i+1 < len(path); i++ {
+		for j := 0; j <= 100; j++ {
+			pt := toLocal(start, path[i])
+			next := toLocal(start, path[i+1])
+			f := float64(j) / 100
+			test.That(t, navigation.WithinGeofences(
+				fromLocal(start, localPoint{x: pt.x + (next.x-pt.x)*f, y: pt.y + (next.y-pt.y)*f}), geofences,
+			), test.ShouldBeTrue)
+		}
+	}
+	// the square is closer to the east side of the path, so it is passed on that side
+	test.That(t, toLocal(start, path[1]).x, test.ShouldBeGreaterThan, 0)
+
+	// waypoints the geofences do not allow cannot be planned to
+	_, err = planAroundGeofences(start, start.PointAtDistanceAndBearing(.025, 0), geofences, 1)
+	test.That(t, err, test.ShouldNotBeNil)
+	allowed := squareGeofence(navigation.GeofenceKindAllowed, start.PointAtDistanceAndBearing(.01, 225), .04)
+	_, err = planAroundGeofences(start, goal, []navigation.Geofence{allowed}, 1)
+	test.That(t, err, test.ShouldNotBeNil)
+
+	// nor can those only reachable by leaving the allowed area
+	geofences = []navigation.Geofence{
+		squareGeofence(navigation.GeofenceKindAllowed, start.PointAtDistanceAndBearing(.01, 225), .02),
+		squareGeofence(navigation.GeofenceKindAllowed, start.PointAtDistanceAndBearing(.05, 0), .02),
+	}
+	_, err = planAroundGeofences(start, start.PointAtDistanceAndBearing(.055, 0), geofences, 1)
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestNavStepAvoidsForbiddenGeofence(t *testing.T) {
+	for _, tc := range []struct {
+		name                                        string
+		arrivalRadiusM, lookaheadM, geofenceMarginM float64
+	}{
+		{"tight", 1, 3, 2},
+		// the defaults reach and look further ahead than the margin around the geofences
+		{"defaults", arrivalRadiusMDefault, lookaheadMDefault, geofenceMarginMDefault},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			origin := geo.NewPoint(40.7, -74)
+			sim := &simulatedRover{location: origin}
+			svc := newSimulatedNavigation(t, sim, headingSourceCompass)
+			svc.arrivalRadiusM = tc.arrivalRadiusM
+			svc.pursuit.lookaheadM = tc.lookaheadM
+			svc.geofenceMarginM = tc.geofenceMarginM
+
+			// the waypoint is just behind the geofence, so the path turns sharply around its corners
+			forbidden := squareGeofence(
+				navigation.GeofenceKindForbidden,
+				origin.PointAtDistanceAndBearing(.005, 0).PointAtDistanceAndBearing(.005, 270),
+				.01,
+			)
+			test.That(t, svc.AddGeofence(ctx, forbidden.Kind, forbidden.Boundary()), test.ShouldBeNil)
+			goal := origin.PointAtDistanceAndBearing(.0165, 0)
+			test.That(t, svc.AddWaypoint(ctx, goal), test.ShouldBeNil)
+
+			var state waypointState
+			for i := 0; i < 10000; i++ {
+				if err := svc.navStep(ctx, &state); err != nil {
+					test.That(t, err.Error(), test.ShouldContainSubstring, "no more waypoints")
+					break
+				}
+				sim.step(100 * time.Millisecond)
+				test.That(t, forbidden.Contains(sim.location), test.ShouldBeFalse)
+			}
+			test.That(t, sim.location.GreatCircleDistance(goal)*1000, test.ShouldBeLessThanOrEqualTo, tc.arrivalRadiusM)
+			wps, err := svc.Waypoints(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, wps, test.ShouldBeEmpty)
+		})
+	}
+}
+
+// countingStore counts how many times the geofences are read from it.
+type countingStore struct {
+	navigation.NavStore
+	geofenceReads int
+}
+
+func (store *countingStore) Geofences(ctx context.Context) ([]navigation.Geofence, error) {
+	store.geofenceReads++
+	return store.NavStore.Geofences(ctx)
+}
+
+func TestNavStepReadsGeofencesOnChange(t *testing.T) {
+	ctx := context.Background()
+	origin := geo.NewPoint(40.7, -74)
+	sim := &simulatedRover{location: origin}
+	svc := newSimulatedNavigation(t, sim, headingSourceCompass)
+	store := &countingStore{NavStore: svc.store}
+	svc.store = store
+	test.That(t, svc.AddWaypoint(ctx, origin.PointAtDistanceAndBearing(.02, 0)), test.ShouldBeNil)
+
+	var state waypointState
+	for i := 0; i < 10; i++ {
+		test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+		sim.step(100 * time.Millisecond)
+	}
+	test.That(t, store.geofenceReads, test.ShouldEqual, 1)
+
+	// a new geofence is read on the next step, and the path replanned around it
+	forbidden := squareGeofence(
+		navigation.GeofenceKindForbidden,
+		origin.PointAtDistanceAndBearing(.01, 0).PointAtDistanceAndBearing(.0025, 270),
+		.005,
+	)
+	test.That(t, svc.AddGeofence(ctx, forbidden.Kind, forbidden.Boundary()), test.ShouldBeNil)
+	test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+	test.That(t, store.geofenceReads, test.ShouldEqual, 2)
+	test.That(t, state.geofences, test.ShouldHaveLength, 1)
+	test.That(t, len(state.path), test.ShouldBeGreaterThan, 2)
+	test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+	test.That(t, store.geofenceReads, test.ShouldEqual, 2)
+}
+
+func TestNavStepOutsideAllowedGeofence(t *testing.T) {
+	ctx := context.Background()
+	origin := geo.NewPoint(40.7, -74)
+	sim := &simulatedRover{location: origin}
+	svc := newSimulatedNavigation(t, sim, headingSourceCompass)
+
+	allowed := squareGeofence(navigation.GeofenceKindAllowed, origin.PointAtDistanceAndBearing(.01, 225), .05)
+	test.That(t, svc.AddGeofence(ctx, allowed.Kind, allowed.Boundary()), test.ShouldBeNil)
+	gfs, err := svc.Geofences(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, gfs, test.ShouldHaveLength, 1)
+	test.That(t, svc.AddWaypoint(ctx, origin.PointAtDistanceAndBearing(.02, 0)), test.ShouldBeNil)
+
+	var state waypointState
+	test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+	test.That(t, sim.linear, test.ShouldBeGreaterThan, 0)
+
+	// once off course out of the allowed area, the base stops rather than driving on
+	sim.location = origin.PointAtDistanceAndBearing(.02, 270)
+	err = svc.navStep(ctx, &state)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "outside the area allowed")
+	test.That(t, sim.stops, test.ShouldEqual, 1)
+	test.That(t, sim.linear, test.ShouldEqual, 0)
+
+	// it drives on again once the geofence no longer applies
+	test.That(t, svc.RemoveGeofence(ctx, gfs[0].ID), test.ShouldBeNil)
+	test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+	test.That(t, sim.linear, test.ShouldBeGreaterThan, 0)
+}
+
+func TestNavStepWaypointActions(t *testing.T) {
+	ctx := context.Background()
+	origin := geo.NewPoint(40.7, -74)
+	sim := &simulatedRover{location: origin}
+	svc := newSimulatedNavigation(t, sim, headingSourceCompass)
+
+	camera := &inject.Generic{}
+	var commands []map[string]interface{}
+	camera.DoFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+		commands = append(commands, cmd)
+		return map[string]interface{}{"ok": true}, nil
+	}
+	r := &inject.Robot{}
+	r.ResourceByNameFunc = func(name resource.Name) (interface{}, error) {
+		if name == camera1 {
+			return camera, nil
+		}
+		return nil, rdkutils.NewResourceNotFoundError(name)
+	}
+	svc.r = r
+
+	actions := []navigation.WaypointAction{
+		{Type: navigation.WaypointActionFaceHeading, HeadingDeg: 90},
+		{Type: navigation.WaypointActionSyncData, Resource: "data_manager1"},
+		{Type: navigation.WaypointActionDoCommand, Resource: camera1.String(), Command: map[string]interface{}{"snap": 1}},
+		{Type: navigation.WaypointActionDwell, DwellSec: .01},
+	}
+	wps, err := svc.store.ImportWaypoints(ctx, []navigation.Waypoint{
+		{Lat: origin.Lat(), Long: origin.Lng(), Actions: actions},
+		{Lat: origin.Lat(), Long: origin.Lng()},
+	})
+	test.That(t, err, test.ShouldBeNil)
+
+	var state waypointState
+	test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+	test.That(t, sim.heading, test.ShouldAlmostEqual, 90)
+	test.That(t, commands, test.ShouldResemble, []map[string]interface{}{{"snap": 1}})
+
+	// the missing data manager does not stop the rest of the actions or the mission
+	progress, err := svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress.ActionErr, test.ShouldNotBeNil)
+	test.That(t, progress.WaypointsReached, test.ShouldEqual, 1)
+	test.That(t, progress.WaypointsRemaining, test.ShouldEqual, 1)
+	test.That(t, progress.Action, test.ShouldEqual, -1)
+	test.That(t, progress.Waypoint.ID, test.ShouldEqual, wps[0].ID)
+
+	test.That(t, svc.navStep(ctx, &state), test.ShouldBeNil)
+	progress, err = svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress.State, test.ShouldEqual, navigation.MissionStateCompleted)
+	test.That(t, progress.WaypointsReached, test.ShouldEqual, 2)
+	test.That(t, progress.WaypointsRemaining, test.ShouldEqual, 0)
+	test.That(t, progress.Waypoint, test.ShouldBeNil)
+	test.That(t, commands, test.ShouldHaveLength, 1)
+}
+
+func TestMissionStates(t *testing.T) {
+	ctx := context.Background()
+	origin := geo.NewPoint(40.7, -74)
+	sim := &simulatedRover{location: origin}
+	svc := newSimulatedNavigation(t, sim, headingSourceCompass)
+
+	progress, err := svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress.State, test.ShouldEqual, navigation.MissionStateIdle)
+	test.That(t, svc.SetMode(ctx, navigation.ModePaused), test.ShouldNotBeNil)
+
+	// a long dwell at where the rover already is holds the mission at the waypoint
+	_, err = svc.store.ImportWaypoints(ctx, []navigation.Waypoint{{
+		Lat:     origin.Lat(),
+		Long:    origin.Lng(),
+		Actions: []navigation.WaypointAction{{Type: navigation.WaypointActionDwell, DwellSec: 60}},
+	}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, svc.SetMode(ctx, navigation.ModeWaypoint), test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		progress, err := svc.Progress(ctx)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, progress.State, test.ShouldEqual, navigation.MissionStateRunning)
+		test.That(tb, progress.Action, test.ShouldEqual, 0)
+	})
+
+	test.That(t, svc.SetMode(ctx, navigation.ModePaused), test.ShouldBeNil)
+	mode, err := svc.Mode(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mode, test.ShouldEqual, navigation.ModePaused)
+	progress, err = svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress.State, test.ShouldEqual, navigation.MissionStatePaused)
+	test.That(t, progress.WaypointsRemaining, test.ShouldEqual, 1)
+
+	// resuming carries on from where the mission was paused rather than starting over, so moving it past the dwell
+	// while paused lets it complete
+	svc.waypointState.action = 1
+	test.That(t, svc.SetMode(ctx, navigation.ModeWaypoint), test.ShouldBeNil)
+	testutils.WaitForAssertion(t, func(tb testing.TB) {
+		tb.Helper()
+		progress, err := svc.Progress(ctx)
+		test.That(tb, err, test.ShouldBeNil)
+		test.That(tb, progress.State, test.ShouldEqual, navigation.MissionStateCompleted)
+	})
+	mode, err = svc.Mode(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mode, test.ShouldEqual, navigation.ModeManual)
+	progress, err = svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress.WaypointsReached, test.ShouldEqual, 1)
+
+	// a new mission starts afresh, and switching to manual before it completes aborts it
+	test.That(t, svc.AddWaypoint(ctx, origin.PointAtDistanceAndBearing(1, 0)), test.ShouldBeNil)
+	test.That(t, svc.SetMode(ctx, navigation.ModeWaypoint), test.ShouldBeNil)
+	progress, err = svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress.State, test.ShouldEqual, navigation.MissionStateRunning)
+	test.That(t, progress.WaypointsReached, test.ShouldEqual, 0)
+	test.That(t, svc.SetMode(ctx, navigation.ModeManual), test.ShouldBeNil)
+	progress, err = svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress.State, test.ShouldEqual, navigation.MissionStateAborted)
+	test.That(t, sim.linear, test.ShouldEqual, 0)
+}
+
+func TestDoCommand(t *testing.T) {
+	ctx := context.Background()
+	origin := geo.NewPoint(40.7, -74)
+	sim := &simulatedRover{location: origin}
+	svc := newSimulatedNavigation(t, sim, headingSourceCompass)
+
+	_, err := svc.DoCommand(ctx, map[string]interface{}{navigation.CommandKey: "dance"})
+	test.That(t, err, test.ShouldEqual, generic.ErrUnimplemented)
+	_, err = svc.DoCommand(ctx, map[string]interface{}{navigation.CommandKey: navigation.CommandPause})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	_, err = svc.store.ImportWaypoints(ctx, []navigation.Waypoint{{
+		Lat:     origin.Lat(),
+		Long:    origin.Lng(),
+		Actions: []navigation.WaypointAction{{Type: navigation.WaypointActionDwell, DwellSec: 60}},
+	}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, svc.SetMode(ctx, navigation.ModeWaypoint), test.ShouldBeNil)
+	resp, err := svc.DoCommand(ctx, map[string]interface{}{navigation.CommandKey: navigation.CommandPause})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, resp, test.ShouldBeEmpty)
+	mode, err := svc.Mode(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, mode, test.ShouldEqual, navigation.ModePaused)
+
+	resp, err = svc.DoCommand(ctx, map[string]interface{}{navigation.CommandKey: navigation.CommandGetProgress})
+	test.That(t, err, test.ShouldBeNil)
+	progress, err := navigation.ProgressFromMap(resp)
+	test.That(t, err, test.ShouldBeNil)
+	expected, err := svc.Progress(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, progress, test.ShouldResemble, expected)
+	test.That(t, progress.State, test.ShouldEqual, navigation.MissionStatePaused)
+	test.That(t, progress.WaypointsRemaining, test.ShouldEqual, 1)
+}
+
+func TestConfigValidate(t *testing.T) {
+	cfg := &Config{
+		Store:              navigation.StoreConfig{Type: navigation.StoreTypeMemory},
+		BaseName:           "base1",
+		MovementSensorName: "gps1",
+	}
+	deps, err := cfg.Validate("path")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, deps, test.ShouldResemble, []string{"base1", "gps1"})
+
+	cfg.MovementSensorName = ""
+	_, err = cfg.Validate("path")
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "movement_sensor")
+}
@@ -0,0 +1,216 @@
+package builtin
+
+import (
+	"math"
+
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+
+	"go.viam.com/rdk/services/navigation"
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+// geofenceSampleM is how far apart points along a path are checked against the geofences.
+const geofenceSampleM = 0.5
+
+// planAroundGeofences returns the shortest path of straight legs from start to goal which stays out of the forbidden
+// geofences and within the allowed ones, if there are any. The path turns only at points the margin away from the corners
+// of the geofences, and includes the start and goal.
+func planAroundGeofences(start, goal *geo.Point, geofences []navigation.Geofence, marginM float64) ([]*geo.Point, error) {
+	if !navigation.WithinGeofences(goal, geofences) {
+		return nil, errors.New("waypoint is outside the area allowed by the geofences")
+	}
+	if len(geofences) == 0 {
+		return []*geo.Point{start, goal}, nil
+	}
+
+	polygons := make([]geofencePolygon, 0, len(geofences))
+	var allowed []geofencePolygon
+	for _, gf := range geofences {
+		p := newGeofencePolygon(start, gf)
+		polygons = append(polygons, p)
+		if p.kind == navigation.GeofenceKindAllowed {
+			allowed = append(allowed, p)
+		}
+	}
+	valid := func(a, b localPoint) bool {
+		for _, p := range polygons {
+			if p.blocks(a, b) {
+				return false
+			}
+		}
+		if len(allowed) == 0 {
+			return true
+		}
+		// allowed geofences may overlap, so it is enough for each point along the leg to be within any of them
+		steps := int(math.Ceil(math.Hypot(b.x-a.x, b.y-a.y) / geofenceSampleM))
+		for i := 1; i < steps; i++ {
+			pt := a.lerp(b, float64(i)/float64(steps))
+			within := false
+			for _, p := range allowed {
+				if p.contains(pt) {
+					within = true
+					break
+				}
+			}
+			if !within {
+				return false
+			}
+		}
+		return true
+	}
+
+	// the nodes to plan between are the start, the goal, and the corners of every geofence pushed out of its way
+	nodes := []localPoint{{}, toLocal(start, goal)}
+	for _, p := range polygons {
+		for _, corner := range p.offsetCorners(marginM) {
+			if navigation.WithinGeofences(fromLocal(start, corner), geofences) {
+				nodes = append(nodes, corner)
+			}
+		}
+	}
+
+	// dijkstra's algorithm over the straight legs between nodes which the geofences allow
+	const goalIdx = 1
+	dist := make([]float64, len(nodes))
+	prev := make([]int, len(nodes))
+	done := make([]bool, len(nodes))
+	for i := range dist {
+		dist[i] = math.Inf(1)
+		prev[i] = -1
+	}
+	dist[0] = 0
+	for {
+		current := -1
+		for i := range nodes {
+			if !done[i] && !math.IsInf(dist[i], 1) && (current == -1 || dist[i] < dist[current]) {
+				current = i
+			}
+		}
+		if current == -1 {
+			return nil, errors.New("no path to waypoint within the geofences")
+		}
+		if current == goalIdx {
+			break
+		}
+		done[current] = true
+		for next := range nodes {
+			if done[next] {
+				continue
+			}
+			d := dist[current] + math.Hypot(nodes[next].x-nodes[current].x, nodes[next].y-nodes[current].y)
+			if d >= dist[next] || !valid(nodes[current], nodes[next]) {
+				continue
+			}
+			dist[next] = d
+			prev[next] = current
+		}
+	}
+
+	path := []*geo.Point{goal}
+	for i := prev[goalIdx]; i > 0; i = prev[i] {
+		path = append([]*geo.Point{fromLocal(start, nodes[i])}, path...)
+	}
+	return append([]*geo.Point{start}, path...), nil
+}
+
+// A geofencePolygon is the polygon bounding a geofence, relative to some origin.
+type geofencePolygon struct {
+	kind     navigation.GeofenceKind
+	vertices []localPoint
+}
+
+func newGeofencePolygon(origin *geo.Point, gf navigation.Geofence) geofencePolygon {
+	vertices := make([]localPoint, 0, len(gf.Vertices))
+	for _, pt := range gf.Boundary() {
+		vertices = append(vertices, toLocal(origin, pt))
+	}
+	return geofencePolygon{kind: gf.Kind, vertices: vertices}
+}
+
+// blocks returns whether the polygon is forbidden and a leg from a to b enters it.
+func (p geofencePolygon) blocks(a, b localPoint) bool {
+	if p.kind != navigation.GeofenceKindForbidden {
+		return false
+	}
+	for i := range p.vertices {
+		if segmentsIntersect(a, b, p.vertices[i], p.vertices[(i+1)%len(p.vertices)]) {
+			return true
+		}
+	}
+	steps := int(math.Ceil(math.Hypot(b.x-a.x, b.y-a.y) / geofenceSampleM))
+	for i := 1; i < steps; i++ {
+		if p.contains(a.lerp(b, float64(i)/float64(steps))) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns whether the point is within the polygon.
+func (p geofencePolygon) contains(pt localPoint) bool {
+	inside := false
+	for i, j := 0, len(p.vertices)-1; i < len(p.vertices); j, i = i, i+1 {
+		vi, vj := p.vertices[i], p.vertices[j]
+		if (vi.y > pt.y) != (vj.y > pt.y) && pt.x < (vj.x-vi.x)*(pt.y-vi.y)/(vj.y-vi.y)+vi.x {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// offsetCorners returns the corners of the polygon moved the margin away from both of their edges, outwards for a
+// forbidden polygon and inwards for an allowed one.
+func (p geofencePolygon) offsetCorners(marginM float64) []localPoint {
+	n := len(p.vertices)
+	// the outward normal of an edge is to its right when the vertices go counterclockwise
+	var area float64
+	for i := range p.vertices {
+		a, b := p.vertices[i], p.vertices[(i+1)%n]
+		area += a.x*b.y - b.x*a.y
+	}
+	outwards := 1.0
+	if area < 0 {
+		outwards = -1
+	}
+	if p.kind == navigation.GeofenceKindAllowed {
+		outwards = -outwards
+	}
+	normal := func(a, b localPoint) localPoint {
+		length := math.Hypot(b.x-a.x, b.y-a.y)
+		return localPoint{x: outwards * (b.y - a.y) / length, y: outwards * -(b.x - a.x) / length}
+	}
+
+	corners := make([]localPoint, 0, n)
+	for i := range p.vertices {
+		prev, v, next := p.vertices[(i+n-1)%n], p.vertices[i], p.vertices[(i+1)%n]
+		n1, n2 := normal(prev, v), normal(v, next)
+		// a corner moved along the sum of the normals of its edges, scaled so each edge moves by the margin
+		scale := marginM / math.Max(1+n1.x*n2.x+n1.y*n2.y, 0.1)
+		corners = append(corners, localPoint{x: v.x + (n1.x+n2.x)*scale, y: v.y + (n1.y+n2.y)*scale})
+	}
+	return corners
+}
+
+// lerp returns the point the given fraction of the way from p to q.
+func (p localPoint) lerp(q localPoint, t float64) localPoint {
+	return localPoint{x: p.x + (q.x-p.x)*t, y: p.y + (q.y-p.y)*t}
+}
+
+// segmentsIntersect returns whether the segments from a to b and from c to d cross.
+func segmentsIntersect(a, b, c, d localPoint) bool {
+	cross := func(o, p, q localPoint) float64 {
+		return (p.x-o.x)*(q.y-o.y) - (p.y-o.y)*(q.x-o.x)
+	}
+	d1, d2 := cross(c, d, a), cross(c, d, b)
+	d3, d4 := cross(a, b, c), cross(a, b, d)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+// fromLocal returns the point at the given position relative to the origin; it is the inverse of toLocal.
+func fromLocal(origin *geo.Point, p localPoint) *geo.Point {
+	return geo.NewPoint(
+		origin.Lat()+rdkutils.RadToDeg(p.y/earthRadiusM),
+		origin.Lng()+rdkutils.RadToDeg(p.x/(earthRadiusM*math.Cos(rdkutils.DegToRad(origin.Lat())))),
+	)
+}
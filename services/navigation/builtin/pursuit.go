@@ -0,0 +1,93 @@
+package builtin
+
+import (
+	"math"
+
+	geo "github.com/kellydunn/golang-geo"
+
+	rdkutils "go.viam.com/rdk/utils"
+)
+
+// earthRadiusM is the radius of the earth used by geo.Point.
+const earthRadiusM = geo.EARTH_RADIUS * 1000
+
+// purePursuit steers a base along the straight path between two points by continually turning along the arc that takes it
+// to the point on the path a lookahead distance ahead of it.
+type purePursuit struct {
+	lookaheadM float64
+	mmPerSec   float64
+	degPerSec  float64
+}
+
+// velocities returns the linear velocity in mm/s and counterclockwise angular velocity in deg/s to drive at, from the
+// current location and compass heading, to follow the path from start to goal.
+func (pp *purePursuit) velocities(current *geo.Point, heading float64, start, goal *geo.Point) (float64, float64) {
+	target := pp.lookaheadPoint(current, start, goal)
+	targetDistanceM := math.Hypot(target.x, target.y)
+	if targetDistanceM < 1e-3 {
+		return 0, 0
+	}
+
+	// alpha is how far clockwise the target is from the heading of the base
+	alpha := computeBearing(heading, rdkutils.RadToDeg(math.Atan2(target.x, target.y)))
+	if math.Abs(alpha) > 90 {
+		// the target is behind, so there is no arc forwards to it
+		return 0, -math.Copysign(pp.degPerSec, alpha)
+	}
+
+	linear := pp.mmPerSec
+	// an arc through the target has a curvature of 2*sin(alpha)/distance
+	angular := -rdkutils.RadToDeg(2 * (linear / 1000) * math.Sin(rdkutils.DegToRad(alpha)) / targetDistanceM)
+	if math.Abs(angular) > pp.degPerSec {
+		// slow down to keep to the same arc without turning faster than allowed
+		linear *= pp.degPerSec / math.Abs(angular)
+		angular = math.Copysign(pp.degPerSec, angular)
+	}
+	return linear, angular
+}
+
+// lookaheadPoint returns where on the path from start to goal the base should head for, relative to its current location.
+func (pp *purePursuit) lookaheadPoint(current, start, goal *geo.Point) localPoint {
+	s := toLocal(current, start)
+	g := toLocal(current, goal)
+	dx, dy := g.x-s.x, g.y-s.y
+	pathLengthM := math.Hypot(dx, dy)
+	if pathLengthM < 1e-6 {
+		return g
+	}
+
+	// how far along the path the base is, given by its projection onto it
+	along := -(s.x*dx + s.y*dy) / pathLengthM
+	along = math.Max(0, math.Min(along, pathLengthM)) + pp.lookaheadM
+	if along >= pathLengthM {
+		return g
+	}
+	return localPoint{x: s.x + dx*along/pathLengthM, y: s.y + dy*along/pathLengthM}
+}
+
+// crossTrackErrorM returns how far the location is from the straight path from start to goal, in meters.
+func crossTrackErrorM(location, start, goal *geo.Point) float64 {
+	s := toLocal(location, start)
+	g := toLocal(location, goal)
+	dx, dy := g.x-s.x, g.y-s.y
+	pathLengthM := math.Hypot(dx, dy)
+	if pathLengthM < 1e-6 {
+		return math.Hypot(s.x, s.y)
+	}
+	along := math.Max(0, math.Min(-(s.x*dx+s.y*dy)/pathLengthM, pathLengthM))
+	return math.Hypot(s.x+dx*along/pathLengthM, s.y+dy*along/pathLengthM)
+}
+
+// A localPoint is a point in meters east (x) and north (y) of some origin.
+type localPoint struct {
+	x, y float64
+}
+
+// toLocal returns where the point is relative to the origin, on a plane tangent to the earth at the origin, which is only
+// accurate for points close by.
+func toLocal(origin, p *geo.Point) localPoint {
+	return localPoint{
+		x: rdkutils.DegToRad(p.Lng()-origin.Lng()) * math.Cos(rdkutils.DegToRad(origin.Lat())) * earthRadiusM,
+		y: rdkutils.DegToRad(p.Lat()-origin.Lat()) * earthRadiusM,
+	}
+}
@@ -10,6 +10,9 @@ import (
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/service/navigation/v1"
 	"go.viam.com/utils/rpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/rdk/protoutils"
 )
 
 // client implements NavigationServiceClient.
@@ -57,6 +60,9 @@ func (c *client) SetMode(ctx context.Context, mode Mode) error {
 		pbMode = pb.Mode_MODE_MANUAL
 	case ModeWaypoint:
 		pbMode = pb.Mode_MODE_WAYPOINT
+	case ModePaused:
+		_, err := c.DoCommand(ctx, map[string]interface{}{CommandKey: CommandPause})
+		return err
 	default:
 		pbMode = pb.Mode_MODE_UNSPECIFIED
 	}
@@ -123,3 +129,58 @@ func (c *client) RemoveWaypoint(ctx context.Context, id primitive.ObjectID) erro
 	}
 	return nil
 }
+
+func (c *client) Geofences(ctx context.Context) ([]Geofence, error) {
+	resp, err := c.DoCommand(ctx, map[string]interface{}{CommandKey: CommandGetGeofences})
+	if err != nil {
+		return nil, err
+	}
+	encoded, _ := resp["geofences"].([]interface{})
+	result := make([]Geofence, 0, len(encoded))
+	for _, gf := range encoded {
+		gfMap, ok := gf.(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("expected a geofence, got %v", gf)
+		}
+		geofence, err := GeofenceFromMap(gfMap)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, geofence)
+	}
+	return result, nil
+}
+
+func (c *client) AddGeofence(ctx context.Context, kind GeofenceKind, boundary []*geo.Point) error {
+	_, err := c.DoCommand(ctx, map[string]interface{}{
+		CommandKey: CommandAddGeofence,
+		"geofence": GeofenceToMap(NewGeofence(kind, boundary)),
+	})
+	return err
+}
+
+func (c *client) RemoveGeofence(ctx context.Context, id primitive.ObjectID) error {
+	_, err := c.DoCommand(ctx, map[string]interface{}{CommandKey: CommandRemoveGeofence, "id": id.Hex()})
+	return err
+}
+
+func (c *client) Progress(ctx context.Context) (MissionProgress, error) {
+	resp, err := c.DoCommand(ctx, map[string]interface{}{CommandKey: CommandGetProgress})
+	if err != nil {
+		return MissionProgress{}, err
+	}
+	return ProgressFromMap(resp)
+}
+
+// DoCommand sends the command to the navigation service through the navigation command service.
+func (c *client) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	req, err := protoutils.StructToStructPb(map[string]interface{}{"name": c.name, "command": cmd})
+	if err != nil {
+		return nil, err
+	}
+	var resp structpb.Struct
+	if err := c.conn.Invoke(ctx, DoCommandMethod, req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.AsMap(), nil
+}
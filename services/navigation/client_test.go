@@ -182,6 +182,90 @@ func TestClient(t *testing.T) {
 		test.That(t, conn.Close(), test.ShouldBeNil)
 	})
 
+	t.Run("client tests for geofences", func(t *testing.T) {
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		geofenceNavClient := navigation.NewClientFromConn(context.Background(), conn, testSvcName1, logger)
+
+		boundary := []*geo.Point{geo.NewPoint(40, -74), geo.NewPoint(40, -73.9), geo.NewPoint(40.1, -73.9)}
+		geofences := []navigation.Geofence{navigation.NewGeofence(navigation.GeofenceKindForbidden, boundary)}
+		geofences[0].ID = primitive.NewObjectID()
+		workingNavigationService.GetGeofencesFunc = func(ctx context.Context) ([]navigation.Geofence, error) {
+			return geofences, nil
+		}
+		var receivedKind navigation.GeofenceKind
+		var receivedBoundary []*geo.Point
+		workingNavigationService.AddGeofenceFunc = func(ctx context.Context, kind navigation.GeofenceKind, boundary []*geo.Point) error {
+			receivedKind = kind
+			receivedBoundary = boundary
+			return nil
+		}
+		var receivedGeofenceID primitive.ObjectID
+		workingNavigationService.RemoveGeofenceFunc = func(ctx context.Context, id primitive.ObjectID) error {
+			receivedGeofenceID = id
+			return errors.New("no such geofence")
+		}
+
+		// test geofences
+		receivedGeofences, err := geofenceNavClient.Geofences(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, receivedGeofences, test.ShouldResemble, geofences)
+
+		// test add geofence
+		err = geofenceNavClient.AddGeofence(context.Background(), navigation.GeofenceKindAllowed, boundary)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, receivedKind, test.ShouldEqual, navigation.GeofenceKindAllowed)
+		test.That(t, receivedBoundary, test.ShouldResemble, boundary)
+
+		// test remove geofence, whose error makes it back to the client
+		err = geofenceNavClient.RemoveGeofence(context.Background(), geofences[0].ID)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "no such geofence")
+		test.That(t, receivedGeofenceID, test.ShouldEqual, geofences[0].ID)
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	})
+
+	t.Run("client tests for paused navigation service", func(t *testing.T) {
+		conn, err := viamgrpc.Dial(context.Background(), listener1.Addr().String(), logger)
+		test.That(t, err, test.ShouldBeNil)
+		pausedNavClient := navigation.NewClientFromConn(context.Background(), conn, testSvcName1, logger)
+
+		progress := navigation.MissionProgress{
+			State:              navigation.MissionStatePaused,
+			Waypoint:           &waypoints[0],
+			Action:             -1,
+			WaypointsReached:   2,
+			WaypointsRemaining: 1,
+		}
+		var receivedCmds []interface{}
+		workingNavigationService.GetModeFunc = func(ctx context.Context) (navigation.Mode, error) {
+			return navigation.ModePaused, nil
+		}
+		workingNavigationService.DoCommandFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+			receivedCmds = append(receivedCmds, cmd[navigation.CommandKey])
+			if cmd[navigation.CommandKey] == navigation.CommandGetProgress {
+				return navigation.ProgressToMap(progress)
+			}
+			return map[string]interface{}{}, nil
+		}
+
+		// test pause
+		err = pausedNavClient.SetMode(context.Background(), navigation.ModePaused)
+		test.That(t, err, test.ShouldBeNil)
+
+		// test mode, where the api reports a paused service as manual and only the progress tells them apart
+		mode, err := pausedNavClient.Mode(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, mode, test.ShouldEqual, navigation.ModeManual)
+
+		// test progress
+		receivedProgress, err := pausedNavClient.Progress(context.Background())
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, receivedProgress, test.ShouldResemble, progress)
+		test.That(t, receivedCmds, test.ShouldResemble, []interface{}{navigation.CommandPause, navigation.CommandGetProgress})
+		test.That(t, conn.Close(), test.ShouldBeNil)
+	})
+
 	go failingServer.Serve(listener2)
 	defer failingServer.Stop()
 
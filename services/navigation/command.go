@@ -0,0 +1,258 @@
+package navigation
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"go.viam.com/rdk/components/generic"
+	"go.viam.com/rdk/protoutils"
+)
+
+// The commands a navigation service can be sent through DoCommand, for what its API has no method for. A command is
+// given by its name under the CommandKey.
+const (
+	CommandKey = "command"
+	// CommandGetProgress returns the progress of the current or last mission, as encoded by ProgressToMap.
+	CommandGetProgress = "get_progress"
+	// CommandPause sets the service to ModePaused.
+	CommandPause = "pause"
+	// CommandGetGeofences returns the geofences, each encoded by GeofenceToMap, under "geofences".
+	CommandGetGeofences = "get_geofences"
+	// CommandAddGeofence adds the geofence encoded by GeofenceToMap under "geofence".
+	CommandAddGeofence = "add_geofence"
+	// CommandRemoveGeofence removes the geofence whose hex ID is under "id".
+	CommandRemoveGeofence = "remove_geofence"
+)
+
+// CommandServiceName is the name of the gRPC service which sends commands to a navigation service. The navigation protos
+// do not describe it, so it carries the name of the service and the command in a struct, and the result as another. Like
+// the resource graph service, it is named under the rdk rather than the API's packages.
+const CommandServiceName = "rdk.service.navigation.v1.NavigationCommandService"
+
+// DoCommandMethod is the full name of the method which sends a command to a navigation service.
+const DoCommandMethod = "/" + CommandServiceName + "/DoCommand"
+
+// CommandServer is the server of the navigation command service.
+type CommandServer interface {
+	DoCommand(ctx context.Context, req *structpb.Struct) (*structpb.Struct, error)
+}
+
+// CommandServiceDesc describes the navigation command service to an rpc.Server, alongside the navigation service.
+var CommandServiceDesc = grpc.ServiceDesc{
+	ServiceName: CommandServiceName,
+	HandlerType: (*CommandServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DoCommand",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (
+				interface{}, error,
+			) {
+				in := new(structpb.Struct)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				if interceptor == nil {
+					return srv.(CommandServer).DoCommand(ctx, in)
+				}
+				info := &grpc.UnaryServerInfo{Server: srv, FullMethod: DoCommandMethod}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					return srv.(CommandServer).DoCommand(ctx, req.(*structpb.Struct))
+				}
+				return interceptor(ctx, in, info, handler)
+			},
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+// DoCommand sends the command in the request to the navigation service named by it, if the service takes commands.
+func (server *subtypeServer) DoCommand(ctx context.Context, req *structpb.Struct) (*structpb.Struct, error) {
+	svc, err := server.service(req.Fields["name"].GetStringValue())
+	if err != nil {
+		return nil, err
+	}
+	doer, ok := svc.(generic.Generic)
+	if !ok {
+		return nil, generic.ErrUnimplemented
+	}
+	result, err := doer.DoCommand(ctx, req.Fields["command"].GetStructValue().AsMap())
+	if err != nil {
+		return nil, err
+	}
+	return protoutils.StructToStructPb(result)
+}
+
+// DoCommand does a navigation command through the methods of the service, for services to take the commands with.
+func DoCommand(ctx context.Context, svc Service, cmd map[string]interface{}) (map[string]interface{}, error) {
+	switch cmd[CommandKey] {
+	case CommandGetProgress:
+		progress, err := svc.Progress(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return ProgressToMap(progress)
+	case CommandPause:
+		if err := svc.SetMode(ctx, ModePaused); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{}, nil
+	case CommandGetGeofences:
+		geofences, err := svc.Geofences(ctx)
+		if err != nil {
+			return nil, err
+		}
+		encoded := make([]interface{}, 0, len(geofences))
+		for _, gf := range geofences {
+			encoded = append(encoded, GeofenceToMap(gf))
+		}
+		return map[string]interface{}{"geofences": encoded}, nil
+	case CommandAddGeofence:
+		gfMap, ok := cmd["geofence"].(map[string]interface{})
+		if !ok {
+			return nil, errors.Errorf("expected a geofence to add, got %v", cmd["geofence"])
+		}
+		gf, err := GeofenceFromMap(gfMap)
+		if err != nil {
+			return nil, err
+		}
+		if err := svc.AddGeofence(ctx, gf.Kind, gf.Boundary()); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{}, nil
+	case CommandRemoveGeofence:
+		idHex, _ := cmd["id"].(string)
+		id, err := primitive.ObjectIDFromHex(idHex)
+		if err != nil {
+			return nil, err
+		}
+		if err := svc.RemoveGeofence(ctx, id); err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{}, nil
+	default:
+		return nil, generic.ErrUnimplemented
+	}
+}
+
+// GeofenceToMap encodes a geofence as it is sent in commands.
+func GeofenceToMap(gf Geofence) map[string]interface{} {
+	vertices := make([]interface{}, 0, len(gf.Vertices))
+	for _, v := range gf.Vertices {
+		vertices = append(vertices, map[string]interface{}{"latitude": v.Lat, "longitude": v.Long})
+	}
+	return map[string]interface{}{
+		"id":       gf.ID.Hex(),
+		"kind":     string(gf.Kind),
+		"vertices": vertices,
+	}
+}
+
+// GeofenceFromMap decodes a geofence sent in a command; it is the inverse of GeofenceToMap.
+func GeofenceFromMap(m map[string]interface{}) (Geofence, error) {
+	idHex, _ := m["id"].(string)
+	id, err := primitive.ObjectIDFromHex(idHex)
+	if err != nil {
+		return Geofence{}, err
+	}
+	kind, _ := m["kind"].(string)
+	gf := Geofence{ID: id, Kind: GeofenceKind(kind)}
+	vertices, _ := m["vertices"].([]interface{})
+	for _, v := range vertices {
+		vMap, ok := v.(map[string]interface{})
+		if !ok {
+			return Geofence{}, errors.Errorf("expected a geofence vertex, got %v", v)
+		}
+		var vertex GeofenceVertex
+		vertex.Lat, _ = vMap["latitude"].(float64)
+		vertex.Long, _ = vMap["longitude"].(float64)
+		gf.Vertices = append(gf.Vertices, vertex)
+	}
+	return gf, nil
+}
+
+// ProgressToMap encodes the progress of a mission as the result of a CommandGetProgress.
+func ProgressToMap(progress MissionProgress) (map[string]interface{}, error) {
+	m := map[string]interface{}{
+		"state":               string(progress.State),
+		"action":              progress.Action,
+		"waypoints_reached":   progress.WaypointsReached,
+		"waypoints_remaining": progress.WaypointsRemaining,
+	}
+	if progress.ActionErr != nil {
+		m["action_error"] = progress.ActionErr.Error()
+	}
+	if wp := progress.Waypoint; wp != nil {
+		actions := []interface{}{}
+		if len(wp.Actions) != 0 {
+			encoded, err := json.Marshal(wp.Actions)
+			if err != nil {
+				return nil, err
+			}
+			if err := json.Unmarshal(encoded, &actions); err != nil {
+				return nil, err
+			}
+		}
+		m["waypoint"] = map[string]interface{}{
+			"id":        wp.ID.Hex(),
+			"order":     wp.Order,
+			"latitude":  wp.Lat,
+			"longitude": wp.Long,
+			"actions":   actions,
+		}
+	}
+	return m, nil
+}
+
+// ProgressFromMap decodes the result of a CommandGetProgress; it is the inverse of ProgressToMap.
+func ProgressFromMap(m map[string]interface{}) (MissionProgress, error) {
+	var progress MissionProgress
+	state, ok := m["state"].(string)
+	if !ok {
+		return progress, errors.Errorf("expected progress to have a state, got %v", m["state"])
+	}
+	progress.State = MissionState(state)
+	progress.Action = intFromMap(m, "action")
+	progress.WaypointsReached = intFromMap(m, "waypoints_reached")
+	progress.WaypointsRemaining = intFromMap(m, "waypoints_remaining")
+	if actionErr, ok := m["action_error"].(string); ok {
+		progress.ActionErr = errors.New(actionErr)
+	}
+	if wpMap, ok := m["waypoint"].(map[string]interface{}); ok {
+		idHex, _ := wpMap["id"].(string)
+		id, err := primitive.ObjectIDFromHex(idHex)
+		if err != nil {
+			return progress, err
+		}
+		wp := Waypoint{ID: id, Order: intFromMap(wpMap, "order")}
+		wp.Lat, _ = wpMap["latitude"].(float64)
+		wp.Long, _ = wpMap["longitude"].(float64)
+		if actions, ok := wpMap["actions"].([]interface{}); ok && len(actions) != 0 {
+			encoded, err := json.Marshal(actions)
+			if err != nil {
+				return progress, err
+			}
+			if err := json.Unmarshal(encoded, &wp.Actions); err != nil {
+				return progress, err
+			}
+		}
+		progress.Waypoint = &wp
+	}
+	return progress, nil
+}
+
+// intFromMap returns the number under the key as an int, whether it is one or was decoded as a float.
+func intFromMap(m map[string]interface{}, key string) int {
+	switch v := m[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
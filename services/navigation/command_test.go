@@ -0,0 +1,87 @@
+package navigation_test
+
+import (
+	"errors"
+	"testing"
+
+	geo "github.com/kellydunn/golang-geo"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/protoutils"
+	"go.viam.com/rdk/services/navigation"
+)
+
+func TestProgressMap(t *testing.T) {
+	// the progress goes through a struct as it would over the wire, where every number becomes a float
+	roundTrip := func(progress navigation.MissionProgress) navigation.MissionProgress {
+		m, err := navigation.ProgressToMap(progress)
+		test.That(t, err, test.ShouldBeNil)
+		pbStruct, err := protoutils.StructToStructPb(m)
+		test.That(t, err, test.ShouldBeNil)
+		decoded, err := navigation.ProgressFromMap(pbStruct.AsMap())
+		test.That(t, err, test.ShouldBeNil)
+		return decoded
+	}
+
+	t.Run("idle", func(t *testing.T) {
+		progress := navigation.MissionProgress{State: navigation.MissionStateIdle, Action: -1}
+		test.That(t, roundTrip(progress), test.ShouldResemble, progress)
+	})
+
+	t.Run("at a waypoint", func(t *testing.T) {
+		progress := navigation.MissionProgress{
+			State: navigation.MissionStateRunning,
+			Waypoint: &navigation.Waypoint{
+				ID:    primitive.NewObjectID(),
+				Order: 3,
+				Lat:   40.5,
+				Long:  -73.25,
+				Actions: []navigation.WaypointAction{
+					{Type: navigation.WaypointActionDwell, DwellSec: 2.5},
+					{
+						Type:     navigation.WaypointActionDoCommand,
+						Resource: "arm1",
+						Command:  map[string]interface{}{"command": "wave", "times": 2.0},
+					},
+				},
+			},
+			Action:             1,
+			ActionErr:          errors.New("arm1 is not there"),
+			WaypointsReached:   4,
+			WaypointsRemaining: 2,
+		}
+		decoded := roundTrip(progress)
+		test.That(t, decoded.ActionErr, test.ShouldBeError, progress.ActionErr.Error())
+		decoded.ActionErr = progress.ActionErr
+		test.That(t, decoded, test.ShouldResemble, progress)
+	})
+
+	t.Run("missing state", func(t *testing.T) {
+		_, err := navigation.ProgressFromMap(map[string]interface{}{})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "state")
+	})
+
+	t.Run("bad waypoint id", func(t *testing.T) {
+		_, err := navigation.ProgressFromMap(map[string]interface{}{
+			"state":    string(navigation.MissionStateRunning),
+			"waypoint": map[string]interface{}{"id": "nope"},
+		})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+}
+
+func TestGeofenceMap(t *testing.T) {
+	boundary := []*geo.Point{geo.NewPoint(40, -74), geo.NewPoint(40, -73.9), geo.NewPoint(40.1, -73.9)}
+	geofence := navigation.NewGeofence(navigation.GeofenceKindForbidden, boundary)
+	geofence.ID = primitive.NewObjectID()
+	pbStruct, err := protoutils.StructToStructPb(navigation.GeofenceToMap(geofence))
+	test.That(t, err, test.ShouldBeNil)
+	decoded, err := navigation.GeofenceFromMap(pbStruct.AsMap())
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, decoded, test.ShouldResemble, geofence)
+
+	_, err = navigation.GeofenceFromMap(map[string]interface{}{"id": geofence.ID.Hex(), "vertices": []interface{}{"north"}})
+	test.That(t, err, test.ShouldNotBeNil)
+}
@@ -0,0 +1,389 @@
+package navigation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.uber.org/multierr"
+)
+
+var defaultFileNavStorePath = filepath.Join(os.Getenv("HOME"), ".viam", "navigation", "store.json")
+
+// fileNavStoreCompactEntries is how many entries the journal of a FileNavigationStore grows to before they are compacted
+// into its snapshot.
+const fileNavStoreCompactEntries = 1000
+
+// A fileNavOp is a change to a FileNavigationStore.
+type fileNavOp string
+
+const (
+	fileNavOpInsertWaypoints = fileNavOp("insert_waypoints")
+	fileNavOpRemoveWaypoint  = fileNavOp("remove_waypoint")
+	fileNavOpWaypointVisited = fileNavOp("waypoint_visited")
+	fileNavOpInsertGeofence  = fileNavOp("insert_geofence")
+	fileNavOpRemoveGeofence  = fileNavOp("remove_geofence")
+)
+
+// A fileNavJournalEntry is a change to a FileNavigationStore, which is appended to its journal before it is made.
+type fileNavJournalEntry struct {
+	Seq       uint64             `json:"seq"`
+	Op        fileNavOp          `json:"op"`
+	Waypoints []Waypoint         `json:"waypoints,omitempty"`
+	Geofence  *Geofence          `json:"geofence,omitempty"`
+	ID        primitive.ObjectID `json:"id"`
+}
+
+// A fileNavSnapshot is the whole of a FileNavigationStore as of some entry of its journal.
+type fileNavSnapshot struct {
+	Seq       uint64     `json:"seq"`
+	Waypoints []Waypoint `json:"waypoints"`
+	Geofences []Geofence `json:"geofences"`
+}
+
+// NewFileNavigationStore creates a new navigation store kept in a local file, at the "path" in the config or else under
+// ~/.viam, so that waypoints and their progress survive restarts without an external database. Every change is first
+// appended to a journal next to the file and synced to disk, and the journal is periodically compacted into the file
+// by atomically replacing it, so the store can be recovered however a write is interrupted.
+func NewFileNavigationStore(config map[string]interface{}) (*FileNavigationStore, error) {
+	path, ok := config["path"].(string)
+	if !ok || path == "" {
+		path = defaultFileNavStorePath
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+
+	store := &FileNavigationStore{
+		path:   path,
+		memory: NewMemoryNavigationStore(),
+	}
+	//nolint:gosec
+	journal, err := os.OpenFile(store.journalPath(), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	store.journal = journal
+	if err := store.load(); err != nil {
+		return nil, multierr.Combine(errors.Wrapf(err, "failed to load navigation store %q", path), journal.Close())
+	}
+	return store, nil
+}
+
+// FileNavigationStore holds the waypoints and geofences for the navigation service in a local file.
+type FileNavigationStore struct {
+	// mu is held while changing the store.
+	mu      sync.Mutex
+	path    string
+	journal *os.File
+	// journalSize and journalEntries are how many bytes and entries have been written to the journal since it was last
+	// compacted, and seq is the sequence number of the last entry.
+	journalSize    int64
+	journalEntries int
+	seq            uint64
+	memory         *MemoryNavigationStore
+}
+
+func (store *FileNavigationStore) journalPath() string {
+	return store.path + ".journal"
+}
+
+// load reads the snapshot and then makes the changes in the journal since it, up to any entry that was only partly
+// written, before compacting them into a new snapshot.
+func (store *FileNavigationStore) load() error {
+	//nolint:gosec
+	data, err := os.ReadFile(store.path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return err
+	default:
+		var snapshot fileNavSnapshot
+		if err := json.Unmarshal(data, &snapshot); err != nil {
+			return err
+		}
+		store.seq = snapshot.Seq
+		store.memory.insertWaypoints(snapshot.Waypoints)
+		for _, gf := range snapshot.Geofences {
+			store.memory.insertGeofence(gf)
+		}
+	}
+
+	data, err = io.ReadAll(store.journal)
+	if err != nil {
+		return err
+	}
+	for lineNum := 1; len(data) != 0; lineNum++ {
+		end := bytes.IndexByte(data, '\n')
+		var entry fileNavJournalEntry
+		var err error
+		if end == -1 {
+			err = errors.New("entry is incomplete")
+		} else {
+			err = decodeJournalLine(data[:end], &entry)
+		}
+		if err != nil {
+			if end == -1 || len(bytes.TrimSpace(data[end+1:])) == 0 {
+				// only the last entry can have been interrupted while being written, and it was never made
+				break
+			}
+			return errors.Wrapf(err, "journal is corrupt at line %d", lineNum)
+		}
+		data = data[end+1:]
+
+		if entry.Seq <= store.seq {
+			// already in the snapshot, as the journal was not cleared after it was last compacted
+			continue
+		}
+		if entry.Seq != store.seq+1 {
+			return errors.Errorf("journal is missing entries before line %d", lineNum)
+		}
+		if err := store.apply(entry); err != nil {
+			return errors.Wrapf(err, "failed to apply line %d of journal", lineNum)
+		}
+		store.seq = entry.Seq
+	}
+	return store.compact()
+}
+
+// commit makes the change after durably appending it to the journal.
+func (store *FileNavigationStore) commit(entry fileNavJournalEntry) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if store.journal == nil {
+		return errors.New("navigation store is closed")
+	}
+
+	entry.Seq = store.seq + 1
+	line, err := encodeJournalLine(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := store.journal.Write(line); err != nil {
+		// cut off whatever part was written, so later entries are not lost after it when loading
+		return multierr.Combine(err, store.journal.Truncate(store.journalSize))
+	}
+	if err := store.journal.Sync(); err != nil {
+		return multierr.Combine(err, store.journal.Truncate(store.journalSize))
+	}
+	store.journalSize += int64(len(line))
+	store.journalEntries++
+	store.seq = entry.Seq
+	if err := store.apply(entry); err != nil {
+		return err
+	}
+
+	if store.journalEntries >= fileNavStoreCompactEntries {
+		return store.compact()
+	}
+	return nil
+}
+
+// apply makes the change in memory.
+func (store *FileNavigationStore) apply(entry fileNavJournalEntry) error {
+	ctx := context.Background()
+	switch entry.Op {
+	case fileNavOpInsertWaypoints:
+		store.memory.insertWaypoints(entry.Waypoints)
+		return nil
+	case fileNavOpRemoveWaypoint:
+		return store.memory.RemoveWaypoint(ctx, entry.ID)
+	case fileNavOpWaypointVisited:
+		return store.memory.WaypointVisited(ctx, entry.ID)
+	case fileNavOpInsertGeofence:
+		if entry.Geofence == nil {
+			return errors.New("geofence to insert is missing")
+		}
+		store.memory.insertGeofence(*entry.Geofence)
+		return nil
+	case fileNavOpRemoveGeofence:
+		return store.memory.RemoveGeofence(ctx, entry.ID)
+	default:
+		return errors.Errorf("unknown operation %q", entry.Op)
+	}
+}
+
+// compact replaces the snapshot with one of the store as it is now and then clears the journal. If interrupted before
+// the journal is cleared, its entries are skipped by their sequence numbers when next loading.
+func (store *FileNavigationStore) compact() error {
+	ctx := context.Background()
+	wps, err := store.memory.AllWaypoints(ctx)
+	if err != nil {
+		return err
+	}
+	gfs, err := store.memory.Geofences(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(fileNavSnapshot{Seq: store.seq, Waypoints: wps, Geofences: gfs})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(store.path, data); err != nil {
+		return err
+	}
+
+	if err := store.journal.Truncate(0); err != nil {
+		return err
+	}
+	if err := store.journal.Sync(); err != nil {
+		return err
+	}
+	store.journalSize = 0
+	store.journalEntries = 0
+	return nil
+}
+
+// writeFileAtomically replaces the file with one of the data, such that it is either wholly the old or the new file
+// however the write is interrupted.
+func writeFileAtomically(path string, data []byte) (err error) {
+	tmpPath := path + ".tmp"
+	//nolint:gosec
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		return multierr.Combine(err, file.Close())
+	}
+	if err := file.Sync(); err != nil {
+		return multierr.Combine(err, file.Close())
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return err
+	}
+
+	// the rename is only durable once the directory is synced
+	//nolint:gosec
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = multierr.Combine(err, dir.Close())
+	}()
+	return dir.Sync()
+}
+
+// encodeJournalLine encodes the entry as a line of its checksum followed by its JSON.
+func encodeJournalLine(entry fileNavJournalEntry) ([]byte, error) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(fmt.Sprintf("%08x %s\n", crc32.ChecksumIEEE(data), data)), nil
+}
+
+func decodeJournalLine(line []byte, entry *fileNavJournalEntry) error {
+	checksum, data, ok := bytes.Cut(line, []byte(" "))
+	if !ok {
+		return errors.New("entry has no checksum")
+	}
+	expected, err := strconv.ParseUint(string(checksum), 16, 32)
+	if err != nil {
+		return errors.Wrap(err, "entry has an invalid checksum")
+	}
+	if uint32(expected) != crc32.ChecksumIEEE(data) {
+		return errors.New("entry does not match its checksum")
+	}
+	return json.Unmarshal(data, entry)
+}
+
+// Close closes the journal; the store cannot be changed after.
+func (store *FileNavigationStore) Close(ctx context.Context) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if store.journal == nil {
+		return nil
+	}
+	err := store.journal.Close()
+	store.journal = nil
+	return err
+}
+
+// Waypoints returns a copy of all of the waypoints in the FileNavigationStore.
+func (store *FileNavigationStore) Waypoints(ctx context.Context) ([]Waypoint, error) {
+	return store.memory.Waypoints(ctx)
+}
+
+// AddWaypoint adds a waypoint to the FileNavigationStore.
+func (store *FileNavigationStore) AddWaypoint(ctx context.Context, point *geo.Point) (Waypoint, error) {
+	newPoint := Waypoint{
+		ID:   primitive.NewObjectID(),
+		Lat:  point.Lat(),
+		Long: point.Lng(),
+	}
+	if err := store.commit(fileNavJournalEntry{Op: fileNavOpInsertWaypoints, Waypoints: []Waypoint{newPoint}}); err != nil {
+		return Waypoint{}, err
+	}
+	return newPoint, nil
+}
+
+// RemoveWaypoint removes a waypoint from the FileNavigationStore.
+func (store *FileNavigationStore) RemoveWaypoint(ctx context.Context, id primitive.ObjectID) error {
+	return store.commit(fileNavJournalEntry{Op: fileNavOpRemoveWaypoint, ID: id})
+}
+
+// NextWaypoint gets the next waypoint that has not been visited.
+func (store *FileNavigationStore) NextWaypoint(ctx context.Context) (Waypoint, error) {
+	return store.memory.NextWaypoint(ctx)
+}
+
+// WaypointVisited sets that a waypoint has been visited.
+func (store *FileNavigationStore) WaypointVisited(ctx context.Context, id primitive.ObjectID) error {
+	return store.commit(fileNavJournalEntry{Op: fileNavOpWaypointVisited, ID: id})
+}
+
+// AllWaypoints returns a copy of all of the waypoints in the FileNavigationStore, including those visited.
+func (store *FileNavigationStore) AllWaypoints(ctx context.Context) ([]Waypoint, error) {
+	return store.memory.AllWaypoints(ctx)
+}
+
+// ImportWaypoints adds the waypoints to the FileNavigationStore.
+func (store *FileNavigationStore) ImportWaypoints(ctx context.Context, wps []Waypoint) ([]Waypoint, error) {
+	for i, wp := range wps {
+		if err := wp.Validate(fmt.Sprintf("waypoints.%d", i)); err != nil {
+			return nil, err
+		}
+	}
+	imported := withNewIDs(wps)
+	if err := store.commit(fileNavJournalEntry{Op: fileNavOpInsertWaypoints, Waypoints: imported}); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+// Geofences returns a copy of all of the geofences in the FileNavigationStore.
+func (store *FileNavigationStore) Geofences(ctx context.Context) ([]Geofence, error) {
+	return store.memory.Geofences(ctx)
+}
+
+// AddGeofence adds a geofence to the FileNavigationStore.
+func (store *FileNavigationStore) AddGeofence(ctx context.Context, geofence Geofence) (Geofence, error) {
+	if err := geofence.Validate("geofence"); err != nil {
+		return Geofence{}, err
+	}
+	geofence.ID = primitive.NewObjectID()
+	if err := store.commit(fileNavJournalEntry{Op: fileNavOpInsertGeofence, Geofence: &geofence}); err != nil {
+		return Geofence{}, err
+	}
+	return geofence, nil
+}
+
+// RemoveGeofence removes a geofence from the FileNavigationStore.
+func (store *FileNavigationStore) RemoveGeofence(ctx context.Context, id primitive.ObjectID) error {
+	return store.commit(fileNavJournalEntry{Op: fileNavOpRemoveGeofence, ID: id})
+}
@@ -0,0 +1,130 @@
+package navigation_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/services/navigation"
+)
+
+func TestFileNavigationStore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nav", "store.json")
+	open := func() *navigation.FileNavigationStore {
+		t.Helper()
+		store, err := navigation.NewFileNavigationStore(map[string]interface{}{"path": path})
+		test.That(t, err, test.ShouldBeNil)
+		return store
+	}
+
+	store := open()
+	wp1, err := store.AddWaypoint(ctx, geo.NewPoint(40.1, -74.1))
+	test.That(t, err, test.ShouldBeNil)
+	wp2, err := store.AddWaypoint(ctx, geo.NewPoint(40.2, -74.2))
+	test.That(t, err, test.ShouldBeNil)
+	imported, err := store.ImportWaypoints(ctx, []navigation.Waypoint{{
+		Lat:     40.3,
+		Long:    -74.3,
+		Order:   1,
+		Actions: []navigation.WaypointAction{{Type: navigation.WaypointActionDwell, DwellSec: 2}},
+	}})
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, store.WaypointVisited(ctx, imported[0].ID), test.ShouldBeNil)
+	test.That(t, store.RemoveWaypoint(ctx, wp2.ID), test.ShouldBeNil)
+	gf, err := store.AddGeofence(ctx, navigation.NewGeofence(navigation.GeofenceKindForbidden, []*geo.Point{
+		geo.NewPoint(40, -74), geo.NewPoint(41, -74), geo.NewPoint(41, -73),
+	}))
+	test.That(t, err, test.ShouldBeNil)
+	_, err = store.ImportWaypoints(ctx, []navigation.Waypoint{{Lat: 100}})
+	test.That(t, err, test.ShouldNotBeNil)
+
+	expectedWaypoints, err := store.AllWaypoints(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, expectedWaypoints, test.ShouldHaveLength, 2)
+	test.That(t, expectedWaypoints[0].ID, test.ShouldEqual, imported[0].ID)
+	test.That(t, expectedWaypoints[1], test.ShouldResemble, wp1)
+	next, err := store.NextWaypoint(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, next, test.ShouldResemble, wp1)
+	// the store is left as if the robot lost power, without being closed
+
+	check := func(t *testing.T, store *navigation.FileNavigationStore) {
+		t.Helper()
+		wps, err := store.AllWaypoints(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, wps, test.ShouldResemble, expectedWaypoints)
+		gfs, err := store.Geofences(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, gfs, test.ShouldResemble, []navigation.Geofence{gf})
+		next, err := store.NextWaypoint(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, next, test.ShouldResemble, wp1)
+	}
+
+	t.Run("reopened", func(t *testing.T) {
+		store := open()
+		check(t, store)
+		test.That(t, store.Close(ctx), test.ShouldBeNil)
+		_, err := store.AddWaypoint(ctx, geo.NewPoint(1, 1))
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
+	t.Run("interrupted write", func(t *testing.T) {
+		store := open()
+		_, err := store.AddWaypoint(ctx, geo.NewPoint(40.4, -74.4))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, store.Close(ctx), test.ShouldBeNil)
+
+		// the entry is cut off partway through
+		journal, err := os.ReadFile(path + ".journal")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, os.WriteFile(path+".journal", journal[:len(journal)/2], 0o600), test.ShouldBeNil)
+		store = open()
+		check(t, store)
+		test.That(t, store.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("interrupted compaction", func(t *testing.T) {
+		store := open()
+		_, err := store.AddWaypoint(ctx, geo.NewPoint(40.4, -74.4))
+		test.That(t, err, test.ShouldBeNil)
+		journal, err := os.ReadFile(path + ".journal")
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, store.Close(ctx), test.ShouldBeNil)
+
+		// opening compacts the journal into the snapshot, so restoring the journal is as if clearing it was interrupted
+		store = open()
+		test.That(t, store.Close(ctx), test.ShouldBeNil)
+		test.That(t, os.WriteFile(path+".journal", journal, 0o600), test.ShouldBeNil)
+		store = open()
+		wps, err := store.AllWaypoints(ctx)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, wps, test.ShouldHaveLength, len(expectedWaypoints)+1)
+		test.That(t, store.RemoveWaypoint(ctx, wps[len(wps)-1].ID), test.ShouldBeNil)
+		check(t, store)
+		test.That(t, store.Close(ctx), test.ShouldBeNil)
+	})
+
+	t.Run("corrupt", func(t *testing.T) {
+		store := open()
+		_, err := store.AddWaypoint(ctx, geo.NewPoint(40.4, -74.4))
+		test.That(t, err, test.ShouldBeNil)
+		_, err = store.AddWaypoint(ctx, geo.NewPoint(40.5, -74.5))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, store.Close(ctx), test.ShouldBeNil)
+
+		// a bad entry with good ones after it was not just interrupted, so is not silently dropped
+		journal, err := os.ReadFile(path + ".journal")
+		test.That(t, err, test.ShouldBeNil)
+		journal[10] ^= 1
+		test.That(t, os.WriteFile(path+".journal", journal, 0o600), test.ShouldBeNil)
+		_, err = navigation.NewFileNavigationStore(map[string]interface{}{"path": path})
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "journal is corrupt at line 1")
+	})
+}
@@ -0,0 +1,95 @@
+package navigation
+
+import (
+	"fmt"
+
+	geo "github.com/kellydunn/golang-geo"
+	"github.com/pkg/errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.viam.com/utils"
+)
+
+// GeofenceKind is whether a geofence bounds an area to stay within or one to keep out of.
+type GeofenceKind string
+
+// The set of known geofence kinds.
+const (
+	// GeofenceKindAllowed is an area to stay within. If there are any, the robot may only be within them.
+	GeofenceKindAllowed = GeofenceKind("allowed")
+	// GeofenceKindForbidden is an area to keep out of.
+	GeofenceKindForbidden = GeofenceKind("forbidden")
+)
+
+// A Geofence is an area to stay within or keep out of, bounded by a polygon.
+type Geofence struct {
+	ID       primitive.ObjectID `bson:"_id"`
+	Kind     GeofenceKind       `bson:"kind"`
+	Vertices []GeofenceVertex   `bson:"vertices"`
+}
+
+// A GeofenceVertex is a corner of the polygon bounding a geofence.
+type GeofenceVertex struct {
+	Lat  float64 `bson:"latitude"`
+	Long float64 `bson:"longitude"`
+}
+
+// NewGeofence returns a geofence of the given kind bounded by the polygon with the given vertices, in order.
+func NewGeofence(kind GeofenceKind, boundary []*geo.Point) Geofence {
+	vertices := make([]GeofenceVertex, 0, len(boundary))
+	for _, pt := range boundary {
+		vertices = append(vertices, GeofenceVertex{Lat: pt.Lat(), Long: pt.Lng()})
+	}
+	return Geofence{Kind: kind, Vertices: vertices}
+}
+
+// Validate ensures the geofence is of a known kind and bounds an area.
+func (gf *Geofence) Validate(path string) error {
+	switch gf.Kind {
+	case GeofenceKindAllowed, GeofenceKindForbidden:
+	case "":
+		return utils.NewConfigValidationFieldRequiredError(path, "kind")
+	default:
+		return utils.NewConfigValidationError(path, errors.Errorf("unknown geofence kind %q", gf.Kind))
+	}
+	if len(gf.Vertices) < 3 {
+		return utils.NewConfigValidationError(
+			fmt.Sprintf("%s.%s", path, "vertices"),
+			errors.Errorf("a geofence needs at least 3 vertices, got %d", len(gf.Vertices)),
+		)
+	}
+	return nil
+}
+
+// Boundary returns the vertices of the polygon bounding the geofence as points.
+func (gf *Geofence) Boundary() []*geo.Point {
+	boundary := make([]*geo.Point, 0, len(gf.Vertices))
+	for _, v := range gf.Vertices {
+		boundary = append(boundary, geo.NewPoint(v.Lat, v.Long))
+	}
+	return boundary
+}
+
+// Contains returns whether the point is within the geofence.
+func (gf *Geofence) Contains(point *geo.Point) bool {
+	return geo.NewPolygon(gf.Boundary()).Contains(point)
+}
+
+// WithinGeofences returns whether the point is somewhere the geofences allow: within an allowed geofence if there are any,
+// and not within a forbidden one.
+func WithinGeofences(point *geo.Point, geofences []Geofence) bool {
+	var anyAllowed, withinAllowed bool
+	for _, gf := range geofences {
+		switch gf.Kind {
+		case GeofenceKindForbidden:
+			if gf.Contains(point) {
+				return false
+			}
+		case GeofenceKindAllowed:
+			anyAllowed = true
+			if !withinAllowed && gf.Contains(point) {
+				withinAllowed = true
+			}
+		}
+	}
+	return !anyAllowed || withinAllowed
+}
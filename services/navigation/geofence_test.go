@@ -0,0 +1,77 @@
+package navigation_test
+
+import (
+	"context"
+	"testing"
+
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/services/navigation"
+)
+
+func TestWithinGeofences(t *testing.T) {
+	square := func(kind navigation.GeofenceKind, lat, lng, size float64) navigation.Geofence {
+		return navigation.NewGeofence(kind, []*geo.Point{
+			geo.NewPoint(lat, lng),
+			geo.NewPoint(lat+size, lng),
+			geo.NewPoint(lat+size, lng+size),
+			geo.NewPoint(lat, lng+size),
+		})
+	}
+	allowed := square(navigation.GeofenceKindAllowed, 40, -74, 1)
+	forbidden := square(navigation.GeofenceKindForbidden, 40.2, -73.8, .2)
+
+	inAllowed := geo.NewPoint(40.1, -73.9)
+	inForbidden := geo.NewPoint(40.3, -73.7)
+	outside := geo.NewPoint(42, -74)
+
+	test.That(t, navigation.WithinGeofences(outside, nil), test.ShouldBeTrue)
+	test.That(t, navigation.WithinGeofences(outside, []navigation.Geofence{forbidden}), test.ShouldBeTrue)
+	test.That(t, navigation.WithinGeofences(inForbidden, []navigation.Geofence{forbidden}), test.ShouldBeFalse)
+
+	geofences := []navigation.Geofence{allowed, forbidden}
+	test.That(t, navigation.WithinGeofences(inAllowed, geofences), test.ShouldBeTrue)
+	test.That(t, navigation.WithinGeofences(inForbidden, geofences), test.ShouldBeFalse)
+	test.That(t, navigation.WithinGeofences(outside, geofences), test.ShouldBeFalse)
+
+	// another allowed geofence widens the area allowed
+	geofences = append(geofences, square(navigation.GeofenceKindAllowed, 41.5, -74.5, 1))
+	test.That(t, navigation.WithinGeofences(outside, geofences), test.ShouldBeTrue)
+}
+
+func TestMemoryStoreGeofences(t *testing.T) {
+	ctx := context.Background()
+	store := navigation.NewMemoryNavigationStore()
+
+	boundary := []*geo.Point{geo.NewPoint(40, -74), geo.NewPoint(41, -74), geo.NewPoint(41, -73)}
+	_, err := store.AddGeofence(ctx, navigation.NewGeofence(navigation.GeofenceKindForbidden, boundary[:2]))
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "at least 3 vertices")
+	_, err = store.AddGeofence(ctx, navigation.NewGeofence("", boundary))
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = store.AddGeofence(ctx, navigation.NewGeofence("somewhere", boundary))
+	test.That(t, err, test.ShouldNotBeNil)
+
+	gf1, err := store.AddGeofence(ctx, navigation.NewGeofence(navigation.GeofenceKindForbidden, boundary))
+	test.That(t, err, test.ShouldBeNil)
+	gf2, err := store.AddGeofence(ctx, navigation.NewGeofence(navigation.GeofenceKindAllowed, boundary))
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, gf1.ID, test.ShouldNotEqual, gf2.ID)
+
+	gfs, err := store.Geofences(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, gfs, test.ShouldResemble, []navigation.Geofence{gf1, gf2})
+	test.That(t, gfs[0].Boundary(), test.ShouldResemble, boundary)
+
+	// the geofences returned are copies
+	gfs[0].Vertices[0].Lat = 0
+	gfs, err = store.Geofences(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, gfs[0].Vertices[0].Lat, test.ShouldEqual, 40)
+
+	test.That(t, store.RemoveGeofence(ctx, gf1.ID), test.ShouldBeNil)
+	gfs, err = store.Geofences(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, gfs, test.ShouldResemble, []navigation.Geofence{gf2})
+}
@@ -0,0 +1,406 @@
+package navigation
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MissionFormat is a file format a route of waypoints can be imported from and exported to.
+type MissionFormat string
+
+// The set of known mission formats.
+const (
+	// MissionFormatGeoJSON is a GeoJSON FeatureCollection with a Point feature for each waypoint.
+	MissionFormatGeoJSON = MissionFormat("geojson")
+	// MissionFormatGPX is a GPX file with a route of the waypoints.
+	MissionFormatGPX = MissionFormat("gpx")
+	// MissionFormatKML is a KML document with a Point placemark for each waypoint.
+	MissionFormatKML = MissionFormat("kml")
+)
+
+// MissionFormatFromPath returns the mission format of a file going by its extension.
+func MissionFormatFromPath(path string) (MissionFormat, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".geojson", ".json":
+		return MissionFormatGeoJSON, nil
+	case ".gpx":
+		return MissionFormatGPX, nil
+	case ".kml":
+		return MissionFormatKML, nil
+	default:
+		return "", errors.Errorf("cannot tell the mission format of %q; expected a .geojson, .gpx or .kml file", path)
+	}
+}
+
+// ImportWaypoints reads a route of waypoints in the given format and adds them to the store after any already in it.
+func ImportWaypoints(ctx context.Context, store NavStore, r io.Reader, format MissionFormat) ([]Waypoint, error) {
+	wps, err := DecodeWaypoints(r, format)
+	if err != nil {
+		return nil, err
+	}
+	return store.ImportWaypoints(ctx, wps)
+}
+
+// ExportWaypoints writes every waypoint in the store, visited or not, in the given format in the order they are
+// navigated to.
+func ExportWaypoints(ctx context.Context, store NavStore, w io.Writer, format MissionFormat) error {
+	wps, err := store.AllWaypoints(ctx)
+	if err != nil {
+		return err
+	}
+	return EncodeWaypoints(w, format, wps)
+}
+
+// EncodeWaypoints writes the waypoints in the given format, in order. Whether each waypoint is visited is kept in all
+// formats; its order and actions are kept in GeoJSON and KML, while GPX keeps the order only by where it is in the route
+// and has no actions.
+func EncodeWaypoints(w io.Writer, format MissionFormat, wps []Waypoint) error {
+	switch format {
+	case MissionFormatGeoJSON:
+		return encodeGeoJSON(w, wps)
+	case MissionFormatGPX:
+		return encodeGPX(w, wps)
+	case MissionFormatKML:
+		return encodeKML(w, wps)
+	default:
+		return errors.Errorf("unknown mission format %q", format)
+	}
+}
+
+// DecodeWaypoints reads waypoints in the given format, in the order they appear, without IDs.
+func DecodeWaypoints(r io.Reader, format MissionFormat) ([]Waypoint, error) {
+	var wps []Waypoint
+	var err error
+	switch format {
+	case MissionFormatGeoJSON:
+		wps, err = decodeGeoJSON(r)
+	case MissionFormatGPX:
+		wps, err = decodeGPX(r)
+	case MissionFormatKML:
+		wps, err = decodeKML(r)
+	default:
+		return nil, errors.Errorf("unknown mission format %q", format)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s mission", format)
+	}
+	for i, wp := range wps {
+		if err := wp.Validate(fmt.Sprintf("waypoints.%d", i)); err != nil {
+			return nil, err
+		}
+	}
+	return wps, nil
+}
+
+type geoJSONFeatureCollection struct {
+	Type     string           `json:"type"`
+	Features []geoJSONFeature `json:"features"`
+}
+
+type geoJSONFeature struct {
+	Type       string            `json:"type"`
+	Geometry   geoJSONGeometry   `json:"geometry"`
+	Properties geoJSONProperties `json:"properties"`
+}
+
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+type geoJSONProperties struct {
+	Visited bool             `json:"visited,omitempty"`
+	Order   int              `json:"order,omitempty"`
+	Actions []WaypointAction `json:"actions,omitempty"`
+}
+
+func encodeGeoJSON(w io.Writer, wps []Waypoint) error {
+	collection := geoJSONFeatureCollection{Type: "FeatureCollection", Features: make([]geoJSONFeature, 0, len(wps))}
+	for _, wp := range wps {
+		// GeoJSON positions are longitude first
+		coordinates, err := json.Marshal([]float64{wp.Long, wp.Lat})
+		if err != nil {
+			return err
+		}
+		collection.Features = append(collection.Features, geoJSONFeature{
+			Type:       "Feature",
+			Geometry:   geoJSONGeometry{Type: "Point", Coordinates: coordinates},
+			Properties: geoJSONProperties{Visited: wp.Visited, Order: wp.Order, Actions: wp.Actions},
+		})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(collection)
+}
+
+// decodeGeoJSON reads the waypoints from the Point, MultiPoint and LineString features of a FeatureCollection, or of
+// a single Feature; the points of a line become one waypoint each, all with the properties of the feature.
+func decodeGeoJSON(r io.Reader) ([]Waypoint, error) {
+	var object struct {
+		Type       string            `json:"type"`
+		Features   []geoJSONFeature  `json:"features"`
+		Geometry   geoJSONGeometry   `json:"geometry"`
+		Properties geoJSONProperties `json:"properties"`
+	}
+	if err := json.NewDecoder(r).Decode(&object); err != nil {
+		return nil, err
+	}
+	var features []geoJSONFeature
+	switch object.Type {
+	case "FeatureCollection":
+		features = object.Features
+	case "Feature":
+		features = []geoJSONFeature{{Type: object.Type, Geometry: object.Geometry, Properties: object.Properties}}
+	default:
+		return nil, errors.Errorf("expected a FeatureCollection or Feature, got %q", object.Type)
+	}
+
+	var wps []Waypoint
+	for i, feature := range features {
+		var positions [][]float64
+		switch feature.Geometry.Type {
+		case "Point":
+			var position []float64
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &position); err != nil {
+				return nil, errors.Wrapf(err, "feature %d", i)
+			}
+			positions = [][]float64{position}
+		case "MultiPoint", "LineString":
+			if err := json.Unmarshal(feature.Geometry.Coordinates, &positions); err != nil {
+				return nil, errors.Wrapf(err, "feature %d", i)
+			}
+		default:
+			return nil, errors.Errorf("feature %d has unsupported geometry type %q", i, feature.Geometry.Type)
+		}
+		for _, position := range positions {
+			if len(position) < 2 {
+				return nil, errors.Errorf("feature %d has a position without a longitude and latitude", i)
+			}
+			wps = append(wps, Waypoint{
+				Visited: feature.Properties.Visited,
+				Order:   feature.Properties.Order,
+				Actions: feature.Properties.Actions,
+				Lat:     position[1],
+				Long:    position[0],
+			})
+		}
+	}
+	return wps, nil
+}
+
+type gpxDocument struct {
+	XMLName xml.Name   `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+	Version string     `xml:"version,attr"`
+	Creator string     `xml:"creator,attr"`
+	Routes  []gpxRoute `xml:"rte"`
+}
+
+type gpxRoute struct {
+	Points []gpxPoint `xml:"rtept"`
+}
+
+// gpxVisitedType is the type (classification) given to visited GPX points.
+const gpxVisitedType = "visited"
+
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Long float64 `xml:"lon,attr"`
+	Type string  `xml:"type,omitempty"`
+}
+
+func encodeGPX(w io.Writer, wps []Waypoint) error {
+	route := gpxRoute{Points: make([]gpxPoint, 0, len(wps))}
+	for _, wp := range wps {
+		point := gpxPoint{Lat: wp.Lat, Long: wp.Long}
+		if wp.Visited {
+			point.Type = gpxVisitedType
+		}
+		route.Points = append(route.Points, point)
+	}
+	return encodeXML(w, gpxDocument{Version: "1.1", Creator: "rdk", Routes: []gpxRoute{route}})
+}
+
+// decodeGPX reads the waypoints from the points of every route in order, or from the waypoints if there are no routes.
+func decodeGPX(r io.Reader) ([]Waypoint, error) {
+	var routePoints, waypoints []gpxPoint
+	if err := decodeXMLElements(r, func(decoder *xml.Decoder, start xml.StartElement) error {
+		var point gpxPoint
+		switch start.Name.Local {
+		case "rtept":
+			if err := decoder.DecodeElement(&point, &start); err != nil {
+				return err
+			}
+			routePoints = append(routePoints, point)
+		case "wpt":
+			if err := decoder.DecodeElement(&point, &start); err != nil {
+				return err
+			}
+			waypoints = append(waypoints, point)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(routePoints) == 0 {
+		routePoints = waypoints
+	}
+
+	wps := make([]Waypoint, 0, len(routePoints))
+	for _, point := range routePoints {
+		wps = append(wps, Waypoint{Visited: point.Type == gpxVisitedType, Lat: point.Lat, Long: point.Long})
+	}
+	return wps, nil
+}
+
+type kmlDocument struct {
+	XMLName    xml.Name       `xml:"http://www.opengis.net/kml/2.2 kml"`
+	Placemarks []kmlPlacemark `xml:"Document>Placemark"`
+}
+
+type kmlPlacemark struct {
+	ExtendedData *kmlExtendedData `xml:"ExtendedData,omitempty"`
+	Point        *kmlCoordinates  `xml:"Point,omitempty"`
+	LineString   *kmlCoordinates  `xml:"LineString,omitempty"`
+}
+
+type kmlExtendedData struct {
+	Data []kmlData `xml:"Data"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlCoordinates struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+func encodeKML(w io.Writer, wps []Waypoint) error {
+	doc := kmlDocument{Placemarks: make([]kmlPlacemark, 0, len(wps))}
+	for _, wp := range wps {
+		data := []kmlData{
+			{Name: "visited", Value: strconv.FormatBool(wp.Visited)},
+			{Name: "order", Value: strconv.Itoa(wp.Order)},
+		}
+		if len(wp.Actions) != 0 {
+			// KML has no structured data, so actions are kept as JSON
+			actions, err := json.Marshal(wp.Actions)
+			if err != nil {
+				return err
+			}
+			data = append(data, kmlData{Name: "actions", Value: string(actions)})
+		}
+		doc.Placemarks = append(doc.Placemarks, kmlPlacemark{
+			ExtendedData: &kmlExtendedData{Data: data},
+			// KML coordinates are longitude first
+			Point: &kmlCoordinates{Coordinates: fmt.Sprintf("%v,%v", wp.Long, wp.Lat)},
+		})
+	}
+	return encodeXML(w, doc)
+}
+
+// decodeKML reads the waypoints from every Point and LineString placemark, even those within folders; the points of a
+// line become one waypoint each.
+func decodeKML(r io.Reader) ([]Waypoint, error) {
+	var wps []Waypoint
+	if err := decodeXMLElements(r, func(decoder *xml.Decoder, start xml.StartElement) error {
+		if start.Name.Local != "Placemark" {
+			return nil
+		}
+		var placemark kmlPlacemark
+		if err := decoder.DecodeElement(&placemark, &start); err != nil {
+			return err
+		}
+
+		var template Waypoint
+		if placemark.ExtendedData != nil {
+			for _, data := range placemark.ExtendedData.Data {
+				var err error
+				switch data.Name {
+				case "visited":
+					template.Visited, err = strconv.ParseBool(strings.TrimSpace(data.Value))
+				case "order":
+					template.Order, err = strconv.Atoi(strings.TrimSpace(data.Value))
+				case "actions":
+					err = json.Unmarshal([]byte(data.Value), &template.Actions)
+				}
+				if err != nil {
+					return errors.Wrapf(err, "invalid placemark %s", data.Name)
+				}
+			}
+		}
+
+		var coordinates string
+		switch {
+		case placemark.Point != nil:
+			coordinates = placemark.Point.Coordinates
+		case placemark.LineString != nil:
+			coordinates = placemark.LineString.Coordinates
+		default:
+			return nil
+		}
+		for _, tuple := range strings.Fields(coordinates) {
+			parts := strings.Split(tuple, ",")
+			if len(parts) < 2 {
+				return errors.Errorf("coordinates %q have no longitude and latitude", tuple)
+			}
+			long, err := strconv.ParseFloat(parts[0], 64)
+			if err != nil {
+				return err
+			}
+			lat, err := strconv.ParseFloat(parts[1], 64)
+			if err != nil {
+				return err
+			}
+			wp := template
+			wp.Lat, wp.Long = lat, long
+			wps = append(wps, wp)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return wps, nil
+}
+
+func encodeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// decodeXMLElements calls the handler with every element of the document, in order, unless the handler decodes the
+// whole of an element itself, in which case those within it are skipped.
+func decodeXMLElements(r io.Reader, handle func(decoder *xml.Decoder, start xml.StartElement) error) error {
+	decoder := xml.NewDecoder(r)
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if start, ok := token.(xml.StartElement); ok {
+			if err := handle(decoder, start); err != nil {
+				return err
+			}
+		}
+	}
+}
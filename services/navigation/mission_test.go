@@ -0,0 +1,207 @@
+package navigation_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	geo "github.com/kellydunn/golang-geo"
+	"go.viam.com/test"
+
+	"go.viam.com/rdk/services/navigation"
+)
+
+func TestMissionFormatFromPath(t *testing.T) {
+	for path, format := range map[string]navigation.MissionFormat{
+		"route.geojson": navigation.MissionFormatGeoJSON,
+		"route.json":    navigation.MissionFormatGeoJSON,
+		"dir/Route.GPX": navigation.MissionFormatGPX,
+		"route.kml":     navigation.MissionFormatKML,
+	} {
+		actual, err := navigation.MissionFormatFromPath(path)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, actual, test.ShouldEqual, format)
+	}
+	_, err := navigation.MissionFormatFromPath("route.kmz")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestMissionRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store := navigation.NewMemoryNavigationStore()
+	for _, pt := range []*geo.Point{geo.NewPoint(40.1, -74.1), geo.NewPoint(40.2, -74.2), geo.NewPoint(40.3, -74.3)} {
+		_, err := store.AddWaypoint(ctx, pt)
+		test.That(t, err, test.ShouldBeNil)
+	}
+	first, err := store.NextWaypoint(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, store.WaypointVisited(ctx, first.ID), test.ShouldBeNil)
+	expected, err := store.AllWaypoints(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, expected, test.ShouldHaveLength, 3)
+	test.That(t, expected[0].Visited, test.ShouldBeTrue)
+
+	for _, format := range []navigation.MissionFormat{
+		navigation.MissionFormatGeoJSON,
+		navigation.MissionFormatGPX,
+		navigation.MissionFormatKML,
+	} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			test.That(t, navigation.ExportWaypoints(ctx, store, &buf, format), test.ShouldBeNil)
+
+			imported := navigation.NewMemoryNavigationStore()
+			wps, err := navigation.ImportWaypoints(ctx, imported, &buf, format)
+			test.That(t, err, test.ShouldBeNil)
+			actual, err := imported.AllWaypoints(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, actual, test.ShouldResemble, wps)
+			test.That(t, actual, test.ShouldHaveLength, len(expected))
+			for i, wp := range actual {
+				test.That(t, wp.ID, test.ShouldNotEqual, expected[i].ID)
+				test.That(t, wp.Visited, test.ShouldEqual, expected[i].Visited)
+				test.That(t, wp.Lat, test.ShouldEqual, expected[i].Lat)
+				test.That(t, wp.Long, test.ShouldEqual, expected[i].Long)
+			}
+
+			// the visited waypoint is not navigated to again
+			next, err := imported.NextWaypoint(ctx)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, next.ID, test.ShouldEqual, actual[1].ID)
+		})
+	}
+}
+
+func TestImportWaypointsOrder(t *testing.T) {
+	ctx := context.Background()
+	store := navigation.NewMemoryNavigationStore()
+	existing, err := store.AddWaypoint(ctx, geo.NewPoint(40, -74))
+	test.That(t, err, test.ShouldBeNil)
+
+	// waypoints of a higher order are navigated to first, and otherwise in the order they were added
+	wps, err := navigation.ImportWaypoints(ctx, store, strings.NewReader(`{
+		"type": "FeatureCollection",
+		"features": [
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-74.1, 40.1]}},
+			{"type": "Feature", "geometry": {"type": "Point", "coordinates": [-74.2, 40.2]}, "properties": {"order": 1}}
+		]
+	}`), navigation.MissionFormatGeoJSON)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, wps, test.ShouldHaveLength, 2)
+
+	all, err := store.AllWaypoints(ctx)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, all, test.ShouldResemble, []navigation.Waypoint{wps[1], existing, wps[0]})
+	test.That(t, all[0].Order, test.ShouldEqual, 1)
+}
+
+func TestDecodeWaypoints(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		format   navigation.MissionFormat
+		contents string
+	}{
+		{
+			"geojson line",
+			navigation.MissionFormatGeoJSON,
+			`{"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[-74.1, 40.1, 10], [-74.2, 40.2, 10]]}}`,
+		},
+		{
+			"gpx waypoints",
+			navigation.MissionFormatGPX,
+			`<?xml version="1.0"?>
+			<gpx version="1.1" creator="somewhere" xmlns="http://www.topografix.com/GPX/1/1">
+				<wpt lat="40.1" lon="-74.1"><name>one</name></wpt>
+				<wpt lat="40.2" lon="-74.2"><name>two</name></wpt>
+			</gpx>`,
+		},
+		{
+			"gpx route",
+			navigation.MissionFormatGPX,
+			`<gpx version="1.1" creator="somewhere" xmlns="http://www.topografix.com/GPX/1/1">
+				<wpt lat="1" lon="1"/>
+				<rte><name>survey</name><rtept lat="40.1" lon="-74.1"/><rtept lat="40.2" lon="-74.2"/></rte>
+			</gpx>`,
+		},
+		{
+			"kml folders",
+			navigation.MissionFormatKML,
+			`<kml xmlns="http://www.opengis.net/kml/2.2"><Document><Folder>
+				<Placemark><name>one</name><Point><coordinates>-74.1,40.1,0</coordinates></Point></Placemark>
+				<Folder><Placemark><Point><coordinates> -74.2,40.2 </coordinates></Point></Placemark></Folder>
+			</Folder></Document></kml>`,
+		},
+		{
+			"kml line",
+			navigation.MissionFormatKML,
+			`<kml xmlns="http://www.opengis.net/kml/2.2"><Placemark><LineString><coordinates>
+				-74.1,40.1,0
+				-74.2,40.2,0
+			</coordinates></LineString></Placemark></kml>`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			wps, err := navigation.DecodeWaypoints(strings.NewReader(tc.contents), tc.format)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, wps, test.ShouldResemble, []navigation.Waypoint{
+				{Lat: 40.1, Long: -74.1},
+				{Lat: 40.2, Long: -74.2},
+			})
+		})
+	}
+
+	_, err := navigation.DecodeWaypoints(strings.NewReader(`{"type": "Polygon"}`), navigation.MissionFormatGeoJSON)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = navigation.DecodeWaypoints(
+		strings.NewReader(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [40.1, -174.1]}}`),
+		navigation.MissionFormatGeoJSON,
+	)
+	test.That(t, err, test.ShouldNotBeNil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, "not a valid latitude and longitude")
+	_, err = navigation.DecodeWaypoints(strings.NewReader(`<gpx><wpt lat="x"/></gpx>`), navigation.MissionFormatGPX)
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = navigation.DecodeWaypoints(strings.NewReader(""), "shp")
+	test.That(t, err, test.ShouldNotBeNil)
+}
+
+func TestMissionActions(t *testing.T) {
+	actions := []navigation.WaypointAction{
+		{Type: navigation.WaypointActionDwell, DwellSec: 5},
+		{Type: navigation.WaypointActionFaceHeading, HeadingDeg: 270},
+		{Type: navigation.WaypointActionDoCommand, Resource: "rdk:component:camera/camera1", Command: map[string]interface{}{"snap": "hi"}},
+		{Type: navigation.WaypointActionSyncData, Resource: "data_manager1"},
+	}
+	wps := []navigation.Waypoint{{Lat: 40.1, Long: -74.1, Actions: actions}, {Lat: 40.2, Long: -74.2}}
+
+	for _, format := range []navigation.MissionFormat{navigation.MissionFormatGeoJSON, navigation.MissionFormatKML} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			test.That(t, navigation.EncodeWaypoints(&buf, format, wps), test.ShouldBeNil)
+			decoded, err := navigation.DecodeWaypoints(&buf, format)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, decoded, test.ShouldResemble, wps)
+		})
+	}
+
+	for _, tc := range []struct {
+		action navigation.WaypointAction
+		err    string
+	}{
+		{navigation.WaypointAction{}, `"type" is required`},
+		{navigation.WaypointAction{Type: "jump"}, "unknown waypoint action type"},
+		{navigation.WaypointAction{Type: navigation.WaypointActionDwell}, "dwell_sec must be positive"},
+		{navigation.WaypointAction{Type: navigation.WaypointActionFaceHeading, HeadingDeg: 360}, "heading_deg must be in"},
+		{navigation.WaypointAction{Type: navigation.WaypointActionDoCommand, Resource: "camera1"}, "not a valid resource name"},
+		{navigation.WaypointAction{Type: navigation.WaypointActionDoCommand, Resource: "rdk:component:camera/camera1"}, `"command" is required`},
+		{navigation.WaypointAction{Type: navigation.WaypointActionSyncData}, `"resource" is required`},
+	} {
+		_, err := navigation.NewMemoryNavigationStore().ImportWaypoints(
+			context.Background(),
+			[]navigation.Waypoint{{Lat: 40, Long: -74, Actions: []navigation.WaypointAction{tc.action}}},
+		)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, err.Error(), test.ShouldContainSubstring, tc.err)
+		test.That(t, err.Error(), test.ShouldContainSubstring, "waypoints.0.actions.0")
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"go.viam.com/utils"
 	"go.viam.com/utils/rpc"
 
+	"go.viam.com/rdk/components/generic"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/subtype"
@@ -22,12 +23,16 @@ import (
 func init() {
 	registry.RegisterResourceSubtype(Subtype, registry.ResourceSubtype{
 		RegisterRPCService: func(ctx context.Context, rpcServer rpc.Server, subtypeSvc subtype.Service) error {
-			return rpcServer.RegisterServiceServer(
+			server := NewServer(subtypeSvc)
+			if err := rpcServer.RegisterServiceServer(
 				ctx,
 				&servicepb.NavigationService_ServiceDesc,
-				NewServer(subtypeSvc),
+				server,
 				servicepb.RegisterNavigationServiceHandlerFromEndpoint,
-			)
+			); err != nil {
+				return err
+			}
+			return rpcServer.RegisterServiceServer(ctx, &CommandServiceDesc, server)
 		},
 		RPCServiceDesc: &servicepb.NavigationService_ServiceDesc,
 		RPCClient: func(ctx context.Context, conn rpc.ClientConn, name string, logger golog.Logger) interface{} {
@@ -44,8 +49,44 @@ type Mode uint8
 const (
 	ModeManual = Mode(iota)
 	ModeWaypoint
+	// ModePaused stops navigating to waypoints while keeping the progress of the mission, so that setting ModeWaypoint
+	// resumes it rather than starting a new one. The API has no paused mode, so over the network a paused service reports
+	// ModeManual and MissionStatePaused tells the two apart.
+	ModePaused
 )
 
+// MissionState is how far along a mission of navigating to waypoints is.
+type MissionState string
+
+// The set of known mission states.
+const (
+	// MissionStateIdle is before any mission has started.
+	MissionStateIdle = MissionState("idle")
+	// MissionStateRunning is while navigating to waypoints, including after being resumed.
+	MissionStateRunning = MissionState("running")
+	// MissionStatePaused is while paused by ModePaused.
+	MissionStatePaused = MissionState("paused")
+	// MissionStateAborted is after ModeManual was set before every waypoint was visited.
+	MissionStateAborted = MissionState("aborted")
+	// MissionStateCompleted is after every waypoint was visited, when the service goes back to ModeManual.
+	MissionStateCompleted = MissionState("completed")
+)
+
+// MissionProgress describes how far along the current or last mission is.
+type MissionProgress struct {
+	State MissionState
+	// Waypoint is the waypoint being navigated to or acted at, if any.
+	Waypoint *Waypoint
+	// Action is the index of the action of the waypoint being done, or -1 if the waypoint has not been reached.
+	Action int
+	// WaypointsReached is how many waypoints have been reached since the mission started.
+	WaypointsReached int
+	// WaypointsRemaining is how many waypoints have yet to be visited.
+	WaypointsRemaining int
+	// ActionErr is the last error doing an action, which does not stop the mission.
+	ActionErr error
+}
+
 // A Service controls the navigation for a robot.
 type Service interface {
 	Mode(ctx context.Context) (Mode, error)
@@ -57,6 +98,14 @@ type Service interface {
 	Waypoints(ctx context.Context) ([]Waypoint, error)
 	AddWaypoint(ctx context.Context, point *geo.Point) error
 	RemoveWaypoint(ctx context.Context, id primitive.ObjectID) error
+
+	// Geofence
+	Geofences(ctx context.Context) ([]Geofence, error)
+	AddGeofence(ctx context.Context, kind GeofenceKind, boundary []*geo.Point) error
+	RemoveGeofence(ctx context.Context, id primitive.ObjectID) error
+
+	// Mission
+	Progress(ctx context.Context) (MissionProgress, error)
 }
 
 var (
@@ -89,18 +138,18 @@ type Config struct {
 	MMPerSecDefault    float64     `json:"mm_per_sec"`
 }
 
-// Validate ensures all parts of the config are valid.
-func (config *Config) Validate(path string) error {
+// Validate ensures all parts of the config are valid, and returns the base and movement sensor it depends on.
+func (config *Config) Validate(path string) ([]string, error) {
 	if err := config.Store.Validate(fmt.Sprintf("%s.%s", path, "store")); err != nil {
-		return err
+		return nil, err
 	}
 	if config.BaseName == "" {
-		return utils.NewConfigValidationFieldRequiredError(path, "base")
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "base")
 	}
 	if config.MovementSensorName == "" {
-		return utils.NewConfigValidationFieldRequiredError(path, "movement_sensor")
+		return nil, utils.NewConfigValidationFieldRequiredError(path, "movement_sensor")
 	}
-	return nil
+	return []string{config.BaseName, config.MovementSensorName}, nil
 }
 
 type reconfigurableNavigation struct {
@@ -145,6 +194,44 @@ func (svc *reconfigurableNavigation) RemoveWaypoint(ctx context.Context, id prim
 	return svc.actual.RemoveWaypoint(ctx, id)
 }
 
+// Geofence.
+func (svc *reconfigurableNavigation) Geofences(ctx context.Context) ([]Geofence, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.Geofences(ctx)
+}
+
+func (svc *reconfigurableNavigation) AddGeofence(ctx context.Context, kind GeofenceKind, boundary []*geo.Point) error {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.AddGeofence(ctx, kind, boundary)
+}
+
+func (svc *reconfigurableNavigation) RemoveGeofence(ctx context.Context, id primitive.ObjectID) error {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.RemoveGeofence(ctx, id)
+}
+
+// Mission.
+func (svc *reconfigurableNavigation) Progress(ctx context.Context) (MissionProgress, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	return svc.actual.Progress(ctx)
+}
+
+// DoCommand sends the command to the navigation service if it takes commands, and otherwise does the navigation
+// command through its methods.
+func (svc *reconfigurableNavigation) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	svc.mu.RLock()
+	defer svc.mu.RUnlock()
+	doer, ok := svc.actual.(generic.Generic)
+	if !ok {
+		return DoCommand(ctx, svc.actual, cmd)
+	}
+	return doer.DoCommand(ctx, cmd)
+}
+
 func (svc *reconfigurableNavigation) Close(ctx context.Context) error {
 	svc.mu.RLock()
 	defer svc.mu.RUnlock()
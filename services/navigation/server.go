@@ -53,6 +53,10 @@ func (server *subtypeServer) GetMode(ctx context.Context, req *pb.GetModeRequest
 		protoMode = pb.Mode_MODE_MANUAL
 	case ModeWaypoint:
 		protoMode = pb.Mode_MODE_WAYPOINT
+	case ModePaused:
+		// the api has no paused mode, and a paused robot is not navigating just as in manual mode; clients tell the two
+		// apart through the state of the mission's progress
+		protoMode = pb.Mode_MODE_MANUAL
 	}
 	return &pb.GetModeResponse{
 		Mode: protoMode,
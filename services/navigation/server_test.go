@@ -11,6 +11,7 @@ import (
 	commonpb "go.viam.com/api/common/v1"
 	pb "go.viam.com/api/service/navigation/v1"
 	"go.viam.com/test"
+	"google.golang.org/protobuf/types/known/structpb"
 
 	"go.viam.com/rdk/resource"
 	"go.viam.com/rdk/services/navigation"
@@ -85,6 +86,15 @@ func TestServer(t *testing.T) {
 		test.That(t, err, test.ShouldBeNil)
 		test.That(t, resp.Mode, test.ShouldEqual, pb.Mode_MODE_WAYPOINT)
 
+		// paused mode is reported as manual, as the api has no paused mode
+		injectSvc.GetModeFunc = func(ctx context.Context) (navigation.Mode, error) {
+			return navigation.ModePaused, nil
+		}
+		req = &pb.GetModeRequest{Name: testSvcName1}
+		resp, err = navServer.GetMode(context.Background(), req)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp.Mode, test.ShouldEqual, pb.Mode_MODE_MANUAL)
+
 		// return unspecified mode when returned mode unrecognized
 		injectSvc.GetModeFunc = func(ctx context.Context) (navigation.Mode, error) {
 			return navigation.Mode(math.MaxUint8), nil
@@ -287,6 +297,98 @@ func TestServer(t *testing.T) {
 		test.That(t, resp, test.ShouldBeNil)
 	})
 
+	t.Run("do command", func(t *testing.T) {
+		cmdServer, ok := navServer.(navigation.CommandServer)
+		test.That(t, ok, test.ShouldBeTrue)
+
+		var receivedCmd map[string]interface{}
+		injectSvc.DoCommandFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+			receivedCmd = cmd
+			return map[string]interface{}{"state": "paused"}, nil
+		}
+		req, err := structpb.NewStruct(map[string]interface{}{
+			"name":    testSvcName1,
+			"command": map[string]interface{}{navigation.CommandKey: navigation.CommandPause},
+		})
+		test.That(t, err, test.ShouldBeNil)
+		resp, err := cmdServer.DoCommand(context.Background(), req)
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, receivedCmd, test.ShouldResemble, map[string]interface{}{navigation.CommandKey: navigation.CommandPause})
+		test.That(t, resp.AsMap(), test.ShouldResemble, map[string]interface{}{"state": "paused"})
+
+		injectSvc.DoCommandFunc = func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+			return nil, errors.New("do command failed")
+		}
+		resp, err = cmdServer.DoCommand(context.Background(), req)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, resp, test.ShouldBeNil)
+
+		req, err = structpb.NewStruct(map[string]interface{}{"name": "dne"})
+		test.That(t, err, test.ShouldBeNil)
+		resp, err = cmdServer.DoCommand(context.Background(), req)
+		test.That(t, err, test.ShouldNotBeNil)
+		test.That(t, resp, test.ShouldBeNil)
+	})
+
+	t.Run("geofence commands", func(t *testing.T) {
+		cmdServer, ok := navServer.(navigation.CommandServer)
+		test.That(t, ok, test.ShouldBeTrue)
+		injectSvc.DoCommandFunc = nil
+		doCommand := func(cmd map[string]interface{}) (map[string]interface{}, error) {
+			req, err := structpb.NewStruct(map[string]interface{}{"name": testSvcName1, "command": cmd})
+			test.That(t, err, test.ShouldBeNil)
+			resp, err := cmdServer.DoCommand(context.Background(), req)
+			if err != nil {
+				return nil, err
+			}
+			return resp.AsMap(), nil
+		}
+
+		boundary := []*geo.Point{geo.NewPoint(40, -74), geo.NewPoint(40, -73.9), geo.NewPoint(40.1, -73.9)}
+		geofence := navigation.NewGeofence(navigation.GeofenceKindAllowed, boundary)
+		geofence.ID = primitive.NewObjectID()
+		injectSvc.GetGeofencesFunc = func(ctx context.Context) ([]navigation.Geofence, error) {
+			return []navigation.Geofence{geofence}, nil
+		}
+		resp, err := doCommand(map[string]interface{}{navigation.CommandKey: navigation.CommandGetGeofences})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, resp["geofences"], test.ShouldHaveLength, 1)
+		received, err := navigation.GeofenceFromMap(resp["geofences"].([]interface{})[0].(map[string]interface{}))
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, received, test.ShouldResemble, geofence)
+
+		var receivedKind navigation.GeofenceKind
+		var receivedBoundary []*geo.Point
+		injectSvc.AddGeofenceFunc = func(ctx context.Context, kind navigation.GeofenceKind, boundary []*geo.Point) error {
+			receivedKind = kind
+			receivedBoundary = boundary
+			return nil
+		}
+		_, err = doCommand(map[string]interface{}{
+			navigation.CommandKey: navigation.CommandAddGeofence,
+			"geofence":            navigation.GeofenceToMap(geofence),
+		})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, receivedKind, test.ShouldEqual, navigation.GeofenceKindAllowed)
+		test.That(t, receivedBoundary, test.ShouldResemble, boundary)
+		_, err = doCommand(map[string]interface{}{navigation.CommandKey: navigation.CommandAddGeofence})
+		test.That(t, err, test.ShouldNotBeNil)
+
+		var receivedID primitive.ObjectID
+		injectSvc.RemoveGeofenceFunc = func(ctx context.Context, id primitive.ObjectID) error {
+			receivedID = id
+			return nil
+		}
+		_, err = doCommand(map[string]interface{}{navigation.CommandKey: navigation.CommandRemoveGeofence, "id": geofence.ID.Hex()})
+		test.That(t, err, test.ShouldBeNil)
+		test.That(t, receivedID, test.ShouldEqual, geofence.ID)
+		_, err = doCommand(map[string]interface{}{navigation.CommandKey: navigation.CommandRemoveGeofence, "id": "nope"})
+		test.That(t, err, test.ShouldNotBeNil)
+
+		_, err = doCommand(map[string]interface{}{navigation.CommandKey: "dance"})
+		test.That(t, err, test.ShouldNotBeNil)
+	})
+
 	resourceMap = map[resource.Name]interface{}{
 		navigation.Named(testSvcName1): "not a frame system",
 	}
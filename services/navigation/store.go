@@ -3,6 +3,8 @@ package navigation
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 	"go.uber.org/multierr"
+	"go.viam.com/utils"
 	mongoutils "go.viam.com/utils/mongo"
 )
 
@@ -26,6 +29,15 @@ type NavStore interface {
 	RemoveWaypoint(ctx context.Context, id primitive.ObjectID) error
 	NextWaypoint(ctx context.Context) (Waypoint, error)
 	WaypointVisited(ctx context.Context, id primitive.ObjectID) error
+	// AllWaypoints returns every waypoint, visited or not, in the order they are navigated to.
+	AllWaypoints(ctx context.Context) ([]Waypoint, error)
+	// ImportWaypoints adds the waypoints, keeping whether they are visited and their order, and returns them with their
+	// new IDs.
+	ImportWaypoints(ctx context.Context, wps []Waypoint) ([]Waypoint, error)
+
+	Geofences(ctx context.Context) ([]Geofence, error)
+	AddGeofence(ctx context.Context, geofence Geofence) (Geofence, error)
+	RemoveGeofence(ctx context.Context, id primitive.ObjectID) error
 }
 
 type storeType string
@@ -35,6 +47,8 @@ const (
 	StoreTypeMemory = "memory"
 	// StoreTypeMongoDB is the constant for the mongodb store type.
 	StoreTypeMongoDB = "mongodb"
+	// StoreTypeFile is the constant for the file store type.
+	StoreTypeFile = "file"
 )
 
 // StoreConfig describes how to configure data storage.
@@ -46,7 +60,7 @@ type StoreConfig struct {
 // Validate ensures all parts of the config are valid.
 func (config *StoreConfig) Validate(path string) error {
 	switch config.Type {
-	case StoreTypeMemory, StoreTypeMongoDB:
+	case StoreTypeMemory, StoreTypeMongoDB, StoreTypeFile:
 	default:
 		return errors.Errorf("unknown store type %q", config.Type)
 	}
@@ -60,6 +74,24 @@ type Waypoint struct {
 	Order   int                `bson:"order"`
 	Lat     float64            `bson:"latitude"`
 	Long    float64            `bson:"longitude"`
+	// Actions are done in order once the waypoint is reached, before it is visited.
+	Actions []WaypointAction `bson:"actions,omitempty"`
+}
+
+// Validate ensures the waypoint is at a valid location and its actions are valid.
+func (wp *Waypoint) Validate(path string) error {
+	if wp.Lat < -90 || wp.Lat > 90 || wp.Long < -180 || wp.Long > 180 {
+		return utils.NewConfigValidationError(
+			path,
+			errors.Errorf("(%v, %v) is not a valid latitude and longitude", wp.Lat, wp.Long),
+		)
+	}
+	for i, action := range wp.Actions {
+		if err := action.Validate(fmt.Sprintf("%s.%s.%d", path, "actions", i)); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ToPoint converts the waypoint to a geo.Point.
@@ -76,6 +108,7 @@ func NewMemoryNavigationStore() *MemoryNavigationStore {
 type MemoryNavigationStore struct {
 	mu        sync.RWMutex
 	waypoints []*Waypoint
+	geofences []Geofence
 }
 
 // Waypoints returns a copy of all of the waypoints in the MemoryNavigationStore.
@@ -95,22 +128,67 @@ func (store *MemoryNavigationStore) Waypoints(ctx context.Context) ([]Waypoint,
 
 // AddWaypoint adds a waypoint to the MemoryNavigationStore.
 func (store *MemoryNavigationStore) AddWaypoint(ctx context.Context, point *geo.Point) (Waypoint, error) {
-	store.mu.Lock()
-	defer store.mu.Unlock()
 	newPoint := Waypoint{
 		ID:   primitive.NewObjectID(),
 		Lat:  point.Lat(),
 		Long: point.Lng(),
 	}
-	store.waypoints = append(store.waypoints, &newPoint)
+	store.insertWaypoints([]Waypoint{newPoint})
 	return newPoint, nil
 }
 
+// AllWaypoints returns a copy of all of the waypoints in the MemoryNavigationStore, including those visited.
+func (store *MemoryNavigationStore) AllWaypoints(ctx context.Context) ([]Waypoint, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	wps := make([]Waypoint, 0, len(store.waypoints))
+	for _, wp := range store.waypoints {
+		wps = append(wps, *wp)
+	}
+	return wps, nil
+}
+
+// ImportWaypoints adds the waypoints to the MemoryNavigationStore.
+func (store *MemoryNavigationStore) ImportWaypoints(ctx context.Context, wps []Waypoint) ([]Waypoint, error) {
+	for i, wp := range wps {
+		if err := wp.Validate(fmt.Sprintf("waypoints.%d", i)); err != nil {
+			return nil, err
+		}
+	}
+	imported := withNewIDs(wps)
+	store.insertWaypoints(imported)
+	return imported, nil
+}
+
+// withNewIDs returns a copy of the waypoints with new IDs.
+func withNewIDs(wps []Waypoint) []Waypoint {
+	withIDs := make([]Waypoint, 0, len(wps))
+	for _, wp := range wps {
+		wp.ID = primitive.NewObjectID()
+		withIDs = append(withIDs, wp)
+	}
+	return withIDs
+}
+
+// insertWaypoints adds the waypoints, which already have IDs, keeping the waypoints in the order they are navigated
+// to: highest order first, then oldest first, as the MongoDBNavigationStore does.
+func (store *MemoryNavigationStore) insertWaypoints(wps []Waypoint) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, wp := range wps {
+		wpCopy := wp
+		store.waypoints = append(store.waypoints, &wpCopy)
+	}
+	sort.SliceStable(store.waypoints, func(i, j int) bool {
+		return store.waypoints[i].Order > store.waypoints[j].Order
+	})
+}
+
 // RemoveWaypoint removes a waypoint from the MemoryNavigationStore.
 func (store *MemoryNavigationStore) RemoveWaypoint(ctx context.Context, id primitive.ObjectID) error {
 	store.mu.Lock()
 	defer store.mu.Unlock()
-	newWps := make([]*Waypoint, 0, len(store.waypoints)-1)
+	newWps := make([]*Waypoint, 0, len(store.waypoints))
 	for _, wp := range store.waypoints {
 		if wp.ID == id {
 			continue
@@ -146,11 +224,57 @@ func (store *MemoryNavigationStore) WaypointVisited(ctx context.Context, id prim
 	return nil
 }
 
+// Geofences returns a copy of all of the geofences in the MemoryNavigationStore.
+func (store *MemoryNavigationStore) Geofences(ctx context.Context) ([]Geofence, error) {
+	store.mu.RLock()
+	defer store.mu.RUnlock()
+	gfs := make([]Geofence, 0, len(store.geofences))
+	for _, gf := range store.geofences {
+		gf.Vertices = append([]GeofenceVertex{}, gf.Vertices...)
+		gfs = append(gfs, gf)
+	}
+	return gfs, nil
+}
+
+// AddGeofence adds a geofence to the MemoryNavigationStore.
+func (store *MemoryNavigationStore) AddGeofence(ctx context.Context, geofence Geofence) (Geofence, error) {
+	if err := geofence.Validate("geofence"); err != nil {
+		return Geofence{}, err
+	}
+	geofence.ID = primitive.NewObjectID()
+	store.insertGeofence(geofence)
+	return geofence, nil
+}
+
+// insertGeofence adds a copy of the geofence, which already has an ID.
+func (store *MemoryNavigationStore) insertGeofence(geofence Geofence) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	geofence.Vertices = append([]GeofenceVertex{}, geofence.Vertices...)
+	store.geofences = append(store.geofences, geofence)
+}
+
+// RemoveGeofence removes a geofence from the MemoryNavigationStore.
+func (store *MemoryNavigationStore) RemoveGeofence(ctx context.Context, id primitive.ObjectID) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	newGfs := make([]Geofence, 0, len(store.geofences))
+	for _, gf := range store.geofences {
+		if gf.ID == id {
+			continue
+		}
+		newGfs = append(newGfs, gf)
+	}
+	store.geofences = newGfs
+	return nil
+}
+
 // Database and collection names used by the MongoDBNavigationStore.
 var (
 	defaultMongoDBURI                = "mongodb://127.0.0.1:27017"
 	MongoDBNavStoreDBName            = "navigation"
 	MongoDBNavStoreWaypointsCollName = "waypoints"
+	MongoDBNavStoreGeofencesCollName = "geofences"
 	mongoDBNavStoreIndexes           = []mongo.IndexModel{
 		{
 			Keys: bson.D{
@@ -183,17 +307,20 @@ func NewMongoDBNavigationStore(ctx context.Context, config map[string]interface{
 	if err := mongoutils.EnsureIndexes(waypoints, mongoDBNavStoreIndexes...); err != nil {
 		return nil, err
 	}
+	geofences := mongoClient.Database(MongoDBNavStoreDBName).Collection(MongoDBNavStoreGeofencesCollName)
 
 	return &MongoDBNavigationStore{
 		mongoClient:   mongoClient,
 		waypointsColl: waypoints,
+		geofencesColl: geofences,
 	}, nil
 }
 
-// MongoDBNavigationStore holds the mongodb client and waypoints collection.
+// MongoDBNavigationStore holds the mongodb client and the waypoints and geofences collections.
 type MongoDBNavigationStore struct {
 	mongoClient   *mongo.Client
 	waypointsColl *mongo.Collection
+	geofencesColl *mongo.Collection
 }
 
 // Close closes the connection with the mongodb client.
@@ -233,6 +360,48 @@ func (store *MongoDBNavigationStore) AddWaypoint(ctx context.Context, point *geo
 	return newPoint, nil
 }
 
+// AllWaypoints returns all the waypoints in the MongoDBNavigationStore, including those visited.
+func (store *MongoDBNavigationStore) AllWaypoints(ctx context.Context) ([]Waypoint, error) {
+	cursor, err := store.waypointsColl.Find(
+		ctx,
+		bson.D{},
+		options.Find().SetSort(bson.D{{"order", -1}, {"_id", 1}}),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Waypoint
+	if err := cursor.All(ctx, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// ImportWaypoints adds the waypoints to the MongoDBNavigationStore.
+func (store *MongoDBNavigationStore) ImportWaypoints(ctx context.Context, wps []Waypoint) ([]Waypoint, error) {
+	if len(wps) == 0 {
+		return nil, nil
+	}
+	for i, wp := range wps {
+		if err := wp.Validate(fmt.Sprintf("waypoints.%d", i)); err != nil {
+			return nil, err
+		}
+	}
+	imported := make([]Waypoint, 0, len(wps))
+	docs := make([]interface{}, 0, len(wps))
+	for _, wp := range wps {
+		// object ids made in turn increase, so the waypoints keep their order among those of the same order
+		wp.ID = primitive.NewObjectID()
+		imported = append(imported, wp)
+		docs = append(docs, wp)
+	}
+	if _, err := store.waypointsColl.InsertMany(ctx, docs, options.InsertMany().SetOrdered(true)); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
 // RemoveWaypoint removes a waypoint from the MongoDBNavigationStore.
 func (store *MongoDBNavigationStore) RemoveWaypoint(ctx context.Context, id primitive.ObjectID) error {
 	_, err := store.waypointsColl.DeleteOne(ctx, bson.D{{"_id", id}})
@@ -263,3 +432,35 @@ func (store *MongoDBNavigationStore) WaypointVisited(ctx context.Context, id pri
 	_, err := store.waypointsColl.UpdateOne(ctx, bson.D{{"_id", id}}, bson.D{{"$set", bson.D{{"visited", true}}}})
 	return err
 }
+
+// Geofences returns all the geofences in the MongoDBNavigationStore.
+func (store *MongoDBNavigationStore) Geofences(ctx context.Context) ([]Geofence, error) {
+	cursor, err := store.geofencesColl.Find(ctx, bson.D{}, options.Find().SetSort(bson.D{{"_id", 1}}))
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Geofence
+	if err := cursor.All(ctx, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// AddGeofence adds a geofence to the MongoDBNavigationStore.
+func (store *MongoDBNavigationStore) AddGeofence(ctx context.Context, geofence Geofence) (Geofence, error) {
+	if err := geofence.Validate("geofence"); err != nil {
+		return Geofence{}, err
+	}
+	geofence.ID = primitive.NewObjectID()
+	if _, err := store.geofencesColl.InsertOne(ctx, geofence); err != nil {
+		return Geofence{}, err
+	}
+	return geofence, nil
+}
+
+// RemoveGeofence removes a geofence from the MongoDBNavigationStore.
+func (store *MongoDBNavigationStore) RemoveGeofence(ctx context.Context, id primitive.ObjectID) error {
+	_, err := store.geofencesColl.DeleteOne(ctx, bson.D{{"_id", id}})
+	return err
+}
@@ -14,6 +14,7 @@ import (
 	"go.viam.com/rdk/referenceframe"
 	"go.viam.com/rdk/registry"
 	"go.viam.com/rdk/resource"
+	"go.viam.com/rdk/robot"
 	"go.viam.com/rdk/subtype"
 	"go.viam.com/rdk/utils"
 	"go.viam.com/rdk/vision"
@@ -58,6 +59,19 @@ func Named(name string) resource.Name {
 	return resource.NameFromSubtype(Subtype, name)
 }
 
+// FromRobot is a helper for getting the named slam service from the given Robot.
+func FromRobot(r robot.Robot, name string) (Service, error) {
+	resource, err := r.ResourceByName(Named(name))
+	if err != nil {
+		return nil, utils.NewResourceNotFoundError(Named(name))
+	}
+	svc, ok := resource.(Service)
+	if !ok {
+		return nil, NewUnimplementedInterfaceError(resource)
+	}
+	return svc, nil
+}
+
 var (
 	_ = Service(&reconfigurableSlam{})
 	_ = resource.Reconfigurable(&reconfigurableSlam{})
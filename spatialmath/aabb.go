@@ -0,0 +1,93 @@
+package spatialmath
+
+import (
+	"math"
+
+	"github.com/golang/geo/r3"
+)
+
+// AABB is an axis-aligned bounding box, defined by its minimum and maximum corners in world coordinates.
+type AABB struct {
+	Min r3.Vector
+	Max r3.Vector
+}
+
+// NewAABB returns the smallest axis-aligned box which contains the given geometry.
+func NewAABB(g Geometry) (AABB, error) {
+	switch geometry := g.(type) {
+	case *sphere:
+		return aabbFromPoints([]r3.Vector{geometry.pose.Point()}).Expand(geometry.radius), nil
+	case *capsule:
+		return aabbFromPoints([]r3.Vector{geometry.segA, geometry.segB}).Expand(geometry.radius), nil
+	case *box:
+		// each row of the rotation matrix is an axis of the box, whose half size projects onto each axis of the world
+		rm := geometry.pose.Orientation().RotationMatrix()
+		var extent [3]float64
+		for i := range extent {
+			for j, halfSize := range geometry.halfSize {
+				extent[i] += math.Abs(rm.At(j, i)) * halfSize
+			}
+		}
+		center := geometry.pose.Point()
+		offset := r3.Vector{X: extent[0], Y: extent[1], Z: extent[2]}
+		return AABB{center.Sub(offset), center.Add(offset)}, nil
+	case *point, *mesh:
+		return aabbFromPoints(geometry.Vertices()), nil
+	default:
+		return AABB{}, newAABBTypeUnsupportedError(g)
+	}
+}
+
+func aabbFromPoints(points []r3.Vector) AABB {
+	bounds := AABB{
+		Min: r3.Vector{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)},
+		Max: r3.Vector{X: math.Inf(-1), Y: math.Inf(-1), Z: math.Inf(-1)},
+	}
+	for _, pt := range points {
+		bounds.Min = r3.Vector{X: math.Min(bounds.Min.X, pt.X), Y: math.Min(bounds.Min.Y, pt.Y), Z: math.Min(bounds.Min.Z, pt.Z)}
+		bounds.Max = r3.Vector{X: math.Max(bounds.Max.X, pt.X), Y: math.Max(bounds.Max.Y, pt.Y), Z: math.Max(bounds.Max.Z, pt.Z)}
+	}
+	return bounds
+}
+
+// Expand returns the AABB grown by the given margin in every direction.
+func (a AABB) Expand(margin float64) AABB {
+	offset := r3.Vector{X: margin, Y: margin, Z: margin}
+	return AABB{a.Min.Sub(offset), a.Max.Add(offset)}
+}
+
+// Union returns the smallest AABB which contains both AABBs.
+func (a AABB) Union(b AABB) AABB {
+	return aabbFromPoints([]r3.Vector{a.Min, a.Max, b.Min, b.Max})
+}
+
+// Overlaps returns true if the two AABBs intersect or touch.
+func (a AABB) Overlaps(b AABB) bool {
+	return a.Min.X <= b.Max.X && b.Min.X <= a.Max.X &&
+		a.Min.Y <= b.Max.Y && b.Min.Y <= a.Max.Y &&
+		a.Min.Z <= b.Max.Z && b.Min.Z <= a.Max.Z
+}
+
+// Distance returns the separation distance between the two AABBs, which is zero if they overlap. Since each AABB contains
+// its geometry, this is a lower bound on the distance between the geometries they bound.
+func (a AABB) Distance(b AABB) float64 {
+	gap := func(aMin, aMax, bMin, bMax float64) float64 {
+		return math.Max(0, math.Max(bMin-aMax, aMin-bMax))
+	}
+	return r3.Vector{
+		X: gap(a.Min.X, a.Max.X, b.Min.X, b.Max.X),
+		Y: gap(a.Min.Y, a.Max.Y, b.Min.Y, b.Max.Y),
+		Z: gap(a.Min.Z, a.Max.Z, b.Min.Z, b.Max.Z),
+	}.Norm()
+}
+
+// Center returns the point at the center of the AABB.
+func (a AABB) Center() r3.Vector {
+	return a.Min.Add(a.Max).Mul(0.5)
+}
+
+// SurfaceArea returns the surface area of the AABB.
+func (a AABB) SurfaceArea() float64 {
+	d := a.Max.Sub(a.Min)
+	return 2 * (d.X*d.Y + d.Y*d.Z + d.Z*d.X)
+}
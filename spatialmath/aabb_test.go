@@ -0,0 +1,81 @@
+package spatialmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+)
+
+func TestNewAABB(t *testing.T) {
+	cases := []struct {
+		name     string
+		geometry Geometry
+		expected AABB
+	}{
+		{
+			"point",
+			NewPoint(r3.Vector{1, 2, 3}),
+			AABB{r3.Vector{1, 2, 3}, r3.Vector{1, 2, 3}},
+		},
+		{
+			"sphere",
+			makeTestSphere(r3.Vector{1, 2, 3}, 2),
+			AABB{r3.Vector{-1, 0, 1}, r3.Vector{3, 4, 5}},
+		},
+		{
+			"rotated box",
+			makeTestBox(&EulerAngles{0, 0, math.Pi / 4}, r3.Vector{}, r3.Vector{2, 2, 2}),
+			AABB{r3.Vector{-math.Sqrt2, -math.Sqrt2, -1}, r3.Vector{math.Sqrt2, math.Sqrt2, 1}},
+		},
+		{
+			"capsule",
+			makeTestCapsule(NewZeroOrientation(), r3.Vector{0, 0, 10}, 1, 6),
+			AABB{r3.Vector{-1, -1, 7}, r3.Vector{1, 1, 13}},
+		},
+		{
+			"mesh",
+			makeTestCube(t, NewZeroOrientation(), r3.Vector{100, 0, 0}),
+			AABB{r3.Vector{50, -50, -50}, r3.Vector{150, 50, 50}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			bounds, err := NewAABB(c.geometry)
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, R3VectorAlmostEqual(bounds.Min, c.expected.Min, 1e-8), test.ShouldBeTrue)
+			test.That(t, R3VectorAlmostEqual(bounds.Max, c.expected.Max, 1e-8), test.ShouldBeTrue)
+		})
+	}
+
+	// the bounds of a box fit its vertices however it is oriented
+	for _, o := range []Orientation{&EulerAngles{0.3, -1.2, 2.5}, &OrientationVectorDegrees{OX: 1, OY: 2, OZ: -1, Theta: 70}} {
+		box := makeTestBox(o, r3.Vector{10, -5, 3}, r3.Vector{4, 2, 7})
+		bounds, err := NewAABB(box)
+		test.That(t, err, test.ShouldBeNil)
+		expected := aabbFromPoints(box.Vertices())
+		test.That(t, R3VectorAlmostEqual(bounds.Min, expected.Min, 1e-8), test.ShouldBeTrue)
+		test.That(t, R3VectorAlmostEqual(bounds.Max, expected.Max, 1e-8), test.ShouldBeTrue)
+	}
+}
+
+func TestAABBOperations(t *testing.T) {
+	a := AABB{r3.Vector{0, 0, 0}, r3.Vector{1, 1, 1}}
+	b := AABB{r3.Vector{1, 0, 0}, r3.Vector{2, 1, 1}}
+	c := AABB{r3.Vector{4, 5, 0}, r3.Vector{5, 6, 1}}
+
+	test.That(t, a.Overlaps(b), test.ShouldBeTrue)
+	test.That(t, a.Overlaps(c), test.ShouldBeFalse)
+	test.That(t, a.Distance(b), test.ShouldEqual, 0)
+	test.That(t, a.Distance(c), test.ShouldAlmostEqual, 5)
+	test.That(t, c.Distance(a), test.ShouldAlmostEqual, 5)
+
+	union := a.Union(c)
+	test.That(t, union.Min, test.ShouldResemble, r3.Vector{0, 0, 0})
+	test.That(t, union.Max, test.ShouldResemble, r3.Vector{5, 6, 1})
+	test.That(t, union.Center(), test.ShouldResemble, r3.Vector{2.5, 3, 0.5})
+	test.That(t, a.SurfaceArea(), test.ShouldEqual, 6)
+	test.That(t, a.Expand(1).Overlaps(c), test.ShouldBeFalse)
+	test.That(t, a.Expand(4).Overlaps(c), test.ShouldBeTrue)
+}
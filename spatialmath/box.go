@@ -115,6 +115,12 @@ func (b *box) CollidesWith(g Geometry) (bool, error) {
 	if other, ok := g.(*point); ok {
 		return pointVsBoxCollision(b, other.pose.Point()), nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsBoxDistance(other, b) <= 0, nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.CollidesWith(b)
+	}
 	return true, newCollisionTypeUnsupportedError(b, g)
 }
 
@@ -129,6 +135,12 @@ func (b *box) DistanceFrom(g Geometry) (float64, error) {
 	if other, ok := g.(*point); ok {
 		return pointVsBoxDistance(b, other.pose.Point()), nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsBoxDistance(other, b), nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.DistanceFrom(b)
+	}
 	return math.Inf(-1), newCollisionTypeUnsupportedError(b, g)
 }
 
@@ -139,6 +151,12 @@ func (b *box) EncompassedBy(g Geometry) (bool, error) {
 	if other, ok := g.(*sphere); ok {
 		return boxInSphere(b, other), nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return boxInCapsule(b, other), nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.encompasses(b)
+	}
 	if _, ok := g.(*point); ok {
 		return false, nil
 	}
@@ -270,6 +288,16 @@ func boxInSphere(b *box, s *sphere) bool {
 	return sphereVsPointDistance(s, b.pose.Point()) <= 0
 }
 
+// boxInCapsule returns a bool describing if the given box is completely encompassed by the given capsule.
+func boxInCapsule(b *box, c *capsule) bool {
+	for _, vertex := range b.Vertices() {
+		if capsuleVsPointDistance(c, vertex) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
 // separatingAxisTest projects two boxes onto the given plane and compute how much distance is between them along
 // this plane.  Per the separating hyperplane theorem, if such a plane exists (and a positive number is returned)
 // this proves that there is no collision between the boxes
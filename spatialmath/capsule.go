@@ -0,0 +1,267 @@
+package spatialmath
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/golang/geo/r3"
+	commonpb "go.viam.com/api/common/v1"
+
+	"go.viam.com/rdk/utils"
+)
+
+// capsuleCreator implements the GeometryCreator interface for capsule structs.
+type capsuleCreator struct {
+	radius float64
+	length float64
+	pointCreator
+}
+
+// capsule is a collision geometry that represents a cylinder capped with two hemispheres. It is centered on its pose and
+// oriented along the pose's Z axis, and is fully defined by its radius and its total length, including both hemispheres.
+type capsule struct {
+	pose   Pose
+	radius float64
+	length float64
+
+	// endpoints of the capsule's central segment, in world coordinates
+	segA r3.Vector
+	segB r3.Vector
+}
+
+// NewCapsuleCreator instantiates a CapsuleCreator class, which allows instantiating capsules given only a pose which is applied
+// at the specified offset from the pose. These capsules have the given radius and total length, which must be at least twice the radius.
+func NewCapsuleCreator(radius, length float64, offset Pose) (GeometryCreator, error) {
+	if radius <= 0 || length < 2*radius {
+		return nil, newBadGeometryDimensionsError(&capsule{})
+	}
+	return &capsuleCreator{radius, length, pointCreator{offset}}, nil
+}
+
+// NewGeometry instantiates a new capsule from a CapsuleCreator class.
+func (cc *capsuleCreator) NewGeometry(pose Pose) Geometry {
+	return newCapsule(Compose(cc.offset, pose), cc.radius, cc.length)
+}
+
+func (cc *capsuleCreator) MarshalJSON() ([]byte, error) {
+	config, err := NewGeometryConfig(cc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(config)
+}
+
+// NewCapsule instantiates a new capsule Geometry.
+func NewCapsule(pose Pose, radius, length float64) (Geometry, error) {
+	if radius < 0 || length < 2*radius {
+		return nil, newBadGeometryDimensionsError(&capsule{})
+	}
+	return newCapsule(pose, radius, length), nil
+}
+
+func newCapsule(pose Pose, radius, length float64) *capsule {
+	halfSegment := NewPoseFromPoint(r3.Vector{Z: length/2 - radius})
+	return &capsule{
+		pose:   pose,
+		radius: radius,
+		length: length,
+		segA:   Compose(pose, PoseInverse(halfSegment)).Point(),
+		segB:   Compose(pose, halfSegment).Point(),
+	}
+}
+
+// Pose returns the pose of the capsule.
+func (c *capsule) Pose() Pose {
+	return c.pose
+}
+
+// Vertices returns the endpoints of the segment at the center of the capsule (as with a sphere, the bounding geometry of a capsule
+// cannot be described by a finite number of points, so these should be used along with the known radius).
+func (c *capsule) Vertices() []r3.Vector {
+	return []r3.Vector{c.segA, c.segB}
+}
+
+// AlmostEqual compares the capsule with another geometry and checks if they are equivalent.
+func (c *capsule) AlmostEqual(g Geometry) bool {
+	other, ok := g.(*capsule)
+	if !ok {
+		return false
+	}
+	return PoseAlmostEqual(c.pose, other.pose) &&
+		utils.Float64AlmostEqual(c.radius, other.radius, 1e-8) &&
+		utils.Float64AlmostEqual(c.length, other.length, 1e-8)
+}
+
+// Transform premultiplies the capsule pose with a transform, allowing the capsule to be moved in space.
+func (c *capsule) Transform(toPremultiply Pose) Geometry {
+	return newCapsule(Compose(toPremultiply, c.pose), c.radius, c.length)
+}
+
+// ToProtobuf converts the capsule to a Geometry proto message. The Geometry message has no capsule type, so the capsule is
+// represented by the smallest box, in the capsule's frame, which contains it.
+func (c *capsule) ToProtobuf() *commonpb.Geometry {
+	return &commonpb.Geometry{
+		Center: PoseToProtobuf(c.pose),
+		GeometryType: &commonpb.Geometry_Box{
+			Box: &commonpb.RectangularPrism{DimsMm: &commonpb.Vector3{
+				X: 2 * c.radius,
+				Y: 2 * c.radius,
+				Z: c.length,
+			}},
+		},
+	}
+}
+
+// CollidesWith checks if the given capsule collides with the given geometry and returns true if it does.
+func (c *capsule) CollidesWith(g Geometry) (bool, error) {
+	distance, err := c.DistanceFrom(g)
+	if err != nil {
+		return true, err
+	}
+	return distance <= 0, nil
+}
+
+// DistanceFrom returns the distance between the capsule and the given geometry. If the returned value is nonpositive it
+// represents the penetration depth of the two geometries.
+func (c *capsule) DistanceFrom(g Geometry) (float64, error) {
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsCapsuleDistance(c, other), nil
+	}
+	if other, ok := g.(*sphere); ok {
+		return capsuleVsSphereDistance(c, other), nil
+	}
+	if other, ok := g.(*box); ok {
+		return capsuleVsBoxDistance(c, other), nil
+	}
+	if other, ok := g.(*point); ok {
+		return capsuleVsPointDistance(c, other.pose.Point()), nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.DistanceFrom(c)
+	}
+	return math.Inf(-1), newCollisionTypeUnsupportedError(c, g)
+}
+
+// EncompassedBy returns a bool describing if the given capsule is completely encompassed by the given geometry.
+// Since a capsule is the convex hull of the spheres at either end of its segment, it is encompassed by a convex
+// geometry if and only if both of these spheres are.
+func (c *capsule) EncompassedBy(g Geometry) (bool, error) {
+	if _, ok := g.(*point); ok {
+		return false, nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.encompasses(c)
+	}
+	for _, end := range c.Vertices() {
+		encompassed, err := (&sphere{NewPoseFromPoint(end), c.radius}).EncompassedBy(g)
+		if err != nil || !encompassed {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// capsuleVsPointDistance takes a capsule and a point as arguments and returns a floating point number. If this number is nonpositive it
+// represents the penetration depth of the point within the capsule.  If the returned float is positive it represents the separation
+// distance between the point and the capsule, which are not in collision.
+func capsuleVsPointDistance(c *capsule, pt r3.Vector) float64 {
+	return closestPointSegmentPoint(c.segA, c.segB, pt).Sub(pt).Norm() - c.radius
+}
+
+// capsuleVsSphereDistance returns the separation distance, or penetration depth if nonpositive, of a capsule and a sphere.
+func capsuleVsSphereDistance(c *capsule, s *sphere) float64 {
+	return capsuleVsPointDistance(c, s.pose.Point()) - s.radius
+}
+
+// capsuleVsCapsuleDistance returns the separation distance, or penetration depth if nonpositive, of two capsules.
+func capsuleVsCapsuleDistance(a, b *capsule) float64 {
+	return segmentVsSegmentDistance(a.segA, a.segB, b.segA, b.segB) - a.radius - b.radius
+}
+
+// capsuleVsBoxDistance returns the separation distance, or penetration depth if nonpositive, of a capsule and a box.
+// The signed distance from a box is a convex function, so its minimum along the capsule's segment can be found with a
+// golden-section search.
+func capsuleVsBoxDistance(c *capsule, b *box) float64 {
+	segment := c.segB.Sub(c.segA)
+	distance := func(t float64) float64 {
+		return pointVsBoxDistance(b, c.segA.Add(segment.Mul(t)))
+	}
+	return goldenSectionMinimum(distance, 0, 1, 1e-6) - c.radius
+}
+
+// closestPointSegmentPoint returns the point on the segment from a to b which is closest to pt.
+func closestPointSegmentPoint(a, b, pt r3.Vector) r3.Vector {
+	ab := b.Sub(a)
+	lengthSquared := ab.Norm2()
+	if lengthSquared == 0 {
+		return a
+	}
+	t := math.Max(0, math.Min(1, pt.Sub(a).Dot(ab)/lengthSquared))
+	return a.Add(ab.Mul(t))
+}
+
+// closestPointsSegmentSegment returns the closest pair of points on the segments p1-q1 and p2-q2.
+// Reference: Real-Time Collision Detection, Christer Ericson, section 5.1.9.
+func closestPointsSegmentSegment(p1, q1, p2, q2 r3.Vector) (r3.Vector, r3.Vector) {
+	const epsilon = 1e-12
+	d1 := q1.Sub(p1)
+	d2 := q2.Sub(p2)
+	r := p1.Sub(p2)
+	a := d1.Norm2()
+	e := d2.Norm2()
+	f := d2.Dot(r)
+
+	var s, t float64
+	switch {
+	case a <= epsilon && e <= epsilon:
+		return p1, p2
+	case a <= epsilon:
+		t = math.Max(0, math.Min(1, f/e))
+	default:
+		c := d1.Dot(r)
+		if e <= epsilon {
+			s = math.Max(0, math.Min(1, -c/a))
+		} else {
+			b := d1.Dot(d2)
+			denom := a*e - b*b
+			if denom != 0 {
+				s = math.Max(0, math.Min(1, (b*f-c*e)/denom))
+			}
+			t = (b*s + f) / e
+			if t < 0 {
+				t = 0
+				s = math.Max(0, math.Min(1, -c/a))
+			} else if t > 1 {
+				t = 1
+				s = math.Max(0, math.Min(1, (b-c)/a))
+			}
+		}
+	}
+	return p1.Add(d1.Mul(s)), p2.Add(d2.Mul(t))
+}
+
+// segmentVsSegmentDistance returns the shortest distance between the segments p1-q1 and p2-q2.
+func segmentVsSegmentDistance(p1, q1, p2, q2 r3.Vector) float64 {
+	c1, c2 := closestPointsSegmentSegment(p1, q1, p2, q2)
+	return c1.Sub(c2).Norm()
+}
+
+// goldenSectionMinimum returns the minimum value of a unimodal function f on the interval [lo, hi].
+func goldenSectionMinimum(f func(float64) float64, lo, hi, tolerance float64) float64 {
+	invPhi := (math.Sqrt(5) - 1) / 2
+	x1 := hi - invPhi*(hi-lo)
+	x2 := lo + invPhi*(hi-lo)
+	f1, f2 := f(x1), f(x2)
+	for hi-lo > tolerance {
+		if f1 < f2 {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - invPhi*(hi-lo)
+			f1 = f(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + invPhi*(hi-lo)
+			f2 = f(x2)
+		}
+	}
+	return math.Min(math.Min(f1, f2), math.Min(f(lo), f(hi)))
+}
@@ -0,0 +1,160 @@
+package spatialmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+)
+
+func makeTestCapsule(o Orientation, point r3.Vector, radius, length float64) Geometry {
+	capsule, _ := NewCapsule(NewPoseFromOrientation(point, o), radius, length)
+	return capsule
+}
+
+func TestNewCapsule(t *testing.T) {
+	offset := NewPoseFromOrientation(r3.Vector{X: 1, Y: 0, Z: 0}, &EulerAngles{0, 0, math.Pi})
+
+	// test capsule created from NewCapsule method
+	geometry, err := NewCapsule(offset, 1, 4)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, geometry.Vertices(), test.ShouldHaveLength, 2)
+	test.That(t, R3VectorAlmostEqual(geometry.Vertices()[0], r3.Vector{1, 0, -1}, 1e-8), test.ShouldBeTrue)
+	test.That(t, R3VectorAlmostEqual(geometry.Vertices()[1], r3.Vector{1, 0, 1}, 1e-8), test.ShouldBeTrue)
+	_, err = NewCapsule(offset, 1, 1)
+	test.That(t, err.Error(), test.ShouldContainSubstring, newBadGeometryDimensionsError(&capsule{}).Error())
+
+	// test capsule created from GeometryCreator with offset
+	gc, err := NewCapsuleCreator(1, 4, offset)
+	test.That(t, err, test.ShouldBeNil)
+	geometry = gc.NewGeometry(PoseInverse(offset))
+	test.That(t, PoseAlmostCoincident(geometry.Pose(), NewZeroPose()), test.ShouldBeTrue)
+}
+
+func TestCapsuleAlmostEqual(t *testing.T) {
+	original := makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4)
+	good := makeTestCapsule(NewZeroOrientation(), r3.Vector{1e-16, 1e-16, 1e-16}, 1+1e-16, 4)
+	bad := makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4+1e-2)
+	test.That(t, original.AlmostEqual(good), test.ShouldBeTrue)
+	test.That(t, original.AlmostEqual(bad), test.ShouldBeFalse)
+}
+
+func TestCapsuleCollision(t *testing.T) {
+	// capsule lying along the X axis
+	xAxis := &OrientationVector{OX: 1}
+	cases := []geometryComparisonTestCase{
+		{
+			"parallel capsules separated",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{3, 0, 0}, 1, 4),
+			},
+			1,
+		},
+		{
+			"crossing capsules",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+				makeTestCapsule(xAxis, r3.Vector{}, 1, 4),
+			},
+			-2,
+		},
+		{
+			"end to end capsules",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{0, 0, 3.5}, 1, 4),
+			},
+			-0.5,
+		},
+		{
+			"capsule and sphere separated at end",
+			[2]Geometry{makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4), makeTestSphere(r3.Vector{0, 0, 4}, 1)},
+			1,
+		},
+		{
+			"capsule and sphere touching at side",
+			[2]Geometry{makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4), makeTestSphere(r3.Vector{2, 0, 0.5}, 1)},
+			0,
+		},
+		{
+			"capsule and point inside",
+			[2]Geometry{makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4), NewPoint(r3.Vector{0, 0.5, 1})},
+			-0.5,
+		},
+		{
+			"capsule and box separated along side",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+				makeTestBox(NewZeroOrientation(), r3.Vector{3, 0, 0}, r3.Vector{2, 2, 2}),
+			},
+			1,
+		},
+		{
+			"capsule and box separated at end",
+			[2]Geometry{
+				makeTestCapsule(xAxis, r3.Vector{}, 1, 4),
+				makeTestBox(NewZeroOrientation(), r3.Vector{5, 0, 0}, r3.Vector{2, 2, 2}),
+			},
+			2,
+		},
+		{
+			"capsule passing through box",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 10),
+				makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{2, 2, 2}),
+			},
+			-2,
+		},
+	}
+	testGeometryCollision(t, cases)
+}
+
+func TestCapsuleEncompassed(t *testing.T) {
+	cases := []geometryComparisonTestCase{
+		{
+			"capsule in box",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+				makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{2, 2, 4}),
+			},
+			0,
+		},
+		{
+			"capsule not in box",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+				makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{2, 2, 3.9}),
+			},
+			1,
+		},
+		{
+			"capsule in sphere",
+			[2]Geometry{makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4), makeTestSphere(r3.Vector{}, 2)},
+			0,
+		},
+		{
+			"capsule in capsule",
+			[2]Geometry{
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{0, 0, 1}, 1.5, 7),
+			},
+			0,
+		},
+		{
+			"sphere in capsule",
+			[2]Geometry{makeTestSphere(r3.Vector{0, 0, 1}, 1), makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4)},
+			0,
+		},
+		{
+			"box not in capsule",
+			[2]Geometry{
+				makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{2, 2, 2}),
+				makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 1, 4),
+			},
+			1,
+		},
+	}
+	testGeometryEncompassed(t, cases)
+}
@@ -21,3 +21,7 @@ func newOrientationTypeUnsupportedError(orientationType string) error {
 func newRotationMatrixInputError(m []float64) error {
 	return errors.Errorf("input slice has %d elements, need exactly 9", len(m))
 }
+
+func newAABBTypeUnsupportedError(g Geometry) error {
+	return errors.Errorf("Bounding boxes for Geometry type %T are not supported", g)
+}
@@ -35,6 +35,8 @@ const (
 	UnknownType = GeometryType("")
 	BoxType     = GeometryType("box")
 	SphereType  = GeometryType("sphere")
+	CapsuleType = GeometryType("capsule")
+	MeshType    = GeometryType("mesh")
 	PointType   = GeometryType("point")
 )
 
@@ -47,9 +49,16 @@ type GeometryConfig struct {
 	Y float64 `json:"y"`
 	Z float64 `json:"z"`
 
-	// parameter used for defining a sphere's radius'
+	// parameter used for defining a sphere's or capsule's radius'
 	R float64 `json:"r"`
 
+	// parameter used for defining a capsule's total length, including both of its hemispherical ends
+	L float64 `json:"l"`
+
+	// path to the STL or OBJ file defining a mesh, and the factor converting its units to mm if they are not mm
+	MeshFile  string  `json:"mesh_file,omitempty"`
+	MeshScale float64 `json:"mesh_scale,omitempty"`
+
 	// define an offset to position the geometry
 	TranslationOffset TranslationConfig `json:"translation"`
 	OrientationOffset OrientationConfig `json:"orientation"`
@@ -67,6 +76,16 @@ func NewGeometryConfig(gc GeometryCreator) (*GeometryConfig, error) {
 	case *sphereCreator:
 		config.Type = SphereType
 		config.R = gc.(*sphereCreator).radius
+	case *capsuleCreator:
+		config.Type = CapsuleType
+		config.R = gc.(*capsuleCreator).radius
+		config.L = gc.(*capsuleCreator).length
+	case *meshCreator:
+		config.Type = MeshType
+		config.MeshFile = gc.(*meshCreator).fileName
+		if scale := gc.(*meshCreator).scale; scale != 1 {
+			config.MeshScale = scale
+		}
 	case *pointCreator:
 		config.Type = PointType
 	default:
@@ -98,6 +117,10 @@ func (config *GeometryConfig) ParseConfig() (GeometryCreator, error) {
 		return NewBoxCreator(r3.Vector{X: config.X, Y: config.Y, Z: config.Z}, offset)
 	case SphereType:
 		return NewSphereCreator(config.R, offset)
+	case CapsuleType:
+		return NewCapsuleCreator(config.R, config.L, offset)
+	case MeshType:
+		return config.newMeshCreator(offset)
 	case PointType:
 		return NewPointCreator(offset), nil
 	case UnknownType:
@@ -105,6 +128,16 @@ func (config *GeometryConfig) ParseConfig() (GeometryCreator, error) {
 		if creator, err := NewBoxCreator(r3.Vector{X: config.X, Y: config.Y, Z: config.Z}, offset); err == nil {
 			return creator, nil
 		}
+		if config.L != 0 {
+			if creator, err := NewCapsuleCreator(config.R, config.L, offset); err == nil {
+				return creator, nil
+			}
+		}
+		if config.MeshFile != "" {
+			if creator, err := config.newMeshCreator(offset); err == nil {
+				return creator, nil
+			}
+		}
 		if creator, err := NewSphereCreator(config.R, offset); err == nil {
 			return creator, nil
 		}
@@ -113,7 +146,15 @@ func (config *GeometryConfig) ParseConfig() (GeometryCreator, error) {
 	return nil, fmt.Errorf("%w %s", ErrGeometryTypeUnsupported, string(config.Type))
 }
 
+func (config *GeometryConfig) newMeshCreator(offset Pose) (GeometryCreator, error) {
+	if config.MeshScale == 0 {
+		return NewMeshCreator(config.MeshFile, offset)
+	}
+	return NewScaledMeshCreator(config.MeshFile, config.MeshScale, offset)
+}
+
 // NewGeometryFromProto instatiates a new Geometry from a protobuf Geometry message.
+// Capsules and meshes are converted to protobuf as boxes, so they will be returned as boxes.
 func NewGeometryFromProto(geometry *commonpb.Geometry) (Geometry, error) {
 	pose := NewPoseFromProtobuf(geometry.Center)
 	if box := geometry.GetBox().GetDimsMm(); box != nil {
@@ -29,6 +29,11 @@ func TestGeometrySerialization(t *testing.T) {
 		{"sphere", GeometryConfig{Type: "sphere", R: 1, TranslationOffset: translation, OrientationOffset: orientation}, true},
 		{"sphere bad dims", GeometryConfig{Type: "sphere", R: -1}, false},
 		{"infer sphere", GeometryConfig{R: 1, OrientationOffset: orientation}, true},
+		{"capsule", GeometryConfig{Type: "capsule", R: 1, L: 4, TranslationOffset: translation, OrientationOffset: orientation}, true},
+		{"capsule bad dims", GeometryConfig{Type: "capsule", R: 1, L: 1}, false},
+		{"infer capsule", GeometryConfig{R: 1, L: 4}, true},
+		{"mesh", GeometryConfig{Type: "mesh", MeshFile: "data/cube.stl", TranslationOffset: translation}, true},
+		{"mesh bad file", GeometryConfig{Type: "mesh", MeshFile: "data/missing.stl"}, false},
 		{"point", GeometryConfig{Type: "point", TranslationOffset: translation, OrientationOffset: orientation}, true},
 		{"infer point", GeometryConfig{}, false},
 		{"bad type", GeometryConfig{Type: "bad"}, false},
@@ -73,6 +78,31 @@ func TestGeometryToFromProtobuf(t *testing.T) {
 		})
 	}
 
+	// capsules and meshes are represented in protobuf by the boxes bounding them
+	boundingCases := []struct {
+		name     string
+		geometry Geometry
+		bounds   Geometry
+	}{
+		{
+			"capsule",
+			makeTestCapsule(&EulerAngles{0, 0, deg45}, r3.Vector{1, 2, 3}, 1, 4),
+			makeTestBox(&EulerAngles{0, 0, deg45}, r3.Vector{1, 2, 3}, r3.Vector{2, 2, 4}),
+		},
+		{
+			"mesh",
+			makeTestCube(t, &EulerAngles{0, 0, deg45}, r3.Vector{1, 2, 3}),
+			makeTestBox(&EulerAngles{0, 0, deg45}, r3.Vector{1, 2, 3}, r3.Vector{100, 100, 100}),
+		},
+	}
+	for _, testCase := range boundingCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			newVol, err := NewGeometryFromProto(testCase.geometry.ToProtobuf())
+			test.That(t, err, test.ShouldBeNil)
+			test.That(t, testCase.bounds.AlmostEqual(newVol), test.ShouldBeTrue)
+		})
+	}
+
 	// test that bad message does not generate error
 	_, err := NewGeometryFromProto(&commonpb.Geometry{Center: PoseToProtobuf(NewZeroPose())})
 	test.That(t, err.Error(), test.ShouldContainSubstring, ErrGeometryTypeUnsupported.Error())
@@ -0,0 +1,546 @@
+package spatialmath
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+	commonpb "go.viam.com/api/common/v1"
+)
+
+// meshCreator implements the GeometryCreator interface for mesh structs.
+type meshCreator struct {
+	triangles []*triangle
+	fileName  string
+	scale     float64
+	pointCreator
+}
+
+// mesh is a collision geometry that represents a closed triangle mesh, such as one exported from CAD software. The triangles
+// are defined relative to the mesh's pose. Meshes are treated as solid, so points within the surface are considered to be
+// in collision with the mesh.
+type mesh struct {
+	pose      Pose
+	triangles []*triangle
+
+	// triangles in world coordinates, along with the center and radius of a sphere bounding them
+	worldTriangles []*triangle
+	center         r3.Vector
+	radius         float64
+}
+
+// triangle is a single face of a mesh.
+type triangle struct {
+	p0, p1, p2 r3.Vector
+
+	// center and radius of a sphere bounding the triangle
+	center r3.Vector
+	radius float64
+}
+
+// NewMeshCreator instantiates a MeshCreator class, which allows instantiating meshes given only a pose which is applied
+// at the specified offset from the pose. The triangles of the mesh are read from the given STL or OBJ file, in mm.
+func NewMeshCreator(fileName string, offset Pose) (GeometryCreator, error) {
+	return NewScaledMeshCreator(fileName, 1, offset)
+}
+
+// NewScaledMeshCreator instantiates a MeshCreator class like NewMeshCreator, for files which are not in mm. The coordinates
+// of the triangles in the file are multiplied by the scale, such as 1000 for files in meters.
+func NewScaledMeshCreator(fileName string, scale float64, offset Pose) (GeometryCreator, error) {
+	if scale <= 0 {
+		return nil, errors.Errorf("mesh scale must be positive, got %v", scale)
+	}
+	triangles, err := readMeshFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(triangles) == 0 {
+		return nil, newBadGeometryDimensionsError(&mesh{})
+	}
+	if scale != 1 {
+		for i, t := range triangles {
+			triangles[i] = newTriangle(t.p0.Mul(scale), t.p1.Mul(scale), t.p2.Mul(scale))
+		}
+	}
+	return &meshCreator{triangles, fileName, scale, pointCreator{offset}}, nil
+}
+
+// NewGeometry instantiates a new mesh from a MeshCreator class.
+func (mc *meshCreator) NewGeometry(pose Pose) Geometry {
+	return newMesh(Compose(mc.offset, pose), mc.triangles)
+}
+
+func (mc *meshCreator) MarshalJSON() ([]byte, error) {
+	config, err := NewGeometryConfig(mc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(config)
+}
+
+// NewMesh instantiates a new mesh Geometry from a list of triangles, each given as its three vertices relative to the pose.
+func NewMesh(pose Pose, triangles [][3]r3.Vector) (Geometry, error) {
+	if len(triangles) == 0 {
+		return nil, newBadGeometryDimensionsError(&mesh{})
+	}
+	tris := make([]*triangle, 0, len(triangles))
+	for _, t := range triangles {
+		tris = append(tris, newTriangle(t[0], t[1], t[2]))
+	}
+	return newMesh(pose, tris), nil
+}
+
+// NewMeshFromFile instantiates a new mesh Geometry from the triangles in the given STL or OBJ file, in mm.
+func NewMeshFromFile(pose Pose, fileName string) (Geometry, error) {
+	mc, err := NewMeshCreator(fileName, NewZeroPose())
+	if err != nil {
+		return nil, err
+	}
+	return mc.NewGeometry(pose), nil
+}
+
+func newMesh(pose Pose, triangles []*triangle) *mesh {
+	m := &mesh{pose: pose, triangles: triangles, worldTriangles: make([]*triangle, 0, len(triangles))}
+	for _, t := range triangles {
+		m.worldTriangles = append(m.worldTriangles, t.transform(pose))
+	}
+	vertices := m.Vertices()
+	for _, v := range vertices {
+		m.center = m.center.Add(v)
+	}
+	m.center = m.center.Mul(1 / float64(len(vertices)))
+	for _, v := range vertices {
+		m.radius = math.Max(m.radius, v.Sub(m.center).Norm())
+	}
+	return m
+}
+
+func newTriangle(p0, p1, p2 r3.Vector) *triangle {
+	center := p0.Add(p1).Add(p2).Mul(1. / 3)
+	radius := math.Max(p0.Sub(center).Norm(), math.Max(p1.Sub(center).Norm(), p2.Sub(center).Norm()))
+	return &triangle{p0, p1, p2, center, radius}
+}
+
+// Pose returns the pose of the mesh.
+func (m *mesh) Pose() Pose {
+	return m.pose
+}
+
+// Vertices returns the vertices of every triangle in the mesh.
+func (m *mesh) Vertices() []r3.Vector {
+	vertices := make([]r3.Vector, 0, 3*len(m.worldTriangles))
+	for _, t := range m.worldTriangles {
+		vertices = append(vertices, t.p0, t.p1, t.p2)
+	}
+	return vertices
+}
+
+// AlmostEqual compares the mesh with another geometry and checks if they are equivalent.
+func (m *mesh) AlmostEqual(g Geometry) bool {
+	other, ok := g.(*mesh)
+	if !ok || len(m.triangles) != len(other.triangles) || !PoseAlmostEqual(m.pose, other.pose) {
+		return false
+	}
+	for i, t := range m.triangles {
+		o := other.triangles[i]
+		if !R3VectorAlmostEqual(t.p0, o.p0, 1e-8) || !R3VectorAlmostEqual(t.p1, o.p1, 1e-8) || !R3VectorAlmostEqual(t.p2, o.p2, 1e-8) {
+			return false
+		}
+	}
+	return true
+}
+
+// Transform premultiplies the mesh pose with a transform, allowing the mesh to be moved in space.
+func (m *mesh) Transform(toPremultiply Pose) Geometry {
+	return newMesh(Compose(toPremultiply, m.pose), m.triangles)
+}
+
+// ToProtobuf converts the mesh to a Geometry proto message. The Geometry message has no mesh type, so the mesh is
+// represented by the smallest box, in the mesh's frame, which contains it.
+func (m *mesh) ToProtobuf() *commonpb.Geometry {
+	minPt := r3.Vector{X: math.Inf(1), Y: math.Inf(1), Z: math.Inf(1)}
+	maxPt := r3.Vector{X: math.Inf(-1), Y: math.Inf(-1), Z: math.Inf(-1)}
+	for _, t := range m.triangles {
+		for _, v := range []r3.Vector{t.p0, t.p1, t.p2} {
+			minPt = r3.Vector{X: math.Min(minPt.X, v.X), Y: math.Min(minPt.Y, v.Y), Z: math.Min(minPt.Z, v.Z)}
+			maxPt = r3.Vector{X: math.Max(maxPt.X, v.X), Y: math.Max(maxPt.Y, v.Y), Z: math.Max(maxPt.Z, v.Z)}
+		}
+	}
+	dims := maxPt.Sub(minPt)
+	return &commonpb.Geometry{
+		Center: PoseToProtobuf(Compose(m.pose, NewPoseFromPoint(minPt.Add(maxPt).Mul(0.5)))),
+		GeometryType: &commonpb.Geometry_Box{
+			Box: &commonpb.RectangularPrism{DimsMm: &commonpb.Vector3{X: dims.X, Y: dims.Y, Z: dims.Z}},
+		},
+	}
+}
+
+// CollidesWith checks if the given mesh collides with the given geometry and returns true if it does.
+func (m *mesh) CollidesWith(g Geometry) (bool, error) {
+	distance, err := m.DistanceFrom(g)
+	if err != nil {
+		return true, err
+	}
+	return distance <= 0, nil
+}
+
+// DistanceFrom returns the distance between the mesh and the given geometry. If the returned value is nonpositive the
+// geometries are in collision, and it represents the depth to which one is inside the other. Geometries which intersect the
+// surface of the mesh are considered to have a penetration depth of zero, plus any radius of the other geometry.
+func (m *mesh) DistanceFrom(g Geometry) (float64, error) {
+	switch other := g.(type) {
+	case *point:
+		return meshVsPointDistance(m, other.pose.Point()), nil
+	case *sphere:
+		return meshVsPointDistance(m, other.pose.Point()) - other.radius, nil
+	case *capsule:
+		return meshVsCapsuleDistance(m, other), nil
+	case *box:
+		return meshVsBoxDistance(m, other), nil
+	case *mesh:
+		return meshVsMeshDistance(m, other), nil
+	default:
+		return math.Inf(-1), newCollisionTypeUnsupportedError(m, g)
+	}
+}
+
+// EncompassedBy returns a bool describing if the given mesh is completely encompassed by the given geometry.
+func (m *mesh) EncompassedBy(g Geometry) (bool, error) {
+	if other, ok := g.(*mesh); ok {
+		return other.encompasses(m)
+	}
+	if _, ok := g.(*point); ok {
+		return false, nil
+	}
+	// All other geometries are convex, so they contain the mesh if they contain all of its vertices
+	for _, v := range m.Vertices() {
+		encompassed, err := NewPoint(v).EncompassedBy(g)
+		if err != nil || !encompassed {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// encompasses returns a bool describing if the given geometry is completely inside the mesh.
+func (m *mesh) encompasses(g Geometry) (bool, error) {
+	switch other := g.(type) {
+	case *point:
+		return m.containsPoint(other.pose.Point()), nil
+	case *sphere:
+		pt := other.pose.Point()
+		return m.containsPoint(pt) && m.surfaceDistanceFromPoint(pt, math.Inf(1)) >= other.radius, nil
+	case *capsule:
+		separation := m.surfaceDistanceFromSegment(other.segA, other.segB)
+		return separation >= other.radius && m.containsPoint(other.segA), nil
+	case *box:
+		return m.surfaceDistanceFromBox(other) > 0 && m.containsPoint(other.pose.Point()), nil
+	case *mesh:
+		return m.surfaceDistanceFromMesh(other) > 0 && m.containsPoint(other.worldTriangles[0].p0), nil
+	default:
+		return false, newCollisionTypeUnsupportedError(g, m)
+	}
+}
+
+// meshVsPointDistance returns the distance from a point to the surface of the mesh, which is negative if the point is inside it.
+func meshVsPointDistance(m *mesh, pt r3.Vector) float64 {
+	distance := m.surfaceDistanceFromPoint(pt, math.Inf(1))
+	if m.containsPoint(pt) {
+		return -distance
+	}
+	return distance
+}
+
+// meshVsCapsuleDistance returns the separation distance, or penetration depth if nonpositive, of a mesh and a capsule.
+func meshVsCapsuleDistance(m *mesh, c *capsule) float64 {
+	separation := m.surfaceDistanceFromSegment(c.segA, c.segB)
+	if separation > 0 && m.containsPoint(c.segA) {
+		return -separation - c.radius
+	}
+	return separation - c.radius
+}
+
+// meshVsBoxDistance returns the separation distance, or penetration depth if nonpositive, of a mesh and a box.
+func meshVsBoxDistance(m *mesh, b *box) float64 {
+	separation := m.surfaceDistanceFromBox(b)
+	if separation > 0 && (m.containsPoint(b.pose.Point()) || pointVsBoxCollision(b, m.worldTriangles[0].p0)) {
+		return -separation
+	}
+	return separation
+}
+
+// meshVsMeshDistance returns the separation distance, or penetration depth if nonpositive, of two meshes.
+func meshVsMeshDistance(a, b *mesh) float64 {
+	separation := a.surfaceDistanceFromMesh(b)
+	if separation > 0 && (a.containsPoint(b.worldTriangles[0].p0) || b.containsPoint(a.worldTriangles[0].p0)) {
+		return -separation
+	}
+	return separation
+}
+
+// surfaceDistanceFromPoint returns the distance from the point to the closest triangle of the mesh. Triangles which can
+// be shown to be further away than upperBound are skipped.
+func (m *mesh) surfaceDistanceFromPoint(pt r3.Vector, upperBound float64) float64 {
+	best := upperBound
+	for _, t := range m.worldTriangles {
+		if t.center.Sub(pt).Norm()-t.radius >= best {
+			continue
+		}
+		best = math.Min(best, t.closestPointToPoint(pt).Sub(pt).Norm())
+	}
+	return best
+}
+
+// surfaceDistanceFromSegment returns the distance from the segment to the closest triangle of the mesh.
+func (m *mesh) surfaceDistanceFromSegment(a, b r3.Vector) float64 {
+	center := a.Add(b).Mul(0.5)
+	radius := a.Sub(b).Norm() / 2
+	best := math.Inf(1)
+	for _, t := range m.worldTriangles {
+		if t.center.Sub(center).Norm()-t.radius-radius >= best {
+			continue
+		}
+		best = math.Min(best, t.distanceFromSegment(a, b))
+		if best == 0 {
+			break
+		}
+	}
+	return best
+}
+
+// surfaceDistanceFromBox returns the distance from the box to the closest triangle of the mesh.
+func (m *mesh) surfaceDistanceFromBox(b *box) float64 {
+	center := b.pose.Point()
+	radius := r3.Vector{X: b.halfSize[0], Y: b.halfSize[1], Z: b.halfSize[2]}.Norm()
+	best := math.Inf(1)
+	for _, t := range m.worldTriangles {
+		if t.center.Sub(center).Norm()-t.radius-radius >= best {
+			continue
+		}
+		best = math.Min(best, t.distanceFromBox(b))
+		if best == 0 {
+			break
+		}
+	}
+	return best
+}
+
+// surfaceDistanceFromMesh returns the distance between the closest triangles of two meshes.
+func (m *mesh) surfaceDistanceFromMesh(other *mesh) float64 {
+	best := math.Inf(1)
+	for _, t := range m.worldTriangles {
+		if t.center.Sub(other.center).Norm()-t.radius-other.radius >= best {
+			continue
+		}
+		for _, o := range other.worldTriangles {
+			if t.center.Sub(o.center).Norm()-t.radius-o.radius >= best {
+				continue
+			}
+			best = math.Min(best, t.distanceFromTriangle(o))
+			if best == 0 {
+				return 0
+			}
+		}
+	}
+	return best
+}
+
+// containsPoint returns whether the point is within the closed surface of the mesh, by counting the number of times a
+// ray cast from it crosses the surface.
+func (m *mesh) containsPoint(pt r3.Vector) bool {
+	if pt.Sub(m.center).Norm() > m.radius {
+		return false
+	}
+	// an arbitrary direction, chosen to make it unlikely that the ray passes exactly through an edge or vertex
+	direction := r3.Vector{X: 0.8728715609, Y: 0.2182178902, Z: 0.4364357805}
+	crossings := 0
+	for _, t := range m.worldTriangles {
+		if _, hit := t.rayIntersection(pt, direction); hit {
+			crossings++
+		}
+	}
+	return crossings%2 == 1
+}
+
+// transform returns the triangle moved by the given pose.
+func (t *triangle) transform(pose Pose) *triangle {
+	return newTriangle(
+		Compose(pose, NewPoseFromPoint(t.p0)).Point(),
+		Compose(pose, NewPoseFromPoint(t.p1)).Point(),
+		Compose(pose, NewPoseFromPoint(t.p2)).Point(),
+	)
+}
+
+func (t *triangle) edges() [3][2]r3.Vector {
+	return [3][2]r3.Vector{{t.p0, t.p1}, {t.p1, t.p2}, {t.p2, t.p0}}
+}
+
+func (t *triangle) normal() r3.Vector {
+	return t.p1.Sub(t.p0).Cross(t.p2.Sub(t.p0))
+}
+
+// closestPointToPoint returns the point on the triangle closest to pt.
+// Reference: Real-Time Collision Detection, Christer Ericson, section 5.1.5.
+func (t *triangle) closestPointToPoint(pt r3.Vector) r3.Vector {
+	a, b, c := t.p0, t.p1, t.p2
+	ab := b.Sub(a)
+	ac := c.Sub(a)
+	ap := pt.Sub(a)
+	d1 := ab.Dot(ap)
+	d2 := ac.Dot(ap)
+	if d1 <= 0 && d2 <= 0 {
+		return a
+	}
+	bp := pt.Sub(b)
+	d3 := ab.Dot(bp)
+	d4 := ac.Dot(bp)
+	if d3 >= 0 && d4 <= d3 {
+		return b
+	}
+	vc := d1*d4 - d3*d2
+	if vc <= 0 && d1 >= 0 && d3 <= 0 {
+		return a.Add(ab.Mul(d1 / (d1 - d3)))
+	}
+	cp := pt.Sub(c)
+	d5 := ab.Dot(cp)
+	d6 := ac.Dot(cp)
+	if d6 >= 0 && d5 <= d6 {
+		return c
+	}
+	vb := d5*d2 - d1*d6
+	if vb <= 0 && d2 >= 0 && d6 <= 0 {
+		return a.Add(ac.Mul(d2 / (d2 - d6)))
+	}
+	va := d3*d6 - d5*d4
+	if va <= 0 && (d4-d3) >= 0 && (d5-d6) >= 0 {
+		return b.Add(c.Sub(b).Mul((d4 - d3) / ((d4 - d3) + (d5 - d6))))
+	}
+	denom := 1 / (va + vb + vc)
+	return a.Add(ab.Mul(vb * denom)).Add(ac.Mul(vc * denom))
+}
+
+// rayIntersection returns the distance along the ray from origin in the given direction at which it crosses the triangle,
+// and whether it does so at all.
+// Reference: Möller and Trumbore, "Fast, Minimum Storage Ray/Triangle Intersection", 1997.
+func (t *triangle) rayIntersection(origin, direction r3.Vector) (float64, bool) {
+	const epsilon = 1e-12
+	e1 := t.p1.Sub(t.p0)
+	e2 := t.p2.Sub(t.p0)
+	h := direction.Cross(e2)
+	det := e1.Dot(h)
+	if math.Abs(det) < epsilon {
+		return 0, false
+	}
+	s := origin.Sub(t.p0)
+	u := s.Dot(h) / det
+	if u < 0 || u > 1 {
+		return 0, false
+	}
+	q := s.Cross(e1)
+	v := direction.Dot(q) / det
+	if v < 0 || u+v > 1 {
+		return 0, false
+	}
+	dist := e2.Dot(q) / det
+	return dist, dist > 0
+}
+
+// intersectsSegment returns whether the segment from a to b passes through the triangle.
+func (t *triangle) intersectsSegment(a, b r3.Vector) bool {
+	dist, hit := t.rayIntersection(a, b.Sub(a))
+	return hit && dist <= 1
+}
+
+// distanceFromSegment returns the shortest distance between the triangle and the segment from a to b.
+func (t *triangle) distanceFromSegment(a, b r3.Vector) float64 {
+	if t.intersectsSegment(a, b) {
+		return 0
+	}
+	best := math.Min(t.closestPointToPoint(a).Sub(a).Norm(), t.closestPointToPoint(b).Sub(b).Norm())
+	for _, edge := range t.edges() {
+		best = math.Min(best, segmentVsSegmentDistance(a, b, edge[0], edge[1]))
+	}
+	return best
+}
+
+// distanceFromTriangle returns the shortest distance between two triangles. As both are convex, if they are disjoint the
+// closest points will be found either between a vertex of one and the face of the other, or between two edges.
+func (t *triangle) distanceFromTriangle(other *triangle) float64 {
+	best := math.Inf(1)
+	for _, edge := range t.edges() {
+		best = math.Min(best, other.distanceFromSegment(edge[0], edge[1]))
+	}
+	for _, edge := range other.edges() {
+		best = math.Min(best, t.distanceFromSegment(edge[0], edge[1]))
+	}
+	return best
+}
+
+// distanceFromBox returns the shortest distance between the triangle and the box.
+func (t *triangle) distanceFromBox(b *box) float64 {
+	if t.intersectsBox(b) {
+		return 0
+	}
+	best := math.Inf(1)
+	for _, v := range []r3.Vector{t.p0, t.p1, t.p2} {
+		best = math.Min(best, pointVsBoxDistance(b, v))
+	}
+	vertices := b.Vertices()
+	for i, v := range vertices {
+		best = math.Min(best, t.closestPointToPoint(v).Sub(v).Norm())
+		// box vertices are indexed by the sign of their offset along each axis, so vertices whose indices differ by a
+		// single bit share an edge
+		for _, bit := range []int{1, 2, 4} {
+			if i&bit == 0 {
+				for _, edge := range t.edges() {
+					best = math.Min(best, segmentVsSegmentDistance(v, vertices[i|bit], edge[0], edge[1]))
+				}
+			}
+		}
+	}
+	return best
+}
+
+// intersectsBox returns whether the triangle and box intersect, using the separating axis theorem.
+// Reference: Akenine-Möller, "Fast 3D Triangle-Box Overlap Testing", 2001.
+func (t *triangle) intersectsBox(b *box) bool {
+	rm := b.pose.Orientation().RotationMatrix()
+	axes := [3]r3.Vector{rm.Row(0), rm.Row(1), rm.Row(2)}
+	center := b.pose.Point()
+
+	// express the triangle in the frame of the box
+	toLocal := func(pt r3.Vector) r3.Vector {
+		d := pt.Sub(center)
+		return r3.Vector{X: d.Dot(axes[0]), Y: d.Dot(axes[1]), Z: d.Dot(axes[2])}
+	}
+	local := newTriangle(toLocal(t.p0), toLocal(t.p1), toLocal(t.p2))
+	halfSize := r3.Vector{X: b.halfSize[0], Y: b.halfSize[1], Z: b.halfSize[2]}
+
+	separated := func(axis r3.Vector) bool {
+		if axis.Norm2() < 1e-18 {
+			return false
+		}
+		p0, p1, p2 := local.p0.Dot(axis), local.p1.Dot(axis), local.p2.Dot(axis)
+		r := halfSize.X*math.Abs(axis.X) + halfSize.Y*math.Abs(axis.Y) + halfSize.Z*math.Abs(axis.Z)
+		return math.Min(p0, math.Min(p1, p2)) > r || math.Max(p0, math.Max(p1, p2)) < -r
+	}
+
+	boxAxes := []r3.Vector{{X: 1}, {Y: 1}, {Z: 1}}
+	for _, axis := range boxAxes {
+		if separated(axis) {
+			return false
+		}
+	}
+	if separated(local.normal()) {
+		return false
+	}
+	for _, edge := range local.edges() {
+		for _, axis := range boxAxes {
+			if separated(edge[1].Sub(edge[0]).Cross(axis)) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,156 @@
+package spatialmath
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/geo/r3"
+	"github.com/pkg/errors"
+)
+
+// size in bytes of the header, and of each triangle, in a binary STL file.
+const (
+	stlHeaderSize   = 84
+	stlTriangleSize = 50
+)
+
+// readMeshFile reads the triangles in an STL or OBJ file, as determined by its extension.
+func readMeshFile(fileName string) ([]*triangle, error) {
+	//nolint:gosec
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read mesh file")
+	}
+	switch ext := strings.ToLower(filepath.Ext(fileName)); ext {
+	case ".stl":
+		return parseSTL(data)
+	case ".obj":
+		return parseOBJ(data)
+	default:
+		return nil, errors.Errorf("unsupported mesh file type %q, supported types are .stl and .obj", ext)
+	}
+}
+
+// parseSTL parses either a binary or an ASCII STL file. Binary files may also begin with "solid", so the file is only
+// treated as ASCII if its length does not match the triangle count in its binary header.
+func parseSTL(data []byte) ([]*triangle, error) {
+	if len(data) >= stlHeaderSize {
+		count := int(binary.LittleEndian.Uint32(data[80:stlHeaderSize]))
+		if len(data) == stlHeaderSize+count*stlTriangleSize {
+			return parseBinarySTL(data[stlHeaderSize:], count), nil
+		}
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("solid")) {
+		return nil, errors.New("invalid STL file")
+	}
+	return parseASCIISTL(data)
+}
+
+func parseBinarySTL(data []byte, count int) []*triangle {
+	readVector := func(b []byte) r3.Vector {
+		return r3.Vector{
+			X: float64(math.Float32frombits(binary.LittleEndian.Uint32(b[0:4]))),
+			Y: float64(math.Float32frombits(binary.LittleEndian.Uint32(b[4:8]))),
+			Z: float64(math.Float32frombits(binary.LittleEndian.Uint32(b[8:12]))),
+		}
+	}
+	triangles := make([]*triangle, 0, count)
+	for i := 0; i < count; i++ {
+		// each triangle is a normal, three vertices, and a two byte attribute count; the normal is recomputed as needed
+		t := data[i*stlTriangleSize : (i+1)*stlTriangleSize]
+		triangles = append(triangles, newTriangle(readVector(t[12:24]), readVector(t[24:36]), readVector(t[36:48])))
+	}
+	return triangles
+}
+
+func parseASCIISTL(data []byte) ([]*triangle, error) {
+	var triangles []*triangle
+	var vertices []r3.Vector
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || fields[0] != "vertex" {
+			continue
+		}
+		v, err := parseVector(fields[1:])
+		if err != nil {
+			return nil, err
+		}
+		vertices = append(vertices, v)
+		if len(vertices) == 3 {
+			triangles = append(triangles, newTriangle(vertices[0], vertices[1], vertices[2]))
+			vertices = vertices[:0]
+		}
+	}
+	if len(vertices) != 0 {
+		return nil, errors.New("STL file contains an incomplete facet")
+	}
+	return triangles, scanner.Err()
+}
+
+// parseOBJ parses the vertices and faces of a Wavefront OBJ file. Faces with more than three vertices are split into triangles
+// around their first vertex. All other statements are ignored.
+func parseOBJ(data []byte) ([]*triangle, error) {
+	var vertices []r3.Vector
+	var triangles []*triangle
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "v":
+			v, err := parseVector(fields[1:])
+			if err != nil {
+				return nil, err
+			}
+			vertices = append(vertices, v)
+		case "f":
+			if len(fields) < 4 {
+				return nil, errors.New("OBJ face has fewer than three vertices")
+			}
+			face := make([]r3.Vector, 0, len(fields)-1)
+			for _, field := range fields[1:] {
+				// vertex references may be followed by texture and normal indices, as in "1/2/3"
+				idx, err := strconv.Atoi(strings.SplitN(field, "/", 2)[0])
+				if err != nil {
+					return nil, errors.Wrap(err, "invalid OBJ face")
+				}
+				// indices are 1-based, and negative indices count backwards from the most recent vertex
+				if idx < 0 {
+					idx += len(vertices) + 1
+				}
+				if idx < 1 || idx > len(vertices) {
+					return nil, errors.Errorf("OBJ face references undefined vertex %d", idx)
+				}
+				face = append(face, vertices[idx-1])
+			}
+			for i := 1; i < len(face)-1; i++ {
+				triangles = append(triangles, newTriangle(face[0], face[i], face[i+1]))
+			}
+		}
+	}
+	return triangles, scanner.Err()
+}
+
+func parseVector(fields []string) (r3.Vector, error) {
+	if len(fields) < 3 {
+		return r3.Vector{}, errors.New("vertex has fewer than three coordinates")
+	}
+	coords := make([]float64, 3)
+	for i := range coords {
+		f, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return r3.Vector{}, errors.Wrap(err, "invalid vertex coordinate")
+		}
+		coords[i] = f
+	}
+	return r3.Vector{X: coords[0], Y: coords[1], Z: coords[2]}, nil
+}
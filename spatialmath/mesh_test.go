@@ -0,0 +1,95 @@
+package spatialmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/golang/geo/r3"
+	"go.viam.com/test"
+)
+
+// makeTestCube returns a mesh of a 100mm cube centered on the given point.
+func makeTestCube(t *testing.T, o Orientation, point r3.Vector) Geometry {
+	t.Helper()
+	cube, err := NewMeshFromFile(NewPoseFromOrientation(point, o), "data/cube.stl")
+	test.That(t, err, test.ShouldBeNil)
+	return cube
+}
+
+func TestNewMesh(t *testing.T) {
+	stl, err := NewMeshFromFile(NewZeroPose(), "data/cube.stl")
+	test.That(t, err, test.ShouldBeNil)
+	obj, err := NewMeshFromFile(NewZeroPose(), "data/cube.obj")
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, stl.AlmostEqual(obj), test.ShouldBeTrue)
+	test.That(t, stl.Vertices(), test.ShouldHaveLength, 36)
+
+	_, err = NewMeshFromFile(NewZeroPose(), "data/orientations.json")
+	test.That(t, err, test.ShouldNotBeNil)
+	_, err = NewMesh(NewZeroPose(), nil)
+	test.That(t, err.Error(), test.ShouldContainSubstring, newBadGeometryDimensionsError(&mesh{}).Error())
+
+	// test mesh created from GeometryCreator with offset
+	offset := NewPoseFromOrientation(r3.Vector{X: 1, Y: 0, Z: 0}, &EulerAngles{0, 0, math.Pi})
+	gc, err := NewMeshCreator("data/cube.obj", offset)
+	test.That(t, err, test.ShouldBeNil)
+	geometry := gc.NewGeometry(PoseInverse(offset))
+	test.That(t, PoseAlmostCoincident(geometry.Pose(), NewZeroPose()), test.ShouldBeTrue)
+	test.That(t, geometry.AlmostEqual(obj), test.ShouldBeTrue)
+
+	// test scaled mesh, as for files in units other than mm
+	_, err = NewScaledMeshCreator("data/cube.stl", 0, NewZeroPose())
+	test.That(t, err, test.ShouldNotBeNil)
+	gc, err = NewScaledMeshCreator("data/cube.stl", 2, NewZeroPose())
+	test.That(t, err, test.ShouldBeNil)
+	scaled := gc.NewGeometry(NewZeroPose())
+	for _, v := range scaled.(*mesh).Vertices() {
+		test.That(t, math.Abs(v.X), test.ShouldAlmostEqual, 100)
+	}
+	config, err := NewGeometryConfig(gc)
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, config.MeshScale, test.ShouldEqual, 2)
+	gc, err = config.ParseConfig()
+	test.That(t, err, test.ShouldBeNil)
+	test.That(t, gc.NewGeometry(NewZeroPose()).AlmostEqual(scaled), test.ShouldBeTrue)
+}
+
+func TestMeshCollision(t *testing.T) {
+	cube := makeTestCube(t, NewZeroOrientation(), r3.Vector{})
+	cases := []geometryComparisonTestCase{
+		{"point outside", [2]Geometry{cube, NewPoint(r3.Vector{60, 0, 0})}, 10},
+		{"point inside", [2]Geometry{cube, NewPoint(r3.Vector{40, 0, 0})}, -10},
+		{"sphere separated", [2]Geometry{cube, makeTestSphere(r3.Vector{0, 0, 70}, 10)}, 10},
+		{"sphere inside", [2]Geometry{cube, makeTestSphere(r3.Vector{}, 10)}, -60},
+		{"sphere crossing face", [2]Geometry{cube, makeTestSphere(r3.Vector{0, 0, 55}, 10)}, -5},
+		{"capsule separated", [2]Geometry{cube, makeTestCapsule(NewZeroOrientation(), r3.Vector{70, 0, 0}, 10, 200)}, 10},
+		{"capsule through mesh", [2]Geometry{cube, makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 10, 200)}, -10},
+		{"box separated", [2]Geometry{cube, makeTestBox(NewZeroOrientation(), r3.Vector{0, 0, 70}, r3.Vector{10, 10, 10})}, 15},
+		{"box crossing face", [2]Geometry{cube, makeTestBox(NewZeroOrientation(), r3.Vector{0, 0, 50}, r3.Vector{10, 10, 10})}, 0},
+		{"box inside", [2]Geometry{cube, makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{10, 10, 10})}, -45},
+		{
+			"rotated box separated",
+			[2]Geometry{cube, makeTestBox(&OrientationVectorDegrees{OX: 1, OY: 1}, r3.Vector{80, 0, 0}, r3.Vector{10, 10, 10})},
+			30 - 5*math.Sqrt2,
+		},
+		{"meshes separated", [2]Geometry{cube, makeTestCube(t, NewZeroOrientation(), r3.Vector{0, 110, 0})}, 10},
+		{"meshes overlapping", [2]Geometry{cube, makeTestCube(t, NewZeroOrientation(), r3.Vector{0, 50, 50})}, 0},
+	}
+	testGeometryCollision(t, cases)
+}
+
+func TestMeshEncompassed(t *testing.T) {
+	cube := makeTestCube(t, NewZeroOrientation(), r3.Vector{})
+	cases := []geometryComparisonTestCase{
+		{"mesh in box", [2]Geometry{cube, makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{100, 100, 100})}, 0},
+		{"mesh not in sphere", [2]Geometry{cube, makeTestSphere(r3.Vector{}, 80)}, 1},
+		{"mesh in sphere", [2]Geometry{cube, makeTestSphere(r3.Vector{}, 87)}, 0},
+		{"sphere in mesh", [2]Geometry{makeTestSphere(r3.Vector{}, 50), cube}, 0},
+		{"sphere not in mesh", [2]Geometry{makeTestSphere(r3.Vector{10, 0, 0}, 50), cube}, 1},
+		{"capsule in mesh", [2]Geometry{makeTestCapsule(NewZeroOrientation(), r3.Vector{}, 10, 100), cube}, 0},
+		{"box in mesh", [2]Geometry{makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{90, 90, 90}), cube}, 0},
+		{"box not in mesh", [2]Geometry{makeTestBox(NewZeroOrientation(), r3.Vector{}, r3.Vector{110, 90, 90}), cube}, 1},
+		{"point in mesh", [2]Geometry{NewPoint(r3.Vector{49, 49, 49}), cube}, 0},
+	}
+	testGeometryEncompassed(t, cases)
+}
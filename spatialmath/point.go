@@ -93,6 +93,12 @@ func (pt *point) CollidesWith(g Geometry) (bool, error) {
 	if other, ok := g.(*point); ok {
 		return pt.AlmostEqual(other), nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsPointDistance(other, pt.pose.Point()) <= 0, nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.CollidesWith(pt)
+	}
 	return true, newCollisionTypeUnsupportedError(pt, g)
 }
 
@@ -107,6 +113,12 @@ func (pt *point) DistanceFrom(g Geometry) (float64, error) {
 	if other, ok := g.(*point); ok {
 		return pt.pose.Point().Sub(other.pose.Point()).Norm(), nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsPointDistance(other, pt.pose.Point()), nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.DistanceFrom(pt)
+	}
 	return math.Inf(-1), newCollisionTypeUnsupportedError(pt, g)
 }
 
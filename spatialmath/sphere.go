@@ -102,6 +102,12 @@ func (s *sphere) CollidesWith(g Geometry) (bool, error) {
 	if other, ok := g.(*point); ok {
 		return sphereVsPointDistance(s, other.pose.Point()) <= 0, nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsSphereDistance(other, s) <= 0, nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.CollidesWith(s)
+	}
 	return true, newCollisionTypeUnsupportedError(s, g)
 }
 
@@ -116,6 +122,12 @@ func (s *sphere) DistanceFrom(g Geometry) (float64, error) {
 	if other, ok := g.(*point); ok {
 		return sphereVsPointDistance(s, other.pose.Point()), nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsSphereDistance(other, s), nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.DistanceFrom(s)
+	}
 	return math.Inf(-1), newCollisionTypeUnsupportedError(s, g)
 }
 
@@ -126,6 +138,12 @@ func (s *sphere) EncompassedBy(g Geometry) (bool, error) {
 	if other, ok := g.(*box); ok {
 		return sphereInBox(s, other), nil
 	}
+	if other, ok := g.(*capsule); ok {
+		return capsuleVsPointDistance(other, s.pose.Point())+s.radius <= 0, nil
+	}
+	if other, ok := g.(*mesh); ok {
+		return other.encompasses(s)
+	}
 	if _, ok := g.(*point); ok {
 		return false, nil
 	}
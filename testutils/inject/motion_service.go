@@ -19,6 +19,7 @@ type MotionService struct {
 		componentName resource.Name,
 		grabPose *referenceframe.PoseInFrame,
 		worldState *commonpb.WorldState,
+		constraints *motion.Constraints,
 		extra map[string]interface{},
 	) (bool, error)
 	GetPoseFunc func(
@@ -36,12 +37,13 @@ func (mgs *MotionService) Move(
 	componentName resource.Name,
 	grabPose *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *motion.Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
 	if mgs.MoveFunc == nil {
-		return mgs.Service.Move(ctx, componentName, grabPose, worldState, extra)
+		return mgs.Service.Move(ctx, componentName, grabPose, worldState, constraints, extra)
 	}
-	return mgs.MoveFunc(ctx, componentName, grabPose, worldState, extra)
+	return mgs.MoveFunc(ctx, componentName, grabPose, worldState, constraints, extra)
 }
 
 // MoveSingleComponent calls the injected MoveSingleComponent or the real variant. It uses the same function as Move.
@@ -50,12 +52,13 @@ func (mgs *MotionService) MoveSingleComponent(
 	componentName resource.Name,
 	grabPose *referenceframe.PoseInFrame,
 	worldState *commonpb.WorldState,
+	constraints *motion.Constraints,
 	extra map[string]interface{},
 ) (bool, error) {
 	if mgs.MoveFunc == nil {
-		return mgs.Service.MoveSingleComponent(ctx, componentName, grabPose, worldState, extra)
+		return mgs.Service.MoveSingleComponent(ctx, componentName, grabPose, worldState, constraints, extra)
 	}
-	return mgs.MoveFunc(ctx, componentName, grabPose, worldState, extra)
+	return mgs.MoveFunc(ctx, componentName, grabPose, worldState, constraints, extra)
 }
 
 // GetPose calls the injected GetPose or the real variant.
@@ -19,6 +19,7 @@ type MovementSensor struct {
 	AngularVelocityFunc func(ctx context.Context) (spatialmath.AngularVelocity, error)
 	CompassHeadingFunc  func(ctx context.Context) (float64, error)
 	OrientationFunc     func(ctx context.Context) (spatialmath.Orientation, error)
+	PropertiesFunc      func(ctx context.Context) (*movementsensor.Properties, error)
 
 	DoFunc    func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error)
 	CloseFunc func() error
@@ -50,7 +51,7 @@ func (i *MovementSensor) Position(ctx context.Context) (*geo.Point, float64, err
 
 // LinearVelocity func or passthrough.
 func (i *MovementSensor) LinearVelocity(ctx context.Context) (r3.Vector, error) {
-	if i.PositionFunc == nil {
+	if i.LinearVelocityFunc == nil {
 		return i.MovementSensor.LinearVelocity(ctx)
 	}
 	return i.LinearVelocityFunc(ctx)
@@ -58,7 +59,7 @@ func (i *MovementSensor) LinearVelocity(ctx context.Context) (r3.Vector, error)
 
 // AngularVelocity func or passthrough.
 func (i *MovementSensor) AngularVelocity(ctx context.Context) (spatialmath.AngularVelocity, error) {
-	if i.PositionFunc == nil {
+	if i.AngularVelocityFunc == nil {
 		return i.MovementSensor.AngularVelocity(ctx)
 	}
 	return i.AngularVelocityFunc(ctx)
@@ -66,7 +67,7 @@ func (i *MovementSensor) AngularVelocity(ctx context.Context) (spatialmath.Angul
 
 // Orientation func or passthrough.
 func (i *MovementSensor) Orientation(ctx context.Context) (spatialmath.Orientation, error) {
-	if i.PositionFunc == nil {
+	if i.OrientationFunc == nil {
 		return i.MovementSensor.Orientation(ctx)
 	}
 	return i.OrientationFunc(ctx)
@@ -74,8 +75,16 @@ func (i *MovementSensor) Orientation(ctx context.Context) (spatialmath.Orientati
 
 // CompassHeading func or passthrough.
 func (i *MovementSensor) CompassHeading(ctx context.Context) (float64, error) {
-	if i.PositionFunc == nil {
+	if i.CompassHeadingFunc == nil {
 		return i.MovementSensor.CompassHeading(ctx)
 	}
 	return i.CompassHeadingFunc(ctx)
 }
+
+// Properties func or passthrough.
+func (i *MovementSensor) Properties(ctx context.Context) (*movementsensor.Properties, error) {
+	if i.PropertiesFunc == nil {
+		return i.MovementSensor.Properties(ctx)
+	}
+	return i.PropertiesFunc(ctx)
+}
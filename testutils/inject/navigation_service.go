@@ -20,6 +20,14 @@ type NavigationService struct {
 	GetWaypointsFunc   func(ctx context.Context) ([]navigation.Waypoint, error)
 	AddWaypointFunc    func(ctx context.Context, point *geo.Point) error
 	RemoveWaypointFunc func(ctx context.Context, id primitive.ObjectID) error
+
+	GetGeofencesFunc   func(ctx context.Context) ([]navigation.Geofence, error)
+	AddGeofenceFunc    func(ctx context.Context, kind navigation.GeofenceKind, boundary []*geo.Point) error
+	RemoveGeofenceFunc func(ctx context.Context, id primitive.ObjectID) error
+
+	ProgressFunc func(ctx context.Context) (navigation.MissionProgress, error)
+
+	DoCommandFunc func(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error)
 }
 
 // Mode calls the injected ModeFunc or the real version.
@@ -69,3 +77,43 @@ func (ns *NavigationService) RemoveWaypoint(ctx context.Context, id primitive.Ob
 	}
 	return ns.RemoveWaypointFunc(ctx, id)
 }
+
+// Geofences calls the injected GetGeofencesFunc or the real version.
+func (ns *NavigationService) Geofences(ctx context.Context) ([]navigation.Geofence, error) {
+	if ns.GetGeofencesFunc == nil {
+		return ns.Service.Geofences(ctx)
+	}
+	return ns.GetGeofencesFunc(ctx)
+}
+
+// AddGeofence calls the injected AddGeofenceFunc or the real version.
+func (ns *NavigationService) AddGeofence(ctx context.Context, kind navigation.GeofenceKind, boundary []*geo.Point) error {
+	if ns.AddGeofenceFunc == nil {
+		return ns.Service.AddGeofence(ctx, kind, boundary)
+	}
+	return ns.AddGeofenceFunc(ctx, kind, boundary)
+}
+
+// RemoveGeofence calls the injected RemoveGeofenceFunc or the real version.
+func (ns *NavigationService) RemoveGeofence(ctx context.Context, id primitive.ObjectID) error {
+	if ns.RemoveGeofenceFunc == nil {
+		return ns.Service.RemoveGeofence(ctx, id)
+	}
+	return ns.RemoveGeofenceFunc(ctx, id)
+}
+
+// Progress calls the injected ProgressFunc or the real version.
+func (ns *NavigationService) Progress(ctx context.Context) (navigation.MissionProgress, error) {
+	if ns.ProgressFunc == nil {
+		return ns.Service.Progress(ctx)
+	}
+	return ns.ProgressFunc(ctx)
+}
+
+// DoCommand calls the injected DoCommandFunc or does the navigation command through the other methods.
+func (ns *NavigationService) DoCommand(ctx context.Context, cmd map[string]interface{}) (map[string]interface{}, error) {
+	if ns.DoCommandFunc == nil {
+		return navigation.DoCommand(ctx, ns, cmd)
+	}
+	return ns.DoCommandFunc(ctx, cmd)
+}
@@ -4,6 +4,7 @@ package inject
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/edaniels/golog"
 	commonpb "go.viam.com/api/common/v1"
@@ -41,6 +42,13 @@ type Robot struct {
 		dst string,
 		additionalTransforms []*commonpb.Transform,
 	) (*referenceframe.PoseInFrame, error)
+	TransformPoseAtTimeFunc func(
+		ctx context.Context,
+		pose *referenceframe.PoseInFrame,
+		dst string,
+		additionalTransforms []*commonpb.Transform,
+		t time.Time,
+	) (*referenceframe.PoseInFrame, error)
 	StatusFunc func(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error)
 
 	ops     *operation.Manager
@@ -197,6 +205,20 @@ func (r *Robot) TransformPose(
 	return r.TransformPoseFunc(ctx, pose, dst, additionalTransforms)
 }
 
+// TransformPoseAtTime calls the injected TransformPoseAtTime or the real version.
+func (r *Robot) TransformPoseAtTime(
+	ctx context.Context,
+	pose *referenceframe.PoseInFrame,
+	dst string,
+	additionalTransforms []*commonpb.Transform,
+	t time.Time,
+) (*referenceframe.PoseInFrame, error) {
+	if r.TransformPoseAtTimeFunc == nil {
+		return r.LocalRobot.TransformPoseAtTime(ctx, pose, dst, additionalTransforms, t)
+	}
+	return r.TransformPoseAtTimeFunc(ctx, pose, dst, additionalTransforms, t)
+}
+
 // Status call the injected Status or the real one.
 func (r *Robot) Status(ctx context.Context, resourceNames []resource.Name) ([]robot.Status, error) {
 	if r.StatusFunc == nil {
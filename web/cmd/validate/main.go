@@ -0,0 +1,37 @@
+// Package main checks a robot config without building any of it. It registers every component and service, like the
+// server, so that the attributes and models of the config can be checked.
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/edaniels/golog"
+	"go.viam.com/utils"
+
+	rdkcli "go.viam.com/rdk/cli"
+	// registers all components.
+	_ "go.viam.com/rdk/components/register"
+	// registers all services.
+	_ "go.viam.com/rdk/services/register"
+)
+
+var logger = golog.NewDevelopmentLogger("validate")
+
+// Arguments for the config validator.
+type Arguments struct {
+	ConfigFile string `flag:"0,required,usage=robot config file"`
+	Against    string `flag:"against,usage=show what would change reconfiguring from the config in this file"`
+}
+
+func main() {
+	utils.ContextualMain(mainWithArgs, logger)
+}
+
+func mainWithArgs(ctx context.Context, args []string, logger golog.Logger) error {
+	var argsParsed Arguments
+	if err := utils.ParseFlags(args, &argsParsed); err != nil {
+		return err
+	}
+	return rdkcli.ValidateConfig(os.Stdout, argsParsed.ConfigFile, argsParsed.Against)
+}
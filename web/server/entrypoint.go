@@ -35,6 +35,7 @@ type Arguments struct {
 	WebProfile                 bool   `flag:"webprofile,usage=include profiler in http server"`
 	WebRTC                     bool   `flag:"webrtc,usage=force webrtc connections instead of direct"`
 	RevealSensitiveConfigDiffs bool   `flag:"reveal-sensitive-config-diffs,usage=show config diffs"`
+	DryRunReconfigure          bool   `flag:"dry-run-reconfigure,usage=log what each new config would change instead of reconfiguring"`
 }
 
 type robotServer struct {
@@ -276,6 +277,15 @@ func (s *robotServer) serveWeb(ctx context.Context, cfg *config.Config) (err err
 					s.logger.Errorw("error processing config", "error", err)
 					continue
 				}
+				if s.args.DryRunReconfigure {
+					plan, err := myRobot.DryRunReconfigure(ctx, processedConfig)
+					if err != nil {
+						s.logger.Errorw("error planning reconfiguration", "error", err)
+						continue
+					}
+					s.logger.Infow("not reconfiguring with new config in dry run", "plan", plan.String())
+					continue
+				}
 				myRobot.Reconfigure(ctx, processedConfig)
 
 				// restart web service if necessary